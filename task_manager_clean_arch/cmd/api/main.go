@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yiheyistm/task_manager/config"
 	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/notifier"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/webhook"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/worker"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/router"
+	"github.com/yiheyistm/task_manager/internal/usecase"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -23,9 +31,40 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 		fmt.Println("------------------------ Production Mode ------------------------")
 	}
-	db := *app.Mongo.Database(env.DBName)
-	defer app.CloseDBConnection()
-	route := router.SetupRouter(env, db)
+	var db mongo.Database
+	if app.Mongo != nil {
+		db = *app.Mongo.Database(env.DBName)
+		defer app.CloseDBConnection()
+		if err := database.CreateIndexes(env, db); err != nil {
+			log.Fatal(err)
+		}
+	}
+	repos := persistence.NewRepositories(env, db)
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	purger := worker.NewTrashPurger(
+		usecase.NewTaskUseCase(repos.Task, repos.TaskHistory, repos.Workflow, events),
+		usecase.NewAttachmentUseCase(repos.Attachments, repos.Task, repos.Blobs,
+			int64(env.AttachmentMaxSizeMB)<<20, env.AttachmentAllowedTypes),
+		time.Duration(env.TrashRetentionHour)*time.Hour,
+		time.Duration(env.TrashPurgeIntervalMinute)*time.Minute,
+	)
+	go purger.Run(context.Background())
+	reminderNotifier, err := notifier.NewNotifier(env)
+	if err != nil {
+		log.Fatal(err)
+	}
+	scheduler := worker.NewReminderScheduler(
+		usecase.NewReminderUseCase(repos.Task, repos.Reminders, repos.Workflow, repos.User, reminderNotifier,
+			time.Duration(env.ReminderWindowMinute)*time.Minute),
+		time.Duration(env.ReminderIntervalMinute)*time.Minute,
+	)
+	go scheduler.Run(context.Background())
+	dispatcher := worker.NewWebhookDispatcher(
+		usecase.NewWebhookUseCase(repos.Webhooks, repos.WebhookDeliveries, webhook.NewHTTPSender(10*time.Second)),
+		time.Duration(env.WebhookDeliveryIntervalSecond)*time.Second,
+	)
+	go dispatcher.Run(context.Background())
+	route := router.SetupRouter(env, repos)
 	route.Run(env.ServerAddress)
 }
 
@@ -37,6 +76,10 @@ type Application struct {
 func App() Application {
 	app := &Application{}
 	app.Env = config.Load()
+	if app.Env.DBDriver == config.DBDriverMemory {
+		fmt.Println("Using in-memory storage, data will be lost on restart")
+		return *app
+	}
 	app.Mongo = database.NewMongoDatabase(app.Env)
 	return *app
 }
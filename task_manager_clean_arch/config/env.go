@@ -4,28 +4,89 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// Supported values for DB_DRIVER
+const (
+	DBDriverMongo  = "mongo"
+	DBDriverMemory = "memory"
+)
+
+// Supported values for NOTIFIER
+const (
+	NotifierLog     = "log"
+	NotifierSMTP    = "smtp"
+	NotifierWebhook = "webhook"
+)
+
+// Supported values for BLOB_STORE
+const (
+	BlobStoreLocal  = "local"
+	BlobStoreGridFS = "gridfs"
+)
+
+// defaultAttachmentTypes are the media types of the files that can be
+// attached to tasks unless ATTACHMENT_ALLOWED_TYPES says otherwise.
+var defaultAttachmentTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain"}
+
 // Config holds application configuration
 
 type Env struct {
-	AppEnv                 string
-	ServerAddress          string
-	ContextTimeout         int
-	DBHost                 string
-	DBUser                 string
-	DBHostURI              string
-	DBPort                 string
-	DBUserCollection       string
-	DBTaskCollection       string
-	DBPass                 string
-	DBName                 string
-	AccessTokenExpiryHour  int
-	RefreshTokenExpiryHour int
-	AccessTokenSecret      string
-	RefreshTokenSecret     string
+	AppEnv                        string
+	ServerAddress                 string
+	ContextTimeout                int
+	DBDriver                      string
+	DBHost                        string
+	DBUser                        string
+	DBHostURI                     string
+	DBPort                        string
+	DBUserCollection              string
+	DBTaskCollection              string
+	DBTaskHistoryCollection       string
+	DBProjectCollection           string
+	DBWorkflowCollection          string
+	DBRefreshTokenCollection      string
+	DBTokenDenylistCollection     string
+	DBReminderCollection          string
+	DBWebhookCollection           string
+	DBWebhookDeliveryCollection   string
+	DBCommentCollection           string
+	DBAttachmentCollection        string
+	DBRateLimitCollection         string
+	DBPass                        string
+	DBName                        string
+	AccessTokenExpiryHour         int
+	RefreshTokenExpiryHour        int
+	AccessTokenSecret             string
+	RefreshTokenSecret            string
+	TrashRetentionHour            int
+	TrashPurgeIntervalMinute      int
+	ReminderWindowMinute          int
+	ReminderIntervalMinute        int
+	Notifier                      string
+	SMTPHost                      string
+	SMTPPort                      string
+	SMTPUsername                  string
+	SMTPPassword                  string
+	SMTPFrom                      string
+	ReminderWebhookURL            string
+	WebhookDeliveryIntervalSecond int
+	EventStreamBufferSize         int
+	BlobStore                     string
+	BlobDir                       string
+	BlobGridFSBucket              string
+	AttachmentMaxSizeMB           int
+	AttachmentAllowedTypes        []string
+	RateLimitPublicPerMinute      int
+	RateLimitPublicBurst          int
+	RateLimitIPPerMinute          int
+	RateLimitIPBurst              int
+	RateLimitUserPerMinute        int
+	RateLimitUserBurst            int
+	TrustedProxies                []string
 }
 
 func Load() *Env {
@@ -35,21 +96,58 @@ func Load() *Env {
 	}
 
 	env := &Env{
-		AppEnv:                 GetEnvString("APP_ENV", "development"),
-		ServerAddress:          GetEnvString("SERVER_ADDRESS", ":8080"),
-		ContextTimeout:         GetEnvInt("CONTEXT_TIMEOUT", 30),
-		DBHost:                 GetEnvString("DB_HOST", "localhost"),
-		DBHostURI:              GetEnvString("DB_HOST_URI", "mongodb://localhost:27017"),
-		DBUser:                 GetEnvString("DB_USER", "user"),
-		DBPort:                 GetEnvString("DB_PORT", "27017"),
-		DBUserCollection:       GetEnvString("DB_USER_COLLECTION", "users"),
-		DBTaskCollection:       GetEnvString("DB_TASK_COLLECTION", "tasks"),
-		DBPass:                 GetEnvString("DB_PASS", "password"),
-		DBName:                 GetEnvString("DB_NAME", "task_manager"),
-		AccessTokenExpiryHour:  GetEnvInt("ACCESS_TOKEN_EXPIRY_HOUR", 1),
-		RefreshTokenExpiryHour: GetEnvInt("REFRESH_TOKEN_EXPIRY_HOUR", 24),
-		AccessTokenSecret:      GetEnvString("ACCESS_TOKEN_SECRET", "secret"),
-		RefreshTokenSecret:     GetEnvString("REFRESH_TOKEN_SECRET", "secret"),
+		AppEnv:                        GetEnvString("APP_ENV", "development"),
+		ServerAddress:                 GetEnvString("SERVER_ADDRESS", ":8080"),
+		ContextTimeout:                GetEnvInt("CONTEXT_TIMEOUT", 30),
+		DBDriver:                      GetEnvString("DB_DRIVER", DBDriverMongo),
+		DBHost:                        GetEnvString("DB_HOST", "localhost"),
+		DBHostURI:                     GetEnvString("DB_HOST_URI", "mongodb://localhost:27017"),
+		DBUser:                        GetEnvString("DB_USER", "user"),
+		DBPort:                        GetEnvString("DB_PORT", "27017"),
+		DBUserCollection:              GetEnvString("DB_USER_COLLECTION", "users"),
+		DBTaskCollection:              GetEnvString("DB_TASK_COLLECTION", "tasks"),
+		DBTaskHistoryCollection:       GetEnvString("DB_TASK_HISTORY_COLLECTION", "task_history"),
+		DBProjectCollection:           GetEnvString("DB_PROJECT_COLLECTION", "projects"),
+		DBWorkflowCollection:          GetEnvString("DB_WORKFLOW_COLLECTION", "workflows"),
+		DBRefreshTokenCollection:      GetEnvString("DB_REFRESH_TOKEN_COLLECTION", "refresh_tokens"),
+		DBTokenDenylistCollection:     GetEnvString("DB_TOKEN_DENYLIST_COLLECTION", "users_token_denylist"),
+		DBReminderCollection:          GetEnvString("DB_REMINDER_COLLECTION", "task_reminders"),
+		DBWebhookCollection:           GetEnvString("DB_WEBHOOK_COLLECTION", "webhooks"),
+		DBWebhookDeliveryCollection:   GetEnvString("DB_WEBHOOK_DELIVERY_COLLECTION", "webhook_deliveries"),
+		DBCommentCollection:           GetEnvString("DB_COMMENT_COLLECTION", "task_comments"),
+		DBAttachmentCollection:        GetEnvString("DB_ATTACHMENT_COLLECTION", "task_attachments"),
+		DBRateLimitCollection:         GetEnvString("DB_RATE_LIMIT_COLLECTION", "rate_limits"),
+		DBPass:                        GetEnvString("DB_PASS", "password"),
+		DBName:                        GetEnvString("DB_NAME", "task_manager"),
+		AccessTokenExpiryHour:         GetEnvInt("ACCESS_TOKEN_EXPIRY_HOUR", 1),
+		RefreshTokenExpiryHour:        GetEnvInt("REFRESH_TOKEN_EXPIRY_HOUR", 24),
+		AccessTokenSecret:             GetEnvString("ACCESS_TOKEN_SECRET", "secret"),
+		RefreshTokenSecret:            GetEnvString("REFRESH_TOKEN_SECRET", "secret"),
+		TrashRetentionHour:            GetEnvInt("TRASH_RETENTION_HOUR", 720),
+		TrashPurgeIntervalMinute:      GetEnvInt("TRASH_PURGE_INTERVAL_MINUTE", 60),
+		ReminderWindowMinute:          GetEnvInt("REMINDER_WINDOW_MINUTE", 60),
+		ReminderIntervalMinute:        GetEnvInt("REMINDER_INTERVAL_MINUTE", 5),
+		Notifier:                      GetEnvString("NOTIFIER", NotifierLog),
+		SMTPHost:                      GetEnvString("SMTP_HOST", "localhost"),
+		SMTPPort:                      GetEnvString("SMTP_PORT", "1025"),
+		SMTPUsername:                  GetEnvString("SMTP_USERNAME", ""),
+		SMTPPassword:                  GetEnvString("SMTP_PASSWORD", ""),
+		SMTPFrom:                      GetEnvString("SMTP_FROM", "task-manager@localhost"),
+		ReminderWebhookURL:            GetEnvString("REMINDER_WEBHOOK_URL", ""),
+		WebhookDeliveryIntervalSecond: GetEnvInt("WEBHOOK_DELIVERY_INTERVAL_SECOND", 10),
+		EventStreamBufferSize:         GetEnvInt("EVENT_STREAM_BUFFER_SIZE", 1000),
+		BlobStore:                     GetEnvString("BLOB_STORE", BlobStoreLocal),
+		BlobDir:                       GetEnvString("BLOB_DIR", "data/attachments"),
+		BlobGridFSBucket:              GetEnvString("BLOB_GRIDFS_BUCKET", "attachments"),
+		AttachmentMaxSizeMB:           GetEnvInt("ATTACHMENT_MAX_SIZE_MB", 10),
+		AttachmentAllowedTypes:        GetEnvList("ATTACHMENT_ALLOWED_TYPES", defaultAttachmentTypes),
+		RateLimitPublicPerMinute:      GetEnvInt("RATE_LIMIT_PUBLIC_PER_MINUTE", 20),
+		RateLimitPublicBurst:          GetEnvInt("RATE_LIMIT_PUBLIC_BURST", 10),
+		RateLimitIPPerMinute:          GetEnvInt("RATE_LIMIT_IP_PER_MINUTE", 600),
+		RateLimitIPBurst:              GetEnvInt("RATE_LIMIT_IP_BURST", 200),
+		RateLimitUserPerMinute:        GetEnvInt("RATE_LIMIT_USER_PER_MINUTE", 300),
+		RateLimitUserBurst:            GetEnvInt("RATE_LIMIT_USER_BURST", 100),
+		TrustedProxies:                GetEnvList("TRUSTED_PROXIES", nil),
 	}
 
 	return env
@@ -70,3 +168,18 @@ func GetEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// GetEnvList splits a comma separated value, dropping empty items.
+func GetEnvList(key string, defaultValue []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Attachment is a file uploaded to a task. Its content is kept in the
+// BlobStore under the hex form of its ID.
+type Attachment struct {
+	ID          primitive.ObjectID
+	TaskID      primitive.ObjectID
+	Filename    string
+	ContentType string
+	Size        int64
+	UploadedBy  string
+	CreatedAt   time.Time
+}
+
+// BlobStore keeps the content of attachments.
+type BlobStore interface {
+	// Put stores the content under the key, replacing any content already
+	// there, and returns its size.
+	Put(ctx context.Context, key string, content io.Reader) (int64, error)
+	// Open returns the content stored under the key. The context only
+	// bounds opening it; the caller closes the reader.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under the key. Deleting a missing
+	// key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentRepository interface {
+	Create(context.Context, *Attachment) error
+	GetById(context.Context, string) (Attachment, error)
+	// GetByTask returns the attachments of a task, oldest first.
+	GetByTask(context.Context, string) ([]Attachment, error)
+	// GetTaskIds lists the tasks that have attachments.
+	GetTaskIds(context.Context) ([]primitive.ObjectID, error)
+	Delete(context.Context, string) error
+}
+
+type IAttachmentUseCase interface {
+	GetByTaskAndUser(string, string) ([]Attachment, error)
+	// CreateByTaskAndUser stores the content and adds the attachment to a
+	// task the user can see.
+	CreateByTaskAndUser(string, *Attachment, io.Reader, string) error
+	// OpenByIdAndUser returns an attachment with its content, which the
+	// caller closes.
+	OpenByIdAndUser(string, string, string) (Attachment, io.ReadCloser, error)
+	// DeleteByIdAndUser deletes an attachment the user uploaded, or any
+	// attachment of a task the user created.
+	DeleteByIdAndUser(string, string, string) error
+	// PurgeOrphans deletes the attachments of tasks that no longer exist,
+	// in or outside the trash, and returns how many were deleted.
+	PurgeOrphans() (int64, error)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxCommentLength is the longest comment body, in characters.
+const MaxCommentLength = 10000
+
+// Comment is a message about a task, written in markdown. Mentions are the
+// users mentioned with @username who exist.
+type Comment struct {
+	ID        primitive.ObjectID
+	TaskID    primitive.ObjectID
+	Author    string
+	Body      string
+	Mentions  []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// mentionPattern matches @username at the start of the text or after a
+// character that cannot be part of a username or an email address.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.+-])@(\w[\w.-]*)`)
+
+// MentionedUsernames lists the usernames mentioned in a comment body, in the
+// order they first appear. A trailing dot ends the sentence rather than the
+// username.
+func MentionedUsernames(body string) []string {
+	var usernames []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		username := strings.TrimRight(match[1], ".")
+		if !slices.Contains(usernames, username) {
+			usernames = append(usernames, username)
+		}
+	}
+	return usernames
+}
+
+// Kinds of activity on a task.
+const (
+	ActivityComment      = "comment"
+	ActivityStatusChange = "status_change"
+)
+
+// Activity is a comment or a status change of a task. Comment is set for
+// comments, From and To for status changes; From is empty when the task was
+// created.
+type Activity struct {
+	Kind    string
+	At      time.Time
+	Actor   string
+	Comment *Comment
+	From    string
+	To      string
+}
+
+type CommentRepository interface {
+	Create(context.Context, *Comment) error
+	GetById(context.Context, string) (Comment, error)
+	// GetByTask returns the comments on a task, oldest first.
+	GetByTask(context.Context, string) ([]Comment, error)
+	Update(context.Context, *Comment) error
+	Delete(context.Context, string) error
+}
+
+type ICommentUseCase interface {
+	GetByTaskAndUser(string, string) ([]Comment, error)
+	CreateByTaskAndUser(string, *Comment, string) error
+	// UpdateByIdAndUser replaces the body of a comment the user wrote.
+	UpdateByIdAndUser(string, string, string, string) (Comment, error)
+	// DeleteByIdAndUser deletes a comment the user wrote, or any comment on a
+	// task the user created.
+	DeleteByIdAndUser(string, string, string) error
+	// GetActivityByTaskAndUser lists the comments and status changes of a
+	// task, most recent first.
+	GetActivityByTaskAndUser(string, string) ([]Activity, error)
+}
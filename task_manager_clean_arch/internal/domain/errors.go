@@ -0,0 +1,44 @@
+package domain
+
+import "errors"
+
+// Error kinds. Every error that should reach a client as something other than
+// a 500 wraps one of these, so callers can classify it with errors.Is.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrForbidden       = errors.New("forbidden")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrValidation      = errors.New("validation failed")
+	ErrTooLarge        = errors.New("too large")
+	ErrUnsupportedType = errors.New("unsupported media type")
+	ErrTooManyRequests = errors.New("too many requests")
+)
+
+// Error is an error of one of the kinds above. Its message is meant for
+// clients and should not carry internal details.
+type Error struct {
+	Kind    error
+	Message string
+	// Fields lists the offending fields of a validation error.
+	Fields []FieldError
+}
+
+// FieldError describes why a single request field failed validation.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// NewError returns an error of the given kind with a client-facing message.
+func NewError(kind error, message string) error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
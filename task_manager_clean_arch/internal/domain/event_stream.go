@@ -0,0 +1,30 @@
+package domain
+
+import "context"
+
+// StreamEvent is an event with its position in the event stream of this
+// process. Positions start at 1 and grow by one with every event published.
+type StreamEvent struct {
+	Seq   uint64
+	Event Event
+}
+
+// EventBus passes events on to the subscribers in this process as they are
+// published. It keeps the latest events so that a subscriber that lost its
+// connection can catch up.
+type EventBus interface {
+	EventPublisher
+	// Subscribe sends the events that match the filter until the context is
+	// done. With a position after zero, the kept events published after it
+	// are sent first, and the returned bool is false when some of them are
+	// no longer kept or the position is unknown. The channel is closed when
+	// the context is done or when the subscriber falls too far behind.
+	Subscribe(ctx context.Context, after uint64, filter func(Event) bool) (<-chan StreamEvent, bool)
+}
+
+type ITaskEventUseCase interface {
+	// Watch streams the task events after the position. Only the events of
+	// tasks the user can view are sent, or all task events when the user is
+	// empty. The bool is false when events after the position were missed.
+	Watch(ctx context.Context, username string, after uint64) (<-chan StreamEvent, bool)
+}
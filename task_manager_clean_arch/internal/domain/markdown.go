@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// htmlTagPattern matches HTML tags and comments, but not autolinks such as
+	// <https://example.com>.
+	htmlTagPattern = regexp.MustCompile(`(?s)<!--.*?-->|</?[A-Za-z][A-Za-z0-9-]*(?:[\s/][^<>]*)?>`)
+	// inlineLinkPattern matches the destination of [text](url) links and
+	// ![alt](url) images, which may contain balanced parentheses.
+	inlineLinkPattern = regexp.MustCompile(`(\]\(\s*)(<[^<>\n]*>|(?:[^\s()]|\([^\s()]*\))*)`)
+	// referenceLinkPattern matches the destination of [label]: url
+	// definitions.
+	referenceLinkPattern = regexp.MustCompile(`(?m)^( {0,3}\[[^\]]+\]:[ \t]*)(<[^<>\n]*>|\S+)`)
+	// autolinkPattern matches <scheme:...> autolinks.
+	autolinkPattern = regexp.MustCompile(`<[A-Za-z][A-Za-z0-9+.-]*:[^\s<>]*>`)
+)
+
+// SanitizeMarkdown makes user supplied markdown safe to render: raw HTML is
+// removed and links whose scheme is not http, https or mailto point to "#"
+// instead.
+func SanitizeMarkdown(markdown string) string {
+	// Removing a tag can join the text around it into a new one, so repeat
+	// until none is left.
+	for {
+		stripped := htmlTagPattern.ReplaceAllString(markdown, "")
+		if stripped == markdown {
+			break
+		}
+		markdown = stripped
+	}
+	markdown = replaceDestinations(inlineLinkPattern, markdown)
+	markdown = replaceDestinations(referenceLinkPattern, markdown)
+	return autolinkPattern.ReplaceAllStringFunc(markdown, safeDestination)
+}
+
+// replaceDestinations makes the link destinations matched by the second group
+// of the pattern safe.
+func replaceDestinations(pattern *regexp.Regexp, markdown string) string {
+	return pattern.ReplaceAllStringFunc(markdown, func(match string) string {
+		parts := pattern.FindStringSubmatch(match)
+		return parts[1] + safeDestination(parts[2])
+	})
+}
+
+// safeDestination returns the link destination, which may be wrapped in angle
+// brackets, or "#" when its scheme is not http, https or mailto. Entities and
+// whitespace are resolved first, as browsers would.
+func safeDestination(destination string) string {
+	url := strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+	url = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(url))
+	scheme, _, found := strings.Cut(url, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return destination
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return destination
+	}
+	return "#"
+}
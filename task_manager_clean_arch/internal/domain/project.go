@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Roles a project member can have. Owners manage the project and its
+// members, editors manage its tasks and viewers can only read them.
+const (
+	ProjectOwner  = "owner"
+	ProjectEditor = "editor"
+	ProjectViewer = "viewer"
+)
+
+// Project groups tasks shared by its members. The user who creates a project
+// is its first owner.
+type Project struct {
+	ID          primitive.ObjectID
+	Name        string
+	Description string
+	CreatedBy   string
+	CreatedAt   time.Time
+	Members     []ProjectMember
+}
+
+type ProjectMember struct {
+	Username string
+	Role     string
+}
+
+// IsProjectRole reports whether role is one of the project roles.
+func IsProjectRole(role string) bool {
+	return role == ProjectOwner || role == ProjectEditor || role == ProjectViewer
+}
+
+// RoleOf returns the user's role in the project, or "" if the user is not a
+// member.
+func (p Project) RoleOf(username string) string {
+	for _, member := range p.Members {
+		if member.Username == username {
+			return member.Role
+		}
+	}
+	return ""
+}
+
+// CanEditTasks reports whether the user may create, change and delete the
+// project's tasks.
+func (p Project) CanEditTasks(username string) bool {
+	role := p.RoleOf(username)
+	return role == ProjectOwner || role == ProjectEditor
+}
+
+// Owners counts the members with the owner role.
+func (p Project) Owners() int {
+	owners := 0
+	for _, member := range p.Members {
+		if member.Role == ProjectOwner {
+			owners++
+		}
+	}
+	return owners
+}
+
+type ProjectRepository interface {
+	Create(context.Context, *Project) error
+	GetById(context.Context, string) (Project, error)
+	// GetByMember lists the projects the user is a member of.
+	GetByMember(context.Context, string) ([]Project, error)
+	// Update changes the name and description of the project.
+	Update(context.Context, string, *Project) error
+	Delete(context.Context, string) error
+	// SetMember adds the member to the project or changes their role.
+	SetMember(context.Context, string, ProjectMember) (Project, error)
+	RemoveMember(context.Context, string, string) (Project, error)
+}
+
+// IProjectUseCase manages projects and their tasks on behalf of a user,
+// whose role in the project decides what they are allowed to do.
+type IProjectUseCase interface {
+	Create(*Project) error
+	GetByIdAndUser(string, string) (Project, error)
+	GetByUser(string) ([]Project, error)
+	UpdateByIdAndUser(string, *Project, string) error
+	DeleteByIdAndUser(string, string) error
+	SetMember(string, ProjectMember, string) (Project, error)
+	RemoveMember(string, string, string) (Project, error)
+	ListTasks(string, TaskQuery, string) (TaskPage, error)
+	GetTask(string, string, string) (Task, error)
+	CreateTask(string, *Task, string) error
+	PatchTask(string, string, TaskUpdate, string) (Task, error)
+	DeleteTask(string, string, int64, string) error
+}
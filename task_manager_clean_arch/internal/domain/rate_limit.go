@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// RateLimit is a token bucket: a client can make Burst requests at once, and
+// regains one more every Every. A limit with a zero Burst or Every does not
+// throttle at all.
+type RateLimit struct {
+	Burst int
+	Every time.Duration
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Burst > 0 && l.Every > 0
+}
+
+// TokenBucket is the state of a client's bucket: the tokens it held when it
+// was last used. A bucket that was never used is full.
+type TokenBucket struct {
+	Tokens float64
+	At     time.Time
+}
+
+// RateLimitResult is the outcome of taking a token. Reset is how long the
+// bucket takes to be full again, and RetryAfter, for a request that was not
+// allowed, how long until the next token.
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket for the time since it was last used, then takes a
+// token from it if it has one.
+func (l RateLimit) Take(bucket TokenBucket, now time.Time) (TokenBucket, RateLimitResult) {
+	burst := float64(l.Burst)
+	tokens := burst
+	if !bucket.At.IsZero() {
+		elapsed := max(now.Sub(bucket.At), 0)
+		tokens = min(burst, bucket.Tokens+float64(elapsed)/float64(l.Every))
+	}
+	result := RateLimitResult{Allowed: tokens >= 1}
+	if result.Allowed {
+		tokens--
+	} else {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(l.Every))
+	}
+	result.Remaining = int(math.Floor(tokens))
+	result.Reset = time.Duration((burst - tokens) * float64(l.Every))
+	return TokenBucket{Tokens: tokens, At: now}, result
+}
+
+// FullAt is when a bucket left in this state is full again, after which it
+// can be forgotten.
+func (l RateLimit) FullAt(bucket TokenBucket) time.Time {
+	return bucket.At.Add(time.Duration((float64(l.Burst) - bucket.Tokens) * float64(l.Every)))
+}
+
+// RateLimitStore keeps the token buckets of the rate limiter, by key.
+type RateLimitStore interface {
+	// Take takes a token from the bucket of the key under the limit, as
+	// RateLimit.Take does, and stores the bucket as it is left.
+	Take(context.Context, string, RateLimit, time.Time) (RateLimitResult, error)
+}
@@ -0,0 +1,421 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Recurrence makes a task repeat. When a recurring task reaches the final
+// status of the workflow, its next occurrence is created with the next due
+// date of the rule.
+type Recurrence struct {
+	// Rule is an RFC 5545 RRULE such as "FREQ=WEEKLY;BYDAY=MO,WE".
+	Rule string
+	// SeriesID is the first occurrence of the series, zero on the first
+	// occurrence itself.
+	SeriesID primitive.ObjectID
+	// Occurrence numbers the occurrences of the series from 1.
+	Occurrence int
+	// NextID is the occurrence created when this one was finished. Every
+	// occurrence creates at most one, even if it is reopened and finished
+	// again.
+	NextID primitive.ObjectID
+	// Template holds the fields the next occurrence is created with after
+	// this occurrence was edited on its own. It is nil when the next
+	// occurrence copies this one.
+	Template *OccurrenceTemplate
+}
+
+// OccurrenceTemplate lists the fields an occurrence passes on to the next
+// one. The due date is the one the next due date is computed from.
+type OccurrenceTemplate struct {
+	Title       string
+	Description string
+	DueDate     time.Time
+	Priority    string
+	Tags        []string
+	Checklist   []ChecklistItem
+}
+
+// TemplateOf returns the fields the task passes on to its next occurrence.
+func TemplateOf(task Task) OccurrenceTemplate {
+	if task.Recurrence != nil && task.Recurrence.Template != nil {
+		return *task.Recurrence.Template
+	}
+	return OccurrenceTemplate{
+		Title:       task.Title,
+		Description: task.Description,
+		DueDate:     task.DueDate,
+		Priority:    task.Priority,
+		Tags:        task.Tags,
+		Checklist:   task.Checklist,
+	}
+}
+
+// Carry returns the template with the fields that changed between before and
+// after changed in the same way.
+func (t OccurrenceTemplate) Carry(before, after Task) OccurrenceTemplate {
+	if after.Title != before.Title {
+		t.Title = after.Title
+	}
+	if after.Description != before.Description {
+		t.Description = after.Description
+	}
+	if !after.DueDate.Equal(before.DueDate) {
+		t.DueDate = after.DueDate
+	}
+	if after.Priority != before.Priority {
+		t.Priority = after.Priority
+	}
+	if !slices.Equal(after.Tags, before.Tags) {
+		t.Tags = after.Tags
+	}
+	if !slices.Equal(after.Checklist, before.Checklist) {
+		t.Checklist = after.Checklist
+	}
+	return t
+}
+
+// Scopes of an edit to a recurring task. Edits of this occurrence only leave
+// the occurrences created after it as they would have been, edits of future
+// occurrences are passed on to them.
+const (
+	ScopeThisOccurrence    = "this"
+	ScopeFutureOccurrences = "future"
+)
+
+// RRule frequencies.
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+const maxRRuleLength = 200
+
+// RRule is the subset of an RFC 5545 recurrence rule that tasks support:
+// FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY and BYMONTH.
+type RRule struct {
+	Freq     string
+	Interval int
+	// Count limits the series to that many occurrences, zero for no limit.
+	Count int
+	// Until is the last time an occurrence can be due, zero for no limit.
+	Until      time.Time
+	ByDay      []RRuleDay
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+// RRuleDay is a BYDAY entry such as "MO" or, in monthly and yearly rules,
+// "1MO" for the first and "-1FR" for the last Friday of the month.
+type RRuleDay struct {
+	Weekday time.Weekday
+	// N picks the nth such weekday of the month, counting from the end when
+	// negative, and every one of them when zero.
+	N int
+}
+
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func invalidRRule(format string, args ...any) error {
+	return NewError(ErrValidation, "invalid rrule: "+fmt.Sprintf(format, args...))
+}
+
+// ParseRRule parses a recurrence rule, with or without the "RRULE:" prefix.
+func ParseRRule(text string) (RRule, error) {
+	if len(text) > maxRRuleLength {
+		return RRule{}, invalidRRule("use at most %d characters", maxRRuleLength)
+	}
+	text = strings.TrimSpace(text)
+	if prefix := "RRULE:"; len(text) >= len(prefix) && strings.EqualFold(text[:len(prefix)], prefix) {
+		text = text[len(prefix):]
+	}
+	rule := RRule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(text, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name, value = strings.ToUpper(strings.TrimSpace(name)), strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return RRule{}, invalidRRule("%q is not a NAME=VALUE pair", part)
+		}
+		if seen[name] {
+			return RRule{}, invalidRRule("%s is given twice", name)
+		}
+		seen[name] = true
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = value
+			if !slices.Contains([]string{FreqDaily, FreqWeekly, FreqMonthly, FreqYearly}, value) {
+				err = invalidRRule("FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY")
+			}
+		case "INTERVAL":
+			rule.Interval, err = parseRRuleNumber(name, value, 1, 1000)
+		case "COUNT":
+			rule.Count, err = parseRRuleNumber(name, value, 1, 1000)
+		case "UNTIL":
+			rule.Until, err = parseRRuleUntil(value)
+		case "BYDAY":
+			rule.ByDay, err = parseRRuleDays(value)
+		case "BYMONTHDAY":
+			for _, item := range strings.Split(value, ",") {
+				var day int
+				if day, err = parseRRuleNumber(name, item, -31, 31); err == nil && day == 0 {
+					err = invalidRRule("BYMONTHDAY cannot be 0")
+				}
+				if err != nil {
+					break
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		case "BYMONTH":
+			for _, item := range strings.Split(value, ",") {
+				var month int
+				if month, err = parseRRuleNumber(name, item, 1, 12); err != nil {
+					break
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(month))
+			}
+		default:
+			err = invalidRRule("%s is not supported", name)
+		}
+		if err != nil {
+			return RRule{}, err
+		}
+	}
+	return rule, rule.validate()
+}
+
+func (r RRule) validate() error {
+	switch {
+	case r.Freq == "":
+		return invalidRRule("FREQ is required")
+	case r.Count > 0 && !r.Until.IsZero():
+		return invalidRRule("COUNT and UNTIL cannot be used together")
+	case r.Freq == FreqWeekly && len(r.ByMonthDay) > 0:
+		return invalidRRule("BYMONTHDAY cannot be used in a weekly rule")
+	case r.Freq == FreqYearly && len(r.ByDay) > 0 && len(r.ByMonth) == 0:
+		return invalidRRule("BYDAY needs BYMONTH in a yearly rule")
+	}
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+			return invalidRRule("numbered BYDAY entries need a monthly or yearly rule")
+		}
+	}
+	return nil
+}
+
+func parseRRuleNumber(name, value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, invalidRRule("%s must be a number between %d and %d", name, min, max)
+	}
+	return n, nil
+}
+
+func parseRRuleUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// A date includes the whole day.
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, invalidRRule("UNTIL must look like 20301231 or 20301231T235959Z")
+}
+
+func parseRRuleDays(value string) ([]RRuleDay, error) {
+	var days []RRuleDay
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, invalidRRule("%q is not a weekday", item)
+		}
+		weekday := slices.Index(rruleWeekdays, item[len(item)-2:])
+		if weekday < 0 {
+			return nil, invalidRRule("%q is not a weekday", item)
+		}
+		day := RRuleDay{Weekday: time.Weekday(weekday)}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, invalidRRule("%q must be numbered between -5 and 5", item)
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// String formats the rule with its parts in a fixed order, so that equal
+// rules compare equal.
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, month := range r.ByMonth {
+			months[i] = strconv.Itoa(int(month))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = rruleWeekdays[day.Weekday]
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// maxRRulePeriods bounds the search for the next occurrence, so that rules
+// matching no date, such as February 30, end it.
+const maxRRulePeriods = 5000
+
+// Next returns the first occurrence after the given one. The given
+// occurrence also anchors the rule: its time of day is kept, and its weekday,
+// day of the month and month are used where the rule does not name them.
+// Next reports false when the rule has no occurrence left before Until.
+// Count is left to the caller, which knows how many occurrences there were.
+func (r RRule) Next(after time.Time) (time.Time, bool) {
+	interval := max(r.Interval, 1)
+	for period := 0; period < maxRRulePeriods; period += interval {
+		for _, day := range r.candidates(after, period) {
+			if !day.After(after) {
+				continue
+			}
+			if !r.Until.IsZero() && day.After(r.Until) {
+				return time.Time{}, false
+			}
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// candidates lists, in order, the occurrences in the period that is offset
+// periods away from the one of the anchor.
+func (r RRule) candidates(anchor time.Time, offset int) []time.Time {
+	year, month, day := anchor.Date()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, anchor.Hour(), anchor.Minute(), anchor.Second(), 0, anchor.Location())
+	}
+	var days []time.Time
+	switch r.Freq {
+	case FreqDaily:
+		date := at(year, month, day+offset)
+		if r.inMonth(date) && r.onMonthDay(date) && r.onWeekday(date) {
+			days = append(days, date)
+		}
+	case FreqWeekly:
+		monday := day - (int(anchor.Weekday())+6)%7 + offset*7
+		for i := range 7 {
+			date := at(year, month, monday+i)
+			matches := date.Weekday() == anchor.Weekday()
+			if len(r.ByDay) > 0 {
+				matches = r.onWeekday(date)
+			}
+			if matches && r.inMonth(date) {
+				days = append(days, date)
+			}
+		}
+	case FreqMonthly:
+		first := at(year, month+time.Month(offset), 1)
+		if r.inMonth(first) {
+			days = r.daysOfMonth(first, day, at)
+		}
+	case FreqYearly:
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{month}
+		}
+		for _, m := range slices.Sorted(slices.Values(months)) {
+			days = append(days, r.daysOfMonth(at(year+offset, m, 1), day, at)...)
+		}
+	}
+	return days
+}
+
+// daysOfMonth lists the days of the month starting on first that match
+// BYMONTHDAY and BYDAY, or the anchor day when the rule names neither.
+func (r RRule) daysOfMonth(first time.Time, anchorDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	var days []time.Time
+	for date := first; date.Month() == first.Month(); date = date.AddDate(0, 0, 1) {
+		matches := date.Day() == anchorDay
+		if len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+			matches = r.onMonthDay(date) && r.onWeekday(date)
+		}
+		if matches {
+			days = append(days, at(date.Year(), date.Month(), date.Day()))
+		}
+	}
+	return days
+}
+
+func (r RRule) inMonth(date time.Time) bool {
+	return len(r.ByMonth) == 0 || slices.Contains(r.ByMonth, date.Month())
+}
+
+func (r RRule) onMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	length := daysIn(date)
+	for _, day := range r.ByMonthDay {
+		if day == date.Day() || length+day+1 == date.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RRule) onWeekday(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday != date.Weekday() {
+			continue
+		}
+		switch {
+		case day.N == 0,
+			day.N > 0 && (date.Day()-1)/7+1 == day.N,
+			day.N < 0 && (daysIn(date)-date.Day())/7+1 == -day.N:
+			return true
+		}
+	}
+	return false
+}
+
+// daysIn is the number of days of the month of the date.
+func daysIn(date time.Time) int {
+	return time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
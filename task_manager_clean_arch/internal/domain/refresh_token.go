@@ -1,23 +1,65 @@
 package domain
 
 import (
+	"context"
+	"time"
+
 	"github.com/golang-jwt/jwt/v4"
 )
 
+var (
+	ErrInvalidRefreshToken = NewError(ErrUnauthorized, "invalid refresh token")
+	ErrRefreshTokenReused  = NewError(ErrUnauthorized, "refresh token reuse detected, please log in again")
+	ErrTokenRevoked        = NewError(ErrUnauthorized, "token has been revoked")
+)
+
 type RefreshToken struct {
 	AccessToken  string
 	RefreshToken string
 }
 
+// RefreshSession is the server-side record of an issued refresh token.
+// Every token rotated from the same login shares the FamilyID.
+type RefreshSession struct {
+	ID        string
+	FamilyID  string
+	Username  string
+	Used      bool
+	Revoked   bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
 type IRefreshTokenUsecase interface {
 	GenerateTokens(user User) (RefreshToken, error)
 	ValidateToken(tokenString string) (jwt.MapClaims, error)
 	ValidateRefreshToken(token string) (jwt.MapClaims, error)
 	GetByUsername(string) (*User, error)
+	Refresh(refreshToken string) (RefreshToken, error)
+	Logout(jti string, familyID string, expiresAt time.Time) error
+	LogoutAll(username string) error
+	IsAccessTokenRevoked(jti string, username string, version int) (bool, error)
 }
 
 type RefreshTokenRepository interface {
 	GenerateTokens(user User) (RefreshToken, error)
+	RotateTokens(user User, familyID string) (RefreshToken, error)
 	ValidateToken(tokenString string) (jwt.MapClaims, error)
 	ValidateRefreshToken(token string) (jwt.MapClaims, error)
 }
+
+type RefreshTokenStore interface {
+	Save(context.Context, *RefreshSession) error
+	GetByID(context.Context, string) (*RefreshSession, error)
+	// MarkUsed atomically flags an unused session as used and reports
+	// whether this call was the one that did it.
+	MarkUsed(context.Context, string) (bool, error)
+	RevokeFamily(context.Context, string) error
+}
+
+// TokenDenylist holds the ids of access tokens that were logged out before
+// they expired.
+type TokenDenylist interface {
+	Add(context.Context, string, time.Time) error
+	Contains(context.Context, string) (bool, error)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Reminder kinds. A task gets a due soon reminder when it comes within the
+// reminder window and an overdue reminder once its due date has passed.
+const (
+	ReminderDueSoon = "due_soon"
+	ReminderOverdue = "overdue"
+)
+
+// Reminder tells the creator and the assignees of a task that it is due soon
+// or overdue.
+type Reminder struct {
+	Kind       string
+	Task       Task
+	Recipients []ReminderRecipient
+}
+
+// ReminderRecipient is a user a reminder is sent to. Email is empty when the
+// user could not be found.
+type ReminderRecipient struct {
+	Username string
+	Email    string
+}
+
+// Notifier delivers reminders, by email, webhook or otherwise.
+type Notifier interface {
+	Notify(context.Context, Reminder) error
+}
+
+// ReminderRepository keeps track of the reminders that have been sent so
+// that each is sent at most once.
+type ReminderRepository interface {
+	// Claim records the reminder of the kind for the task due at the time.
+	// It returns false when the reminder has already been claimed, so only
+	// one caller gets to send it.
+	Claim(context.Context, primitive.ObjectID, string, time.Time) (bool, error)
+}
+
+type IReminderUseCase interface {
+	// SendReminders sends the reminders that are due and returns how many
+	// were sent.
+	SendReminders() (int, error)
+}
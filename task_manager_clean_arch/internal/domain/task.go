@@ -2,7 +2,10 @@ package domain
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -14,6 +17,257 @@ type Task struct {
 	Description string
 	DueDate     time.Time
 	Status      string
+	// Priority is one of the Priority constants.
+	Priority string
+	// Tags are free-form labels, lower-cased and without duplicates.
+	Tags []string
+	// Version starts at 1 and goes up with every write. When set on a task
+	// passed to Update, the update only succeeds if the stored task still
+	// has that version.
+	Version int64
+	// DeletedAt is set while the task is in the trash. Trashed tasks are
+	// left out of every read except the trash listing.
+	DeletedAt time.Time
+	// Assignees are the users the task is shared with. They can read the
+	// task and change its status; everything else is left to its creator.
+	Assignees []string
+	// ProjectID is the project the task belongs to, zero for a personal
+	// task. It is set when the task is created and never changes.
+	ProjectID primitive.ObjectID
+	// ParentID is the task this one is a subtask of, zero for a top-level
+	// task. Like ProjectID it never changes. Subtasks cannot have subtasks of
+	// their own.
+	ParentID primitive.ObjectID
+	// Checklist holds the steps of the task that do not need a subtask.
+	Checklist []ChecklistItem
+	// Subtasks counts the subtasks outside the trash. It is not stored but
+	// filled in by the use case when the task is read.
+	Subtasks SubtaskCount
+	// BlockedBy lists the tasks that have to be finished before this one can
+	// be worked on. Like the assignees, it is only changed by AddBlocker and
+	// RemoveBlocker.
+	BlockedBy []primitive.ObjectID
+	// Blocked reports whether a task in BlockedBy is outside the trash and not
+	// finished yet. Like Subtasks it is filled in by the use case.
+	Blocked bool
+	// Overdue reports whether the task is past its due date without being
+	// finished. Like Subtasks it is filled in by the use case.
+	Overdue bool
+	// Recurrence is set on the occurrences of a recurring task.
+	Recurrence *Recurrence
+}
+
+// SeriesID is the first occurrence of the series the task belongs to, the
+// task itself when it does not recur or is the first occurrence.
+func (t Task) SeriesID() primitive.ObjectID {
+	if t.Recurrence != nil && !t.Recurrence.SeriesID.IsZero() {
+		return t.Recurrence.SeriesID
+	}
+	return t.ID
+}
+
+// ChecklistItem is one step of a task's checklist.
+type ChecklistItem struct {
+	Text string
+	Done bool
+}
+
+// SubtaskCount counts the subtasks of a task and how many of them are in the
+// final status of the workflow.
+type SubtaskCount struct {
+	Total int
+	Done  int
+}
+
+// Completion is the percentage of the task's subtasks and checklist items that
+// are done, rounded down. It is false when the task has neither.
+func (t Task) Completion() (int, bool) {
+	total, done := t.Subtasks.Total+len(t.Checklist), t.Subtasks.Done
+	if total == 0 {
+		return 0, false
+	}
+	for _, item := range t.Checklist {
+		if item.Done {
+			done++
+		}
+	}
+	return done * 100 / total, true
+}
+
+// Task priorities. Tasks created without a priority get PriorityMedium.
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+func IsPriority(priority string) bool {
+	switch priority {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// NormalizeTags trims and lower-cases the tags, dropping empty ones and
+// duplicates while keeping the original order.
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// IsOverdue reports whether the task is due before now and not in the final
+// status.
+func (t Task) IsOverdue(now time.Time, final string) bool {
+	return !t.DueDate.IsZero() && t.DueDate.Before(now) && t.Status != final
+}
+
+func (t Task) IsBlockedBy(id primitive.ObjectID) bool {
+	return slices.Contains(t.BlockedBy, id)
+}
+
+func (t Task) IsAssignee(username string) bool {
+	return slices.Contains(t.Assignees, username)
+}
+
+// CanView reports whether the user created the task or is assigned to it.
+func (t Task) CanView(username string) bool {
+	return t.CreatedBy == username || t.IsAssignee(username)
+}
+
+// TaskUpdate lists the task fields to change. Nil fields are left as they are.
+// A Recurrence with an empty rule stops the task from recurring. A non-zero
+// Version is the version the update expects to replace.
+type TaskUpdate struct {
+	Title       *string
+	Description *string
+	DueDate     *time.Time
+	Status      *string
+	Priority    *string
+	Tags        *[]string
+	Checklist   *[]ChecklistItem
+	Recurrence  *Recurrence
+	Version     int64
+}
+
+func (u TaskUpdate) IsEmpty() bool {
+	return u.Title == nil && u.Description == nil && u.DueDate == nil && u.Status == nil &&
+		u.Priority == nil && u.Tags == nil && u.Checklist == nil && u.Recurrence == nil
+}
+
+// Apply returns the task with the fields of the update changed.
+func (u TaskUpdate) Apply(task Task) Task {
+	if u.Title != nil {
+		task.Title = *u.Title
+	}
+	if u.Description != nil {
+		task.Description = *u.Description
+	}
+	if u.DueDate != nil {
+		task.DueDate = *u.DueDate
+	}
+	if u.Status != nil {
+		task.Status = *u.Status
+	}
+	if u.Priority != nil {
+		task.Priority = *u.Priority
+	}
+	if u.Tags != nil {
+		task.Tags = *u.Tags
+	}
+	if u.Checklist != nil {
+		task.Checklist = *u.Checklist
+	}
+	if u.Recurrence != nil {
+		task.Recurrence = nil
+		if u.Recurrence.Rule != "" {
+			recurrence := *u.Recurrence
+			task.Recurrence = &recurrence
+		}
+	}
+	return task
+}
+
+// OnlyStatus reports whether the update changes nothing but the status, the
+// one change assignees are allowed to make.
+func (u TaskUpdate) OnlyStatus() bool {
+	return u.Title == nil && u.Description == nil && u.DueDate == nil && u.Priority == nil && u.Tags == nil &&
+		u.Checklist == nil && u.Recurrence == nil
+}
+
+var (
+	ErrInvalidCursor   = NewError(ErrValidation, "invalid cursor")
+	ErrVersionConflict = NewError(ErrConflict, "task has been modified since it was read")
+)
+
+// Sort orders accepted by TaskQuery. A leading "-" sorts descending.
+const (
+	SortDueDateAsc  = "due_date"
+	SortDueDateDesc = "-due_date"
+	SortTitleAsc    = "title"
+	SortTitleDesc   = "-title"
+)
+
+const (
+	DefaultTaskPageLimit = 20
+	MaxTaskPageLimit     = 100
+)
+
+// TaskQuery filters, sorts and pages a task listing. Zero values mean "no
+// filter". When Cursor is set it takes precedence over Page.
+type TaskQuery struct {
+	CreatedBy string
+	Assignee  string
+	ProjectID primitive.ObjectID
+	Status    string
+	Priority  string
+	Tag       string
+	DueBefore time.Time
+	DueAfter  time.Time
+	Sort      string
+	Page      int
+	Limit     int
+	Cursor    string
+}
+
+// TaskPage is one page of a task listing. Total counts every task matching
+// the filters, and NextCursor is empty on the last page.
+type TaskPage struct {
+	Tasks      []Task
+	Total      int64
+	Page       int
+	Limit      int
+	NextCursor string
+}
+
+// TaskSearch is a full-text search over task titles and descriptions. An
+// empty CreatedBy searches every user's tasks.
+type TaskSearch struct {
+	Text      string
+	CreatedBy string
+	Limit     int
+}
+
+// Terms splits the search text into lower-cased words.
+func (s TaskSearch) Terms() []string {
+	return strings.FieldsFunc(strings.ToLower(s.Text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// TaskMatch is a task found by a search. Highlights holds the matched fields,
+// HTML-escaped, with the search terms wrapped in <em> tags.
+type TaskMatch struct {
+	Task       Task
+	Score      float64
+	Highlights map[string]string
 }
 
 type StatusCount struct {
@@ -21,30 +275,127 @@ type StatusCount struct {
 	Count  int
 }
 
+type PriorityCount struct {
+	Priority string
+	Count    int
+}
+
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// TaskStats counts tasks by status, by priority and by tag, largest groups
+// first. A task is counted once for each of its tags.
+type TaskStats struct {
+	ByStatus   []StatusCount
+	ByPriority []PriorityCount
+	ByTag      []TagCount
+}
+
 type TaskRepository interface {
 	GetAll(context.Context) ([]Task, error)
+	// GetAllInBatches calls the function with the tasks outside the trash,
+	// oldest first, in batches of at most the given size, so that they never
+	// have to be in memory at once. It stops at the first error the function
+	// returns.
+	GetAllInBatches(context.Context, int, func([]Task) error) error
 	GetById(context.Context, string) (Task, error)
 	GetByIdAndUser(context.Context, string, string) (Task, error)
 	Create(context.Context, *Task) error
+	// CreateMany inserts the tasks in order and stops at the first one that
+	// fails. The tasks that were inserted receive their ID and version.
+	CreateMany(context.Context, []*Task) error
+	// BulkWrite makes the writes in one request and returns one error per
+	// write, nil for the writes that were applied. A write whose task is
+	// missing or has another version fails with domain.ErrNotFound or
+	// domain.ErrVersionConflict. When atomic, either every write is applied
+	// or none is, and the writes that did not fail themselves get
+	// domain.ErrBatchAborted.
+	BulkWrite(context.Context, []TaskWrite, bool) ([]error, error)
 	Update(context.Context, string, *Task) error
 	UpdateByIdAndUser(context.Context, string, *Task, string) error
-	Delete(context.Context, string) error
-	DeleteByIdAndUser(context.Context, string, string) error
+	Patch(context.Context, string, TaskUpdate) (Task, error)
+	PatchByIdAndUser(context.Context, string, TaskUpdate, string) (Task, error)
+	Delete(context.Context, string, int64) error
+	DeleteByIdAndUser(context.Context, string, string, int64) error
+	GetTrashByUser(context.Context, string) ([]Task, error)
+	RestoreByIdAndUser(context.Context, string, string) (Task, error)
+	PurgeDeletedBefore(context.Context, time.Time) (int64, error)
+	AddAssignee(context.Context, string, string) (Task, error)
+	RemoveAssignee(context.Context, string, string) (Task, error)
+	AddBlocker(context.Context, string, primitive.ObjectID) (Task, error)
+	RemoveBlocker(context.Context, string, primitive.ObjectID) (Task, error)
+	// GetDueBetween lists the tasks outside the trash that are due after the
+	// first time and before the second one, and not in the given status, due
+	// first.
+	GetDueBetween(context.Context, time.Time, time.Time, string) ([]Task, error)
+	// GetStatuses returns the status of each of the tasks that is outside
+	// the trash.
+	GetStatuses(context.Context, []primitive.ObjectID) (map[primitive.ObjectID]string, error)
+	// GetExistingIds returns the IDs of the tasks that exist, in or outside
+	// the trash.
+	GetExistingIds(context.Context, []primitive.ObjectID) ([]primitive.ObjectID, error)
+	// CountByProject counts the tasks of the project, in or outside the
+	// trash.
+	CountByProject(context.Context, primitive.ObjectID) (int64, error)
 	GetByUser(context.Context, string) ([]Task, error)
-	GetTaskStatsByUser(context.Context, string) ([]StatusCount, error)
-	GetTaskCountByStatus(context.Context) ([]StatusCount, error)
+	GetTaskStatsByUser(context.Context, string) (TaskStats, error)
+	GetTaskCountByStatus(context.Context) (TaskStats, error)
+	Find(context.Context, TaskQuery) (TaskPage, error)
+	Search(context.Context, TaskSearch) ([]TaskMatch, error)
+	// GetSubtasks lists the subtasks of a task outside the trash, oldest
+	// first.
+	GetSubtasks(context.Context, string) ([]Task, error)
+	// CountSubtasksByStatus counts the subtasks outside the trash of each of
+	// the tasks by status. Tasks without subtasks are left out.
+	CountSubtasksByStatus(context.Context, []primitive.ObjectID) (map[primitive.ObjectID][]StatusCount, error)
 }
 
 type ITaskUseCase interface {
 	GetAll() ([]Task, error)
+	// ExportAll calls the function with every task outside the trash, oldest
+	// first, and stops at the first error it returns.
+	ExportAll(func(Task) error) error
 	GetById(string) (Task, error)
 	GetByIdAndUser(string, string) (Task, error)
 	Create(*Task) error
-	Update(string, *Task) error
+	// ImportByUser creates tasks for the user, or only checks them on a dry
+	// run. It returns one error per task, nil for the tasks that were
+	// created or passed the checks.
+	ImportByUser(string, []*Task, bool) ([]error, error)
+	Update(string, *Task, string) error
 	UpdateByIdAndUser(string, *Task, string) error
-	Delete(string) error
-	DeleteByIdAndUser(string, string) error
+	// UpdateOccurrenceByIdAndUser is UpdateByIdAndUser for a recurring task,
+	// with a scope saying whether the edit is passed on to the next
+	// occurrences.
+	UpdateOccurrenceByIdAndUser(string, *Task, string, string) error
+	Patch(string, TaskUpdate, string) (Task, error)
+	PatchByIdAndUser(string, TaskUpdate, string) (Task, error)
+	Delete(string, int64, string) error
+	DeleteByIdAndUser(string, string, int64) error
+	// Batch runs the operations on behalf of the actor and returns the
+	// outcome of each, in order. When atomic, either every operation is
+	// applied or none is.
+	Batch([]TaskOperation, bool, string) ([]TaskOperationResult, error)
+	// BatchByUser is Batch on the tasks the user created or, for status
+	// changes, is assigned to.
+	BatchByUser([]TaskOperation, bool, string) ([]TaskOperationResult, error)
+	GetHistory(string) ([]TaskHistory, error)
+	GetHistoryByIdAndUser(string, string) ([]TaskHistory, error)
+	GetTrashByUser(string) ([]Task, error)
+	RestoreByIdAndUser(string, string) (Task, error)
+	PurgeTrash(time.Duration) (int64, error)
+	AssignByIdAndUser(string, string, string) (Task, error)
+	UnassignByIdAndUser(string, string, string) (Task, error)
+	AddBlockerByIdAndUser(string, string, string) (Task, error)
+	RemoveBlockerByIdAndUser(string, string, string) (Task, error)
+	GetNextTasksByUser(string) ([]Task, error)
 	GetTasksByUser(string) ([]Task, error)
-	GetTaskStatsByUser(string) ([]StatusCount, error)
-	GetTaskCountByStatus() ([]StatusCount, error)
+	GetTaskStatsByUser(string) (TaskStats, error)
+	GetTaskCountByStatus() (TaskStats, error)
+	ListTasks(TaskQuery) (TaskPage, error)
+	SearchTasks(TaskSearch) ([]TaskMatch, error)
+	CreateSubtask(string, *Task, string) error
+	GetSubtasksByIdAndUser(string, string) ([]Task, error)
 }
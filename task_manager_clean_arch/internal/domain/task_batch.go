@@ -0,0 +1,40 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Kinds of operation in a task batch. A status change is an update of the
+// status only, which assignees are allowed to make.
+const (
+	TaskOpUpdate = "update"
+	TaskOpStatus = "status"
+	TaskOpDelete = "delete"
+)
+
+// ErrBatchAborted is the error of the operations of an all-or-nothing batch
+// that were not applied because another operation failed.
+var ErrBatchAborted = NewError(ErrConflict, "operation was not applied because another operation in the batch failed")
+
+// TaskOperation is one operation of a batch. Update holds the changes of an
+// update or the new status of a status change. Its Version, when not zero,
+// is the version the operation expects to replace, for deletes too.
+type TaskOperation struct {
+	Kind   string
+	ID     string
+	Update TaskUpdate
+}
+
+// TaskOperationResult is the outcome of an operation: the task as the
+// operation left it, or the error that kept it from being applied.
+type TaskOperationResult struct {
+	Task Task
+	Err  error
+}
+
+// TaskWrite is one write of a bulk write: an update of the task or, when
+// Delete is set, moving it to the trash. The Version of the update is the
+// version the write replaces and has to be set.
+type TaskWrite struct {
+	ID     primitive.ObjectID
+	Update TaskUpdate
+	Delete bool
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Actions recorded in a task's history.
+const (
+	TaskCreated  = "created"
+	TaskUpdated  = "updated"
+	TaskDeleted  = "deleted"
+	TaskRestored = "restored"
+)
+
+// TaskHistory is one change made to a task. Owner is the user the task
+// belonged to at the time, Actor the user who made the change, and Version
+// the task version the change produced.
+type TaskHistory struct {
+	ID      primitive.ObjectID
+	TaskID  primitive.ObjectID
+	Owner   string
+	Action  string
+	Actor   string
+	At      time.Time
+	Version int64
+	Changes []TaskChange
+}
+
+// TaskChange is the old and new value of one task field. Values are rendered
+// as strings, with empty strings for unset fields, RFC 3339 for times and
+// comma-separated lists for tags, assignees and the IDs of blocking tasks.
+// Checklist items are rendered as "[x] text" when done and "[ ] text"
+// otherwise, and recurrences as their rule.
+type TaskChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// DiffTasks lists the fields that differ between two versions of a task.
+// The ID and version are not compared.
+func DiffTasks(before, after Task) []TaskChange {
+	var changes []TaskChange
+	for _, field := range []struct {
+		name          string
+		before, after string
+	}{
+		{"title", before.Title, after.Title},
+		{"description", before.Description, after.Description},
+		{"due_date", formatTaskTime(before.DueDate), formatTaskTime(after.DueDate)},
+		{"status", before.Status, after.Status},
+		{"priority", before.Priority, after.Priority},
+		{"tags", strings.Join(before.Tags, ","), strings.Join(after.Tags, ",")},
+		{"checklist", formatChecklist(before.Checklist), formatChecklist(after.Checklist)},
+		{"created_by", before.CreatedBy, after.CreatedBy},
+		{"assignees", strings.Join(before.Assignees, ","), strings.Join(after.Assignees, ",")},
+		{"blocked_by", formatTaskIDs(before.BlockedBy), formatTaskIDs(after.BlockedBy)},
+		{"rrule", formatRecurrence(before.Recurrence), formatRecurrence(after.Recurrence)},
+		{"deleted_at", formatTaskTime(before.DeletedAt), formatTaskTime(after.DeletedAt)},
+	} {
+		if field.before != field.after {
+			changes = append(changes, TaskChange{Field: field.name, From: field.before, To: field.after})
+		}
+	}
+	return changes
+}
+
+func formatTaskIDs(ids []primitive.ObjectID) string {
+	formatted := make([]string, len(ids))
+	for i, id := range ids {
+		formatted[i] = id.Hex()
+	}
+	return strings.Join(formatted, ",")
+}
+
+func formatRecurrence(recurrence *Recurrence) string {
+	if recurrence == nil {
+		return ""
+	}
+	return recurrence.Rule
+}
+
+func formatChecklist(items []ChecklistItem) string {
+	formatted := make([]string, len(items))
+	for i, item := range items {
+		if item.Done {
+			formatted[i] = "[x] " + item.Text
+		} else {
+			formatted[i] = "[ ] " + item.Text
+		}
+	}
+	return strings.Join(formatted, ",")
+}
+
+func formatTaskTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+type TaskHistoryRepository interface {
+	Add(context.Context, *TaskHistory) error
+	// GetByTask returns the changes made to a task, most recent first.
+	GetByTask(context.Context, string) ([]TaskHistory, error)
+	GetByTaskAndUser(context.Context, string, string) ([]TaskHistory, error)
+}
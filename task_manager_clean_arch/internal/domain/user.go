@@ -12,6 +12,9 @@ type User struct {
 	Email    string
 	Password string
 	Role     string
+	// TokenVersion is bumped on "log out everywhere"; tokens carrying an
+	// older version are rejected.
+	TokenVersion int
 }
 type UserRepository interface {
 	GetAll(context.Context) ([]User, error)
@@ -20,6 +23,7 @@ type UserRepository interface {
 	Insert(context.Context, *User) error
 	GetUser(context.Context, string, string) (*User, error)
 	GetUserFromContext(c *gin.Context) *User
+	IncrementTokenVersion(context.Context, string) error
 }
 
 type IUserUseCase interface {
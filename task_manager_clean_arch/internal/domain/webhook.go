@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Event types webhooks can subscribe to.
+const (
+	EventTaskCreated    = "task.created"
+	EventTaskUpdated    = "task.updated"
+	EventTaskDeleted    = "task.deleted"
+	EventUserRegistered = "user.registered"
+)
+
+func IsEventType(eventType string) bool {
+	switch eventType {
+	case EventTaskCreated, EventTaskUpdated, EventTaskDeleted, EventUserRegistered:
+		return true
+	}
+	return false
+}
+
+// Event is something that happened to a task or a user. Task is set on task
+// events and User on user events.
+type Event struct {
+	ID    primitive.ObjectID
+	Type  string
+	At    time.Time
+	Actor string
+	Task  *Task
+	User  *User
+}
+
+// Webhook subscribes a URL to events. Deliveries are signed with the secret
+// so that the receiver can check they come from this API.
+type Webhook struct {
+	ID        primitive.ObjectID
+	URL       string
+	Events    []string
+	Secret    string
+	Active    bool
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// Subscribes reports whether the webhook is active and wants events of the
+// type.
+func (w Webhook) Subscribes(eventType string) bool {
+	return w.Active && slices.Contains(w.Events, eventType)
+}
+
+// Delivery statuses. A pending delivery is retried until it succeeds or has
+// been attempted MaxWebhookAttempts times.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+const MaxWebhookAttempts = 8
+
+// WebhookBackoff is how long to wait before the next attempt of a delivery
+// that failed the given number of times: 30 seconds after the first failure,
+// doubling with every failure after that.
+func WebhookBackoff(failures int) time.Duration {
+	return 30 * time.Second << max(failures-1, 0)
+}
+
+// WebhookDelivery is one delivery of an event to a webhook, with the outcome
+// of its last attempt.
+type WebhookDelivery struct {
+	ID             primitive.ObjectID
+	WebhookID      primitive.ObjectID
+	Event          Event
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    time.Time
+}
+
+type WebhookRepository interface {
+	GetAll(context.Context) ([]Webhook, error)
+	GetById(context.Context, string) (Webhook, error)
+	// GetByEvent lists the active webhooks subscribed to the event type.
+	GetByEvent(context.Context, string) ([]Webhook, error)
+	Create(context.Context, *Webhook) error
+	Update(context.Context, string, *Webhook) error
+	Delete(context.Context, string) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(context.Context, *WebhookDelivery) error
+	GetById(context.Context, string) (WebhookDelivery, error)
+	// GetByWebhook lists the deliveries of a webhook, most recent first.
+	GetByWebhook(context.Context, string, int) ([]WebhookDelivery, error)
+	// ClaimDue takes the pending delivery whose next attempt is the earliest
+	// one not after the time, and moves its next attempt back by the lease so
+	// that no one else takes it meanwhile. It fails with ErrNotFound when no
+	// delivery is due.
+	ClaimDue(context.Context, time.Time, time.Duration) (WebhookDelivery, error)
+	Update(context.Context, *WebhookDelivery) error
+	DeleteByWebhook(context.Context, string) error
+}
+
+// WebhookSender posts a delivery to its webhook and returns the status code
+// of the response, zero when there was none.
+type WebhookSender interface {
+	Send(context.Context, Webhook, WebhookDelivery) (int, error)
+}
+
+// EventPublisher queues an event for delivery to the webhooks subscribed to
+// it.
+type EventPublisher interface {
+	Publish(context.Context, Event)
+}
+
+type IWebhookUseCase interface {
+	GetAll() ([]Webhook, error)
+	GetById(string) (Webhook, error)
+	Create(*Webhook) error
+	Update(string, *Webhook) error
+	Delete(string) error
+	GetDeliveries(string) ([]WebhookDelivery, error)
+	// Redeliver queues the event of a delivery again and returns the new
+	// delivery.
+	Redeliver(string, string) (WebhookDelivery, error)
+	// DeliverDue attempts the deliveries that are due and returns how many
+	// succeeded.
+	DeliverDue() (int, error)
+}
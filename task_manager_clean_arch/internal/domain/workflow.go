@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const MaxWorkflowStatuses = 20
+
+var workflowStatusPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// Workflow lists the statuses a task can have and which status changes are
+// allowed. The first status is the one new tasks start in and the last one
+// marks them as finished. Every task
+// follows the same workflow, which admins can replace at any time.
+type Workflow struct {
+	Statuses []string
+	// Transitions maps a status to the statuses a task can move to from it.
+	Transitions map[string][]string
+	UpdatedBy   string
+	UpdatedAt   time.Time
+}
+
+// DefaultWorkflow is used until an admin configures one. Tasks move freely
+// between pending and completed.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Statuses: []string{"pending", "completed"},
+		Transitions: map[string][]string{
+			"pending":   {"completed"},
+			"completed": {"pending"},
+		},
+	}
+}
+
+// Initial is the status new tasks start in.
+func (w Workflow) Initial() string {
+	if len(w.Statuses) == 0 {
+		return ""
+	}
+	return w.Statuses[0]
+}
+
+// Final is the status finished tasks end in, the last one of the workflow.
+func (w Workflow) Final() string {
+	if len(w.Statuses) == 0 {
+		return ""
+	}
+	return w.Statuses[len(w.Statuses)-1]
+}
+
+func (w Workflow) Has(status string) bool {
+	return slices.Contains(w.Statuses, status)
+}
+
+// CanMove reports whether a task can move from one status to another. Tasks
+// whose status is no longer part of the workflow can move to any status of
+// it, so that they are not stuck after the workflow changes.
+func (w Workflow) CanMove(from, to string) bool {
+	if !w.Has(to) {
+		return false
+	}
+	if from == to || !w.Has(from) {
+		return true
+	}
+	return slices.Contains(w.Transitions[from], to)
+}
+
+// CheckMove returns the error to report when a task cannot move from one
+// status to another.
+func (w Workflow) CheckMove(from, to string) error {
+	if !w.Has(to) {
+		return NewError(ErrValidation, "status must be one of "+strings.Join(w.Statuses, ", "))
+	}
+	if !w.CanMove(from, to) {
+		return NewError(ErrConflict, fmt.Sprintf("a task cannot move from %q to %q", from, to))
+	}
+	return nil
+}
+
+// Validate checks that the statuses are well-formed and unique and that the
+// transitions only name statuses of the workflow.
+func (w Workflow) Validate() error {
+	if len(w.Statuses) == 0 || len(w.Statuses) > MaxWorkflowStatuses {
+		return NewError(ErrValidation, fmt.Sprintf("a workflow needs between 1 and %d statuses", MaxWorkflowStatuses))
+	}
+	for i, status := range w.Statuses {
+		if !workflowStatusPattern.MatchString(status) {
+			return NewError(ErrValidation, fmt.Sprintf("invalid status %q: use up to 32 lower-case letters, digits and underscores", status))
+		}
+		if slices.Contains(w.Statuses[:i], status) {
+			return NewError(ErrValidation, fmt.Sprintf("duplicate status %q", status))
+		}
+	}
+	for from, targets := range w.Transitions {
+		if !w.Has(from) {
+			return NewError(ErrValidation, fmt.Sprintf("transition from unknown status %q", from))
+		}
+		for _, to := range targets {
+			if !w.Has(to) {
+				return NewError(ErrValidation, fmt.Sprintf("transition to unknown status %q", to))
+			}
+		}
+	}
+	return nil
+}
+
+type WorkflowRepository interface {
+	// Get returns the configured workflow, or an ErrNotFound error if none
+	// has been saved yet.
+	Get(context.Context) (Workflow, error)
+	Save(context.Context, *Workflow) error
+}
+
+type IWorkflowUseCase interface {
+	Get() (Workflow, error)
+	Update(*Workflow) error
+}
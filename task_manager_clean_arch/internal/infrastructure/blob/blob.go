@@ -0,0 +1,30 @@
+package blob
+
+import (
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NewBlobStore builds the store selected by BLOB_STORE. GridFS needs the
+// Mongo driver, so the memory driver always keeps blobs on the local disk.
+func NewBlobStore(env *config.Env, db mongo.Database) domain.BlobStore {
+	if env.BlobStore == config.BlobStoreGridFS && env.DBDriver != config.DBDriverMemory {
+		return NewGridFSStore(db, env.BlobGridFSBucket)
+	}
+	return NewLocalStore(env.BlobDir)
+}
+
+// checkKey only lets through keys made of letters, digits, '-' and '_', so
+// that a key can never name a path outside the store.
+func checkKey(key string) error {
+	if key == "" {
+		return domain.NewError(domain.ErrValidation, "blob key cannot be empty")
+	}
+	for _, r := range key {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '-' || r == '_') {
+			return domain.NewError(domain.ErrValidation, "invalid blob key")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFSStore keeps blobs in a GridFS bucket, using the key as the file ID.
+type GridFSStore struct {
+	Database mongo.Database
+	Bucket   string
+}
+
+func NewGridFSStore(db mongo.Database, bucket string) domain.BlobStore {
+	return &GridFSStore{Database: db, Bucket: bucket}
+}
+
+// bucket opens the bucket with the deadline of the context, as GridFS
+// streams do not take a context.
+func (s *GridFSStore) bucket(ctx context.Context) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(&s.Database, options.GridFSBucket().SetName(s.Bucket))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetReadDeadline(deadline)
+		bucket.SetWriteDeadline(deadline)
+	}
+	return bucket, nil
+}
+
+// Put replaces the content of a key by deleting the old file before the new
+// one is uploaded.
+func (s *GridFSStore) Put(ctx context.Context, key string, content io.Reader) (int64, error) {
+	if err := checkKey(key); err != nil {
+		return 0, err
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		return 0, err
+	}
+	bucket, err := s.bucket(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stream, err := bucket.OpenUploadStreamWithID(key, key)
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(stream, content)
+	if err != nil {
+		stream.Abort()
+		return 0, err
+	}
+	return size, stream.Close()
+}
+
+// Open starts a download without a deadline, as the stream keeps the
+// deadline of its bucket and is read after the call returns.
+func (s *GridFSStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	bucket, err := s.bucket(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	stream, err := bucket.OpenDownloadStream(key)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, errBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (s *GridFSStore) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	bucket, err := s.bucket(ctx)
+	if err != nil {
+		return err
+	}
+	if err := bucket.DeleteContext(ctx, key); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+var errBlobNotFound = domain.NewError(domain.ErrNotFound, "file not found")
+
+// LocalStore keeps every blob in a file of its own, named after its key, in
+// a directory that is created on the first Put.
+type LocalStore struct {
+	Dir string
+}
+
+func NewLocalStore(dir string) domain.BlobStore {
+	return &LocalStore{Dir: dir}
+}
+
+// Put writes the content to a temporary file first and renames it, so that
+// readers never see a partly written blob.
+func (s *LocalStore) Put(ctx context.Context, key string, content io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(s.Dir, 0o750); err != nil {
+		return 0, err
+	}
+	file, err := os.CreateTemp(s.Dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+	size, err := io.Copy(file, &contextReader{ctx: ctx, reader: content})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return size, os.Rename(file.Name(), path)
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errBlobNotFound
+	}
+	return file, err
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Dir, key), nil
+}
+
+// contextReader stops reading once the context is done.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
@@ -0,0 +1,13 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type AttachmentEntity struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID      primitive.ObjectID `bson:"task_id"`
+	Filename    string             `bson:"filename"`
+	ContentType string             `bson:"content_type"`
+	Size        int64              `bson:"size"`
+	UploadedBy  string             `bson:"uploaded_by"`
+	CreatedAt   primitive.DateTime `bson:"created_at"`
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func FromDomainToAttachmentEntity(a *domain.Attachment) (*AttachmentEntity, error) {
+	if a == nil {
+		return nil, errors.New("attachment cannot be nil")
+	}
+	return &AttachmentEntity{
+		ID:          a.ID,
+		TaskID:      a.TaskID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   primitive.NewDateTimeFromTime(a.CreatedAt),
+	}, nil
+}
+
+func FromAttachmentEntityToDomain(e *AttachmentEntity) *domain.Attachment {
+	return &domain.Attachment{
+		ID:          e.ID,
+		TaskID:      e.TaskID,
+		Filename:    e.Filename,
+		ContentType: e.ContentType,
+		Size:        e.Size,
+		UploadedBy:  e.UploadedBy,
+		CreatedAt:   e.CreatedAt.Time(),
+	}
+}
+
+func FromAttachmentEntityListToDomainList(entities []AttachmentEntity) []domain.Attachment {
+	var attachments []domain.Attachment
+	for _, entity := range entities {
+		attachments = append(attachments, *FromAttachmentEntityToDomain(&entity))
+	}
+	return attachments
+}
@@ -0,0 +1,13 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type CommentEntity struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID    primitive.ObjectID `bson:"task_id"`
+	Author    string             `bson:"author"`
+	Body      string             `bson:"body"`
+	Mentions  []string           `bson:"mentions"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+	UpdatedAt primitive.DateTime `bson:"updated_at,omitempty"`
+}
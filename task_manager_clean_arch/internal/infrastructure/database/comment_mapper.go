@@ -0,0 +1,43 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func FromDomainToCommentEntity(c *domain.Comment) (*CommentEntity, error) {
+	if c == nil {
+		return nil, errors.New("comment cannot be nil")
+	}
+	return &CommentEntity{
+		ID:        c.ID,
+		TaskID:    c.TaskID,
+		Author:    c.Author,
+		Body:      c.Body,
+		Mentions:  append([]string{}, c.Mentions...),
+		CreatedAt: primitive.NewDateTimeFromTime(c.CreatedAt),
+		UpdatedAt: fromOptionalTime(c.UpdatedAt),
+	}, nil
+}
+
+func FromCommentEntityToDomain(e *CommentEntity) *domain.Comment {
+	return &domain.Comment{
+		ID:        e.ID,
+		TaskID:    e.TaskID,
+		Author:    e.Author,
+		Body:      e.Body,
+		Mentions:  append([]string{}, e.Mentions...),
+		CreatedAt: e.CreatedAt.Time(),
+		UpdatedAt: toOptionalTime(e.UpdatedAt),
+	}
+}
+
+func FromCommentEntityListToDomainList(entities []CommentEntity) []domain.Comment {
+	var comments []domain.Comment
+	for _, entity := range entities {
+		comments = append(comments, *FromCommentEntityToDomain(&entity))
+	}
+	return comments
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/yiheyistm/task_manager/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TaskTitleWeight makes a match in the title count this many times more than
+// a match in the description when ranking search results.
+const TaskTitleWeight = 3
+
+// CreateIndexes creates the indexes the repositories rely on. Creating an
+// index that already exists is a no-op, so it is safe to run on every start.
+func CreateIndexes(env *config.Env, db mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	textIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().
+			SetName("task_text").
+			SetWeights(bson.D{{Key: "title", Value: TaskTitleWeight}, {Key: "description", Value: 1}}),
+	}
+	if _, err := db.Collection(env.DBTaskCollection).Indexes().CreateOne(ctx, textIndex); err != nil {
+		return err
+	}
+
+	historyIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "task_id", Value: 1}, {Key: "at", Value: -1}},
+		Options: options.Index().SetName("task_history_task"),
+	}
+	if _, err := db.Collection(env.DBTaskHistoryCollection).Indexes().CreateOne(ctx, historyIndex); err != nil {
+		return err
+	}
+
+	projectTaskIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "project_id", Value: 1}},
+		Options: options.Index().SetName("task_project").SetSparse(true),
+	}
+	if _, err := db.Collection(env.DBTaskCollection).Indexes().CreateOne(ctx, projectTaskIndex); err != nil {
+		return err
+	}
+
+	tagIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "tags", Value: 1}},
+		Options: options.Index().SetName("task_tags").SetSparse(true),
+	}
+	if _, err := db.Collection(env.DBTaskCollection).Indexes().CreateOne(ctx, tagIndex); err != nil {
+		return err
+	}
+
+	subtaskIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "parent_id", Value: 1}},
+		Options: options.Index().SetName("task_parent").SetSparse(true),
+	}
+	if _, err := db.Collection(env.DBTaskCollection).Indexes().CreateOne(ctx, subtaskIndex); err != nil {
+		return err
+	}
+
+	dueIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "due_date", Value: 1}},
+		Options: options.Index().SetName("task_due_date"),
+	}
+	if _, err := db.Collection(env.DBTaskCollection).Indexes().CreateOne(ctx, dueIndex); err != nil {
+		return err
+	}
+
+	dueDeliveryIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		Options: options.Index().SetName("webhook_delivery_due"),
+	}
+	if _, err := db.Collection(env.DBWebhookDeliveryCollection).Indexes().CreateOne(ctx, dueDeliveryIndex); err != nil {
+		return err
+	}
+
+	webhookDeliveryIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("webhook_delivery_webhook"),
+	}
+	if _, err := db.Collection(env.DBWebhookDeliveryCollection).Indexes().CreateOne(ctx, webhookDeliveryIndex); err != nil {
+		return err
+	}
+
+	commentIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "task_id", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("task_comments_task"),
+	}
+	if _, err := db.Collection(env.DBCommentCollection).Indexes().CreateOne(ctx, commentIndex); err != nil {
+		return err
+	}
+
+	attachmentIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "task_id", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("task_attachments_task"),
+	}
+	if _, err := db.Collection(env.DBAttachmentCollection).Indexes().CreateOne(ctx, attachmentIndex); err != nil {
+		return err
+	}
+
+	// Refresh sessions and logged out access tokens are of no use once the
+	// token expired, so MongoDB removes them then.
+	refreshTokenIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("refresh_token_expiry").SetExpireAfterSeconds(0),
+	}
+	if _, err := db.Collection(env.DBRefreshTokenCollection).Indexes().CreateOne(ctx, refreshTokenIndex); err != nil {
+		return err
+	}
+
+	denylistIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("token_denylist_expiry").SetExpireAfterSeconds(0),
+	}
+	if _, err := db.Collection(env.DBTokenDenylistCollection).Indexes().CreateOne(ctx, denylistIndex); err != nil {
+		return err
+	}
+
+	// Rate limit buckets are removed once they are full again, as a bucket
+	// that is not stored is full.
+	rateLimitIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("rate_limit_expiry").SetExpireAfterSeconds(0),
+	}
+	if _, err := db.Collection(env.DBRateLimitCollection).Indexes().CreateOne(ctx, rateLimitIndex); err != nil {
+		return err
+	}
+
+	memberIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "members.username", Value: 1}},
+		Options: options.Index().SetName("project_members"),
+	}
+	_, err := db.Collection(env.DBProjectCollection).Indexes().CreateOne(ctx, memberIndex)
+	return err
+}
@@ -0,0 +1,17 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type ProjectEntity struct {
+	ID          primitive.ObjectID    `bson:"_id,omitempty"`
+	Name        string                `bson:"name"`
+	Description string                `bson:"description"`
+	CreatedBy   string                `bson:"created_by"`
+	CreatedAt   primitive.DateTime    `bson:"created_at"`
+	Members     []ProjectMemberEntity `bson:"members"`
+}
+
+type ProjectMemberEntity struct {
+	Username string `bson:"username"`
+	Role     string `bson:"role"`
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func FromDomainToProjectEntity(p *domain.Project) (*ProjectEntity, error) {
+	if p == nil {
+		return nil, errors.New("project cannot be nil")
+	}
+	members := make([]ProjectMemberEntity, len(p.Members))
+	for i, member := range p.Members {
+		members[i] = ProjectMemberEntity{Username: member.Username, Role: member.Role}
+	}
+	return &ProjectEntity{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		CreatedBy:   p.CreatedBy,
+		CreatedAt:   primitive.NewDateTimeFromTime(p.CreatedAt),
+		Members:     members,
+	}, nil
+}
+
+func FromProjectEntityToDomain(e *ProjectEntity) *domain.Project {
+	var members []domain.ProjectMember
+	for _, member := range e.Members {
+		members = append(members, domain.ProjectMember{Username: member.Username, Role: member.Role})
+	}
+	return &domain.Project{
+		ID:          e.ID,
+		Name:        e.Name,
+		Description: e.Description,
+		CreatedBy:   e.CreatedBy,
+		CreatedAt:   e.CreatedAt.Time(),
+		Members:     members,
+	}
+}
+
+func FromProjectEntityListToDomainList(entities []ProjectEntity) []domain.Project {
+	var projects []domain.Project
+	for _, entity := range entities {
+		projects = append(projects, *FromProjectEntityToDomain(&entity))
+	}
+	return projects
+}
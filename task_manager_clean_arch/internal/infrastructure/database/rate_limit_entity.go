@@ -0,0 +1,14 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// RateLimitEntity is the token bucket of a rate limit key. Version guards the
+// read-modify-write of the bucket, and ExpiresAt, when the bucket is full
+// again, lets the TTL index remove it.
+type RateLimitEntity struct {
+	ID        string             `bson:"_id"`
+	Tokens    float64            `bson:"tokens"`
+	At        primitive.DateTime `bson:"at"`
+	Version   int64              `bson:"version"`
+	ExpiresAt primitive.DateTime `bson:"expires_at"`
+}
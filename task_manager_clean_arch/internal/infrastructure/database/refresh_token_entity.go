@@ -0,0 +1,18 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type RefreshSessionEntity struct {
+	ID        string             `bson:"_id"`
+	FamilyID  string             `bson:"family_id"`
+	Username  string             `bson:"username"`
+	Used      bool               `bson:"used"`
+	Revoked   bool               `bson:"revoked"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+	ExpiresAt primitive.DateTime `bson:"expires_at"`
+}
+
+type DeniedTokenEntity struct {
+	ID        string             `bson:"_id"`
+	ExpiresAt primitive.DateTime `bson:"expires_at"`
+}
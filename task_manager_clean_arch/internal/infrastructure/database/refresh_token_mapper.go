@@ -0,0 +1,35 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func FromDomainToRefreshSessionEntity(s *domain.RefreshSession) (*RefreshSessionEntity, error) {
+	if s == nil {
+		return nil, errors.New("refresh session cannot be nil")
+	}
+	return &RefreshSessionEntity{
+		ID:        s.ID,
+		FamilyID:  s.FamilyID,
+		Username:  s.Username,
+		Used:      s.Used,
+		Revoked:   s.Revoked,
+		CreatedAt: primitive.NewDateTimeFromTime(s.CreatedAt),
+		ExpiresAt: primitive.NewDateTimeFromTime(s.ExpiresAt),
+	}, nil
+}
+
+func FromRefreshSessionEntityToDomain(e *RefreshSessionEntity) *domain.RefreshSession {
+	return &domain.RefreshSession{
+		ID:        e.ID,
+		FamilyID:  e.FamilyID,
+		Username:  e.Username,
+		Used:      e.Used,
+		Revoked:   e.Revoked,
+		CreatedAt: e.CreatedAt.Time(),
+		ExpiresAt: e.ExpiresAt.Time(),
+	}
+}
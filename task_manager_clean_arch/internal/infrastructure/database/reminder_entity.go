@@ -0,0 +1,13 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ReminderEntity records a reminder that has been sent. Its ID is built by
+// ReminderID, so a second insert of the same reminder fails.
+type ReminderEntity struct {
+	ID      string             `bson:"_id"`
+	TaskID  primitive.ObjectID `bson:"task_id"`
+	Kind    string             `bson:"kind"`
+	DueDate primitive.DateTime `bson:"due_date"`
+	SentAt  primitive.DateTime `bson:"sent_at"`
+}
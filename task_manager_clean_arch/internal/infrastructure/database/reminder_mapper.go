@@ -0,0 +1,24 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReminderID identifies the reminder of the kind for the task due at the
+// time. A task whose due date changes gets its reminders again.
+func ReminderID(taskID primitive.ObjectID, kind string, dueDate time.Time) string {
+	return fmt.Sprintf("%s:%s:%d", taskID.Hex(), kind, primitive.NewDateTimeFromTime(dueDate))
+}
+
+func NewReminderEntity(taskID primitive.ObjectID, kind string, dueDate, sentAt time.Time) *ReminderEntity {
+	return &ReminderEntity{
+		ID:      ReminderID(taskID, kind, dueDate),
+		TaskID:  taskID,
+		Kind:    kind,
+		DueDate: primitive.NewDateTimeFromTime(dueDate),
+		SentAt:  primitive.NewDateTimeFromTime(sentAt),
+	}
+}
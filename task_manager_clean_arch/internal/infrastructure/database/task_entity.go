@@ -2,19 +2,92 @@
 package database
 
 import (
+	"github.com/yiheyistm/task_manager/internal/domain"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type TaskEntity struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Title       string             `bson:"title"`
-	CreatedBy   string             `bson:"created_by"`
-	Description string             `bson:"description"`
-	DueDate     primitive.DateTime `bson:"due_date"`
-	Status      string             `bson:"status"`
+	ID          primitive.ObjectID    `bson:"_id,omitempty"`
+	Title       string                `bson:"title"`
+	CreatedBy   string                `bson:"created_by"`
+	Description string                `bson:"description"`
+	DueDate     primitive.DateTime    `bson:"due_date"`
+	Status      string                `bson:"status"`
+	Priority    string                `bson:"priority,omitempty"`
+	Tags        []string              `bson:"tags,omitempty"`
+	Version     int64                 `bson:"version,omitempty"`
+	DeletedAt   primitive.DateTime    `bson:"deleted_at,omitempty"`
+	Assignees   []string              `bson:"assignees,omitempty"`
+	ProjectID   primitive.ObjectID    `bson:"project_id,omitempty"`
+	ParentID    primitive.ObjectID    `bson:"parent_id,omitempty"`
+	Checklist   []ChecklistItemEntity `bson:"checklist,omitempty"`
+	BlockedBy   []primitive.ObjectID  `bson:"blocked_by,omitempty"`
+	Recurrence  *RecurrenceEntity     `bson:"recurrence,omitempty"`
 }
 
-type StatusCount struct {
-	Status string `bson:"_id"`
-	Count  int    `bson:"count"`
+type RecurrenceEntity struct {
+	Rule       string                    `bson:"rule"`
+	SeriesID   primitive.ObjectID        `bson:"series_id,omitempty"`
+	Occurrence int                       `bson:"occurrence"`
+	NextID     primitive.ObjectID        `bson:"next_id,omitempty"`
+	Template   *OccurrenceTemplateEntity `bson:"template,omitempty"`
+}
+
+type OccurrenceTemplateEntity struct {
+	Title       string                `bson:"title"`
+	Description string                `bson:"description"`
+	DueDate     primitive.DateTime    `bson:"due_date"`
+	Priority    string                `bson:"priority"`
+	Tags        []string              `bson:"tags,omitempty"`
+	Checklist   []ChecklistItemEntity `bson:"checklist,omitempty"`
+}
+
+type ChecklistItemEntity struct {
+	Text string `bson:"text"`
+	Done bool   `bson:"done"`
+}
+
+// TaskSearchEntity is a task returned by a $text query along with its
+// relevance score.
+type TaskSearchEntity struct {
+	TaskEntity `bson:",inline"`
+	Score      float64 `bson:"score"`
+}
+
+// EffectivePriority is the priority of the task, with tasks stored before
+// priorities existed counting as medium.
+func (e TaskEntity) EffectivePriority() string {
+	if e.Priority == "" {
+		return domain.PriorityMedium
+	}
+	return e.Priority
+}
+
+// TaskStatusEntity is a task projected to its status.
+type TaskStatusEntity struct {
+	ID     primitive.ObjectID `bson:"_id"`
+	Status string             `bson:"status"`
+}
+
+// GroupCount is one group of a $group stage counting tasks.
+type GroupCount struct {
+	Key   string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// SubtaskCountEntity is one group of the $group stage counting subtasks by
+// parent and status.
+type SubtaskCountEntity struct {
+	Key struct {
+		ParentID primitive.ObjectID `bson:"parent_id"`
+		Status   string             `bson:"status"`
+	} `bson:"_id"`
+	Count int `bson:"count"`
+}
+
+// TaskStatsEntity is the result of the $facet stage computing task stats.
+type TaskStatsEntity struct {
+	ByStatus   []GroupCount `bson:"by_status"`
+	ByPriority []GroupCount `bson:"by_priority"`
+	ByTag      []GroupCount `bson:"by_tag"`
 }
@@ -0,0 +1,20 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type TaskHistoryEntity struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID  primitive.ObjectID `bson:"task_id"`
+	Owner   string             `bson:"owner"`
+	Action  string             `bson:"action"`
+	Actor   string             `bson:"actor"`
+	At      primitive.DateTime `bson:"at"`
+	Version int64              `bson:"version"`
+	Changes []TaskChangeEntity `bson:"changes"`
+}
+
+type TaskChangeEntity struct {
+	Field string `bson:"field"`
+	From  string `bson:"from"`
+	To    string `bson:"to"`
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func FromDomainToTaskHistoryEntity(h *domain.TaskHistory) (*TaskHistoryEntity, error) {
+	if h == nil {
+		return nil, errors.New("task history cannot be nil")
+	}
+	changes := make([]TaskChangeEntity, len(h.Changes))
+	for i, change := range h.Changes {
+		changes[i] = TaskChangeEntity{Field: change.Field, From: change.From, To: change.To}
+	}
+	return &TaskHistoryEntity{
+		ID:      h.ID,
+		TaskID:  h.TaskID,
+		Owner:   h.Owner,
+		Action:  h.Action,
+		Actor:   h.Actor,
+		At:      primitive.NewDateTimeFromTime(h.At),
+		Version: h.Version,
+		Changes: changes,
+	}, nil
+}
+
+func FromTaskHistoryEntityToDomain(e *TaskHistoryEntity) *domain.TaskHistory {
+	var changes []domain.TaskChange
+	for _, change := range e.Changes {
+		changes = append(changes, domain.TaskChange{Field: change.Field, From: change.From, To: change.To})
+	}
+	return &domain.TaskHistory{
+		ID:      e.ID,
+		TaskID:  e.TaskID,
+		Owner:   e.Owner,
+		Action:  e.Action,
+		Actor:   e.Actor,
+		At:      e.At.Time(),
+		Version: e.Version,
+		Changes: changes,
+	}
+}
+
+func FromTaskHistoryEntityListToDomainList(entities []TaskHistoryEntity) []domain.TaskHistory {
+	var history []domain.TaskHistory
+	for _, entity := range entities {
+		history = append(history, *FromTaskHistoryEntityToDomain(&entity))
+	}
+	return history
+}
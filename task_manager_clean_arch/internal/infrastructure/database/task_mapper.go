@@ -2,8 +2,10 @@ package database
 
 import (
 	"errors"
+	"time"
 
 	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -19,6 +21,16 @@ func FromDomainToTaskEntity(u *domain.Task) (*TaskEntity, error) {
 		CreatedBy:   u.CreatedBy,
 		DueDate:     primitive.NewDateTimeFromTime(u.DueDate),
 		Status:      u.Status,
+		Priority:    u.Priority,
+		Tags:        u.Tags,
+		Version:     u.Version,
+		DeletedAt:   fromDeletedAt(u.DeletedAt),
+		Assignees:   u.Assignees,
+		ProjectID:   u.ProjectID,
+		ParentID:    u.ParentID,
+		Checklist:   FromDomainChecklistToEntity(u.Checklist),
+		BlockedBy:   u.BlockedBy,
+		Recurrence:  FromDomainRecurrenceToEntity(u.Recurrence),
 	}, nil
 }
 
@@ -30,9 +42,130 @@ func FromTaskEntityToDomain(e *TaskEntity) *domain.Task {
 		Description: e.Description,
 		DueDate:     e.DueDate.Time(),
 		Status:      e.Status,
+		Priority:    e.EffectivePriority(),
+		Tags:        e.Tags,
+		Version:     e.Version,
+		DeletedAt:   toDeletedAt(e.DeletedAt),
+		Assignees:   e.Assignees,
+		ProjectID:   e.ProjectID,
+		ParentID:    e.ParentID,
+		Checklist:   FromChecklistEntityToDomain(e.Checklist),
+		BlockedBy:   e.BlockedBy,
+		Recurrence:  FromRecurrenceEntityToDomain(e.Recurrence),
 	}
 }
 
+func FromDomainChecklistToEntity(items []domain.ChecklistItem) []ChecklistItemEntity {
+	var entities []ChecklistItemEntity
+	for _, item := range items {
+		entities = append(entities, ChecklistItemEntity{Text: item.Text, Done: item.Done})
+	}
+	return entities
+}
+
+func FromChecklistEntityToDomain(entities []ChecklistItemEntity) []domain.ChecklistItem {
+	var items []domain.ChecklistItem
+	for _, entity := range entities {
+		items = append(items, domain.ChecklistItem{Text: entity.Text, Done: entity.Done})
+	}
+	return items
+}
+
+// FromDomainRecurrenceToEntity maps a recurrence to its entity, leaving out
+// a recurrence without a rule.
+func FromDomainRecurrenceToEntity(r *domain.Recurrence) *RecurrenceEntity {
+	if r == nil || r.Rule == "" {
+		return nil
+	}
+	entity := &RecurrenceEntity{
+		Rule:       r.Rule,
+		SeriesID:   r.SeriesID,
+		Occurrence: r.Occurrence,
+		NextID:     r.NextID,
+	}
+	if t := r.Template; t != nil {
+		entity.Template = &OccurrenceTemplateEntity{
+			Title:       t.Title,
+			Description: t.Description,
+			DueDate:     primitive.NewDateTimeFromTime(t.DueDate),
+			Priority:    t.Priority,
+			Tags:        t.Tags,
+			Checklist:   FromDomainChecklistToEntity(t.Checklist),
+		}
+	}
+	return entity
+}
+
+func FromRecurrenceEntityToDomain(e *RecurrenceEntity) *domain.Recurrence {
+	if e == nil {
+		return nil
+	}
+	recurrence := &domain.Recurrence{
+		Rule:       e.Rule,
+		SeriesID:   e.SeriesID,
+		Occurrence: e.Occurrence,
+		NextID:     e.NextID,
+	}
+	if t := e.Template; t != nil {
+		recurrence.Template = &domain.OccurrenceTemplate{
+			Title:       t.Title,
+			Description: t.Description,
+			DueDate:     t.DueDate.Time(),
+			Priority:    t.Priority,
+			Tags:        t.Tags,
+			Checklist:   FromChecklistEntityToDomain(t.Checklist),
+		}
+	}
+	return recurrence
+}
+
+// fromDeletedAt stores the zero time of a task that is not in the trash as
+// zero, so that the field is omitted.
+func fromDeletedAt(t time.Time) primitive.DateTime {
+	if t.IsZero() {
+		return 0
+	}
+	return primitive.NewDateTimeFromTime(t)
+}
+
+func toDeletedAt(d primitive.DateTime) time.Time {
+	if d == 0 {
+		return time.Time{}
+	}
+	return d.Time()
+}
+
+// FromDomainTaskUpdateToSet builds the $set document for the fields of a
+// partial update.
+func FromDomainTaskUpdateToSet(u domain.TaskUpdate) bson.M {
+	set := bson.M{}
+	if u.Title != nil {
+		set["title"] = *u.Title
+	}
+	if u.Description != nil {
+		set["description"] = *u.Description
+	}
+	if u.DueDate != nil {
+		set["due_date"] = primitive.NewDateTimeFromTime(*u.DueDate)
+	}
+	if u.Status != nil {
+		set["status"] = *u.Status
+	}
+	if u.Priority != nil {
+		set["priority"] = *u.Priority
+	}
+	if u.Tags != nil {
+		set["tags"] = *u.Tags
+	}
+	if u.Checklist != nil {
+		set["checklist"] = FromDomainChecklistToEntity(*u.Checklist)
+	}
+	if u.Recurrence != nil {
+		set["recurrence"] = FromDomainRecurrenceToEntity(u.Recurrence)
+	}
+	return set
+}
+
 func FromTaskEntityListToDomainList(entities []TaskEntity) []domain.Task {
 	var tasks []domain.Task
 	for _, entity := range entities {
@@ -41,13 +174,44 @@ func FromTaskEntityListToDomainList(entities []TaskEntity) []domain.Task {
 	return tasks
 }
 
-func FromStatusCountListToDomainList(entities []StatusCount) []domain.StatusCount {
-	var statusCounts []domain.StatusCount
+func FromTaskSearchEntityListToDomainList(entities []TaskSearchEntity) []domain.TaskMatch {
+	var matches []domain.TaskMatch
 	for _, entity := range entities {
-		statusCounts = append(statusCounts, domain.StatusCount{
-			Status: entity.Status,
-			Count:  entity.Count,
+		matches = append(matches, domain.TaskMatch{
+			Task:  *FromTaskEntityToDomain(&entity.TaskEntity),
+			Score: entity.Score,
 		})
 	}
-	return statusCounts
+	return matches
+}
+
+func FromTaskStatusEntityListToDomain(entities []TaskStatusEntity) map[primitive.ObjectID]string {
+	statuses := make(map[primitive.ObjectID]string)
+	for _, entity := range entities {
+		statuses[entity.ID] = entity.Status
+	}
+	return statuses
+}
+
+// FromSubtaskCountEntityListToDomain groups the subtask counts by parent.
+func FromSubtaskCountEntityListToDomain(entities []SubtaskCountEntity) map[primitive.ObjectID][]domain.StatusCount {
+	counts := make(map[primitive.ObjectID][]domain.StatusCount)
+	for _, entity := range entities {
+		counts[entity.Key.ParentID] = append(counts[entity.Key.ParentID], domain.StatusCount{Status: entity.Key.Status, Count: entity.Count})
+	}
+	return counts
+}
+
+func FromTaskStatsEntityToDomain(e *TaskStatsEntity) domain.TaskStats {
+	var stats domain.TaskStats
+	for _, group := range e.ByStatus {
+		stats.ByStatus = append(stats.ByStatus, domain.StatusCount{Status: group.Key, Count: group.Count})
+	}
+	for _, group := range e.ByPriority {
+		stats.ByPriority = append(stats.ByPriority, domain.PriorityCount{Priority: group.Key, Count: group.Count})
+	}
+	for _, group := range e.ByTag {
+		stats.ByTag = append(stats.ByTag, domain.TagCount{Tag: group.Key, Count: group.Count})
+	}
+	return stats
 }
@@ -11,20 +11,22 @@ func FromDomainToEntity(u *domain.User) (*UserEntity, error) {
 		return nil, errors.New("user cannot be nil")
 	}
 	return &UserEntity{
-		Username: u.Username,
-		Email:    u.Email,
-		Password: u.Password,
-		Role:     u.Role,
+		Username:     u.Username,
+		Email:        u.Email,
+		Password:     u.Password,
+		Role:         u.Role,
+		TokenVersion: u.TokenVersion,
 	}, nil
 }
 
 func FromEntityToDomain(e *UserEntity) *domain.User {
 	return &domain.User{
-		ID:       e.ID.Hex(),
-		Username: e.Username,
-		Email:    e.Email,
-		Password: e.Password,
-		Role:     e.Role,
+		ID:           e.ID.Hex(),
+		Username:     e.Username,
+		Email:        e.Email,
+		Password:     e.Password,
+		Role:         e.Role,
+		TokenVersion: e.TokenVersion,
 	}
 }
 func FromEntityListToDomainList(entities []UserEntity) []domain.User {
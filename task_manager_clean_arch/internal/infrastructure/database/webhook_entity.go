@@ -0,0 +1,46 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type WebhookEntity struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	URL       string             `bson:"url"`
+	Events    []string           `bson:"events"`
+	Secret    string             `bson:"secret"`
+	Active    bool               `bson:"active"`
+	CreatedBy string             `bson:"created_by"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+type WebhookDeliveryEntity struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	WebhookID      primitive.ObjectID `bson:"webhook_id"`
+	Event          EventEntity        `bson:"event"`
+	Status         string             `bson:"status"`
+	Attempts       int                `bson:"attempts"`
+	NextAttemptAt  primitive.DateTime `bson:"next_attempt_at"`
+	LastStatusCode int                `bson:"last_status_code,omitempty"`
+	LastError      string             `bson:"last_error,omitempty"`
+	CreatedAt      primitive.DateTime `bson:"created_at"`
+	DeliveredAt    primitive.DateTime `bson:"delivered_at,omitempty"`
+}
+
+// EventEntity is the event a delivery carries, with a copy of the task or
+// user as it was when the event happened.
+type EventEntity struct {
+	ID    primitive.ObjectID `bson:"id"`
+	Type  string             `bson:"type"`
+	At    primitive.DateTime `bson:"at"`
+	Actor string             `bson:"actor,omitempty"`
+	Task  *TaskEntity        `bson:"task,omitempty"`
+	User  *EventUserEntity   `bson:"user,omitempty"`
+}
+
+// EventUserEntity is the part of a user copied into an event. The password
+// is left out.
+type EventUserEntity struct {
+	ID       string `bson:"id"`
+	Username string `bson:"username"`
+	Email    string `bson:"email"`
+	Role     string `bson:"role"`
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func FromDomainToWebhookEntity(w *domain.Webhook) (*WebhookEntity, error) {
+	if w == nil {
+		return nil, errors.New("webhook cannot be nil")
+	}
+	return &WebhookEntity{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    w.Events,
+		Secret:    w.Secret,
+		Active:    w.Active,
+		CreatedBy: w.CreatedBy,
+		CreatedAt: primitive.NewDateTimeFromTime(w.CreatedAt),
+	}, nil
+}
+
+func FromWebhookEntityToDomain(e *WebhookEntity) *domain.Webhook {
+	return &domain.Webhook{
+		ID:        e.ID,
+		URL:       e.URL,
+		Events:    e.Events,
+		Secret:    e.Secret,
+		Active:    e.Active,
+		CreatedBy: e.CreatedBy,
+		CreatedAt: e.CreatedAt.Time(),
+	}
+}
+
+func FromWebhookEntityListToDomainList(entities []WebhookEntity) []domain.Webhook {
+	var webhooks []domain.Webhook
+	for _, entity := range entities {
+		webhooks = append(webhooks, *FromWebhookEntityToDomain(&entity))
+	}
+	return webhooks
+}
+
+func FromDomainToWebhookDeliveryEntity(d *domain.WebhookDelivery) (*WebhookDeliveryEntity, error) {
+	if d == nil {
+		return nil, errors.New("webhook delivery cannot be nil")
+	}
+	event, err := FromDomainToEventEntity(d.Event)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookDeliveryEntity{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		Event:          event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  primitive.NewDateTimeFromTime(d.NextAttemptAt),
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      primitive.NewDateTimeFromTime(d.CreatedAt),
+		DeliveredAt:    fromOptionalTime(d.DeliveredAt),
+	}, nil
+}
+
+func FromWebhookDeliveryEntityToDomain(e *WebhookDeliveryEntity) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             e.ID,
+		WebhookID:      e.WebhookID,
+		Event:          FromEventEntityToDomain(e.Event),
+		Status:         e.Status,
+		Attempts:       e.Attempts,
+		NextAttemptAt:  e.NextAttemptAt.Time(),
+		LastStatusCode: e.LastStatusCode,
+		LastError:      e.LastError,
+		CreatedAt:      e.CreatedAt.Time(),
+		DeliveredAt:    toOptionalTime(e.DeliveredAt),
+	}
+}
+
+func FromWebhookDeliveryEntityListToDomainList(entities []WebhookDeliveryEntity) []domain.WebhookDelivery {
+	var deliveries []domain.WebhookDelivery
+	for _, entity := range entities {
+		deliveries = append(deliveries, *FromWebhookDeliveryEntityToDomain(&entity))
+	}
+	return deliveries
+}
+
+func FromDomainToEventEntity(e domain.Event) (EventEntity, error) {
+	entity := EventEntity{
+		ID:    e.ID,
+		Type:  e.Type,
+		At:    primitive.NewDateTimeFromTime(e.At),
+		Actor: e.Actor,
+	}
+	if e.Task != nil {
+		task, err := FromDomainToTaskEntity(e.Task)
+		if err != nil {
+			return EventEntity{}, err
+		}
+		entity.Task = task
+	}
+	if e.User != nil {
+		entity.User = &EventUserEntity{ID: e.User.ID, Username: e.User.Username, Email: e.User.Email, Role: e.User.Role}
+	}
+	return entity, nil
+}
+
+func FromEventEntityToDomain(e EventEntity) domain.Event {
+	event := domain.Event{
+		ID:    e.ID,
+		Type:  e.Type,
+		At:    e.At.Time(),
+		Actor: e.Actor,
+	}
+	if e.Task != nil {
+		event.Task = FromTaskEntityToDomain(e.Task)
+	}
+	if e.User != nil {
+		event.User = &domain.User{ID: e.User.ID, Username: e.User.Username, Email: e.User.Email, Role: e.User.Role}
+	}
+	return event
+}
+
+// fromOptionalTime stores a zero time as an unset field.
+func fromOptionalTime(t time.Time) primitive.DateTime {
+	if t.IsZero() {
+		return 0
+	}
+	return primitive.NewDateTimeFromTime(t)
+}
+
+func toOptionalTime(d primitive.DateTime) time.Time {
+	if d == 0 {
+		return time.Time{}
+	}
+	return d.Time()
+}
@@ -0,0 +1,14 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// WorkflowID is the _id of the single workflow document.
+const WorkflowID = "tasks"
+
+type WorkflowEntity struct {
+	ID          string              `bson:"_id"`
+	Statuses    []string            `bson:"statuses"`
+	Transitions map[string][]string `bson:"transitions"`
+	UpdatedBy   string              `bson:"updated_by"`
+	UpdatedAt   primitive.DateTime  `bson:"updated_at"`
+}
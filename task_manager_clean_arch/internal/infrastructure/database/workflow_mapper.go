@@ -0,0 +1,42 @@
+package database
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func FromDomainToWorkflowEntity(w *domain.Workflow) (*WorkflowEntity, error) {
+	if w == nil {
+		return nil, errors.New("workflow cannot be nil")
+	}
+	return &WorkflowEntity{
+		ID:          WorkflowID,
+		Statuses:    slices.Clone(w.Statuses),
+		Transitions: cloneTransitions(w.Transitions),
+		UpdatedBy:   w.UpdatedBy,
+		UpdatedAt:   primitive.NewDateTimeFromTime(w.UpdatedAt),
+	}, nil
+}
+
+func FromWorkflowEntityToDomain(e *WorkflowEntity) domain.Workflow {
+	return domain.Workflow{
+		Statuses:    slices.Clone(e.Statuses),
+		Transitions: cloneTransitions(e.Transitions),
+		UpdatedBy:   e.UpdatedBy,
+		UpdatedAt:   e.UpdatedAt.Time(),
+	}
+}
+
+// cloneTransitions copies the transitions so that the stored workflow does
+// not share slices with the caller's.
+func cloneTransitions(transitions map[string][]string) map[string][]string {
+	cloned := maps.Clone(transitions)
+	for from, targets := range cloned {
+		cloned[from] = slices.Clone(targets)
+	}
+	return cloned
+}
@@ -0,0 +1,21 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// LogNotifier writes reminders to the log. It is meant for development.
+type LogNotifier struct{}
+
+func NewLogNotifier() domain.Notifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, reminder domain.Reminder) error {
+	log.Printf("Reminder: %s (due %s) for %s", describe(reminder), reminder.Task.DueDate.Format(time.RFC3339), usernames(reminder.Recipients))
+	return nil
+}
@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// NewNotifier builds the notifier selected by NOTIFIER.
+func NewNotifier(env *config.Env) (domain.Notifier, error) {
+	switch env.Notifier {
+	case config.NotifierLog:
+		return NewLogNotifier(), nil
+	case config.NotifierSMTP:
+		return NewSMTPNotifier(env.SMTPHost, env.SMTPPort, env.SMTPUsername, env.SMTPPassword, env.SMTPFrom), nil
+	case config.NotifierWebhook:
+		if env.ReminderWebhookURL == "" {
+			return nil, fmt.Errorf("REMINDER_WEBHOOK_URL is required by the webhook notifier")
+		}
+		return NewWebhookNotifier(env.ReminderWebhookURL), nil
+	}
+	return nil, fmt.Errorf("unknown NOTIFIER %q", env.Notifier)
+}
+
+// usernames lists the usernames of the recipients, separated by commas.
+func usernames(recipients []domain.ReminderRecipient) string {
+	names := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		names[i] = recipient.Username
+	}
+	return strings.Join(names, ", ")
+}
+
+// describe says what the reminder is about in a short sentence.
+func describe(reminder domain.Reminder) string {
+	state := "is due soon"
+	if reminder.Kind == domain.ReminderOverdue {
+		state = "is overdue"
+	}
+	return fmt.Sprintf("Task %q %s", reminder.Task.Title, state)
+}
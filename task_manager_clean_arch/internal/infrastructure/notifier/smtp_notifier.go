@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// SMTPNotifier emails reminders to the recipients that have an email
+// address. Without a username it sends without authentication, as local test
+// servers like MailHog expect.
+type SMTPNotifier struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+}
+
+func NewSMTPNotifier(host, port, username, password, from string) domain.Notifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPNotifier{
+		Addr: net.JoinHostPort(host, port),
+		Auth: auth,
+		From: from,
+	}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, reminder domain.Reminder) error {
+	var to []string
+	for _, recipient := range reminder.Recipients {
+		if recipient.Email != "" {
+			to = append(to, recipient.Email)
+		}
+	}
+	if len(to) == 0 {
+		return nil
+	}
+	return smtp.SendMail(n.Addr, n.Auth, n.From, to, n.message(reminder, to))
+}
+
+// message builds the email. The subject is encoded so that a task title
+// cannot add headers of its own.
+func (n *SMTPNotifier) message(reminder domain.Reminder, to []string) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", describe(reminder)))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s.\r\n\r\n", describe(reminder))
+	fmt.Fprintf(&msg, "Due: %s\r\n", reminder.Task.DueDate.Format(time.RFC1123))
+	fmt.Fprintf(&msg, "Status: %s\r\n", reminder.Task.Status)
+	fmt.Fprintf(&msg, "Task ID: %s\r\n", reminder.Task.ID.Hex())
+	return msg.Bytes()
+}
@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// WebhookNotifier posts reminders as JSON to a URL. Any status outside 2xx
+// counts as a failed delivery.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string) domain.Notifier {
+	return &WebhookNotifier{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ReminderPayload is the body posted for a reminder.
+type ReminderPayload struct {
+	Kind       string    `json:"kind"`
+	TaskID     string    `json:"task_id"`
+	Title      string    `json:"title"`
+	DueDate    time.Time `json:"due_date"`
+	Status     string    `json:"status"`
+	Recipients []string  `json:"recipients"`
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, reminder domain.Reminder) error {
+	payload := ReminderPayload{
+		Kind:       reminder.Kind,
+		TaskID:     reminder.Task.ID.Hex(),
+		Title:      reminder.Task.Title,
+		DueDate:    reminder.Task.DueDate,
+		Status:     reminder.Task.Status,
+		Recipients: make([]string, len(reminder.Recipients)),
+	}
+	for i, recipient := range reminder.Recipients {
+		payload.Recipients[i] = recipient.Username
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AttachmentRepositoryImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewAttachmentRepository(db mongo.Database, collection string) domain.AttachmentRepository {
+	return &AttachmentRepositoryImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+var errAttachmentNotFound = domain.NewError(domain.ErrNotFound, "attachment not found")
+
+func (r *AttachmentRepositoryImpl) Create(ctx context.Context, attachment *domain.Attachment) error {
+	entity, err := database.FromDomainToAttachmentEntity(attachment)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	if _, err := r.Database.Collection(r.Collection).InsertOne(ctx, entity); err != nil {
+		return err
+	}
+	attachment.ID = entity.ID
+	return nil
+}
+
+func (r *AttachmentRepositoryImpl) GetById(ctx context.Context, id string) (domain.Attachment, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Attachment{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	var entity database.AttachmentEntity
+	err = r.Database.Collection(r.Collection).FindOne(ctx, bson.M{"_id": objectID}).Decode(&entity)
+	if err == mongo.ErrNoDocuments {
+		return domain.Attachment{}, errAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return *database.FromAttachmentEntityToDomain(&entity), nil
+}
+
+func (r *AttachmentRepositoryImpl) GetByTask(ctx context.Context, taskID string) ([]domain.Attachment, error) {
+	objectID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.Database.Collection(r.Collection).Find(ctx, bson.M{"task_id": objectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var entities []database.AttachmentEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, err
+	}
+	return database.FromAttachmentEntityListToDomainList(entities), nil
+}
+
+func (r *AttachmentRepositoryImpl) GetTaskIds(ctx context.Context) ([]primitive.ObjectID, error) {
+	values, err := r.Database.Collection(r.Collection).Distinct(ctx, "task_id", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	ids := []primitive.ObjectID{}
+	for _, value := range values {
+		if id, ok := value.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *AttachmentRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	result, err := r.Database.Collection(r.Collection).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errAttachmentNotFound
+	}
+	return nil
+}
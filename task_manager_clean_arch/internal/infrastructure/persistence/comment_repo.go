@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentRepositoryImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewCommentRepository(db mongo.Database, collection string) domain.CommentRepository {
+	return &CommentRepositoryImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+var errCommentNotFound = domain.NewError(domain.ErrNotFound, "comment not found")
+
+func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *domain.Comment) error {
+	entity, err := database.FromDomainToCommentEntity(comment)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	if _, err := r.Database.Collection(r.Collection).InsertOne(ctx, entity); err != nil {
+		return err
+	}
+	comment.ID = entity.ID
+	return nil
+}
+
+func (r *CommentRepositoryImpl) GetById(ctx context.Context, id string) (domain.Comment, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Comment{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	var entity database.CommentEntity
+	err = r.Database.Collection(r.Collection).FindOne(ctx, bson.M{"_id": objectID}).Decode(&entity)
+	if err == mongo.ErrNoDocuments {
+		return domain.Comment{}, errCommentNotFound
+	}
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return *database.FromCommentEntityToDomain(&entity), nil
+}
+
+func (r *CommentRepositoryImpl) GetByTask(ctx context.Context, taskID string) ([]domain.Comment, error) {
+	objectID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.Database.Collection(r.Collection).Find(ctx, bson.M{"task_id": objectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var entities []database.CommentEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, err
+	}
+	return database.FromCommentEntityListToDomainList(entities), nil
+}
+
+// Update replaces the body and mentions of the comment.
+func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *domain.Comment) error {
+	entity, err := database.FromDomainToCommentEntity(comment)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"body": entity.Body, "mentions": entity.Mentions, "updated_at": entity.UpdatedAt}}
+	result, err := r.Database.Collection(r.Collection).UpdateByID(ctx, entity.ID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errCommentNotFound
+	}
+	return nil
+}
+
+func (r *CommentRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	result, err := r.Database.Collection(r.Collection).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errCommentNotFound
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryAttachmentRepositoryImpl keeps attachments in process memory. It
+// mirrors the behaviour of AttachmentRepositoryImpl.
+type MemoryAttachmentRepositoryImpl struct {
+	mu          sync.RWMutex
+	attachments map[primitive.ObjectID]database.AttachmentEntity
+}
+
+func NewMemoryAttachmentRepository() domain.AttachmentRepository {
+	return &MemoryAttachmentRepositoryImpl{
+		attachments: make(map[primitive.ObjectID]database.AttachmentEntity),
+	}
+}
+
+func (r *MemoryAttachmentRepositoryImpl) Create(ctx context.Context, attachment *domain.Attachment) error {
+	entity, err := database.FromDomainToAttachmentEntity(attachment)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.attachments[entity.ID]; ok {
+		return domain.NewError(domain.ErrConflict, "attachment already exists")
+	}
+	r.attachments[entity.ID] = *entity
+	attachment.ID = entity.ID
+	return nil
+}
+
+func (r *MemoryAttachmentRepositoryImpl) GetById(ctx context.Context, id string) (domain.Attachment, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Attachment{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entity, ok := r.attachments[objectID]
+	if !ok {
+		return domain.Attachment{}, errAttachmentNotFound
+	}
+	return *database.FromAttachmentEntityToDomain(&entity), nil
+}
+
+func (r *MemoryAttachmentRepositoryImpl) GetByTask(ctx context.Context, taskID string) ([]domain.Attachment, error) {
+	objectID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	var entities []database.AttachmentEntity
+	for _, entity := range r.attachments {
+		if entity.TaskID == objectID {
+			entities = append(entities, entity)
+		}
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(entities, func(a, b database.AttachmentEntity) int {
+		return cmp.Or(cmp.Compare(a.CreatedAt, b.CreatedAt), bytes.Compare(a.ID[:], b.ID[:]))
+	})
+	return database.FromAttachmentEntityListToDomainList(entities), nil
+}
+
+func (r *MemoryAttachmentRepositoryImpl) GetTaskIds(ctx context.Context) ([]primitive.ObjectID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := []primitive.ObjectID{}
+	for _, entity := range r.attachments {
+		if !slices.Contains(ids, entity.TaskID) {
+			ids = append(ids, entity.TaskID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *MemoryAttachmentRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.attachments[objectID]; !ok {
+		return errAttachmentNotFound
+	}
+	delete(r.attachments, objectID)
+	return nil
+}
@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryCommentRepositoryImpl keeps comments in process memory. It mirrors
+// the behaviour of CommentRepositoryImpl.
+type MemoryCommentRepositoryImpl struct {
+	mu       sync.RWMutex
+	comments map[primitive.ObjectID]database.CommentEntity
+}
+
+func NewMemoryCommentRepository() domain.CommentRepository {
+	return &MemoryCommentRepositoryImpl{
+		comments: make(map[primitive.ObjectID]database.CommentEntity),
+	}
+}
+
+func (r *MemoryCommentRepositoryImpl) Create(ctx context.Context, comment *domain.Comment) error {
+	entity, err := database.FromDomainToCommentEntity(comment)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.comments[entity.ID]; ok {
+		return domain.NewError(domain.ErrConflict, "comment already exists")
+	}
+	r.comments[entity.ID] = *entity
+	comment.ID = entity.ID
+	return nil
+}
+
+func (r *MemoryCommentRepositoryImpl) GetById(ctx context.Context, id string) (domain.Comment, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Comment{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entity, ok := r.comments[objectID]
+	if !ok {
+		return domain.Comment{}, errCommentNotFound
+	}
+	return *database.FromCommentEntityToDomain(&entity), nil
+}
+
+func (r *MemoryCommentRepositoryImpl) GetByTask(ctx context.Context, taskID string) ([]domain.Comment, error) {
+	objectID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	var entities []database.CommentEntity
+	for _, entity := range r.comments {
+		if entity.TaskID == objectID {
+			entities = append(entities, entity)
+		}
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(entities, func(a, b database.CommentEntity) int {
+		return cmp.Or(cmp.Compare(a.CreatedAt, b.CreatedAt), bytes.Compare(a.ID[:], b.ID[:]))
+	})
+	return database.FromCommentEntityListToDomainList(entities), nil
+}
+
+func (r *MemoryCommentRepositoryImpl) Update(ctx context.Context, comment *domain.Comment) error {
+	entity, err := database.FromDomainToCommentEntity(comment)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.comments[entity.ID]
+	if !ok {
+		return errCommentNotFound
+	}
+	stored.Body, stored.Mentions, stored.UpdatedAt = entity.Body, entity.Mentions, entity.UpdatedAt
+	r.comments[entity.ID] = stored
+	return nil
+}
+
+func (r *MemoryCommentRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.comments[objectID]; !ok {
+		return errCommentNotFound
+	}
+	delete(r.comments, objectID)
+	return nil
+}
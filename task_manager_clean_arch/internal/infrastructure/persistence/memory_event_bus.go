@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// subscriberBuffer is how many events a subscriber can be behind before it is
+// dropped.
+const subscriberBuffer = 64
+
+type eventSubscriber struct {
+	events chan domain.StreamEvent
+	filter func(domain.Event) bool
+}
+
+// MemoryEventBusImpl keeps the latest events in a ring buffer. Subscribers
+// that do not keep up are dropped rather than slowing down the publisher;
+// they can resume from the last event they received.
+type MemoryEventBusImpl struct {
+	mu          sync.Mutex
+	seq         uint64
+	kept        []domain.StreamEvent
+	size        int
+	subscribers map[*eventSubscriber]struct{}
+}
+
+// NewMemoryEventBus keeps the latest size events for subscribers that resume.
+func NewMemoryEventBus(size int) domain.EventBus {
+	return &MemoryEventBusImpl{
+		size:        max(size, 0),
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+func (b *MemoryEventBusImpl) Publish(ctx context.Context, event domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	streamed := domain.StreamEvent{Seq: b.seq, Event: event}
+	if b.size > 0 {
+		if len(b.kept) == b.size {
+			b.kept = append(b.kept[:0], b.kept[1:]...)
+		}
+		b.kept = append(b.kept, streamed)
+	}
+	for subscriber := range b.subscribers {
+		if !subscriber.filter(event) {
+			continue
+		}
+		select {
+		case subscriber.events <- streamed:
+		default:
+			b.unsubscribe(subscriber)
+		}
+	}
+}
+
+func (b *MemoryEventBusImpl) Subscribe(ctx context.Context, after uint64, filter func(domain.Event) bool) (<-chan domain.StreamEvent, bool) {
+	if filter == nil {
+		filter = func(domain.Event) bool { return true }
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	complete := true
+	switch {
+	case after > b.seq:
+		// The position comes from before the process was restarted.
+		complete = false
+	case after > 0 && after < b.seq:
+		complete = len(b.kept) > 0 && b.kept[0].Seq <= after+1
+	}
+	var missed []domain.StreamEvent
+	if after > 0 {
+		for _, streamed := range b.kept {
+			if streamed.Seq > after && filter(streamed.Event) {
+				missed = append(missed, streamed)
+			}
+		}
+	}
+	subscriber := &eventSubscriber{
+		events: make(chan domain.StreamEvent, subscriberBuffer+len(missed)),
+		filter: filter,
+	}
+	for _, streamed := range missed {
+		subscriber.events <- streamed
+	}
+	b.subscribers[subscriber] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.unsubscribe(subscriber)
+	}()
+	return subscriber.events, complete
+}
+
+// unsubscribe closes the channel of the subscriber unless it is already
+// gone. The lock must be held.
+func (b *MemoryEventBusImpl) unsubscribe(subscriber *eventSubscriber) {
+	if _, ok := b.subscribers[subscriber]; ok {
+		delete(b.subscribers, subscriber)
+		close(subscriber.events)
+	}
+}
@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryProjectRepositoryImpl keeps projects in process memory. It mirrors
+// the behaviour of ProjectRepositoryImpl.
+type MemoryProjectRepositoryImpl struct {
+	mu       sync.RWMutex
+	projects map[primitive.ObjectID]database.ProjectEntity
+}
+
+func NewMemoryProjectRepository() domain.ProjectRepository {
+	return &MemoryProjectRepositoryImpl{
+		projects: make(map[primitive.ObjectID]database.ProjectEntity),
+	}
+}
+
+func (r *MemoryProjectRepositoryImpl) Create(ctx context.Context, project *domain.Project) error {
+	entity, err := database.FromDomainToProjectEntity(project)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.projects[entity.ID]; ok {
+		return domain.NewError(domain.ErrConflict, "project already exists")
+	}
+	r.projects[entity.ID] = *entity
+	project.ID = entity.ID
+	return nil
+}
+
+func (r *MemoryProjectRepositoryImpl) GetById(ctx context.Context, id string) (domain.Project, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Project{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entity, ok := r.projects[objectID]
+	if !ok {
+		return domain.Project{}, errProjectNotFound
+	}
+	return *database.FromProjectEntityToDomain(&entity), nil
+}
+
+func (r *MemoryProjectRepositoryImpl) GetByMember(ctx context.Context, username string) ([]domain.Project, error) {
+	r.mu.RLock()
+	var entities []database.ProjectEntity
+	for _, entity := range r.projects {
+		if slices.ContainsFunc(entity.Members, func(m database.ProjectMemberEntity) bool { return m.Username == username }) {
+			entities = append(entities, entity)
+		}
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(entities, func(a, b database.ProjectEntity) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), bytes.Compare(a.ID[:], b.ID[:]))
+	})
+	return database.FromProjectEntityListToDomainList(entities), nil
+}
+
+func (r *MemoryProjectRepositoryImpl) Update(ctx context.Context, id string, project *domain.Project) error {
+	_, err := r.change(id, func(entity *database.ProjectEntity) {
+		entity.Name = project.Name
+		entity.Description = project.Description
+	})
+	return err
+}
+
+func (r *MemoryProjectRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.projects[objectID]; !ok {
+		return errProjectNotFound
+	}
+	delete(r.projects, objectID)
+	return nil
+}
+
+func (r *MemoryProjectRepositoryImpl) SetMember(ctx context.Context, id string, member domain.ProjectMember) (domain.Project, error) {
+	return r.change(id, func(entity *database.ProjectEntity) {
+		for i := range entity.Members {
+			if entity.Members[i].Username == member.Username {
+				entity.Members[i].Role = member.Role
+				return
+			}
+		}
+		entity.Members = append(entity.Members, database.ProjectMemberEntity{Username: member.Username, Role: member.Role})
+	})
+}
+
+func (r *MemoryProjectRepositoryImpl) RemoveMember(ctx context.Context, id string, username string) (domain.Project, error) {
+	return r.change(id, func(entity *database.ProjectEntity) {
+		entity.Members = slices.DeleteFunc(entity.Members, func(m database.ProjectMemberEntity) bool { return m.Username == username })
+	})
+}
+
+// change applies the change to a copy of the stored project and stores the
+// result, so that projects returned earlier are left untouched.
+func (r *MemoryProjectRepositoryImpl) change(id string, change func(*database.ProjectEntity)) (domain.Project, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Project{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entity, ok := r.projects[objectID]
+	if !ok {
+		return domain.Project{}, errProjectNotFound
+	}
+	entity.Members = slices.Clone(entity.Members)
+	change(&entity)
+	r.projects[objectID] = entity
+	return *database.FromProjectEntityToDomain(&entity), nil
+}
@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// rateLimitSweepInterval is how often the buckets that are full again are
+// dropped, so that clients seen once do not stay in memory.
+const rateLimitSweepInterval = time.Minute
+
+type rateLimitBucket struct {
+	bucket domain.TokenBucket
+	fullAt time.Time
+}
+
+type MemoryRateLimitStoreImpl struct {
+	mu      sync.Mutex
+	buckets map[string]rateLimitBucket
+	swept   time.Time
+}
+
+func NewMemoryRateLimitStore() domain.RateLimitStore {
+	return &MemoryRateLimitStoreImpl{
+		buckets: make(map[string]rateLimitBucket),
+	}
+}
+
+func (s *MemoryRateLimitStoreImpl) Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.swept) >= rateLimitSweepInterval {
+		for k, b := range s.buckets {
+			if !b.fullAt.After(now) {
+				delete(s.buckets, k)
+			}
+		}
+		s.swept = now
+	}
+	bucket, result := limit.Take(s.buckets[key].bucket, now)
+	s.buckets[key] = rateLimitBucket{bucket: bucket, fullAt: limit.FullAt(bucket)}
+	return result, nil
+}
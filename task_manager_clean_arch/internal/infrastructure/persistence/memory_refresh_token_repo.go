@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+type MemoryRefreshTokenStoreImpl struct {
+	mu       sync.Mutex
+	sessions map[string]domain.RefreshSession
+}
+
+func NewMemoryRefreshTokenStore() domain.RefreshTokenStore {
+	return &MemoryRefreshTokenStoreImpl{
+		sessions: make(map[string]domain.RefreshSession),
+	}
+}
+
+func (s *MemoryRefreshTokenStoreImpl) Save(ctx context.Context, session *domain.RefreshSession) error {
+	if session == nil {
+		return errors.New("refresh session cannot be nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.sessions[session.ID]; exists {
+		return domain.NewError(domain.ErrConflict, "duplicate key error: refresh session "+session.ID+" already exists")
+	}
+	s.sessions[session.ID] = *session
+	return nil
+}
+
+func (s *MemoryRefreshTokenStoreImpl) GetByID(ctx context.Context, id string) (*domain.RefreshSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrNotFound, "refresh session not found")
+	}
+	return &session, nil
+}
+
+func (s *MemoryRefreshTokenStoreImpl) MarkUsed(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if !ok || session.Used || session.Revoked {
+		return false, nil
+	}
+	session.Used = true
+	s.sessions[id] = session
+	return true, nil
+}
+
+func (s *MemoryRefreshTokenStoreImpl) RevokeFamily(ctx context.Context, familyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, session := range s.sessions {
+		if session.FamilyID == familyID {
+			session.Revoked = true
+			s.sessions[id] = session
+		}
+	}
+	return nil
+}
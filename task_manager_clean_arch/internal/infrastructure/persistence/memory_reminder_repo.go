@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MemoryReminderRepositoryImpl struct {
+	mu        sync.Mutex
+	reminders map[string]database.ReminderEntity
+}
+
+func NewMemoryReminderRepository() domain.ReminderRepository {
+	return &MemoryReminderRepositoryImpl{
+		reminders: make(map[string]database.ReminderEntity),
+	}
+}
+
+func (r *MemoryReminderRepositoryImpl) Claim(ctx context.Context, taskID primitive.ObjectID, kind string, dueDate time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entity := database.NewReminderEntity(taskID, kind, dueDate, time.Now())
+	if _, claimed := r.reminders[entity.ID]; claimed {
+		return false, nil
+	}
+	r.reminders[entity.ID] = *entity
+	return true, nil
+}
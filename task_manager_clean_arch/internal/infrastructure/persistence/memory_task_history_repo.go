@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryTaskHistoryRepositoryImpl keeps task history in process memory, in
+// the order it was recorded.
+type MemoryTaskHistoryRepositoryImpl struct {
+	mu      sync.RWMutex
+	history []database.TaskHistoryEntity
+}
+
+func NewMemoryTaskHistoryRepository() domain.TaskHistoryRepository {
+	return &MemoryTaskHistoryRepositoryImpl{}
+}
+
+func (r *MemoryTaskHistoryRepositoryImpl) Add(ctx context.Context, history *domain.TaskHistory) error {
+	entity, err := database.FromDomainToTaskHistoryEntity(history)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.history = append(r.history, *entity)
+	history.ID = entity.ID
+	return nil
+}
+
+func (r *MemoryTaskHistoryRepositoryImpl) GetByTask(ctx context.Context, taskID string) ([]domain.TaskHistory, error) {
+	objectID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	return r.find(func(entity database.TaskHistoryEntity) bool {
+		return entity.TaskID == objectID
+	}), nil
+}
+
+func (r *MemoryTaskHistoryRepositoryImpl) GetByTaskAndUser(ctx context.Context, taskID, username string) ([]domain.TaskHistory, error) {
+	objectID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	return r.find(func(entity database.TaskHistoryEntity) bool {
+		return entity.TaskID == objectID && entity.Owner == username
+	}), nil
+}
+
+// find returns the matching entries, most recent first.
+func (r *MemoryTaskHistoryRepositoryImpl) find(match func(database.TaskHistoryEntity) bool) []domain.TaskHistory {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var entities []database.TaskHistoryEntity
+	for i := len(r.history) - 1; i >= 0; i-- {
+		if match(r.history[i]) {
+			entities = append(entities, r.history[i])
+		}
+	}
+	return database.FromTaskHistoryEntityListToDomainList(entities)
+}
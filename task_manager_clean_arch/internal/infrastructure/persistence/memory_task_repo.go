@@ -0,0 +1,693 @@
+package persistence
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryTaskRepositoryImpl keeps tasks in process memory. It mirrors the
+// behaviour of TaskRepositoryImpl (including its error messages) so it can be
+// used in place of MongoDB for local development and tests.
+type MemoryTaskRepositoryImpl struct {
+	mu    sync.RWMutex
+	tasks map[primitive.ObjectID]database.TaskEntity
+	order []primitive.ObjectID
+}
+
+func NewMemoryTaskRepository() domain.TaskRepository {
+	return &MemoryTaskRepositoryImpl{
+		tasks: make(map[primitive.ObjectID]database.TaskEntity),
+	}
+}
+
+// find returns the stored tasks outside the trash that match the filter, in
+// insertion order. The caller must hold the lock.
+func (r *MemoryTaskRepositoryImpl) find(match func(database.TaskEntity) bool) []database.TaskEntity {
+	return r.findAll(func(task database.TaskEntity) bool { return task.DeletedAt == 0 && match(task) })
+}
+
+// findAll is find including trashed tasks. The caller must hold the lock.
+func (r *MemoryTaskRepositoryImpl) findAll(match func(database.TaskEntity) bool) []database.TaskEntity {
+	var tasks []database.TaskEntity
+	for _, id := range r.order {
+		task := r.tasks[id]
+		if match(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// stats is the in-memory equivalent of the $facet stage of the Mongo
+// repository. The caller must hold the lock.
+func (r *MemoryTaskRepositoryImpl) stats(match func(database.TaskEntity) bool) domain.TaskStats {
+	byStatus, byPriority, byTag := map[string]int{}, map[string]int{}, map[string]int{}
+	for _, task := range r.find(match) {
+		byStatus[task.Status]++
+		byPriority[task.EffectivePriority()]++
+		for _, tag := range task.Tags {
+			byTag[tag]++
+		}
+	}
+	return database.FromTaskStatsEntityToDomain(&database.TaskStatsEntity{
+		ByStatus:   groupCounts(byStatus),
+		ByPriority: groupCounts(byPriority),
+		ByTag:      groupCounts(byTag),
+	})
+}
+
+// groupCounts sorts the counts like the $sort stage of the Mongo repository:
+// largest first, then by key.
+func groupCounts(counts map[string]int) []database.GroupCount {
+	var groups []database.GroupCount
+	for key, count := range counts {
+		groups = append(groups, database.GroupCount{Key: key, Count: count})
+	}
+	slices.SortFunc(groups, func(a, b database.GroupCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Key, b.Key))
+	})
+	return groups
+}
+
+func (r *MemoryTaskRepositoryImpl) GetAll(ctx context.Context) ([]domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := r.find(func(database.TaskEntity) bool { return true })
+	return database.FromTaskEntityListToDomainList(tasks), nil
+}
+
+// GetAllInBatches only holds the lock while it copies a batch, so that the
+// function can use the repository.
+func (r *MemoryTaskRepositoryImpl) GetAllInBatches(ctx context.Context, size int, fn func([]domain.Task) error) error {
+	r.mu.RLock()
+	ids := slices.Clone(r.order)
+	r.mu.RUnlock()
+	for chunk := range slices.Chunk(ids, size) {
+		r.mu.RLock()
+		var batch []database.TaskEntity
+		for _, id := range chunk {
+			if task, ok := r.tasks[id]; ok && task.DeletedAt == 0 {
+				batch = append(batch, task)
+			}
+		}
+		r.mu.RUnlock()
+		if len(batch) == 0 {
+			continue
+		}
+		if err := fn(database.FromTaskEntityListToDomainList(batch)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetById(ctx context.Context, id string) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	task, ok := r.tasks[objectID]
+	if !ok || task.DeletedAt != 0 {
+		return domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found")
+	}
+	return *database.FromTaskEntityToDomain(&task), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) Create(ctx context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.create(task)
+}
+
+func (r *MemoryTaskRepositoryImpl) CreateMany(ctx context.Context, tasks []*domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, task := range tasks {
+		if err := r.create(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// create inserts a task. The caller must hold the lock.
+func (r *MemoryTaskRepositoryImpl) create(task *domain.Task) error {
+	taskEntity, err := database.FromDomainToTaskEntity(task)
+	if err != nil {
+		return err
+	}
+	if taskEntity.ID.IsZero() {
+		taskEntity.ID = primitive.NewObjectID()
+	}
+	if _, exists := r.tasks[taskEntity.ID]; exists {
+		return domain.NewError(domain.ErrConflict, "duplicate key error: task "+taskEntity.ID.Hex()+" already exists")
+	}
+	taskEntity.Version = 1
+	r.tasks[taskEntity.ID] = *taskEntity
+	r.order = append(r.order, taskEntity.ID)
+	task.ID = taskEntity.ID
+	task.Version = taskEntity.Version
+	return nil
+}
+
+// lookup returns the stored task when it is outside the trash and matches
+// the filter, notFound when it does not, and domain.ErrVersionConflict when it
+// has another version than the non-zero version asked for. The caller must
+// hold the lock.
+func (r *MemoryTaskRepositoryImpl) lookup(id primitive.ObjectID, version int64, match func(database.TaskEntity) bool, notFound error) (database.TaskEntity, error) {
+	current, ok := r.tasks[id]
+	if !ok || current.DeletedAt != 0 || !match(current) {
+		return database.TaskEntity{}, notFound
+	}
+	if version != 0 && current.Version != version {
+		return database.TaskEntity{}, domain.ErrVersionConflict
+	}
+	return current, nil
+}
+
+// update applies the $set semantics of the Mongo repository: every field of
+// the task is overwritten and the version goes up by one.
+// The caller must hold the lock.
+func (r *MemoryTaskRepositoryImpl) update(id primitive.ObjectID, updateTask *domain.Task, match func(database.TaskEntity) bool, notFound error) error {
+	current, err := r.lookup(id, updateTask.Version, match, notFound)
+	if err != nil {
+		return err
+	}
+	taskEntity, _ := database.FromDomainToTaskEntity(updateTask)
+	taskEntity.ID = id
+	taskEntity.Version = current.Version + 1
+	taskEntity.Assignees = current.Assignees
+	taskEntity.BlockedBy = current.BlockedBy
+	taskEntity.ProjectID = current.ProjectID
+	taskEntity.ParentID = current.ParentID
+	r.tasks[id] = *taskEntity
+	updateTask.ID = id
+	updateTask.Version = taskEntity.Version
+	updateTask.Assignees = current.Assignees
+	updateTask.BlockedBy = current.BlockedBy
+	updateTask.ProjectID = current.ProjectID
+	updateTask.ParentID = current.ParentID
+	return nil
+}
+
+// trash moves the task to the trash when it matches the filter and version.
+// The caller must hold the lock.
+func (r *MemoryTaskRepositoryImpl) trash(id primitive.ObjectID, version int64, match func(database.TaskEntity) bool, notFound error) error {
+	task, err := r.lookup(id, version, match, notFound)
+	if err != nil {
+		return err
+	}
+	task.DeletedAt = primitive.NewDateTimeFromTime(time.Now())
+	task.Version++
+	r.tasks[id] = task
+	return nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetTrashByUser(ctx context.Context, username string) ([]domain.Task, error) {
+	r.mu.RLock()
+	tasks := r.findAll(func(task database.TaskEntity) bool {
+		return task.DeletedAt != 0 && task.CreatedBy == username
+	})
+	r.mu.RUnlock()
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].DeletedAt != tasks[j].DeletedAt {
+			return tasks[i].DeletedAt > tasks[j].DeletedAt
+		}
+		return bytes.Compare(tasks[i].ID[:], tasks[j].ID[:]) > 0
+	})
+	return database.FromTaskEntityListToDomainList(tasks), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) RestoreByIdAndUser(ctx context.Context, id string, username string) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[objectID]
+	if !ok || task.DeletedAt == 0 || task.CreatedBy != username {
+		return domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found in trash")
+	}
+	task.DeletedAt = 0
+	task.Version++
+	r.tasks[objectID] = task
+	return *database.FromTaskEntityToDomain(&task), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) AddAssignee(ctx context.Context, id string, username string) (domain.Task, error) {
+	return r.changeAssignees(id, func(assignees []string) []string {
+		if slices.Contains(assignees, username) {
+			return assignees
+		}
+		return append(slices.Clone(assignees), username)
+	})
+}
+
+func (r *MemoryTaskRepositoryImpl) RemoveAssignee(ctx context.Context, id string, username string) (domain.Task, error) {
+	return r.changeAssignees(id, func(assignees []string) []string {
+		return slices.DeleteFunc(slices.Clone(assignees), func(assignee string) bool { return assignee == username })
+	})
+}
+
+// changeAssignees is the in-memory equivalent of $addToSet and $pull on the
+// assignees.
+func (r *MemoryTaskRepositoryImpl) changeAssignees(id string, change func([]string) []string) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, err := r.lookup(objectID, 0, func(database.TaskEntity) bool { return true }, domain.NewError(domain.ErrNotFound, "task not found"))
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.Assignees = change(task.Assignees)
+	task.Version++
+	r.tasks[objectID] = task
+	return *database.FromTaskEntityToDomain(&task), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) AddBlocker(ctx context.Context, id string, blockerID primitive.ObjectID) (domain.Task, error) {
+	return r.changeBlockers(id, func(blockers []primitive.ObjectID) []primitive.ObjectID {
+		if slices.Contains(blockers, blockerID) {
+			return blockers
+		}
+		return append(slices.Clone(blockers), blockerID)
+	})
+}
+
+func (r *MemoryTaskRepositoryImpl) RemoveBlocker(ctx context.Context, id string, blockerID primitive.ObjectID) (domain.Task, error) {
+	return r.changeBlockers(id, func(blockers []primitive.ObjectID) []primitive.ObjectID {
+		return slices.DeleteFunc(slices.Clone(blockers), func(blocker primitive.ObjectID) bool { return blocker == blockerID })
+	})
+}
+
+// changeBlockers is the in-memory equivalent of $addToSet and $pull on the
+// blockers.
+func (r *MemoryTaskRepositoryImpl) changeBlockers(id string, change func([]primitive.ObjectID) []primitive.ObjectID) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, err := r.lookup(objectID, 0, func(database.TaskEntity) bool { return true }, domain.NewError(domain.ErrNotFound, "task not found"))
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.BlockedBy = change(task.BlockedBy)
+	task.Version++
+	r.tasks[objectID] = task
+	return *database.FromTaskEntityToDomain(&task), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetStatuses(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	statuses := make(map[primitive.ObjectID]string)
+	for _, id := range ids {
+		if task, ok := r.tasks[id]; ok && task.DeletedAt == 0 {
+			statuses[id] = task.Status
+		}
+	}
+	return statuses, nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetExistingIds(ctx context.Context, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	existing := []primitive.ObjectID{}
+	for _, id := range ids {
+		if _, ok := r.tasks[id]; ok {
+			existing = append(existing, id)
+		}
+	}
+	return existing, nil
+}
+
+func (r *MemoryTaskRepositoryImpl) CountByProject(ctx context.Context, projectID primitive.ObjectID) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := r.findAll(func(task database.TaskEntity) bool { return task.ProjectID == projectID })
+	return int64(len(tasks)), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetDueBetween(ctx context.Context, after, before time.Time, status string) ([]domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	from, to := primitive.NewDateTimeFromTime(after), primitive.NewDateTimeFromTime(before)
+	tasks := r.find(func(task database.TaskEntity) bool {
+		return task.DueDate > from && task.DueDate < to && task.Status != status
+	})
+	slices.SortStableFunc(tasks, func(a, b database.TaskEntity) int { return cmp.Compare(a.DueDate, b.DueDate) })
+	return database.FromTaskEntityListToDomainList(tasks), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limit := primitive.NewDateTimeFromTime(cutoff)
+	var purged int64
+	kept := r.order[:0]
+	for _, id := range r.order {
+		task := r.tasks[id]
+		if task.DeletedAt != 0 && task.DeletedAt < limit {
+			delete(r.tasks, id)
+			purged++
+			continue
+		}
+		kept = append(kept, id)
+	}
+	r.order = kept
+	return purged, nil
+}
+
+func (r *MemoryTaskRepositoryImpl) Update(ctx context.Context, id string, updateTask *domain.Task) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.update(objectID, updateTask, func(database.TaskEntity) bool { return true }, domain.NewError(domain.ErrNotFound, "failed to update task or already up to date"))
+}
+
+func (r *MemoryTaskRepositoryImpl) Delete(ctx context.Context, id string, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.trash(objectID, version, func(database.TaskEntity) bool { return true }, domain.NewError(domain.ErrNotFound, "task not found or already deleted"))
+}
+
+func (r *MemoryTaskRepositoryImpl) GetTaskCountByStatus(ctx context.Context) (domain.TaskStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.stats(func(database.TaskEntity) bool { return true }), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetByUser(ctx context.Context, username string) ([]domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := r.find(func(task database.TaskEntity) bool { return task.CreatedBy == username })
+	return database.FromTaskEntityListToDomainList(tasks), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetByIdAndUser(ctx context.Context, taskID, username string) (domain.Task, error) {
+	id, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	task, ok := r.tasks[id]
+	if !ok || task.DeletedAt != 0 || task.CreatedBy != username {
+		return domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found")
+	}
+	return *database.FromTaskEntityToDomain(&task), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) UpdateByIdAndUser(ctx context.Context, id string, updateTask *domain.Task, username string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	owned := func(task database.TaskEntity) bool { return task.CreatedBy == username }
+	return r.update(objectID, updateTask, owned, domain.NewError(domain.ErrNotFound, "failed to update task or task not found for user"))
+}
+
+func (r *MemoryTaskRepositoryImpl) Patch(ctx context.Context, id string, update domain.TaskUpdate) (domain.Task, error) {
+	return r.patch(id, update, func(database.TaskEntity) bool { return true })
+}
+
+func (r *MemoryTaskRepositoryImpl) PatchByIdAndUser(ctx context.Context, id string, update domain.TaskUpdate, username string) (domain.Task, error) {
+	return r.patch(id, update, func(task database.TaskEntity) bool { return task.CreatedBy == username })
+}
+
+func (r *MemoryTaskRepositoryImpl) patch(id string, update domain.TaskUpdate, match func(database.TaskEntity) bool) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, err := r.lookup(objectID, update.Version, match, domain.NewError(domain.ErrNotFound, "task not found"))
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task = patchEntity(task, update)
+	r.tasks[objectID] = task
+	return *database.FromTaskEntityToDomain(&task), nil
+}
+
+// patchEntity applies the $set semantics of a patch: only the fields of the
+// update are changed and the version goes up by one.
+func patchEntity(task database.TaskEntity, update domain.TaskUpdate) database.TaskEntity {
+	task.Version++
+	if update.Title != nil {
+		task.Title = *update.Title
+	}
+	if update.Description != nil {
+		task.Description = *update.Description
+	}
+	if update.DueDate != nil {
+		task.DueDate = primitive.NewDateTimeFromTime(*update.DueDate)
+	}
+	if update.Status != nil {
+		task.Status = *update.Status
+	}
+	if update.Priority != nil {
+		task.Priority = *update.Priority
+	}
+	if update.Tags != nil {
+		task.Tags = slices.Clone(*update.Tags)
+	}
+	if update.Checklist != nil {
+		task.Checklist = database.FromDomainChecklistToEntity(*update.Checklist)
+	}
+	if update.Recurrence != nil {
+		task.Recurrence = database.FromDomainRecurrenceToEntity(update.Recurrence)
+	}
+	return task
+}
+
+func (r *MemoryTaskRepositoryImpl) DeleteByIdAndUser(ctx context.Context, id string, username string, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	owned := func(task database.TaskEntity) bool { return task.CreatedBy == username }
+	return r.trash(objectID, version, owned, domain.NewError(domain.ErrNotFound, "task not found or not owned by user"))
+}
+
+// BulkWrite holds the lock for the whole batch. When atomic, every write is
+// checked before any is applied, which is what the transaction of the Mongo
+// repository amounts to.
+func (r *MemoryTaskRepositoryImpl) BulkWrite(ctx context.Context, writes []domain.TaskWrite, atomic bool) ([]error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	all := func(database.TaskEntity) bool { return true }
+	notFound := domain.NewError(domain.ErrNotFound, "task not found")
+	errs := make([]error, len(writes))
+	if atomic {
+		failed := false
+		for i, write := range writes {
+			if _, err := r.lookup(write.ID, write.Update.Version, all, notFound); err != nil {
+				errs[i] = err
+				failed = true
+			}
+		}
+		if failed {
+			for i := range errs {
+				if errs[i] == nil {
+					errs[i] = domain.ErrBatchAborted
+				}
+			}
+			return errs, nil
+		}
+	}
+	for i, write := range writes {
+		if write.Delete {
+			errs[i] = r.trash(write.ID, write.Update.Version, all, notFound)
+			continue
+		}
+		task, err := r.lookup(write.ID, write.Update.Version, all, notFound)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		r.tasks[write.ID] = patchEntity(task, write.Update)
+	}
+	return errs, nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetTaskStatsByUser(ctx context.Context, username string) (domain.TaskStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.stats(func(task database.TaskEntity) bool { return task.CreatedBy == username }), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) Find(ctx context.Context, query domain.TaskQuery) (domain.TaskPage, error) {
+	r.mu.RLock()
+	tasks := r.find(func(task database.TaskEntity) bool { return matchTaskQuery(task, query) })
+	r.mu.RUnlock()
+
+	limit := taskPageLimit(query)
+	field, desc := taskSortField(query.Sort)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return compareTasks(tasks[i], tasks[j], field, desc) < 0
+	})
+	total := int64(len(tasks))
+
+	start := 0
+	if query.Cursor != "" {
+		cursor, err := decodeTaskCursor(query)
+		if err != nil {
+			return domain.TaskPage{}, err
+		}
+		position := cursor.entity()
+		start = sort.Search(len(tasks), func(i int) bool {
+			return compareTasks(tasks[i], position, field, desc) > 0
+		})
+	} else if query.Page > 1 {
+		start = min((query.Page-1)*limit, len(tasks))
+	}
+	end := min(start+limit+1, len(tasks))
+	return newTaskPage(tasks[start:end], total, query, limit), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) GetSubtasks(ctx context.Context, parentID string) ([]domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(parentID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := r.find(func(task database.TaskEntity) bool { return task.ParentID == objectID })
+	return database.FromTaskEntityListToDomainList(tasks), nil
+}
+
+func (r *MemoryTaskRepositoryImpl) CountSubtasksByStatus(ctx context.Context, parentIDs []primitive.ObjectID) (map[primitive.ObjectID][]domain.StatusCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var groups []database.SubtaskCountEntity
+	for _, task := range r.find(func(task database.TaskEntity) bool {
+		return !task.ParentID.IsZero() && slices.Contains(parentIDs, task.ParentID)
+	}) {
+		i := slices.IndexFunc(groups, func(group database.SubtaskCountEntity) bool {
+			return group.Key.ParentID == task.ParentID && group.Key.Status == task.Status
+		})
+		if i < 0 {
+			group := database.SubtaskCountEntity{}
+			group.Key.ParentID, group.Key.Status = task.ParentID, task.Status
+			groups = append(groups, group)
+			i = len(groups) - 1
+		}
+		groups[i].Count++
+	}
+	return database.FromSubtaskCountEntityListToDomain(groups), nil
+}
+
+func matchTaskQuery(task database.TaskEntity, query domain.TaskQuery) bool {
+	if query.CreatedBy != "" && task.CreatedBy != query.CreatedBy {
+		return false
+	}
+	if query.Assignee != "" && !slices.Contains(task.Assignees, query.Assignee) {
+		return false
+	}
+	if !query.ProjectID.IsZero() && task.ProjectID != query.ProjectID {
+		return false
+	}
+	if query.Status != "" && task.Status != query.Status {
+		return false
+	}
+	if query.Priority != "" && task.EffectivePriority() != query.Priority {
+		return false
+	}
+	if query.Tag != "" && !slices.Contains(task.Tags, query.Tag) {
+		return false
+	}
+	if !query.DueBefore.IsZero() && !task.DueDate.Time().Before(query.DueBefore) {
+		return false
+	}
+	if !query.DueAfter.IsZero() && !task.DueDate.Time().After(query.DueAfter) {
+		return false
+	}
+	return true
+}
+
+// compareTasks orders two tasks the way the Mongo repository sorts them: by
+// the sort field, then by _id in the same direction.
+func compareTasks(a, b database.TaskEntity, field string, desc bool) int {
+	result := 0
+	switch field {
+	case "due_date":
+		result = cmp.Compare(a.DueDate, b.DueDate)
+	case "title":
+		result = strings.Compare(a.Title, b.Title)
+	}
+	if result == 0 {
+		result = bytes.Compare(a.ID[:], b.ID[:])
+	}
+	if desc {
+		return -result
+	}
+	return result
+}
+
+// Search approximates a Mongo $text query: a task matches when a word of its
+// title or description starts with one of the search terms, and title matches
+// weigh database.TaskTitleWeight times more in the score.
+func (r *MemoryTaskRepositoryImpl) Search(ctx context.Context, search domain.TaskSearch) ([]domain.TaskMatch, error) {
+	terms := search.Terms()
+	r.mu.RLock()
+	var results []database.TaskSearchEntity
+	for _, task := range r.find(func(task database.TaskEntity) bool {
+		return search.CreatedBy == "" || task.CreatedBy == search.CreatedBy
+	}) {
+		score := float64(database.TaskTitleWeight*countTermHits(task.Title, terms) + countTermHits(task.Description, terms))
+		if score > 0 {
+			results = append(results, database.TaskSearchEntity{TaskEntity: task, Score: score})
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if search.Limit > 0 && len(results) > search.Limit {
+		results = results[:search.Limit]
+	}
+	return database.FromTaskSearchEntityListToDomainList(results), nil
+}
+
+func countTermHits(text string, terms []string) int {
+	hits := 0
+	for _, word := range (domain.TaskSearch{Text: text}).Terms() {
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) {
+				hits++
+				break
+			}
+		}
+	}
+	return hits
+}
@@ -0,0 +1,41 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+type MemoryTokenDenylistImpl struct {
+	mu     sync.Mutex
+	tokens map[string]time.Time
+}
+
+func NewMemoryTokenDenylist() domain.TokenDenylist {
+	return &MemoryTokenDenylistImpl{
+		tokens: make(map[string]time.Time),
+	}
+}
+
+// Add records the token and drops entries that are past their expiry.
+func (s *MemoryTokenDenylistImpl) Add(ctx context.Context, jti string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for id, exp := range s.tokens {
+		if !exp.After(now) {
+			delete(s.tokens, id)
+		}
+	}
+	s.tokens[jti] = expiresAt
+	return nil
+}
+
+func (s *MemoryTokenDenylistImpl) Contains(ctx context.Context, jti string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exp, ok := s.tokens[jti]
+	return ok && exp.After(time.Now()), nil
+}
@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryUserRepositoryImpl keeps users in process memory. Usernames are
+// unique, matching the unique index used on the Mongo users collection.
+type MemoryUserRepositoryImpl struct {
+	mu    sync.RWMutex
+	users []database.UserEntity
+}
+
+func NewMemoryUserRepository() domain.UserRepository {
+	return &MemoryUserRepositoryImpl{}
+}
+
+func (s *MemoryUserRepositoryImpl) Insert(ctx context.Context, user *domain.User) error {
+	userEntity, err := database.FromDomainToEntity(user)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.users {
+		if existing.Username == userEntity.Username {
+			return domain.NewError(domain.ErrConflict, "duplicate key error: username "+userEntity.Username+" already exists")
+		}
+	}
+	userEntity.ID = primitive.NewObjectID()
+	s.users = append(s.users, *userEntity)
+	return nil
+}
+
+func (s *MemoryUserRepositoryImpl) GetAll(ctx context.Context) ([]domain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	users := make([]database.UserEntity, len(s.users))
+	copy(users, s.users)
+	return database.FromEntityListToDomainList(users), nil
+}
+
+// GetUser looks a user up by one of the stored field names, the same keys
+// accepted by the Mongo implementation.
+func (s *MemoryUserRepositoryImpl) GetUser(ctx context.Context, key, value string) (*domain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, user := range s.users {
+		if userField(user, key) == value {
+			return database.FromEntityToDomain(&user), nil
+		}
+	}
+	return nil, domain.NewError(domain.ErrNotFound, "user not found")
+}
+
+func (s *MemoryUserRepositoryImpl) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return s.GetUser(ctx, "username", username)
+}
+
+func (s *MemoryUserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return s.GetUser(ctx, "email", email)
+}
+
+func (s *MemoryUserRepositoryImpl) GetUserFromContext(c *gin.Context) *domain.User {
+	username := c.GetString("username")
+	user, _ := s.GetByUsername(c, username)
+	return user
+}
+
+func (s *MemoryUserRepositoryImpl) IncrementTokenVersion(ctx context.Context, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.users {
+		if s.users[i].Username == username {
+			s.users[i].TokenVersion++
+			return nil
+		}
+	}
+	return domain.NewError(domain.ErrNotFound, "user not found")
+}
+
+// userField returns the value stored under the given bson field name, or nil
+// for unknown keys so that they never match.
+func userField(user database.UserEntity, key string) any {
+	switch key {
+	case "username":
+		return user.Username
+	case "email":
+		return user.Email
+	case "role":
+		return user.Role
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package persistence
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryWebhookDeliveryRepositoryImpl keeps webhook deliveries in process
+// memory. It mirrors the behaviour of WebhookDeliveryRepositoryImpl.
+type MemoryWebhookDeliveryRepositoryImpl struct {
+	mu         sync.Mutex
+	deliveries map[primitive.ObjectID]database.WebhookDeliveryEntity
+}
+
+func NewMemoryWebhookDeliveryRepository() domain.WebhookDeliveryRepository {
+	return &MemoryWebhookDeliveryRepositoryImpl{
+		deliveries: make(map[primitive.ObjectID]database.WebhookDeliveryEntity),
+	}
+}
+
+func (r *MemoryWebhookDeliveryRepositoryImpl) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	entity, err := database.FromDomainToWebhookDeliveryEntity(delivery)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.deliveries[entity.ID]; ok {
+		return domain.NewError(domain.ErrConflict, "webhook delivery already exists")
+	}
+	r.deliveries[entity.ID] = *entity
+	delivery.ID = entity.ID
+	return nil
+}
+
+func (r *MemoryWebhookDeliveryRepositoryImpl) GetById(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.WebhookDelivery{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entity, ok := r.deliveries[objectID]
+	if !ok {
+		return domain.WebhookDelivery{}, errDeliveryNotFound
+	}
+	return *database.FromWebhookDeliveryEntityToDomain(&entity), nil
+}
+
+func (r *MemoryWebhookDeliveryRepositoryImpl) GetByWebhook(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	var entities []database.WebhookDeliveryEntity
+	for _, entity := range r.deliveries {
+		if entity.WebhookID == objectID {
+			entities = append(entities, entity)
+		}
+	}
+	r.mu.Unlock()
+	slices.SortFunc(entities, func(a, b database.WebhookDeliveryEntity) int {
+		return cmp.Or(cmp.Compare(b.CreatedAt, a.CreatedAt), bytes.Compare(b.ID[:], a.ID[:]))
+	})
+	if len(entities) > limit {
+		entities = entities[:limit]
+	}
+	return database.FromWebhookDeliveryEntityListToDomainList(entities), nil
+}
+
+func (r *MemoryWebhookDeliveryRepositoryImpl) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limit := primitive.NewDateTimeFromTime(now)
+	var due *database.WebhookDeliveryEntity
+	for _, entity := range r.deliveries {
+		if entity.Status != domain.DeliveryPending || entity.NextAttemptAt > limit {
+			continue
+		}
+		if due == nil || cmp.Or(cmp.Compare(entity.NextAttemptAt, due.NextAttemptAt), bytes.Compare(entity.ID[:], due.ID[:])) < 0 {
+			due = &entity
+		}
+	}
+	if due == nil {
+		return domain.WebhookDelivery{}, errNoDeliveryDue
+	}
+	due.NextAttemptAt = primitive.NewDateTimeFromTime(now.Add(lease))
+	r.deliveries[due.ID] = *due
+	return *database.FromWebhookDeliveryEntityToDomain(due), nil
+}
+
+func (r *MemoryWebhookDeliveryRepositoryImpl) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	entity, err := database.FromDomainToWebhookDeliveryEntity(delivery)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.deliveries[entity.ID]; !ok {
+		return errDeliveryNotFound
+	}
+	r.deliveries[entity.ID] = *entity
+	return nil
+}
+
+func (r *MemoryWebhookDeliveryRepositoryImpl) DeleteByWebhook(ctx context.Context, webhookID string) error {
+	objectID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, entity := range r.deliveries {
+		if entity.WebhookID == objectID {
+			delete(r.deliveries, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryWebhookRepositoryImpl keeps webhooks in process memory. It mirrors
+// the behaviour of WebhookRepositoryImpl.
+type MemoryWebhookRepositoryImpl struct {
+	mu       sync.RWMutex
+	webhooks map[primitive.ObjectID]database.WebhookEntity
+}
+
+func NewMemoryWebhookRepository() domain.WebhookRepository {
+	return &MemoryWebhookRepositoryImpl{
+		webhooks: make(map[primitive.ObjectID]database.WebhookEntity),
+	}
+}
+
+func (r *MemoryWebhookRepositoryImpl) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	return r.find(func(database.WebhookEntity) bool { return true }), nil
+}
+
+func (r *MemoryWebhookRepositoryImpl) GetByEvent(ctx context.Context, eventType string) ([]domain.Webhook, error) {
+	return r.find(func(entity database.WebhookEntity) bool {
+		return entity.Active && slices.Contains(entity.Events, eventType)
+	}), nil
+}
+
+func (r *MemoryWebhookRepositoryImpl) find(match func(database.WebhookEntity) bool) []domain.Webhook {
+	r.mu.RLock()
+	var entities []database.WebhookEntity
+	for _, entity := range r.webhooks {
+		if match(entity) {
+			entities = append(entities, entity)
+		}
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(entities, func(a, b database.WebhookEntity) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+	return database.FromWebhookEntityListToDomainList(entities)
+}
+
+func (r *MemoryWebhookRepositoryImpl) GetById(ctx context.Context, id string) (domain.Webhook, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Webhook{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entity, ok := r.webhooks[objectID]
+	if !ok {
+		return domain.Webhook{}, errWebhookNotFound
+	}
+	return *database.FromWebhookEntityToDomain(&entity), nil
+}
+
+func (r *MemoryWebhookRepositoryImpl) Create(ctx context.Context, webhook *domain.Webhook) error {
+	entity, err := database.FromDomainToWebhookEntity(webhook)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.webhooks[entity.ID]; ok {
+		return domain.NewError(domain.ErrConflict, "webhook already exists")
+	}
+	r.webhooks[entity.ID] = *entity
+	webhook.ID = entity.ID
+	return nil
+}
+
+func (r *MemoryWebhookRepositoryImpl) Update(ctx context.Context, id string, webhook *domain.Webhook) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entity, ok := r.webhooks[objectID]
+	if !ok {
+		return errWebhookNotFound
+	}
+	entity.URL = webhook.URL
+	entity.Events = webhook.Events
+	entity.Secret = webhook.Secret
+	entity.Active = webhook.Active
+	r.webhooks[objectID] = entity
+	return nil
+}
+
+func (r *MemoryWebhookRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.webhooks[objectID]; !ok {
+		return errWebhookNotFound
+	}
+	delete(r.webhooks, objectID)
+	return nil
+}
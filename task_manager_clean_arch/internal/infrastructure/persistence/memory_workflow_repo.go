@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+)
+
+// MemoryWorkflowRepositoryImpl keeps the task workflow in process memory. It
+// mirrors the behaviour of WorkflowRepositoryImpl.
+type MemoryWorkflowRepositoryImpl struct {
+	mu       sync.RWMutex
+	workflow *database.WorkflowEntity
+}
+
+func NewMemoryWorkflowRepository() domain.WorkflowRepository {
+	return &MemoryWorkflowRepositoryImpl{}
+}
+
+func (r *MemoryWorkflowRepositoryImpl) Get(ctx context.Context) (domain.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.workflow == nil {
+		return domain.Workflow{}, errWorkflowNotFound
+	}
+	return database.FromWorkflowEntityToDomain(r.workflow), nil
+}
+
+func (r *MemoryWorkflowRepositoryImpl) Save(ctx context.Context, workflow *domain.Workflow) error {
+	entity, err := database.FromDomainToWorkflowEntity(workflow)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.workflow = entity
+	return nil
+}
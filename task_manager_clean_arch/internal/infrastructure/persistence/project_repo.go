@@ -0,0 +1,140 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ProjectRepositoryImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewProjectRepository(db mongo.Database, collection string) domain.ProjectRepository {
+	return &ProjectRepositoryImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+var errProjectNotFound = domain.NewError(domain.ErrNotFound, "project not found")
+
+func (r *ProjectRepositoryImpl) Create(ctx context.Context, project *domain.Project) error {
+	entity, err := database.FromDomainToProjectEntity(project)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	if _, err := r.Database.Collection(r.Collection).InsertOne(ctx, entity); err != nil {
+		return err
+	}
+	project.ID = entity.ID
+	return nil
+}
+
+func (r *ProjectRepositoryImpl) GetById(ctx context.Context, id string) (domain.Project, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Project{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	var entity database.ProjectEntity
+	err = r.Database.Collection(r.Collection).FindOne(ctx, bson.M{"_id": objectID}).Decode(&entity)
+	if err == mongo.ErrNoDocuments {
+		return domain.Project{}, errProjectNotFound
+	}
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return *database.FromProjectEntityToDomain(&entity), nil
+}
+
+func (r *ProjectRepositoryImpl) GetByMember(ctx context.Context, username string) ([]domain.Project, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.Database.Collection(r.Collection).Find(ctx, bson.M{"members.username": username}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var entities []database.ProjectEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, err
+	}
+	return database.FromProjectEntityListToDomainList(entities), nil
+}
+
+func (r *ProjectRepositoryImpl) Update(ctx context.Context, id string, project *domain.Project) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	update := bson.M{"$set": bson.M{"name": project.Name, "description": project.Description}}
+	result, err := r.Database.Collection(r.Collection).UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errProjectNotFound
+	}
+	return nil
+}
+
+func (r *ProjectRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	result, err := r.Database.Collection(r.Collection).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errProjectNotFound
+	}
+	return nil
+}
+
+// SetMember changes the role of an existing member in place, and appends the
+// member otherwise.
+func (r *ProjectRepositoryImpl) SetMember(ctx context.Context, id string, member domain.ProjectMember) (domain.Project, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Project{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	project, err := r.findOneAndUpdate(ctx,
+		bson.M{"_id": objectID, "members.username": member.Username},
+		bson.M{"$set": bson.M{"members.$.role": member.Role}})
+	if err != errProjectNotFound {
+		return project, err
+	}
+	return r.findOneAndUpdate(ctx,
+		bson.M{"_id": objectID, "members.username": bson.M{"$ne": member.Username}},
+		bson.M{"$push": bson.M{"members": database.ProjectMemberEntity{Username: member.Username, Role: member.Role}}})
+}
+
+func (r *ProjectRepositoryImpl) RemoveMember(ctx context.Context, id string, username string) (domain.Project, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Project{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	return r.findOneAndUpdate(ctx, bson.M{"_id": objectID}, bson.M{"$pull": bson.M{"members": bson.M{"username": username}}})
+}
+
+func (r *ProjectRepositoryImpl) findOneAndUpdate(ctx context.Context, filter bson.M, update bson.M) (domain.Project, error) {
+	var entity database.ProjectEntity
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.Database.Collection(r.Collection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&entity)
+	if err == mongo.ErrNoDocuments {
+		return domain.Project{}, errProjectNotFound
+	}
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return *database.FromProjectEntityToDomain(&entity), nil
+}
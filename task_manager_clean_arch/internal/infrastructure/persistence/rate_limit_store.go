@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// rateLimitAttempts is how many times a token is taken again when another
+// request changed the bucket in between.
+const rateLimitAttempts = 5
+
+type RateLimitStoreImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewRateLimitStore(db mongo.Database, collection string) domain.RateLimitStore {
+	return &RateLimitStoreImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+// Take reads the bucket and replaces it only if it is still the version that
+// was read, so that concurrent requests, from any instance, cannot spend the
+// same token. A key that keeps losing that race is refused for now.
+func (s *RateLimitStoreImpl) Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitResult, error) {
+	collection := s.Database.Collection(s.Collection)
+	for range rateLimitAttempts {
+		var entity database.RateLimitEntity
+		err := collection.FindOne(ctx, bson.M{"_id": key}).Decode(&entity)
+		found := err == nil
+		if err != nil && err != mongo.ErrNoDocuments {
+			return domain.RateLimitResult{}, err
+		}
+		var bucket domain.TokenBucket
+		if found {
+			bucket = domain.TokenBucket{Tokens: entity.Tokens, At: entity.At.Time()}
+		}
+		bucket, result := limit.Take(bucket, now)
+		next := database.RateLimitEntity{
+			ID:        key,
+			Tokens:    bucket.Tokens,
+			At:        primitive.NewDateTimeFromTime(bucket.At),
+			Version:   entity.Version + 1,
+			ExpiresAt: primitive.NewDateTimeFromTime(limit.FullAt(bucket)),
+		}
+		if !found {
+			_, err = collection.InsertOne(ctx, next)
+			if mongo.IsDuplicateKeyError(err) {
+				continue
+			}
+		} else {
+			var res *mongo.UpdateResult
+			res, err = collection.ReplaceOne(ctx, bson.M{"_id": key, "version": entity.Version}, next)
+			if err == nil && res.MatchedCount == 0 {
+				continue
+			}
+		}
+		if err != nil {
+			return domain.RateLimitResult{}, err
+		}
+		return result, nil
+	}
+	return domain.RateLimitResult{RetryAfter: limit.Every, Reset: time.Duration(limit.Burst) * limit.Every}, nil
+}
@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RefreshTokenStoreImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewRefreshTokenStore(db mongo.Database, collection string) domain.RefreshTokenStore {
+	return &RefreshTokenStoreImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+func (s *RefreshTokenStoreImpl) Save(ctx context.Context, session *domain.RefreshSession) error {
+	entity, err := database.FromDomainToRefreshSessionEntity(session)
+	if err != nil {
+		return err
+	}
+	_, err = s.Database.Collection(s.Collection).InsertOne(ctx, entity)
+	return err
+}
+
+func (s *RefreshTokenStoreImpl) GetByID(ctx context.Context, id string) (*domain.RefreshSession, error) {
+	var entity database.RefreshSessionEntity
+	err := s.Database.Collection(s.Collection).FindOne(ctx, bson.M{"_id": id}).Decode(&entity)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.NewError(domain.ErrNotFound, "refresh session not found")
+		}
+		return nil, err
+	}
+	return database.FromRefreshSessionEntityToDomain(&entity), nil
+}
+
+func (s *RefreshTokenStoreImpl) MarkUsed(ctx context.Context, id string) (bool, error) {
+	filter := bson.M{"_id": id, "used": false, "revoked": false}
+	update := bson.M{"$set": bson.M{"used": true}}
+	result, err := s.Database.Collection(s.Collection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (s *RefreshTokenStoreImpl) RevokeFamily(ctx context.Context, familyID string) error {
+	update := bson.M{"$set": bson.M{"revoked": true}}
+	_, err := s.Database.Collection(s.Collection).UpdateMany(ctx, bson.M{"family_id": familyID}, update)
+	return err
+}
@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ReminderRepositoryImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewReminderRepository(db mongo.Database, collection string) domain.ReminderRepository {
+	return &ReminderRepositoryImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+// Claim inserts the reminder under an ID derived from it and lets the unique
+// _id index reject a reminder that was claimed before.
+func (r *ReminderRepositoryImpl) Claim(ctx context.Context, taskID primitive.ObjectID, kind string, dueDate time.Time) (bool, error) {
+	entity := database.NewReminderEntity(taskID, kind, dueDate, time.Now())
+	_, err := r.Database.Collection(r.Collection).InsertOne(ctx, entity)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/blob"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Repositories groups the repository implementations shared by the routers so
+// that every handler works against the same storage.
+type Repositories struct {
+	Task              domain.TaskRepository
+	TaskHistory       domain.TaskHistoryRepository
+	Project           domain.ProjectRepository
+	Workflow          domain.WorkflowRepository
+	User              domain.UserRepository
+	RefreshTokens     domain.RefreshTokenStore
+	TokenDenylist     domain.TokenDenylist
+	Reminders         domain.ReminderRepository
+	Webhooks          domain.WebhookRepository
+	WebhookDeliveries domain.WebhookDeliveryRepository
+	Comments          domain.CommentRepository
+	Attachments       domain.AttachmentRepository
+	// Blobs keeps the content of attachments in the store selected by
+	// BLOB_STORE.
+	Blobs domain.BlobStore
+	// RateLimits keeps the token buckets of the rate limiter.
+	RateLimits domain.RateLimitStore
+	// Events is kept in process memory with either driver.
+	Events domain.EventBus
+}
+
+// NewRepositories builds the repositories for the configured DB_DRIVER.
+// The Mongo database is ignored when the memory driver is selected.
+func NewRepositories(env *config.Env, db mongo.Database) *Repositories {
+	if env.DBDriver == config.DBDriverMemory {
+		return &Repositories{
+			Task:              NewMemoryTaskRepository(),
+			TaskHistory:       NewMemoryTaskHistoryRepository(),
+			Project:           NewMemoryProjectRepository(),
+			Workflow:          NewMemoryWorkflowRepository(),
+			User:              NewMemoryUserRepository(),
+			RefreshTokens:     NewMemoryRefreshTokenStore(),
+			TokenDenylist:     NewMemoryTokenDenylist(),
+			Reminders:         NewMemoryReminderRepository(),
+			Webhooks:          NewMemoryWebhookRepository(),
+			WebhookDeliveries: NewMemoryWebhookDeliveryRepository(),
+			Comments:          NewMemoryCommentRepository(),
+			Attachments:       NewMemoryAttachmentRepository(),
+			Blobs:             blob.NewBlobStore(env, db),
+			RateLimits:        NewMemoryRateLimitStore(),
+			Events:            NewMemoryEventBus(env.EventStreamBufferSize),
+		}
+	}
+	return &Repositories{
+		Task:              NewTaskRepository(db, env.DBTaskCollection),
+		TaskHistory:       NewTaskHistoryRepository(db, env.DBTaskHistoryCollection),
+		Project:           NewProjectRepository(db, env.DBProjectCollection),
+		Workflow:          NewWorkflowRepository(db, env.DBWorkflowCollection),
+		User:              NewUserRepository(db, env.DBUserCollection),
+		RefreshTokens:     NewRefreshTokenStore(db, env.DBRefreshTokenCollection),
+		TokenDenylist:     NewTokenDenylist(db, env.DBTokenDenylistCollection),
+		Reminders:         NewReminderRepository(db, env.DBReminderCollection),
+		Webhooks:          NewWebhookRepository(db, env.DBWebhookCollection),
+		WebhookDeliveries: NewWebhookDeliveryRepository(db, env.DBWebhookDeliveryCollection),
+		Comments:          NewCommentRepository(db, env.DBCommentCollection),
+		Attachments:       NewAttachmentRepository(db, env.DBAttachmentCollection),
+		Blobs:             blob.NewBlobStore(env, db),
+		RateLimits:        NewRateLimitStore(db, env.DBRateLimitCollection),
+		Events:            NewMemoryEventBus(env.EventStreamBufferSize),
+	}
+}
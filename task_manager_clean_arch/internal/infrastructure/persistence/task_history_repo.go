@@ -0,0 +1,68 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TaskHistoryRepositoryImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewTaskHistoryRepository(db mongo.Database, collection string) domain.TaskHistoryRepository {
+	return &TaskHistoryRepositoryImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+func (r *TaskHistoryRepositoryImpl) Add(ctx context.Context, history *domain.TaskHistory) error {
+	entity, err := database.FromDomainToTaskHistoryEntity(history)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	if _, err := r.Database.Collection(r.Collection).InsertOne(ctx, entity); err != nil {
+		return err
+	}
+	history.ID = entity.ID
+	return nil
+}
+
+func (r *TaskHistoryRepositoryImpl) GetByTask(ctx context.Context, taskID string) ([]domain.TaskHistory, error) {
+	objectID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	return r.find(ctx, bson.M{"task_id": objectID})
+}
+
+func (r *TaskHistoryRepositoryImpl) GetByTaskAndUser(ctx context.Context, taskID, username string) ([]domain.TaskHistory, error) {
+	objectID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	return r.find(ctx, bson.M{"task_id": objectID, "owner": username})
+}
+
+func (r *TaskHistoryRepositoryImpl) find(ctx context.Context, filter bson.M) ([]domain.TaskHistory, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.Database.Collection(r.Collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var entities []database.TaskHistoryEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, err
+	}
+	return database.FromTaskHistoryEntityListToDomainList(entities), nil
+}
@@ -0,0 +1,117 @@
+package persistence
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// taskCursor is the decoded form of TaskPage.NextCursor. It records the sort
+// key of the last task on a page so the next page can resume after it, and
+// the sort order and filters of the listing it belongs to, since resuming
+// under others would skip or repeat tasks.
+type taskCursor struct {
+	ID      primitive.ObjectID `json:"id"`
+	Title   string             `json:"title,omitempty"`
+	DueDate time.Time          `json:"due_date"`
+	Sort    string             `json:"sort,omitempty"`
+	Filter  string             `json:"filter"`
+}
+
+func encodeTaskCursor(task database.TaskEntity, query domain.TaskQuery) string {
+	data, _ := json.Marshal(taskCursor{
+		ID:      task.ID,
+		Title:   task.Title,
+		DueDate: task.DueDate.Time(),
+		Sort:    taskCursorSort(query.Sort),
+		Filter:  taskCursorFilterHash(query),
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeTaskCursor rejects cursors that are malformed or come from a listing
+// with another sort order or other filters.
+func decodeTaskCursor(query domain.TaskQuery) (taskCursor, error) {
+	var cursor taskCursor
+	data, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+	if err != nil {
+		return taskCursor{}, domain.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID.IsZero() {
+		return taskCursor{}, domain.ErrInvalidCursor
+	}
+	if cursor.Sort != taskCursorSort(query.Sort) || cursor.Filter != taskCursorFilterHash(query) {
+		return taskCursor{}, domain.ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// taskCursorSort is the sort order a cursor is valid for, in the same form
+// for every sort value that orders tasks the same way.
+func taskCursorSort(sort string) string {
+	field, desc := taskSortField(sort)
+	if desc {
+		return "-" + field
+	}
+	return field
+}
+
+// taskCursorFilterHash identifies the filters of a query. Paging and sorting
+// are left out, so the page size can change from one page to the next.
+func taskCursorFilterHash(query domain.TaskQuery) string {
+	query.Sort, query.Page, query.Limit, query.Cursor = "", 0, 0, ""
+	data, _ := json.Marshal(query)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// entity returns a task placed exactly at the cursor position, for comparing
+// against stored tasks.
+func (c taskCursor) entity() database.TaskEntity {
+	return database.TaskEntity{ID: c.ID, Title: c.Title, DueDate: primitive.NewDateTimeFromTime(c.DueDate)}
+}
+
+// taskSortField maps a TaskQuery sort order to the stored field name and its
+// direction. Without a sort order tasks are returned by _id, which follows
+// insertion order.
+func taskSortField(sort string) (string, bool) {
+	desc := strings.HasPrefix(sort, "-")
+	switch strings.TrimPrefix(sort, "-") {
+	case domain.SortDueDateAsc:
+		return "due_date", desc
+	case domain.SortTitleAsc:
+		return "title", desc
+	default:
+		return "_id", false
+	}
+}
+
+// taskPageLimit returns the page size to use, falling back to the default
+// when the query does not set one.
+func taskPageLimit(query domain.TaskQuery) int {
+	if query.Limit <= 0 {
+		return domain.DefaultTaskPageLimit
+	}
+	return query.Limit
+}
+
+// newTaskPage builds a page out of up to limit+1 sorted tasks; the extra task
+// only signals that another page exists.
+func newTaskPage(tasks []database.TaskEntity, total int64, query domain.TaskQuery, limit int) domain.TaskPage {
+	page := domain.TaskPage{Total: total, Limit: limit}
+	if query.Cursor == "" {
+		page.Page = max(query.Page, 1)
+	}
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		page.NextCursor = encodeTaskCursor(tasks[limit-1], query)
+	}
+	page.Tasks = database.FromTaskEntityListToDomainList(tasks)
+	return page
+}
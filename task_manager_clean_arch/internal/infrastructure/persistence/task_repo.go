@@ -3,12 +3,14 @@ package persistence
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/yiheyistm/task_manager/internal/domain"
 	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TaskRepositoryImpl struct {
@@ -26,7 +28,7 @@ func NewTaskRepository(db mongo.Database, collection string) domain.TaskReposito
 func (r *TaskRepositoryImpl) GetAll(ctx context.Context) ([]domain.Task, error) {
 
 	var tasks []database.TaskEntity
-	cursor, err := r.Database.Collection(r.Collection).Find(ctx, bson.M{})
+	cursor, err := r.Database.Collection(r.Collection).Find(ctx, notTrashed(bson.M{}))
 	if err != nil {
 		return nil, err
 	}
@@ -41,16 +43,47 @@ func (r *TaskRepositoryImpl) GetAll(ctx context.Context) ([]domain.Task, error)
 	}
 	return database.FromTaskEntityListToDomainList(tasks), nil
 }
+func (r *TaskRepositoryImpl) GetAllInBatches(ctx context.Context, size int, fn func([]domain.Task) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetBatchSize(int32(size))
+	cursor, err := r.Database.Collection(r.Collection).Find(ctx, notTrashed(bson.M{}), opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	batch := make([]database.TaskEntity, 0, size)
+	for cursor.Next(ctx) {
+		var task database.TaskEntity
+		if err := cursor.Decode(&task); err != nil {
+			return err
+		}
+		batch = append(batch, task)
+		if len(batch) == size {
+			if err := fn(database.FromTaskEntityListToDomainList(batch)); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return fn(database.FromTaskEntityListToDomainList(batch))
+	}
+	return nil
+}
+
 func (r *TaskRepositoryImpl) GetById(ctx context.Context, id string) (domain.Task, error) {
 	var taskEntity database.TaskEntity
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return domain.Task{}, errors.New("invalid ObjectID")
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
 	}
-	err = r.Database.Collection(r.Collection).FindOne(ctx, bson.M{"_id": objectID}).Decode(&taskEntity)
+	err = r.Database.Collection(r.Collection).FindOne(ctx, notTrashed(bson.M{"_id": objectID})).Decode(&taskEntity)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return domain.Task{}, errors.New("task not found")
+			return domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found")
 		}
 		return domain.Task{}, err
 	}
@@ -61,6 +94,15 @@ func (r *TaskRepositoryImpl) GetById(ctx context.Context, id string) (domain.Tas
 		CreatedBy:   taskEntity.CreatedBy,
 		DueDate:     taskEntity.DueDate.Time(),
 		Status:      taskEntity.Status,
+		Priority:    taskEntity.EffectivePriority(),
+		Tags:        taskEntity.Tags,
+		Version:     taskEntity.Version,
+		Assignees:   taskEntity.Assignees,
+		ProjectID:   taskEntity.ProjectID,
+		ParentID:    taskEntity.ParentID,
+		Checklist:   database.FromChecklistEntityToDomain(taskEntity.Checklist),
+		BlockedBy:   taskEntity.BlockedBy,
+		Recurrence:  database.FromRecurrenceEntityToDomain(taskEntity.Recurrence),
 	}, nil
 }
 
@@ -69,6 +111,7 @@ func (s *TaskRepositoryImpl) Create(ctx context.Context, task *domain.Task) erro
 	if err != nil {
 		return err
 	}
+	taskEntity.Version = 1
 	result, err := s.Database.Collection(s.Collection).InsertOne(ctx, taskEntity)
 	if err != nil {
 		return err
@@ -77,69 +120,304 @@ func (s *TaskRepositoryImpl) Create(ctx context.Context, task *domain.Task) erro
 		return errors.New("failed to insert task")
 	}
 	task.ID = result.InsertedID.(primitive.ObjectID)
+	task.Version = taskEntity.Version
 	return nil
 }
 
+// CreateMany gives the tasks their IDs before inserting them, so that an
+// ordered InsertMany that fails part way tells which tasks were inserted.
+func (s *TaskRepositoryImpl) CreateMany(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	entities := make([]*database.TaskEntity, len(tasks))
+	documents := make([]interface{}, len(tasks))
+	for i, task := range tasks {
+		taskEntity, err := database.FromDomainToTaskEntity(task)
+		if err != nil {
+			return err
+		}
+		if taskEntity.ID.IsZero() {
+			taskEntity.ID = primitive.NewObjectID()
+		}
+		taskEntity.Version = 1
+		entities[i] = taskEntity
+		documents[i] = taskEntity
+	}
+	_, err := s.Database.Collection(s.Collection).InsertMany(ctx, documents)
+	inserted := len(tasks)
+	if err != nil {
+		inserted = 0
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) && len(bulkErr.WriteErrors) > 0 {
+			inserted = bulkErr.WriteErrors[0].Index
+		}
+	}
+	for i := range inserted {
+		tasks[i].ID = entities[i].ID
+		tasks[i].Version = entities[i].Version
+	}
+	return err
+}
+
 func (s *TaskRepositoryImpl) Update(ctx context.Context, id string, updateTask *domain.Task) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("invalid ObjectID")
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
 	}
+	return s.replace(ctx, bson.M{"_id": objectID}, updateTask, domain.NewError(domain.ErrNotFound, "failed to update task or already up to date"))
+}
+
+// notTrashed narrows the filter to tasks that are not in the trash. A null
+// deleted_at also matches documents written before soft deletes existed.
+func notTrashed(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
+// replace overwrites every field of the task matching the filter and bumps
+// its version. When the task carries a version, only that version is
+// replaced. On success the task receives its ID and new version.
+func (s *TaskRepositoryImpl) replace(ctx context.Context, filter bson.M, updateTask *domain.Task, notFound error) error {
+	filter = notTrashed(filter)
 	taskEntity, _ := database.FromDomainToTaskEntity(updateTask)
+	taskEntity.Version = 0 // left out of $set, it is bumped by $inc
+	taskEntity.DeletedAt = 0
+	taskEntity.Assignees = nil // assignees are only changed by AddAssignee and RemoveAssignee
+	taskEntity.BlockedBy = nil // and blockers by AddBlocker and RemoveBlocker
+	taskEntity.ProjectID = primitive.NilObjectID
+	taskEntity.ParentID = primitive.NilObjectID
 	update := bson.M{
 		"$set": taskEntity,
+		"$inc": bson.M{"version": 1},
+	}
+	// Empty lists and a missing recurrence are left out of $set, so they have
+	// to be removed.
+	unset := bson.M{}
+	if len(taskEntity.Tags) == 0 {
+		unset["tags"] = ""
+	}
+	if len(taskEntity.Checklist) == 0 {
+		unset["checklist"] = ""
+	}
+	if taskEntity.Recurrence == nil {
+		unset["recurrence"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	var stored database.TaskEntity
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := s.Database.Collection(s.Collection).
+		FindOneAndUpdate(ctx, withVersion(filter, updateTask.Version), update, opts).
+		Decode(&stored)
+	if err == mongo.ErrNoDocuments {
+		return s.versionConflict(ctx, filter, updateTask.Version, notFound)
 	}
-	result, err := s.Database.Collection(s.Collection).UpdateOne(ctx, bson.M{"_id": objectID}, update)
 	if err != nil {
 		return err
 	}
-	if result.ModifiedCount == 0 {
-		return errors.New("failed to update task or already up to date")
-	}
+	updateTask.ID = stored.ID
+	updateTask.Version = stored.Version
+	updateTask.Assignees = stored.Assignees
+	updateTask.BlockedBy = stored.BlockedBy
+	updateTask.ProjectID = stored.ProjectID
+	updateTask.ParentID = stored.ParentID
 	return nil
 }
 
-func (s *TaskRepositoryImpl) Delete(ctx context.Context, id string) error {
+// withVersion narrows the filter to the given version of the task, unless
+// the version is zero.
+func withVersion(filter bson.M, version int64) bson.M {
+	if version == 0 {
+		return filter
+	}
+	versioned := bson.M{"version": version}
+	for key, value := range filter {
+		versioned[key] = value
+	}
+	return versioned
+}
+
+// versionConflict explains why a versioned write matched nothing: either the
+// task exists with another version, or notFound applies.
+func (s *TaskRepositoryImpl) versionConflict(ctx context.Context, filter bson.M, version int64, notFound error) error {
+	if version == 0 {
+		return notFound
+	}
+	count, err := s.Database.Collection(s.Collection).CountDocuments(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrVersionConflict
+	}
+	return notFound
+}
+
+func (s *TaskRepositoryImpl) Delete(ctx context.Context, id string, version int64) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("invalid ObjectID")
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	return s.trash(ctx, bson.M{"_id": objectID}, version, domain.NewError(domain.ErrNotFound, "task not found or already deleted"))
+}
+
+// trash moves the task matching the filter to the trash by stamping its
+// deleted_at, which counts as a write and bumps its version.
+func (s *TaskRepositoryImpl) trash(ctx context.Context, filter bson.M, version int64, notFound error) error {
+	filter = notTrashed(filter)
+	update := bson.M{
+		"$set": bson.M{"deleted_at": primitive.NewDateTimeFromTime(time.Now())},
+		"$inc": bson.M{"version": 1},
 	}
-	result, err := s.Database.Collection(s.Collection).DeleteOne(ctx, bson.M{"_id": objectID})
+	result, err := s.Database.Collection(s.Collection).UpdateOne(ctx, withVersion(filter, version), update)
 	if err != nil {
 		return err
 	}
-	if result.DeletedCount == 0 {
-		return errors.New("task not found or already deleted")
+	if result.MatchedCount == 0 {
+		return s.versionConflict(ctx, filter, version, notFound)
 	}
 	return nil
 }
 
-func (s *TaskRepositoryImpl) GetTaskCountByStatus(ctx context.Context) ([]domain.StatusCount, error) {
+// GetTrashByUser lists the user's trashed tasks, most recently deleted first.
+func (s *TaskRepositoryImpl) GetTrashByUser(ctx context.Context, username string) ([]domain.Task, error) {
+	filter := bson.M{"created_by": username, "deleted_at": bson.M{"$ne": nil}}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := s.Database.Collection(s.Collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []database.TaskEntity
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return database.FromTaskEntityListToDomainList(tasks), nil
+}
+
+// RestoreByIdAndUser takes one of the user's tasks out of the trash.
+func (s *TaskRepositoryImpl) RestoreByIdAndUser(ctx context.Context, id string, username string) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	filter := bson.M{"_id": objectID, "created_by": username, "deleted_at": bson.M{"$ne": nil}}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+	var task database.TaskEntity
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = s.Database.Collection(s.Collection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&task)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found in trash")
+		}
+		return domain.Task{}, err
+	}
+	return *database.FromTaskEntityToDomain(&task), nil
+}
+
+// AddAssignee adds the user to the task's assignees, if not already there.
+func (s *TaskRepositoryImpl) AddAssignee(ctx context.Context, id string, username string) (domain.Task, error) {
+	return s.changeList(ctx, id, bson.M{"$addToSet": bson.M{"assignees": username}})
+}
+
+// RemoveAssignee removes the user from the task's assignees.
+func (s *TaskRepositoryImpl) RemoveAssignee(ctx context.Context, id string, username string) (domain.Task, error) {
+	return s.changeList(ctx, id, bson.M{"$pull": bson.M{"assignees": username}})
+}
+
+// AddBlocker records that the task is blocked by another one.
+func (s *TaskRepositoryImpl) AddBlocker(ctx context.Context, id string, blockerID primitive.ObjectID) (domain.Task, error) {
+	return s.changeList(ctx, id, bson.M{"$addToSet": bson.M{"blocked_by": blockerID}})
+}
+
+// RemoveBlocker drops another task from the task's blockers.
+func (s *TaskRepositoryImpl) RemoveBlocker(ctx context.Context, id string, blockerID primitive.ObjectID) (domain.Task, error) {
+	return s.changeList(ctx, id, bson.M{"$pull": bson.M{"blocked_by": blockerID}})
+}
+
+// changeList applies an $addToSet or $pull on one of the task's lists and
+// returns the task as stored afterwards.
+func (s *TaskRepositoryImpl) changeList(ctx context.Context, id string, update bson.M) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	update["$inc"] = bson.M{"version": 1}
+	var task database.TaskEntity
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = s.Database.Collection(s.Collection).
+		FindOneAndUpdate(ctx, notTrashed(bson.M{"_id": objectID}), update, opts).
+		Decode(&task)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found")
+		}
+		return domain.Task{}, err
+	}
+	return *database.FromTaskEntityToDomain(&task), nil
+}
+
+// PurgeDeletedBefore permanently removes the tasks trashed before the cutoff
+// and returns how many were removed.
+func (s *TaskRepositoryImpl) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": primitive.NewDateTimeFromTime(cutoff)}}
+	result, err := s.Database.Collection(s.Collection).DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (s *TaskRepositoryImpl) GetTaskCountByStatus(ctx context.Context) (domain.TaskStats, error) {
+	return s.stats(ctx, notTrashed(bson.M{}))
+}
+
+// stats counts the tasks matching the filter by status, priority and tag in
+// a single $facet stage.
+func (s *TaskRepositoryImpl) stats(ctx context.Context, filter bson.M) (domain.TaskStats, error) {
+	countBy := func(key interface{}) bson.A {
+		return bson.A{
+			bson.D{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: key},
+				{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		}
+	}
+	byTag := append(bson.A{bson.D{{Key: "$unwind", Value: "$tags"}}}, countBy("$tags")...)
 	pipeline := mongo.Pipeline{
-		{{Key: "$group", Value: bson.D{
-			{Key: "_id", Value: "$status"},
-			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		{{Key: "$match", Value: filter}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "by_status", Value: countBy("$status")},
+			{Key: "by_priority", Value: countBy(bson.D{{Key: "$ifNull", Value: bson.A{"$priority", domain.PriorityMedium}}})},
+			{Key: "by_tag", Value: byTag},
 		}}},
 	}
 
 	cursor, err := s.Database.Collection(s.Collection).Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, err
+		return domain.TaskStats{}, err
 	}
 
-	var results []database.StatusCount
+	var results []database.TaskStatsEntity
 	if err := cursor.All(ctx, &results); err != nil {
-		return nil, err
+		return domain.TaskStats{}, err
 	}
-
-	return database.FromStatusCountListToDomainList(results), nil
+	if len(results) == 0 {
+		return domain.TaskStats{}, nil
+	}
+	return database.FromTaskStatsEntityToDomain(&results[0]), nil
 }
 
 // GetByUser
 func (s *TaskRepositoryImpl) GetByUser(ctx context.Context, username string) ([]domain.Task, error) {
 
 	var tasks []database.TaskEntity
-	filter := bson.M{"created_by": username}
+	filter := notTrashed(bson.M{"created_by": username})
 	cursor, err := s.Database.Collection(s.Collection).Find(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -160,14 +438,14 @@ func (s *TaskRepositoryImpl) GetByUser(ctx context.Context, username string) ([]
 func (s *TaskRepositoryImpl) GetByIdAndUser(ctx context.Context, taskID, username string) (domain.Task, error) {
 	id, err := primitive.ObjectIDFromHex(taskID)
 	if err != nil {
-		return domain.Task{}, errors.New("invalid ObjectID")
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
 	}
 
 	var task database.TaskEntity
-	filter := bson.M{"_id": id, "created_by": username}
+	filter := notTrashed(bson.M{"_id": id, "created_by": username})
 	if err := s.Database.Collection(s.Collection).FindOne(ctx, filter).Decode(&task); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return domain.Task{}, errors.New("task not found")
+			return domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found")
 		}
 		return domain.Task{}, err
 	}
@@ -178,60 +456,380 @@ func (s *TaskRepositoryImpl) GetByIdAndUser(ctx context.Context, taskID, usernam
 func (s *TaskRepositoryImpl) UpdateByIdAndUser(ctx context.Context, id string, updateTask *domain.Task, username string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("invalid ObjectID")
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
 	}
-	taskEntity, _ := database.FromDomainToTaskEntity(updateTask)
+	filter := bson.M{"_id": objectID, "created_by": username}
+	return s.replace(ctx, filter, updateTask, domain.NewError(domain.ErrNotFound, "failed to update task or task not found for user"))
+}
 
-	update := bson.M{
-		"$set": taskEntity,
+// Patch sets only the fields present in the update and returns the task as
+// stored afterwards.
+func (s *TaskRepositoryImpl) Patch(ctx context.Context, id string, update domain.TaskUpdate) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
 	}
-	result, err := s.Database.Collection(s.Collection).UpdateOne(ctx, bson.M{"_id": objectID, "created_by": username}, update)
+	return s.patch(ctx, bson.M{"_id": objectID}, update)
+}
+
+// PatchByIdAndUser
+func (s *TaskRepositoryImpl) PatchByIdAndUser(ctx context.Context, id string, update domain.TaskUpdate, username string) (domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return err
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
 	}
-	if result.ModifiedCount == 0 {
-		return errors.New("failed to update task or task not found for user")
+	return s.patch(ctx, bson.M{"_id": objectID, "created_by": username}, update)
+}
+
+func (s *TaskRepositoryImpl) patch(ctx context.Context, filter bson.M, update domain.TaskUpdate) (domain.Task, error) {
+	filter = notTrashed(filter)
+	var task database.TaskEntity
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	changes := bson.M{
+		"$set": database.FromDomainTaskUpdateToSet(update),
+		"$inc": bson.M{"version": 1},
+	}
+	err := s.Database.Collection(s.Collection).
+		FindOneAndUpdate(ctx, withVersion(filter, update.Version), changes, opts).
+		Decode(&task)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.Task{}, s.versionConflict(ctx, filter, update.Version, domain.NewError(domain.ErrNotFound, "task not found"))
+		}
+		return domain.Task{}, err
 	}
-	updateTask.ID = objectID
-	return nil
+	return *database.FromTaskEntityToDomain(&task), nil
 }
 
 // DeleteByIdAndUser
-func (s *TaskRepositoryImpl) DeleteByIdAndUser(ctx context.Context, id string, username string) error {
+func (s *TaskRepositoryImpl) DeleteByIdAndUser(ctx context.Context, id string, username string, version int64) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("invalid ObjectID")
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
 	}
-	result, err := s.Database.Collection(s.Collection).DeleteOne(ctx, bson.M{"_id": objectID, "created_by": username})
+	filter := bson.M{"_id": objectID, "created_by": username}
+	return s.trash(ctx, filter, version, domain.NewError(domain.ErrNotFound, "task not found or not owned by user"))
+}
+
+// errWriteFailed aborts the transaction of an atomic bulk write.
+var errWriteFailed = errors.New("bulk write did not match every task")
+
+// BulkWrite sends the writes as one unordered bulk write. When atomic, the
+// bulk write runs in a transaction, which needs a replica set, and the
+// transaction is aborted if a write did not match.
+func (s *TaskRepositoryImpl) BulkWrite(ctx context.Context, writes []domain.TaskWrite, atomic bool) ([]error, error) {
+	if len(writes) == 0 {
+		return nil, nil
+	}
+	models := make([]mongo.WriteModel, len(writes))
+	for i, write := range writes {
+		update := bson.M{"$inc": bson.M{"version": 1}}
+		if write.Delete {
+			update["$set"] = bson.M{"deleted_at": primitive.NewDateTimeFromTime(time.Now())}
+		} else {
+			update["$set"] = database.FromDomainTaskUpdateToSet(write.Update)
+		}
+		filter := withVersion(notTrashed(bson.M{"_id": write.ID}), write.Update.Version)
+		models[i] = mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update)
+	}
+	if !atomic {
+		return s.bulkWrite(ctx, writes, models)
+	}
+
+	session, err := s.Database.Client().StartSession()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+	var errs []error
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var err error
+		if errs, err = s.bulkWrite(sc, writes, models); err != nil {
+			return nil, err
+		}
+		for _, err := range errs {
+			if err != nil {
+				return nil, errWriteFailed
+			}
+		}
+		return nil, nil
+	})
+	if errors.Is(err, errWriteFailed) {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = domain.ErrBatchAborted
+			}
+		}
+		return errs, nil
 	}
-	if result.DeletedCount == 0 {
-		return errors.New("task not found or not owned by user")
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return errs, nil
+}
+
+// bulkWrite runs the bulk write of BulkWrite. Its result only counts the
+// writes that matched, so when some did not, the tasks are read back to tell
+// which: a write was applied if its task has the version the write gave it.
+func (s *TaskRepositoryImpl) bulkWrite(ctx context.Context, writes []domain.TaskWrite, models []mongo.WriteModel) ([]error, error) {
+	collection := s.Database.Collection(s.Collection)
+	result, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(writes))
+	if result.MatchedCount == int64(len(writes)) {
+		return errs, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(writes))
+	for i, write := range writes {
+		ids[i] = write.ID
+	}
+	opts := options.Find().SetProjection(bson.M{"version": 1, "deleted_at": 1})
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var stored []database.TaskEntity
+	if err := cursor.All(ctx, &stored); err != nil {
+		return nil, err
+	}
+	tasks := make(map[primitive.ObjectID]database.TaskEntity, len(stored))
+	for _, task := range stored {
+		tasks[task.ID] = task
+	}
+	for i, write := range writes {
+		task, ok := tasks[write.ID]
+		trashed := ok && task.DeletedAt != 0
+		switch {
+		case ok && task.Version == write.Update.Version+1 && trashed == write.Delete:
+		case ok && !trashed:
+			errs[i] = domain.ErrVersionConflict
+		default:
+			errs[i] = domain.NewError(domain.ErrNotFound, "task not found")
+		}
+	}
+	return errs, nil
 }
 
 // GetTaskStatsByUser
-func (s *TaskRepositoryImpl) GetTaskStatsByUser(ctx context.Context, username string) ([]domain.StatusCount, error) {
+func (s *TaskRepositoryImpl) GetTaskStatsByUser(ctx context.Context, username string) (domain.TaskStats, error) {
+	return s.stats(ctx, notTrashed(bson.M{"created_by": username}))
+}
+
+// Find returns one page of tasks matching the query. Pages are addressed by
+// number through $skip, or by cursor through a range filter on the sort key,
+// which stays fast on large collections.
+func (s *TaskRepositoryImpl) Find(ctx context.Context, query domain.TaskQuery) (domain.TaskPage, error) {
+	collection := s.Database.Collection(s.Collection)
+	filter := taskQueryFilter(query)
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+
+	limit := taskPageLimit(query)
+	field, desc := taskSortField(query.Sort)
+	direction := 1
+	if desc {
+		direction = -1
+	}
+	sort := bson.D{{Key: field, Value: direction}}
+	if field != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+	opts := options.Find().SetSort(sort).SetLimit(int64(limit) + 1)
+	if query.Cursor != "" {
+		cursor, err := decodeTaskCursor(query)
+		if err != nil {
+			return domain.TaskPage{}, err
+		}
+		filter = bson.M{"$and": bson.A{filter, taskCursorFilter(cursor, field, desc)}}
+	} else if query.Page > 1 {
+		opts.SetSkip(int64((query.Page - 1) * limit))
+	}
 
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	var tasks []database.TaskEntity
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return domain.TaskPage{}, err
+	}
+	return newTaskPage(tasks, total, query, limit), nil
+}
+
+func taskQueryFilter(query domain.TaskQuery) bson.M {
+	filter := notTrashed(bson.M{})
+	if query.CreatedBy != "" {
+		filter["created_by"] = query.CreatedBy
+	}
+	if query.Assignee != "" {
+		filter["assignees"] = query.Assignee
+	}
+	if !query.ProjectID.IsZero() {
+		filter["project_id"] = query.ProjectID
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.Priority == domain.PriorityMedium {
+		// Tasks stored before priorities existed count as medium.
+		filter["priority"] = bson.M{"$in": bson.A{domain.PriorityMedium, nil}}
+	} else if query.Priority != "" {
+		filter["priority"] = query.Priority
+	}
+	if query.Tag != "" {
+		filter["tags"] = query.Tag
+	}
+	dueDate := bson.M{}
+	if !query.DueBefore.IsZero() {
+		dueDate["$lt"] = primitive.NewDateTimeFromTime(query.DueBefore)
+	}
+	if !query.DueAfter.IsZero() {
+		dueDate["$gt"] = primitive.NewDateTimeFromTime(query.DueAfter)
+	}
+	if len(dueDate) > 0 {
+		filter["due_date"] = dueDate
+	}
+	return filter
+}
+
+// taskCursorFilter matches the tasks sorted after the cursor position.
+func taskCursorFilter(cursor taskCursor, field string, desc bool) bson.M {
+	op := "$gt"
+	if desc {
+		op = "$lt"
+	}
+	if field == "_id" {
+		return bson.M{"_id": bson.M{op: cursor.ID}}
+	}
+	var value interface{} = cursor.Title
+	if field == "due_date" {
+		value = primitive.NewDateTimeFromTime(cursor.DueDate)
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, "_id": bson.M{op: cursor.ID}},
+	}}
+}
+
+// Search runs a $text query against the task text index and returns the
+// matches ranked by relevance.
+func (s *TaskRepositoryImpl) Search(ctx context.Context, search domain.TaskSearch) ([]domain.TaskMatch, error) {
+	filter := notTrashed(bson.M{"$text": bson.M{"$search": search.Text}})
+	if search.CreatedBy != "" {
+		filter["created_by"] = search.CreatedBy
+	}
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}}).
+		SetLimit(int64(search.Limit))
+
+	cursor, err := s.Database.Collection(s.Collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var results []database.TaskSearchEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return database.FromTaskSearchEntityListToDomainList(results), nil
+}
+
+// GetSubtasks lists the subtasks of a task outside the trash, oldest first.
+func (s *TaskRepositoryImpl) GetSubtasks(ctx context.Context, parentID string) ([]domain.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(parentID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := s.Database.Collection(s.Collection).Find(ctx, notTrashed(bson.M{"parent_id": objectID}), opts)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []database.TaskEntity
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return database.FromTaskEntityListToDomainList(tasks), nil
+}
+
+// CountSubtasksByStatus counts the subtasks of the tasks by parent and status
+// in a single $group stage.
+func (s *TaskRepositoryImpl) CountSubtasksByStatus(ctx context.Context, parentIDs []primitive.ObjectID) (map[primitive.ObjectID][]domain.StatusCount, error) {
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.M{"created_by": username}}},
+		{{Key: "$match", Value: notTrashed(bson.M{"parent_id": bson.M{"$in": parentIDs}})}},
 		{{Key: "$group", Value: bson.D{
-			{Key: "_id", Value: "$status"},
+			{Key: "_id", Value: bson.D{{Key: "parent_id", Value: "$parent_id"}, {Key: "status", Value: "$status"}}},
 			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
 		}}},
 	}
-
 	cursor, err := s.Database.Collection(s.Collection).Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
+	var results []database.SubtaskCountEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return database.FromSubtaskCountEntityListToDomain(results), nil
+}
 
-	var results []database.StatusCount
+// GetStatuses returns the status of each of the tasks outside the trash.
+func (s *TaskRepositoryImpl) GetStatuses(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]string, error) {
+	opts := options.Find().SetProjection(bson.M{"status": 1})
+	cursor, err := s.Database.Collection(s.Collection).Find(ctx, notTrashed(bson.M{"_id": bson.M{"$in": ids}}), opts)
+	if err != nil {
+		return nil, err
+	}
+	var results []database.TaskStatusEntity
 	if err := cursor.All(ctx, &results); err != nil {
 		return nil, err
 	}
+	return database.FromTaskStatusEntityListToDomain(results), nil
+}
+
+// GetExistingIds returns the IDs of the tasks that exist, including those in
+// the trash.
+func (s *TaskRepositoryImpl) GetExistingIds(ctx context.Context, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	values, err := s.Database.Collection(s.Collection).Distinct(ctx, "_id", bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	existing := []primitive.ObjectID{}
+	for _, value := range values {
+		if id, ok := value.(primitive.ObjectID); ok {
+			existing = append(existing, id)
+		}
+	}
+	return existing, nil
+}
+
+// CountByProject counts the tasks of the project, including those in the
+// trash, which would come back to the project if restored.
+func (s *TaskRepositoryImpl) CountByProject(ctx context.Context, projectID primitive.ObjectID) (int64, error) {
+	return s.Database.Collection(s.Collection).CountDocuments(ctx, bson.M{"project_id": projectID})
+}
 
-	return database.FromStatusCountListToDomainList(results), nil
+// GetDueBetween lists the tasks outside the trash due after the first time
+// and before the second one and not in the status, due first.
+func (s *TaskRepositoryImpl) GetDueBetween(ctx context.Context, after, before time.Time, status string) ([]domain.Task, error) {
+	filter := notTrashed(bson.M{
+		"due_date": bson.M{"$gt": primitive.NewDateTimeFromTime(after), "$lt": primitive.NewDateTimeFromTime(before)},
+		"status":   bson.M{"$ne": status},
+	})
+	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.Database.Collection(s.Collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []database.TaskEntity
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return database.FromTaskEntityListToDomainList(tasks), nil
 }
@@ -0,0 +1,43 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TokenDenylistImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewTokenDenylist(db mongo.Database, collection string) domain.TokenDenylist {
+	return &TokenDenylistImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+func (s *TokenDenylistImpl) Add(ctx context.Context, jti string, expiresAt time.Time) error {
+	entity := database.DeniedTokenEntity{ID: jti, ExpiresAt: primitive.NewDateTimeFromTime(expiresAt)}
+	opts := options.Replace().SetUpsert(true)
+	_, err := s.Database.Collection(s.Collection).ReplaceOne(ctx, bson.M{"_id": jti}, entity, opts)
+	return err
+}
+
+// Contains ignores entries that already expired, so the check stays correct
+// even before the TTL monitor removes them.
+func (s *TokenDenylistImpl) Contains(ctx context.Context, jti string) (bool, error) {
+	filter := bson.M{"_id": jti, "expires_at": bson.M{"$gt": primitive.NewDateTimeFromTime(time.Now())}}
+	count, err := s.Database.Collection(s.Collection).CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
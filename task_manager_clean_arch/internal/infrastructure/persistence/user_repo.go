@@ -2,7 +2,6 @@ package persistence
 
 import (
 	"context"
-	"errors"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yiheyistm/task_manager/internal/domain"
@@ -29,7 +28,7 @@ func (s *UserRepositoryImpl) Insert(ctx context.Context, user *domain.User) erro
 		return err
 	}
 	if userEntity == nil {
-		return errors.New("user cannot be Empty")
+		return domain.NewError(domain.ErrValidation, "user cannot be Empty")
 	}
 
 	_, err = s.DB.Collection(s.Collection).InsertOne(ctx, userEntity)
@@ -66,7 +65,7 @@ func (s *UserRepositoryImpl) GetUser(ctx context.Context, key, value string) (*d
 	err := s.DB.Collection(s.Collection).FindOne(ctx, filter).Decode(&user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("user not found")
+			return nil, domain.NewError(domain.ErrNotFound, "user not found")
 		}
 		return nil, err
 	}
@@ -89,3 +88,15 @@ func (s *UserRepositoryImpl) GetUserFromContext(c *gin.Context) *domain.User {
 	user, _ := s.GetByUsername(c, username)
 	return user
 }
+
+func (s *UserRepositoryImpl) IncrementTokenVersion(ctx context.Context, username string) error {
+	update := bson.M{"$inc": bson.M{"token_version": 1}}
+	result, err := s.DB.Collection(s.Collection).UpdateOne(ctx, bson.M{"username": username}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.NewError(domain.ErrNotFound, "user not found")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookDeliveryRepositoryImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewWebhookDeliveryRepository(db mongo.Database, collection string) domain.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepositoryImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+var (
+	errDeliveryNotFound = domain.NewError(domain.ErrNotFound, "webhook delivery not found")
+	errNoDeliveryDue    = domain.NewError(domain.ErrNotFound, "no webhook delivery is due")
+)
+
+func (r *WebhookDeliveryRepositoryImpl) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	entity, err := database.FromDomainToWebhookDeliveryEntity(delivery)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	if _, err := r.Database.Collection(r.Collection).InsertOne(ctx, entity); err != nil {
+		return err
+	}
+	delivery.ID = entity.ID
+	return nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) GetById(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.WebhookDelivery{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	var entity database.WebhookDeliveryEntity
+	err = r.Database.Collection(r.Collection).FindOne(ctx, bson.M{"_id": objectID}).Decode(&entity)
+	if err == mongo.ErrNoDocuments {
+		return domain.WebhookDelivery{}, errDeliveryNotFound
+	}
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return *database.FromWebhookDeliveryEntityToDomain(&entity), nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) GetByWebhook(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.Database.Collection(r.Collection).Find(ctx, bson.M{"webhook_id": objectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var entities []database.WebhookDeliveryEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, err
+	}
+	return database.FromWebhookDeliveryEntityListToDomainList(entities), nil
+}
+
+// ClaimDue moves the next attempt of the delivery in the same update that
+// finds it, so two workers never claim the same delivery.
+func (r *WebhookDeliveryRepositoryImpl) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (domain.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          domain.DeliveryPending,
+		"next_attempt_at": bson.M{"$lte": primitive.NewDateTimeFromTime(now)},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": primitive.NewDateTimeFromTime(now.Add(lease))}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+	var entity database.WebhookDeliveryEntity
+	err := r.Database.Collection(r.Collection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&entity)
+	if err == mongo.ErrNoDocuments {
+		return domain.WebhookDelivery{}, errNoDeliveryDue
+	}
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return *database.FromWebhookDeliveryEntityToDomain(&entity), nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	entity, err := database.FromDomainToWebhookDeliveryEntity(delivery)
+	if err != nil {
+		return err
+	}
+	result, err := r.Database.Collection(r.Collection).ReplaceOne(ctx, bson.M{"_id": entity.ID}, entity)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errDeliveryNotFound
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) DeleteByWebhook(ctx context.Context, webhookID string) error {
+	objectID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	_, err = r.Database.Collection(r.Collection).DeleteMany(ctx, bson.M{"webhook_id": objectID})
+	return err
+}
@@ -0,0 +1,117 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepositoryImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewWebhookRepository(db mongo.Database, collection string) domain.WebhookRepository {
+	return &WebhookRepositoryImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+var errWebhookNotFound = domain.NewError(domain.ErrNotFound, "webhook not found")
+
+func (r *WebhookRepositoryImpl) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *WebhookRepositoryImpl) GetByEvent(ctx context.Context, eventType string) ([]domain.Webhook, error) {
+	return r.find(ctx, bson.M{"active": true, "events": eventType})
+}
+
+// find lists the webhooks matching the filter, oldest first.
+func (r *WebhookRepositoryImpl) find(ctx context.Context, filter bson.M) ([]domain.Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.Database.Collection(r.Collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var entities []database.WebhookEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, err
+	}
+	return database.FromWebhookEntityListToDomainList(entities), nil
+}
+
+func (r *WebhookRepositoryImpl) GetById(ctx context.Context, id string) (domain.Webhook, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Webhook{}, domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	var entity database.WebhookEntity
+	err = r.Database.Collection(r.Collection).FindOne(ctx, bson.M{"_id": objectID}).Decode(&entity)
+	if err == mongo.ErrNoDocuments {
+		return domain.Webhook{}, errWebhookNotFound
+	}
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	return *database.FromWebhookEntityToDomain(&entity), nil
+}
+
+func (r *WebhookRepositoryImpl) Create(ctx context.Context, webhook *domain.Webhook) error {
+	entity, err := database.FromDomainToWebhookEntity(webhook)
+	if err != nil {
+		return err
+	}
+	if entity.ID.IsZero() {
+		entity.ID = primitive.NewObjectID()
+	}
+	if _, err := r.Database.Collection(r.Collection).InsertOne(ctx, entity); err != nil {
+		return err
+	}
+	webhook.ID = entity.ID
+	return nil
+}
+
+// Update changes the URL, events, secret and state of the webhook. Its
+// creator and creation time are kept.
+func (r *WebhookRepositoryImpl) Update(ctx context.Context, id string, webhook *domain.Webhook) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	update := bson.M{"$set": bson.M{
+		"url":    webhook.URL,
+		"events": webhook.Events,
+		"secret": webhook.Secret,
+		"active": webhook.Active,
+	}}
+	result, err := r.Database.Collection(r.Collection).UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.NewError(domain.ErrValidation, "invalid ObjectID")
+	}
+	result, err := r.Database.Collection(r.Collection).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errWebhookNotFound
+	}
+	return nil
+}
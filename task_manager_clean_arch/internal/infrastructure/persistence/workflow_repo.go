@@ -0,0 +1,48 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WorkflowRepositoryImpl stores the task workflow as a single document.
+type WorkflowRepositoryImpl struct {
+	Database   mongo.Database
+	Collection string
+}
+
+func NewWorkflowRepository(db mongo.Database, collection string) domain.WorkflowRepository {
+	return &WorkflowRepositoryImpl{
+		Database:   db,
+		Collection: collection,
+	}
+}
+
+var errWorkflowNotFound = domain.NewError(domain.ErrNotFound, "workflow not found")
+
+func (r *WorkflowRepositoryImpl) Get(ctx context.Context) (domain.Workflow, error) {
+	var entity database.WorkflowEntity
+	err := r.Database.Collection(r.Collection).FindOne(ctx, bson.M{"_id": database.WorkflowID}).Decode(&entity)
+	if err == mongo.ErrNoDocuments {
+		return domain.Workflow{}, errWorkflowNotFound
+	}
+	if err != nil {
+		return domain.Workflow{}, err
+	}
+	return database.FromWorkflowEntityToDomain(&entity), nil
+}
+
+func (r *WorkflowRepositoryImpl) Save(ctx context.Context, workflow *domain.Workflow) error {
+	entity, err := database.FromDomainToWorkflowEntity(workflow)
+	if err != nil {
+		return err
+	}
+	opts := options.Replace().SetUpsert(true)
+	_, err = r.Database.Collection(r.Collection).ReplaceOne(ctx, bson.M{"_id": database.WorkflowID}, entity, opts)
+	return err
+}
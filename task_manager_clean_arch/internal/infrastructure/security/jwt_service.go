@@ -1,8 +1,9 @@
 package security
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -25,12 +26,32 @@ func NewJWTService(accessSecret, refreshSecret string, accessExpiry, refreshExpi
 	}
 }
 
+// GenerateTokens issues a token pair that starts a new refresh token family.
 func (s *JwtService) GenerateTokens(user domain.User) (domain.RefreshToken, error) {
-	fmt.Println(s, user)
+	return s.RotateTokens(user, "")
+}
+
+// RotateTokens issues a token pair whose refresh token belongs to the given
+// family. An empty family starts a new one named after the token's jti.
+func (s *JwtService) RotateTokens(user domain.User, familyID string) (domain.RefreshToken, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	accessJti, err := newTokenID()
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if familyID == "" {
+		familyID = jti
+	}
 	accessClaims := jwt.MapClaims{
 		"sub":      user.ID,
 		"username": user.Username,
 		"role":     user.Role,
+		"jti":      accessJti,
+		"fam":      familyID,
+		"ver":      user.TokenVersion,
 		"exp":      time.Now().Add(s.AccessExpiry).Unix(),
 		"iat":      time.Now().Unix(),
 	}
@@ -43,6 +64,9 @@ func (s *JwtService) GenerateTokens(user domain.User) (domain.RefreshToken, erro
 	refreshClaims := jwt.MapClaims{
 		"sub":      user.ID,
 		"username": user.Username,
+		"jti":      jti,
+		"fam":      familyID,
+		"ver":      user.TokenVersion,
 		"exp":      time.Now().Add(s.RefreshExpiry).Unix(),
 		"iat":      time.Now().Unix(),
 	}
@@ -89,3 +113,11 @@ func (s *JwtService) ValidateRefreshToken(token string) (jwt.MapClaims, error) {
 	}
 	return nil, errors.New("invalid token")
 }
+
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// Headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+)
+
+// SignatureTolerance is how far the timestamp of a delivery may be from the
+// receiver's clock. Receivers should refuse deliveries outside of it, so a
+// captured delivery cannot be replayed later.
+const SignatureTolerance = 5 * time.Minute
+
+// HTTPSender posts deliveries as JSON. Any status outside 2xx counts as a
+// failed attempt.
+type HTTPSender struct {
+	Client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) domain.WebhookSender {
+	return &HTTPSender{Client: &http.Client{Timeout: timeout}}
+}
+
+// Sign is the signature sent in HeaderSignature: the hex encoded HMAC-SHA256
+// of the timestamp sent in HeaderTimestamp, a dot and the body, keyed with
+// the webhook secret and prefixed "sha256=". Signing the timestamp keeps it
+// from being changed to replay a delivery.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a delivery as a receiver would: the signature must match and
+// the timestamp, in Unix seconds, be within SignatureTolerance of now.
+func Verify(secret, signature, timestamp string, body []byte, now time.Time) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if diff := now.Sub(time.Unix(seconds, 0)); diff > SignatureTolerance || diff < -SignatureTolerance {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+func (s *HTTPSender) Send(ctx context.Context, hook domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(NewEventPayload(delivery.Event))
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-manager-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Event.Type)
+	req.Header.Set(HeaderDelivery, delivery.ID.Hex())
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(hook.Secret, timestamp, body))
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// EventPayload is the body posted for an event. Data holds the task of a task
+// event or the user of a user event.
+type EventPayload struct {
+	ID    string      `json:"id"`
+	Type  string      `json:"type"`
+	At    time.Time   `json:"at"`
+	Actor string      `json:"actor,omitempty"`
+	Data  PayloadData `json:"data"`
+}
+
+type PayloadData struct {
+	Task *TaskPayload `json:"task,omitempty"`
+	User *UserPayload `json:"user,omitempty"`
+}
+
+type TaskPayload struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	DueDate     time.Time  `json:"due_date"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	Tags        []string   `json:"tags"`
+	CreatedBy   string     `json:"created_by"`
+	Assignees   []string   `json:"assignees"`
+	ProjectID   string     `json:"project_id,omitempty"`
+	ParentID    string     `json:"parent_id,omitempty"`
+	Version     int64      `json:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+type UserPayload struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+}
+
+func NewEventPayload(event domain.Event) EventPayload {
+	payload := EventPayload{
+		ID:    event.ID.Hex(),
+		Type:  event.Type,
+		At:    event.At,
+		Actor: event.Actor,
+	}
+	if task := event.Task; task != nil {
+		payload.Data.Task = &TaskPayload{
+			ID:          task.ID.Hex(),
+			Title:       task.Title,
+			Description: task.Description,
+			DueDate:     task.DueDate,
+			Status:      task.Status,
+			Priority:    task.Priority,
+			Tags:        append([]string{}, task.Tags...),
+			CreatedBy:   task.CreatedBy,
+			Assignees:   append([]string{}, task.Assignees...),
+			Version:     task.Version,
+		}
+		if !task.ProjectID.IsZero() {
+			payload.Data.Task.ProjectID = task.ProjectID.Hex()
+		}
+		if !task.ParentID.IsZero() {
+			payload.Data.Task.ParentID = task.ParentID.Hex()
+		}
+		if !task.DeletedAt.IsZero() {
+			payload.Data.Task.DeletedAt = &task.DeletedAt
+		}
+	}
+	if user := event.User; user != nil {
+		payload.Data.User = &UserPayload{Username: user.Username, Email: user.Email, Role: user.Role}
+	}
+	return payload
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// ReminderScheduler periodically sends the reminders of tasks that are due
+// soon or overdue.
+type ReminderScheduler struct {
+	ReminderUsecase domain.IReminderUseCase
+	Interval        time.Duration
+}
+
+func NewReminderScheduler(reminderUsecase domain.IReminderUseCase, interval time.Duration) *ReminderScheduler {
+	return &ReminderScheduler{
+		ReminderUsecase: reminderUsecase,
+		Interval:        interval,
+	}
+}
+
+// Run sends reminders once right away and then every Interval until the
+// context is cancelled. A non-positive Interval disables reminders.
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	if s.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		s.send()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReminderScheduler) send() {
+	sent, err := s.ReminderUsecase.SendReminders()
+	if err != nil {
+		log.Println("Failed to send reminders:", err)
+	}
+	if sent > 0 {
+		log.Printf("Sent %d reminders", sent)
+	}
+}
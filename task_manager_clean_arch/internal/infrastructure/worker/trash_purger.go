@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// TrashPurger periodically removes the tasks that have been in the trash for
+// longer than the retention, along with their attachments.
+type TrashPurger struct {
+	TaskUsecase       domain.ITaskUseCase
+	AttachmentUsecase domain.IAttachmentUseCase
+	Retention         time.Duration
+	Interval          time.Duration
+}
+
+func NewTrashPurger(taskUsecase domain.ITaskUseCase, attachmentUsecase domain.IAttachmentUseCase, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		TaskUsecase:       taskUsecase,
+		AttachmentUsecase: attachmentUsecase,
+		Retention:         retention,
+		Interval:          interval,
+	}
+}
+
+// Run purges the trash once right away and then every Interval until the
+// context is cancelled. A non-positive Interval disables purging.
+func (p *TrashPurger) Run(ctx context.Context) {
+	if p.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		p.purge()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge also removes the attachments left behind by tasks purged in an
+// earlier run whose attachments could not be removed then.
+func (p *TrashPurger) purge() {
+	purged, err := p.TaskUsecase.PurgeTrash(p.Retention)
+	if err != nil {
+		log.Println("Failed to purge trashed tasks:", err)
+	} else if purged > 0 {
+		log.Printf("Purged %d trashed tasks", purged)
+	}
+	orphans, err := p.AttachmentUsecase.PurgeOrphans()
+	if err != nil {
+		log.Println("Failed to purge attachments of purged tasks:", err)
+	} else if orphans > 0 {
+		log.Printf("Purged %d attachments of purged tasks", orphans)
+	}
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// WebhookDispatcher periodically delivers the queued webhook deliveries that
+// are due, including retries.
+type WebhookDispatcher struct {
+	WebhookUsecase domain.IWebhookUseCase
+	Interval       time.Duration
+}
+
+func NewWebhookDispatcher(webhookUsecase domain.IWebhookUseCase, interval time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		WebhookUsecase: webhookUsecase,
+		Interval:       interval,
+	}
+}
+
+// Run delivers once right away and then every Interval until the context is
+// cancelled. A non-positive Interval disables deliveries.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	if d.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		d.deliver()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *WebhookDispatcher) deliver() {
+	delivered, err := d.WebhookUsecase.DeliverDue()
+	if err != nil {
+		log.Println("Failed to deliver webhooks:", err)
+	}
+	if delivered > 0 {
+		log.Printf("Delivered %d webhooks", delivered)
+	}
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/yiheyistm/task_manager/internal/domain"
+
+func FromDomainAttachmentToResponse(attachment *domain.Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:          attachment.ID.Hex(),
+		TaskID:      attachment.TaskID.Hex(),
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		UploadedBy:  attachment.UploadedBy,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func FromDomainAttachmentToResponseList(attachments []domain.Attachment) []AttachmentResponse {
+	responses := []AttachmentResponse{}
+	for _, attachment := range attachments {
+		responses = append(responses, *FromDomainAttachmentToResponse(&attachment))
+	}
+	return responses
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// CommentRequest creates or edits a comment. The body is markdown; raw HTML
+// and unsafe links are removed before it is stored.
+type CommentRequest struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type CommentResponse struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	Mentions  []string   `json:"mentions"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ActivityResponse carries the comment for comment activity, and the old
+// and new status for status changes.
+type ActivityResponse struct {
+	Kind    string           `json:"kind"`
+	At      time.Time        `json:"at"`
+	Actor   string           `json:"actor"`
+	Comment *CommentResponse `json:"comment,omitempty"`
+	From    *string          `json:"from,omitempty"`
+	To      *string          `json:"to,omitempty"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "github.com/yiheyistm/task_manager/internal/domain"
+
+func (r *CommentRequest) ToDomainComment() *domain.Comment {
+	return &domain.Comment{Body: r.Body}
+}
+
+func FromDomainCommentToResponse(comment *domain.Comment) *CommentResponse {
+	response := &CommentResponse{
+		ID:        comment.ID.Hex(),
+		TaskID:    comment.TaskID.Hex(),
+		Author:    comment.Author,
+		Body:      comment.Body,
+		Mentions:  append([]string{}, comment.Mentions...),
+		CreatedAt: comment.CreatedAt,
+	}
+	if !comment.UpdatedAt.IsZero() {
+		updatedAt := comment.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+func FromDomainCommentToResponseList(comments []domain.Comment) []CommentResponse {
+	responses := []CommentResponse{}
+	for _, comment := range comments {
+		responses = append(responses, *FromDomainCommentToResponse(&comment))
+	}
+	return responses
+}
+
+func FromDomainActivityToResponseList(activity []domain.Activity) []ActivityResponse {
+	responses := []ActivityResponse{}
+	for _, entry := range activity {
+		response := ActivityResponse{Kind: entry.Kind, At: entry.At, Actor: entry.Actor}
+		if entry.Comment != nil {
+			response.Comment = FromDomainCommentToResponse(entry.Comment)
+		}
+		if entry.Kind == domain.ActivityStatusChange {
+			from, to := entry.From, entry.To
+			response.From, response.To = &from, &to
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
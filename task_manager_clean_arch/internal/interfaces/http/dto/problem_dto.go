@@ -0,0 +1,20 @@
+package dto
+
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// ProblemResponse is an RFC 7807 problem details object.
+type ProblemResponse struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail,omitempty"`
+	Instance string                 `json:"instance,omitempty"`
+	Errors   []FieldProblemResponse `json:"errors,omitempty"`
+}
+
+// FieldProblemResponse explains why one request field was rejected.
+type FieldProblemResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
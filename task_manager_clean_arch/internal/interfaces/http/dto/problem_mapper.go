@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"net/http"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+func FromDomainErrorToProblem(status int, detail string, fields []domain.FieldError, instance string) ProblemResponse {
+	problem := ProblemResponse{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+	}
+	for _, field := range fields {
+		problem.Errors = append(problem.Errors, FieldProblemResponse{Field: field.Field, Message: field.Message})
+	}
+	return problem
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type ProjectRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+}
+
+// ProjectMemberRequest sets the role of the member named in the URL.
+type ProjectMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+type ProjectResponse struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	CreatedBy   string                  `json:"created_by"`
+	CreatedAt   time.Time               `json:"created_at"`
+	Members     []ProjectMemberResponse `json:"members"`
+}
+
+type ProjectMemberResponse struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
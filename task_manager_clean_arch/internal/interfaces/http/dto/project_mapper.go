@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/yiheyistm/task_manager/internal/domain"
+
+func (r *ProjectRequest) ToDomainProject() *domain.Project {
+	return &domain.Project{
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+func FromDomainProjectToResponse(project *domain.Project) *ProjectResponse {
+	members := []ProjectMemberResponse{}
+	for _, member := range project.Members {
+		members = append(members, ProjectMemberResponse{Username: member.Username, Role: member.Role})
+	}
+	return &ProjectResponse{
+		ID:          project.ID.Hex(),
+		Name:        project.Name,
+		Description: project.Description,
+		CreatedBy:   project.CreatedBy,
+		CreatedAt:   project.CreatedAt,
+		Members:     members,
+	}
+}
+
+func FromDomainProjectToResponseList(projects []domain.Project) []ProjectResponse {
+	responses := []ProjectResponse{}
+	for _, project := range projects {
+		responses = append(responses, *FromDomainProjectToResponse(&project))
+	}
+	return responses
+}
@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+// Statuses of the operations of a batch report.
+const (
+	BatchOpApplied = "applied"
+	BatchOpFailed  = "failed"
+)
+
+// TaskBatchRequest is the body of the task batch endpoints. With Atomic set,
+// either every operation is applied or none is.
+type TaskBatchRequest struct {
+	Atomic     bool                   `json:"atomic"`
+	Operations []TaskOperationRequest `json:"operations" validate:"required,min=1,max=100"`
+}
+
+// TaskOperationRequest is one operation of a batch. The fields from Title on
+// are the changes of an update; fields left out or null are not changed. A
+// status change only takes Status, and a delete none of them. A non-zero
+// Version makes the operation fail with a conflict if the task has changed
+// since that version.
+type TaskOperationRequest struct {
+	Op          string                  `json:"op" validate:"required,oneof=update status delete"`
+	ID          string                  `json:"id" validate:"required"`
+	Version     int64                   `json:"version" validate:"min=0"`
+	Title       *string                 `json:"title" validate:"omitnil,min=1"`
+	Description *string                 `json:"description"`
+	DueDate     *time.Time              `json:"due_date"`
+	Status      *string                 `json:"status"`
+	Priority    *string                 `json:"priority" validate:"omitnil,oneof=low medium high urgent"`
+	Tags        *[]string               `json:"tags" validate:"omitnil,max=20,dive,max=32"`
+	Checklist   *[]ChecklistItemRequest `json:"checklist" validate:"omitnil,max=50,dive"`
+	RRule       *string                 `json:"rrule" validate:"omitnil,max=200"`
+}
+
+// TaskBatchResponse reports the outcome of every operation of a batch, in
+// the order of the request.
+type TaskBatchResponse struct {
+	Atomic    bool                    `json:"atomic"`
+	Total     int                     `json:"total"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Results   []TaskOperationResponse `json:"results"`
+}
+
+// TaskOperationResponse is the outcome of one operation. Task is the task as
+// the operation left it and is set on applied operations, Error and Errors
+// on failed ones.
+type TaskOperationResponse struct {
+	Index  int                    `json:"index"`
+	Op     string                 `json:"op"`
+	ID     string                 `json:"id"`
+	Status string                 `json:"status"`
+	Task   *TaskResponse          `json:"task,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+	Errors []FieldProblemResponse `json:"errors,omitempty"`
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+func (r *TaskOperationRequest) ToDomainTaskOperation() domain.TaskOperation {
+	update := domain.TaskUpdate{
+		Title:       r.Title,
+		Description: r.Description,
+		DueDate:     r.DueDate,
+		Status:      r.Status,
+		Priority:    r.Priority,
+		Tags:        r.Tags,
+		Version:     r.Version,
+	}
+	if r.Checklist != nil {
+		checklist := []domain.ChecklistItem{}
+		for _, item := range *r.Checklist {
+			checklist = append(checklist, domain.ChecklistItem{Text: item.Text, Done: item.Done})
+		}
+		update.Checklist = &checklist
+	}
+	if r.RRule != nil {
+		update.Recurrence = &domain.Recurrence{Rule: *r.RRule}
+	}
+	return domain.TaskOperation{Kind: r.Op, ID: r.ID, Update: update}
+}
+
+// FromTaskOperationResultToResponse reports the outcome of the operation at
+// the index. As in error responses, errors that are not of a domain kind do
+// not show their text.
+func FromTaskOperationResultToResponse(index int, op TaskOperationRequest, result domain.TaskOperationResult) TaskOperationResponse {
+	response := TaskOperationResponse{Index: index, Op: op.Op, ID: op.ID}
+	var domainErr *domain.Error
+	switch {
+	case errors.As(result.Err, &domainErr):
+		response.Status = BatchOpFailed
+		response.Error = domainErr.Message
+		for _, field := range domainErr.Fields {
+			response.Errors = append(response.Errors, FieldProblemResponse{Field: field.Field, Message: field.Message})
+		}
+	case result.Err != nil:
+		response.Status = BatchOpFailed
+		response.Error = "The operation could not be applied"
+	default:
+		response.Status = BatchOpApplied
+		response.Task = FromDomainTaskToResponse(&result.Task)
+	}
+	return response
+}
+
+func FromTaskOperationResponsesToBatch(results []TaskOperationResponse, atomic bool) TaskBatchResponse {
+	response := TaskBatchResponse{Atomic: atomic, Total: len(results), Results: results}
+	if response.Results == nil {
+		response.Results = []TaskOperationResponse{}
+	}
+	for _, result := range results {
+		if result.Status == BatchOpFailed {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	return response
+}
@@ -4,19 +4,136 @@ import (
 	"time"
 )
 
+// TaskRequest is the body of the task create and update endpoints. The status
+// is checked against the workflow by the use case; an empty status starts a
+// new task in the workflow's first status and keeps the current one on
+// update. RRule makes the task recur, see domain.ParseRRule for the rules
+// accepted.
 type TaskRequest struct {
-	Title       string    `json:"title" validate:"required"`
-	CreatedBy   string    `json:"created_by"`
-	Description string    `json:"description"`
-	DueDate     time.Time `json:"due_date" validate:"required"`
-	Status      string    `json:"status" validate:"oneof=pending completed"`
+	Title       string                 `json:"title" validate:"required"`
+	CreatedBy   string                 `json:"created_by"`
+	Description string                 `json:"description"`
+	DueDate     time.Time              `json:"due_date" validate:"required"`
+	Status      string                 `json:"status"`
+	Priority    string                 `json:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	Tags        []string               `json:"tags" validate:"max=20,dive,max=32"`
+	Checklist   []ChecklistItemRequest `json:"checklist" validate:"max=50,dive"`
+	RRule       string                 `json:"rrule" validate:"max=200"`
+}
+
+type ChecklistItemRequest struct {
+	Text string `json:"text" validate:"required,max=200"`
+	Done bool   `json:"done"`
 }
 
 type TaskResponse struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	CreatedBy   string    `json:"created_by"`
-	Description string    `json:"description"`
-	DueDate     time.Time `json:"due_date"`
-	Status      string    `json:"status"`
+	ID          string                  `json:"id"`
+	Title       string                  `json:"title"`
+	CreatedBy   string                  `json:"created_by"`
+	Description string                  `json:"description"`
+	DueDate     time.Time               `json:"due_date"`
+	Status      string                  `json:"status"`
+	Priority    string                  `json:"priority"`
+	Tags        []string                `json:"tags"`
+	Assignees   []string                `json:"assignees"`
+	ProjectID   string                  `json:"project_id,omitempty"`
+	ParentID    string                  `json:"parent_id,omitempty"`
+	Checklist   []ChecklistItemResponse `json:"checklist"`
+	Subtasks    SubtaskCountResponse    `json:"subtasks"`
+	// Completion is the percentage of subtasks and checklist items that are
+	// done. It is left out for tasks that have neither.
+	Completion *int `json:"completion,omitempty"`
+	// BlockedBy lists the IDs of the tasks this one waits for, and Blocked
+	// whether any of them is still unfinished.
+	BlockedBy []string `json:"blocked_by"`
+	Blocked   bool     `json:"blocked"`
+	// Overdue is set while the task is past its due date and not finished.
+	Overdue    bool                `json:"overdue"`
+	Recurrence *RecurrenceResponse `json:"recurrence,omitempty"`
+	Version    int64               `json:"version"`
+	// DeletedAt is only set on tasks in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Score and Highlights are only set on search results.
+	Score      float64           `json:"score,omitempty"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+type ChecklistItemResponse struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// RecurrenceResponse describes the series a recurring task belongs to.
+// NextID is set once the next occurrence has been created.
+type RecurrenceResponse struct {
+	RRule      string `json:"rrule"`
+	SeriesID   string `json:"series_id"`
+	Occurrence int    `json:"occurrence"`
+	NextID     string `json:"next_id,omitempty"`
+}
+
+type SubtaskCountResponse struct {
+	Total int `json:"total"`
+	Done  int `json:"done"`
+}
+
+// TaskAssigneeRequest names the user to assign to a task.
+type TaskAssigneeRequest struct {
+	Username string `json:"username" validate:"required"`
+}
+
+// TaskBlockerRequest names the task to wait for.
+type TaskBlockerRequest struct {
+	TaskID string `json:"task_id" validate:"required"`
+}
+
+// TaskQueryRequest holds the query parameters accepted by the task list
+// endpoints. Dates use RFC 3339.
+type TaskQueryRequest struct {
+	Page      int       `form:"page" validate:"omitempty,min=1"`
+	Limit     int       `form:"limit" validate:"omitempty,min=1,max=100"`
+	Sort      string    `form:"sort" validate:"omitempty,oneof=due_date -due_date title -title"`
+	Status    string    `form:"status"`
+	Priority  string    `form:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	Tag       string    `form:"tag"`
+	DueBefore time.Time `form:"due_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	DueAfter  time.Time `form:"due_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor    string    `form:"cursor"`
+}
+
+type TaskPageResponse struct {
+	Tasks      []TaskResponse `json:"tasks"`
+	Total      int64          `json:"total"`
+	Page       int            `json:"page,omitempty"`
+	Limit      int            `json:"limit"`
+	TotalPages int            `json:"total_pages"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type TaskSearchRequest struct {
+	Query string `form:"q" validate:"required"`
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// TaskStatsResponse is the body of the task stats endpoints. Every list has
+// its largest groups first.
+type TaskStatsResponse struct {
+	ByStatus   []StatusCountResponse   `json:"by_status"`
+	ByPriority []PriorityCountResponse `json:"by_priority"`
+	ByTag      []TagCountResponse      `json:"by_tag"`
+}
+
+type StatusCountResponse struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+type PriorityCountResponse struct {
+	Priority string `json:"priority"`
+	Count    int    `json:"count"`
+}
+
+type TagCountResponse struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
 }
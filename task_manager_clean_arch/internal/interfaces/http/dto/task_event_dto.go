@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// TaskEventResponse is the data of an event sent on a task event stream. The
+// task is the task as it is after the change.
+type TaskEventResponse struct {
+	ID    string       `json:"id"`
+	Type  string       `json:"type"`
+	At    time.Time    `json:"at"`
+	Actor string       `json:"actor"`
+	Task  TaskResponse `json:"task"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/yiheyistm/task_manager/internal/domain"
+
+func FromDomainEventToTaskEventResponse(event *domain.Event) *TaskEventResponse {
+	response := &TaskEventResponse{
+		ID:    event.ID.Hex(),
+		Type:  event.Type,
+		At:    event.At,
+		Actor: event.Actor,
+	}
+	if event.Task != nil {
+		response.Task = *FromDomainTaskToResponse(event.Task)
+	}
+	return response
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type TaskHistoryResponse struct {
+	ID      string               `json:"id"`
+	TaskID  string               `json:"task_id"`
+	Action  string               `json:"action"`
+	Actor   string               `json:"actor"`
+	At      time.Time            `json:"at"`
+	Version int64                `json:"version"`
+	Changes []TaskChangeResponse `json:"changes"`
+}
+
+// TaskChangeResponse is the old and new value of one task field; unset
+// values are empty strings.
+type TaskChangeResponse struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/yiheyistm/task_manager/internal/domain"
+
+func FromDomainTaskHistoryToResponse(history *domain.TaskHistory) *TaskHistoryResponse {
+	changes := []TaskChangeResponse{}
+	for _, change := range history.Changes {
+		changes = append(changes, TaskChangeResponse{Field: change.Field, From: change.From, To: change.To})
+	}
+	return &TaskHistoryResponse{
+		ID:      history.ID.Hex(),
+		TaskID:  history.TaskID.Hex(),
+		Action:  history.Action,
+		Actor:   history.Actor,
+		At:      history.At,
+		Version: history.Version,
+		Changes: changes,
+	}
+}
+
+func FromDomainTaskHistoryToResponseList(history []domain.TaskHistory) []TaskHistoryResponse {
+	responses := []TaskHistoryResponse{}
+	for _, entry := range history {
+		responses = append(responses, *FromDomainTaskHistoryToResponse(&entry))
+	}
+	return responses
+}
@@ -0,0 +1,28 @@
+package dto
+
+// Statuses of the rows of an import report.
+const (
+	ImportRowCreated = "created"
+	ImportRowValid   = "valid"
+	ImportRowFailed  = "failed"
+)
+
+// TaskImportResponse reports the outcome of every row of a bulk import.
+// Succeeded counts the created tasks, or on a dry run the valid ones.
+type TaskImportResponse struct {
+	DryRun    bool                    `json:"dry_run"`
+	Total     int                     `json:"total"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Rows      []TaskImportRowResponse `json:"rows"`
+}
+
+// TaskImportRowResponse is the outcome of one row. ID is set on created
+// tasks, Error and Errors on failed rows.
+type TaskImportRowResponse struct {
+	Line   int                    `json:"line"`
+	Status string                 `json:"status"`
+	ID     string                 `json:"id,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+	Errors []FieldProblemResponse `json:"errors,omitempty"`
+}
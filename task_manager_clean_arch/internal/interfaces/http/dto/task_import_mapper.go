@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// FromImportedTaskToRowResponse reports the outcome of importing the task
+// read from a line. As in error responses, errors that are not of a domain
+// kind do not show their text.
+func FromImportedTaskToRowResponse(line int, task *domain.Task, err error, dryRun bool) TaskImportRowResponse {
+	row := TaskImportRowResponse{Line: line}
+	var domainErr *domain.Error
+	switch {
+	case errors.As(err, &domainErr):
+		row.Status = ImportRowFailed
+		row.Error = domainErr.Message
+		for _, field := range domainErr.Fields {
+			row.Errors = append(row.Errors, FieldProblemResponse{Field: field.Field, Message: field.Message})
+		}
+	case err != nil:
+		row.Status = ImportRowFailed
+		row.Error = "The task could not be saved"
+	case dryRun:
+		row.Status = ImportRowValid
+	default:
+		row.Status = ImportRowCreated
+		row.ID = task.ID.Hex()
+	}
+	return row
+}
+
+func FromImportRowsToResponse(rows []TaskImportRowResponse, dryRun bool) TaskImportResponse {
+	response := TaskImportResponse{DryRun: dryRun, Total: len(rows), Rows: rows}
+	if response.Rows == nil {
+		response.Rows = []TaskImportRowResponse{}
+	}
+	for _, row := range rows {
+		if row.Status == ImportRowFailed {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	return response
+}
@@ -1,7 +1,13 @@
 package dto
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/patch"
 )
 
 func (r *TaskRequest) FromRequestToDomainTask() *domain.Task {
@@ -11,17 +17,97 @@ func (r *TaskRequest) FromRequestToDomainTask() *domain.Task {
 		Description: r.Description,
 		DueDate:     r.DueDate,
 		Status:      r.Status,
+		Priority:    r.Priority,
+		Tags:        r.Tags,
+		Checklist:   r.toDomainChecklist(),
+		Recurrence:  r.toDomainRecurrence(),
+	}
+}
+
+func (r *TaskRequest) toDomainRecurrence() *domain.Recurrence {
+	if r.RRule == "" {
+		return nil
+	}
+	return &domain.Recurrence{Rule: r.RRule}
+}
+
+func ruleOf(task *domain.Task) string {
+	if task.Recurrence == nil {
+		return ""
+	}
+	return task.Recurrence.Rule
+}
+
+func (r *TaskRequest) toDomainChecklist() []domain.ChecklistItem {
+	var items []domain.ChecklistItem
+	for _, item := range r.Checklist {
+		items = append(items, domain.ChecklistItem{Text: item.Text, Done: item.Done})
+	}
+	return items
+}
+
+func fromDomainChecklistToRequest(items []domain.ChecklistItem) []ChecklistItemRequest {
+	var requests []ChecklistItemRequest
+	for _, item := range items {
+		requests = append(requests, ChecklistItemRequest{Text: item.Text, Done: item.Done})
 	}
+	return requests
 }
+
 func FromDomainTaskToResponse(task *domain.Task) *TaskResponse {
-	return &TaskResponse{
+	response := &TaskResponse{
 		ID:          task.ID.Hex(),
 		Title:       task.Title,
 		CreatedBy:   task.CreatedBy,
 		Description: task.Description,
 		DueDate:     task.DueDate,
 		Status:      task.Status,
+		Priority:    task.Priority,
+		Tags:        task.Tags,
+		Assignees:   task.Assignees,
+		Checklist:   []ChecklistItemResponse{},
+		Subtasks:    SubtaskCountResponse{Total: task.Subtasks.Total, Done: task.Subtasks.Done},
+		BlockedBy:   []string{},
+		Blocked:     task.Blocked,
+		Overdue:     task.Overdue,
+		Version:     task.Version,
+	}
+	for _, id := range task.BlockedBy {
+		response.BlockedBy = append(response.BlockedBy, id.Hex())
+	}
+	if recurrence := task.Recurrence; recurrence != nil {
+		response.Recurrence = &RecurrenceResponse{
+			RRule:      recurrence.Rule,
+			SeriesID:   task.SeriesID().Hex(),
+			Occurrence: recurrence.Occurrence,
+		}
+		if !recurrence.NextID.IsZero() {
+			response.Recurrence.NextID = recurrence.NextID.Hex()
+		}
 	}
+	for _, item := range task.Checklist {
+		response.Checklist = append(response.Checklist, ChecklistItemResponse{Text: item.Text, Done: item.Done})
+	}
+	if completion, ok := task.Completion(); ok {
+		response.Completion = &completion
+	}
+	if response.Tags == nil {
+		response.Tags = []string{}
+	}
+	if response.Assignees == nil {
+		response.Assignees = []string{}
+	}
+	if !task.ProjectID.IsZero() {
+		response.ProjectID = task.ProjectID.Hex()
+	}
+	if !task.ParentID.IsZero() {
+		response.ParentID = task.ParentID.Hex()
+	}
+	if !task.DeletedAt.IsZero() {
+		deletedAt := task.DeletedAt
+		response.DeletedAt = &deletedAt
+	}
+	return response
 }
 
 func FromDomainTaskToResponseList(tasks []domain.Task) []TaskResponse {
@@ -31,3 +117,130 @@ func FromDomainTaskToResponseList(tasks []domain.Task) []TaskResponse {
 	}
 	return taskResponses
 }
+
+func (r *TaskQueryRequest) ToDomainTaskQuery() domain.TaskQuery {
+	return domain.TaskQuery{
+		Status:    r.Status,
+		Priority:  r.Priority,
+		Tag:       strings.ToLower(strings.TrimSpace(r.Tag)),
+		DueBefore: r.DueBefore,
+		DueAfter:  r.DueAfter,
+		Sort:      r.Sort,
+		Page:      r.Page,
+		Limit:     r.Limit,
+		Cursor:    r.Cursor,
+	}
+}
+
+func FromDomainTaskPageToResponse(page domain.TaskPage) TaskPageResponse {
+	tasks := FromDomainTaskToResponseList(page.Tasks)
+	if tasks == nil {
+		tasks = []TaskResponse{}
+	}
+	response := TaskPageResponse{
+		Tasks:      tasks,
+		Total:      page.Total,
+		Page:       page.Page,
+		Limit:      page.Limit,
+		NextCursor: page.NextCursor,
+	}
+	if page.Limit > 0 {
+		response.TotalPages = int((page.Total + int64(page.Limit) - 1) / int64(page.Limit))
+	}
+	return response
+}
+
+func (r *TaskSearchRequest) ToDomainTaskSearch() domain.TaskSearch {
+	return domain.TaskSearch{
+		Text:  r.Query,
+		Limit: r.Limit,
+	}
+}
+
+func FromDomainTaskMatchListToResponse(matches []domain.TaskMatch) []TaskResponse {
+	taskResponses := []TaskResponse{}
+	for _, match := range matches {
+		response := FromDomainTaskToResponse(&match.Task)
+		response.Score = match.Score
+		response.Highlights = match.Highlights
+		taskResponses = append(taskResponses, *response)
+	}
+	return taskResponses
+}
+
+// ApplyTaskPatch applies a merge patch or JSON Patch document to the task's
+// request representation. The result still has to be validated.
+func ApplyTaskPatch(task *domain.Task, patchDoc []byte, contentType string) (*TaskRequest, error) {
+	doc, err := json.Marshal(TaskRequest{
+		Title:       task.Title,
+		CreatedBy:   task.CreatedBy,
+		Description: task.Description,
+		DueDate:     task.DueDate,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		Tags:        task.Tags,
+		Checklist:   fromDomainChecklistToRequest(task.Checklist),
+		RRule:       ruleOf(task),
+	})
+	if err != nil {
+		return nil, err
+	}
+	patched, err := patch.Apply(doc, patchDoc, contentType)
+	if err != nil {
+		return nil, err
+	}
+	var request TaskRequest
+	if err := json.Unmarshal(patched, &request); err != nil {
+		return nil, fmt.Errorf("%w: %v", patch.ErrInvalidPatch, err)
+	}
+	return &request, nil
+}
+
+// ToDomainTaskUpdate lists the fields of the patched request that differ from
+// the original task. The owner of a task cannot be changed.
+func (r *TaskRequest) ToDomainTaskUpdate(original *domain.Task) domain.TaskUpdate {
+	var update domain.TaskUpdate
+	if r.Title != original.Title {
+		update.Title = &r.Title
+	}
+	if r.Description != original.Description {
+		update.Description = &r.Description
+	}
+	if !r.DueDate.Equal(original.DueDate) {
+		update.DueDate = &r.DueDate
+	}
+	if r.Status != original.Status {
+		update.Status = &r.Status
+	}
+	if r.Priority != original.Priority {
+		update.Priority = &r.Priority
+	}
+	if !slices.Equal(r.Tags, original.Tags) {
+		update.Tags = &r.Tags
+	}
+	if checklist := r.toDomainChecklist(); !slices.Equal(checklist, original.Checklist) {
+		update.Checklist = &checklist
+	}
+	if r.RRule != ruleOf(original) {
+		update.Recurrence = &domain.Recurrence{Rule: r.RRule}
+	}
+	return update
+}
+
+func FromDomainTaskStatsToResponse(stats domain.TaskStats) TaskStatsResponse {
+	response := TaskStatsResponse{
+		ByStatus:   []StatusCountResponse{},
+		ByPriority: []PriorityCountResponse{},
+		ByTag:      []TagCountResponse{},
+	}
+	for _, count := range stats.ByStatus {
+		response.ByStatus = append(response.ByStatus, StatusCountResponse{Status: count.Status, Count: count.Count})
+	}
+	for _, count := range stats.ByPriority {
+		response.ByPriority = append(response.ByPriority, PriorityCountResponse{Priority: count.Priority, Count: count.Count})
+	}
+	for _, count := range stats.ByTag {
+		response.ByTag = append(response.ByTag, TagCountResponse{Tag: count.Tag, Count: count.Count})
+	}
+	return response
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "github.com/yiheyistm/task_manager/internal/domain"
+
+func (r *WebhookRequest) ToDomainWebhook() *domain.Webhook {
+	return &domain.Webhook{
+		URL:    r.URL,
+		Events: r.Events,
+		Secret: r.Secret,
+		Active: r.Active == nil || *r.Active,
+	}
+}
+
+func FromDomainWebhookToResponse(webhook *domain.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:        webhook.ID.Hex(),
+		URL:       webhook.URL,
+		Events:    append([]string{}, webhook.Events...),
+		Active:    webhook.Active,
+		CreatedBy: webhook.CreatedBy,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func FromDomainWebhookToResponseList(webhooks []domain.Webhook) []WebhookResponse {
+	responses := []WebhookResponse{}
+	for _, webhook := range webhooks {
+		responses = append(responses, *FromDomainWebhookToResponse(&webhook))
+	}
+	return responses
+}
+
+func FromDomainWebhookDeliveryToResponse(delivery *domain.WebhookDelivery) *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		ID:             delivery.ID.Hex(),
+		WebhookID:      delivery.WebhookID.Hex(),
+		EventID:        delivery.Event.ID.Hex(),
+		Event:          delivery.Event.Type,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == domain.DeliveryPending {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		response.DeliveredAt = &delivery.DeliveredAt
+	}
+	return response
+}
+
+func FromDomainWebhookDeliveryToResponseList(deliveries []domain.WebhookDelivery) []WebhookDeliveryResponse {
+	responses := []WebhookDeliveryResponse{}
+	for _, delivery := range deliveries {
+		responses = append(responses, *FromDomainWebhookDeliveryToResponse(&delivery))
+	}
+	return responses
+}
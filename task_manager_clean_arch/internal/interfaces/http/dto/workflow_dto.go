@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// WorkflowRequest replaces the task workflow. The first status is the one
+// new tasks start in.
+type WorkflowRequest struct {
+	Statuses    []string            `json:"statuses" validate:"required,min=1,max=20,dive,required"`
+	Transitions map[string][]string `json:"transitions"`
+}
+
+type WorkflowResponse struct {
+	Statuses      []string            `json:"statuses"`
+	InitialStatus string              `json:"initial_status"`
+	Transitions   map[string][]string `json:"transitions"`
+	UpdatedBy     string              `json:"updated_by,omitempty"`
+	// UpdatedAt is not set while the default workflow is in use.
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/yiheyistm/task_manager/internal/domain"
+
+func (r *WorkflowRequest) ToDomainWorkflow() *domain.Workflow {
+	return &domain.Workflow{
+		Statuses:    r.Statuses,
+		Transitions: r.Transitions,
+	}
+}
+
+// FromDomainWorkflowToResponse lists every status in the transitions, with
+// an empty list for the statuses a task cannot leave.
+func FromDomainWorkflowToResponse(workflow *domain.Workflow) *WorkflowResponse {
+	response := &WorkflowResponse{
+		Statuses:      workflow.Statuses,
+		InitialStatus: workflow.Initial(),
+		Transitions:   make(map[string][]string, len(workflow.Statuses)),
+		UpdatedBy:     workflow.UpdatedBy,
+	}
+	for _, status := range workflow.Statuses {
+		targets := workflow.Transitions[status]
+		if targets == nil {
+			targets = []string{}
+		}
+		response.Transitions[status] = targets
+	}
+	if !workflow.UpdatedAt.IsZero() {
+		updatedAt := workflow.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
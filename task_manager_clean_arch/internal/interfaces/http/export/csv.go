@@ -0,0 +1,101 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// listSeparator joins the tags and assignees of a task in a single column.
+const listSeparator = ";"
+
+var csvHeader = []string{
+	"id", "title", "description", "status", "priority", "due_date", "tags",
+	"assignees", "created_by", "project_id", "parent_id", "blocked", "overdue", "version",
+}
+
+// csvWriter writes one row per task after a header row.
+type csvWriter struct {
+	w       *csv.Writer
+	started bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *csvWriter) start() error {
+	if cw.started {
+		return nil
+	}
+	cw.started = true
+	return cw.w.Write(csvHeader)
+}
+
+func (cw *csvWriter) Write(task *domain.Task) error {
+	if err := cw.start(); err != nil {
+		return err
+	}
+	dueDate := ""
+	if !task.DueDate.IsZero() {
+		dueDate = task.DueDate.UTC().Format(time.RFC3339)
+	}
+	projectID, parentID := "", ""
+	if !task.ProjectID.IsZero() {
+		projectID = task.ProjectID.Hex()
+	}
+	if !task.ParentID.IsZero() {
+		parentID = task.ParentID.Hex()
+	}
+	return cw.w.Write([]string{
+		task.ID.Hex(),
+		safeCell(task.Title),
+		safeCell(task.Description),
+		safeCell(task.Status),
+		safeCell(task.Priority),
+		dueDate,
+		safeCell(strings.Join(task.Tags, listSeparator)),
+		safeCell(strings.Join(task.Assignees, listSeparator)),
+		safeCell(task.CreatedBy),
+		projectID,
+		parentID,
+		strconv.FormatBool(task.Blocked),
+		strconv.FormatBool(task.Overdue),
+		strconv.FormatInt(task.Version, 10),
+	})
+}
+
+func (cw *csvWriter) Close() error {
+	if err := cw.start(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// formulaStarts are the characters spreadsheets start a formula with.
+const formulaStarts = "=+-@\t\r"
+
+// safeCell keeps spreadsheets from running text as a formula by prefixing
+// the characters that start one with a quote. Every text column goes through
+// it: besides titles and tags, statuses come from the admin's workflow and
+// usernames from whoever registered.
+func safeCell(value string) string {
+	if value != "" && strings.ContainsRune(formulaStarts, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// UnescapeCell undoes safeCell, so that exported files can be imported again
+// unchanged.
+func UnescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaStarts, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
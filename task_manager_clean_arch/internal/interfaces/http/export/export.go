@@ -0,0 +1,54 @@
+// Package export writes tasks as CSV, JSON or iCalendar files. The writers
+// stream the tasks one at a time, so that exports never have to hold every
+// task in memory.
+package export
+
+import (
+	"io"
+	"strings"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatICS  = "ics"
+)
+
+// Formats lists the supported formats, the default one first.
+var Formats = []string{FormatJSON, FormatCSV, FormatICS}
+
+// TaskWriter writes tasks to a file. Nothing is written to the underlying
+// writer before the first task or Close, so that a failure to find the tasks
+// can still be reported as an error response. A file whose writer is not
+// closed is left incomplete.
+type TaskWriter interface {
+	Write(*domain.Task) error
+	Close() error
+}
+
+// NewTaskWriter returns a writer for the format. The workflow tells which
+// statuses mean that a task has not been started or is finished.
+func NewTaskWriter(format string, w io.Writer, workflow domain.Workflow) (TaskWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatJSON:
+		return newJSONWriter(w), nil
+	case FormatICS:
+		return newICSWriter(w, workflow), nil
+	}
+	return nil, domain.NewError(domain.ErrValidation, "format must be one of "+strings.Join(Formats, ", "))
+}
+
+// ContentType is the media type of files of the format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatICS:
+		return "text/calendar; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+const (
+	// icsProductID identifies the application that made the calendar.
+	icsProductID = "-//task_manager//Task Export//EN"
+	// icsLineLength is the longest line, in bytes, before it is folded.
+	icsLineLength = 75
+	icsTimeLayout = "20060102T150405Z"
+)
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icsWriter writes an iCalendar (RFC 5545) calendar with one to-do per task.
+type icsWriter struct {
+	w        *bufio.Writer
+	workflow domain.Workflow
+	stamp    string
+	started  bool
+	// err is the first error writing to w.
+	err error
+}
+
+func newICSWriter(w io.Writer, workflow domain.Workflow) *icsWriter {
+	return &icsWriter{
+		w:        bufio.NewWriter(w),
+		workflow: workflow,
+		stamp:    time.Now().UTC().Format(icsTimeLayout),
+	}
+}
+
+func (iw *icsWriter) start() {
+	if iw.started {
+		return
+	}
+	iw.started = true
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:" + icsProductID)
+	iw.line("CALSCALE:GREGORIAN")
+}
+
+func (iw *icsWriter) Write(task *domain.Task) error {
+	iw.start()
+	iw.line("BEGIN:VTODO")
+	iw.line("UID:" + task.ID.Hex() + "@task-manager")
+	iw.line("DTSTAMP:" + iw.stamp)
+	iw.line("SUMMARY:" + icsEscaper.Replace(task.Title))
+	if task.Description != "" {
+		iw.line("DESCRIPTION:" + icsEscaper.Replace(task.Description))
+	}
+	if !task.DueDate.IsZero() {
+		iw.line("DUE:" + task.DueDate.UTC().Format(icsTimeLayout))
+	}
+	iw.line("STATUS:" + icsStatus(task.Status, iw.workflow))
+	iw.line(fmt.Sprintf("PRIORITY:%d", icsPriority(task.Priority)))
+	if len(task.Tags) > 0 {
+		categories := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			categories[i] = icsEscaper.Replace(tag)
+		}
+		iw.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+	iw.line("END:VTODO")
+	return iw.err
+}
+
+func (iw *icsWriter) Close() error {
+	iw.start()
+	iw.line("END:VCALENDAR")
+	if iw.err != nil {
+		return iw.err
+	}
+	return iw.w.Flush()
+}
+
+// line writes a content line ending in CRLF, folded so that no line is
+// longer than 75 bytes. Folding never splits a UTF-8 sequence.
+func (iw *icsWriter) line(content string) {
+	if iw.err != nil {
+		return
+	}
+	var folded strings.Builder
+	limit := icsLineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		folded.WriteString(content[:cut])
+		folded.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards
+		// their length.
+		limit = icsLineLength - 1
+	}
+	folded.WriteString(content)
+	folded.WriteString("\r\n")
+	_, iw.err = iw.w.WriteString(folded.String())
+}
+
+// icsStatus maps the status of a task to a to-do status: tasks in the first
+// status of the workflow still need action, tasks in the last one are
+// completed and the rest are in process.
+func icsStatus(status string, workflow domain.Workflow) string {
+	switch status {
+	case workflow.Final():
+		return "COMPLETED"
+	case workflow.Initial():
+		return "NEEDS-ACTION"
+	}
+	return "IN-PROCESS"
+}
+
+// icsPriority maps a priority to the 1 (highest) to 9 (lowest) scale of
+// iCalendar.
+func icsPriority(priority string) int {
+	switch priority {
+	case domain.PriorityUrgent:
+		return 1
+	case domain.PriorityHigh:
+		return 3
+	case domain.PriorityLow:
+		return 9
+	}
+	return 5
+}
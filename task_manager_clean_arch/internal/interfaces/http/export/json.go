@@ -0,0 +1,45 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+// jsonWriter writes an array of tasks with the fields of the task responses.
+type jsonWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: w}
+}
+
+func (jw *jsonWriter) Write(task *domain.Task) error {
+	data, err := json.Marshal(dto.FromDomainTaskToResponse(task))
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if !jw.started {
+		separator = "[\n"
+		jw.started = true
+	}
+	if _, err := io.WriteString(jw.w, separator); err != nil {
+		return err
+	}
+	_, err = jw.w.Write(data)
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	end := "\n]\n"
+	if !jw.started {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(jw.w, end)
+	return err
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+// multipartOverhead is the room left in an upload request for the multipart
+// headers and boundaries around the file.
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	AttachmentUsecase domain.IAttachmentUseCase
+	UserUsecase       domain.IUserUseCase
+	// MaxUploadSize is the largest file accepted, in bytes.
+	MaxUploadSize int64
+}
+
+// GetTaskAttachments lists the attachments of one of the current user's
+// tasks, oldest first
+func (ah *AttachmentHandler) GetTaskAttachments(c *gin.Context) {
+	user := ah.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+
+	attachments, err := ah.AttachmentUsecase.GetByTaskAndUser(c.Param("id"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch attachments")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"attachments": dto.FromDomainAttachmentToResponseList(attachments)})
+}
+
+// UploadTaskAttachment attaches the file sent in the "file" field of a
+// multipart form to one of the current user's tasks
+func (ah *AttachmentHandler) UploadTaskAttachment(c *gin.Context) {
+	user := ah.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to upload files on behalf of other user")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, ah.MaxUploadSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		reject(c, domain.ErrTooLarge, fmt.Sprintf("file is larger than %d bytes", ah.MaxUploadSize))
+		return
+	}
+	if err != nil {
+		reject(c, domain.ErrValidation, "Request must be a multipart form with a file field")
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		fail(c, err, "Failed to read uploaded file")
+		return
+	}
+	defer file.Close()
+
+	attachment := &domain.Attachment{Filename: header.Filename, Size: header.Size}
+	if err := ah.AttachmentUsecase.CreateByTaskAndUser(c.Param("id"), attachment, file, user.Username); err != nil {
+		fail(c, err, "Failed to upload attachment")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.FromDomainAttachmentToResponse(attachment))
+}
+
+// DownloadTaskAttachment sends the content of an attachment of one of the
+// current user's tasks. Browsers are told to save it rather than display it.
+func (ah *AttachmentHandler) DownloadTaskAttachment(c *gin.Context) {
+	user := ah.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+
+	attachment, content, err := ah.AttachmentUsecase.OpenByIdAndUser(c.Param("id"), c.Param("attachmentId"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to download attachment")
+		return
+	}
+	defer content.Close()
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteTaskAttachment deletes an attachment the current user uploaded, or
+// any attachment of a task they created
+func (ah *AttachmentHandler) DeleteTaskAttachment(c *gin.Context) {
+	user := ah.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to delete files on behalf of other user")
+		return
+	}
+
+	if err := ah.AttachmentUsecase.DeleteByIdAndUser(c.Param("id"), c.Param("attachmentId"), user.Username); err != nil {
+		fail(c, err, "Failed to delete attachment")
+		return
+	}
+	c.JSON(http.StatusNoContent, gin.H{"message": "Attachment deleted successfully"})
+}
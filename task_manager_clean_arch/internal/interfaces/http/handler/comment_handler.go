@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+type CommentHandler struct {
+	CommentUsecase domain.ICommentUseCase
+	UserUsecase    domain.IUserUseCase
+}
+
+// GetTaskComments lists the comments on one of the current user's tasks,
+// oldest first
+func (ch *CommentHandler) GetTaskComments(c *gin.Context) {
+	user := ch.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+
+	comments, err := ch.CommentUsecase.GetByTaskAndUser(c.Param("id"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch comments")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"comments": dto.FromDomainCommentToResponseList(comments)})
+}
+
+// CreateTaskComment adds a comment to one of the current user's tasks
+func (ch *CommentHandler) CreateTaskComment(c *gin.Context) {
+	user := ch.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to comment on behalf of other user")
+		return
+	}
+
+	var req dto.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		invalid(c, err)
+		return
+	}
+
+	comment := req.ToDomainComment()
+	if err := ch.CommentUsecase.CreateByTaskAndUser(c.Param("id"), comment, user.Username); err != nil {
+		fail(c, err, "Failed to create comment")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.FromDomainCommentToResponse(comment))
+}
+
+// UpdateTaskComment edits a comment the current user wrote
+func (ch *CommentHandler) UpdateTaskComment(c *gin.Context) {
+	user := ch.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to comment on behalf of other user")
+		return
+	}
+
+	var req dto.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		invalid(c, err)
+		return
+	}
+
+	comment, err := ch.CommentUsecase.UpdateByIdAndUser(c.Param("id"), c.Param("commentId"), req.Body, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to update comment")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainCommentToResponse(&comment))
+}
+
+// DeleteTaskComment deletes a comment the current user wrote, or any comment
+// on a task they created
+func (ch *CommentHandler) DeleteTaskComment(c *gin.Context) {
+	user := ch.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to delete comments on behalf of other user")
+		return
+	}
+
+	if err := ch.CommentUsecase.DeleteByIdAndUser(c.Param("id"), c.Param("commentId"), user.Username); err != nil {
+		fail(c, err, "Failed to delete comment")
+		return
+	}
+	c.JSON(http.StatusNoContent, gin.H{"message": "Comment deleted successfully"})
+}
+
+// GetTaskActivity lists the comments and status changes of one of the
+// current user's tasks, most recent first
+func (ch *CommentHandler) GetTaskActivity(c *gin.Context) {
+	user := ch.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+
+	activity, err := ch.CommentUsecase.GetActivityByTaskAndUser(c.Param("id"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch task activity")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"activity": dto.FromDomainActivityToResponseList(activity)})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+var validate = newValidator()
+
+// newValidator reports fields by their JSON or query parameter name rather
+// than the Go field name.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.Split(field.Tag.Get(tag), ",")[0]
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	return v
+}
+
+// fail records err for middleware.ErrorHandler, which writes the response,
+// and stops the request. Errors that are not one of the domain error kinds
+// are reported as 500 with message as the detail, so their text never
+// reaches the client.
+func fail(c *gin.Context, err error, message string) {
+	c.Error(err).SetMeta(message)
+	c.Abort()
+}
+
+// reject fails the request with a new domain error of the given kind.
+func reject(c *gin.Context, kind error, message string) {
+	fail(c, domain.NewError(kind, message), message)
+}
+
+// invalid fails the request with a validation error. Errors from the
+// validator are broken down per field.
+func invalid(c *gin.Context, err error) {
+	validationErr := validationError(err, "Request has invalid fields")
+	fail(c, validationErr, validationErr.Message)
+}
+
+// validationError turns err into a validation error. Errors from the
+// validator get the message and are broken down per field; other errors keep
+// their text.
+func validationError(err error, message string) *domain.Error {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return &domain.Error{Kind: domain.ErrValidation, Message: err.Error()}
+	}
+	validationErr := &domain.Error{Kind: domain.ErrValidation, Message: message}
+	for _, fe := range fieldErrors {
+		validationErr.Fields = append(validationErr.Fields, domain.FieldError{Field: fe.Field(), Message: fieldMessage(fe)})
+	}
+	return validationErr
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return "must be one of: " + fe.Param()
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	default:
+		return fmt.Sprintf("failed the %q check", fe.Tag())
+	}
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+type ProjectHandler struct {
+	ProjectUsecase domain.IProjectUseCase
+	UserUsecase    domain.IUserUseCase
+}
+
+// GetProjects lists the projects the current user is a member of
+func (ph *ProjectHandler) GetProjects(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	projects, err := ph.ProjectUsecase.GetByUser(user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch projects")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"projects": dto.FromDomainProjectToResponseList(projects)})
+}
+
+// GetProject
+func (ph *ProjectHandler) GetProject(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	project, err := ph.ProjectUsecase.GetByIdAndUser(c.Param("id"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch project")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainProjectToResponse(&project))
+}
+
+// CreateProject creates a project owned by the current user
+func (ph *ProjectHandler) CreateProject(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	var request dto.ProjectRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+	project := request.ToDomainProject()
+	project.CreatedBy = user.Username
+	if err := ph.ProjectUsecase.Create(project); err != nil {
+		fail(c, err, "Failed to create project")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.FromDomainProjectToResponse(project))
+}
+
+// UpdateProject renames a project or changes its description
+func (ph *ProjectHandler) UpdateProject(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	var request dto.ProjectRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+	project := request.ToDomainProject()
+	if err := ph.ProjectUsecase.UpdateByIdAndUser(c.Param("id"), project, user.Username); err != nil {
+		fail(c, err, "Failed to update project")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainProjectToResponse(project))
+}
+
+// DeleteProject deletes a project that has no tasks left
+func (ph *ProjectHandler) DeleteProject(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	if err := ph.ProjectUsecase.DeleteByIdAndUser(c.Param("id"), user.Username); err != nil {
+		fail(c, err, "Failed to delete project")
+		return
+	}
+	c.JSON(http.StatusNoContent, gin.H{"message": "Project deleted successfully"})
+}
+
+// SetProjectMember adds a user to a project or changes their role
+func (ph *ProjectHandler) SetProjectMember(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	var request dto.ProjectMemberRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+	username := strings.ToLower(c.Param("username"))
+	if _, err := ph.UserUsecase.GetByUsername(username); err != nil {
+		fail(c, err, "Failed to fetch user")
+		return
+	}
+	member := domain.ProjectMember{Username: username, Role: request.Role}
+	project, err := ph.ProjectUsecase.SetMember(c.Param("id"), member, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to set project member")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainProjectToResponse(&project))
+}
+
+// RemoveProjectMember removes a user from a project. Members can also use it
+// to leave a project.
+func (ph *ProjectHandler) RemoveProjectMember(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	project, err := ph.ProjectUsecase.RemoveMember(c.Param("id"), c.Param("username"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to remove project member")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainProjectToResponse(&project))
+}
+
+// GetProjectTasks lists the tasks of a project
+func (ph *ProjectHandler) GetProjectTasks(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	query, ok := bindTaskQuery(c)
+	if !ok {
+		return
+	}
+	page, err := ph.ProjectUsecase.ListTasks(c.Param("id"), query, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch project tasks")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainTaskPageToResponse(page))
+}
+
+// GetProjectTask
+func (ph *ProjectHandler) GetProjectTask(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	task, err := ph.ProjectUsecase.GetTask(c.Param("id"), c.Param("taskId"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch task")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&task))
+}
+
+// CreateProjectTask adds a task to a project
+func (ph *ProjectHandler) CreateProjectTask(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	var newTask dto.TaskRequest
+	if err := c.ShouldBindJSON(&newTask); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(newTask); err != nil {
+		invalid(c, err)
+		return
+	}
+	task := newTask.FromRequestToDomainTask()
+	if err := ph.ProjectUsecase.CreateTask(c.Param("id"), task, user.Username); err != nil {
+		fail(c, err, "Failed to create task")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusCreated, dto.FromDomainTaskToResponse(task))
+}
+
+// PatchProjectTask changes only the fields in the patch of a project task
+func (ph *ProjectHandler) PatchProjectTask(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	projectID, taskID := c.Param("id"), c.Param("taskId")
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	task, err := ph.ProjectUsecase.GetTask(projectID, taskID, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch task")
+		return
+	}
+	if version != 0 && version != task.Version {
+		fail(c, domain.ErrVersionConflict, "")
+		return
+	}
+	patched, ok := applyTaskPatch(c, &task)
+	if !ok {
+		return
+	}
+	update := patched.ToDomainTaskUpdate(&task)
+	update.Version = version
+	updated, err := ph.ProjectUsecase.PatchTask(projectID, taskID, update, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to update task")
+		return
+	}
+	setETag(c, updated.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&updated))
+}
+
+// DeleteProjectTask moves a project task to its creator's trash
+func (ph *ProjectHandler) DeleteProjectTask(c *gin.Context) {
+	user := ph.UserUsecase.GetUserFromContext(c)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	err := ph.ProjectUsecase.DeleteTask(c.Param("id"), c.Param("taskId"), version, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to delete task")
+		return
+	}
+	c.JSON(http.StatusNoContent, gin.H{"message": "Task deleted successfully"})
+}
@@ -17,25 +17,13 @@ func (rtc *RefreshTokenHandler) RefreshToken(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		invalid(c, err)
 		return
 	}
 
-	claims, err := rtc.RefreshTokenUsecase.ValidateRefreshToken(request.RefreshToken)
+	response, err := rtc.RefreshTokenUsecase.Refresh(request.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-		return
-	}
-	username := claims["username"].(string)
-	user, err := rtc.RefreshTokenUsecase.GetByUsername(username)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-		return
-	}
-
-	response, err := rtc.RefreshTokenUsecase.GenerateTokens(*user)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err, "Failed to refresh token")
 		return
 	}
 
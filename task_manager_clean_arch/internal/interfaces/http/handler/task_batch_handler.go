@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+type TaskBatchHandler struct {
+	TaskUsecase domain.ITaskUseCase
+	UserUsecase domain.IUserUseCase
+}
+
+// BatchUserTasks updates, changes the status of or deletes many of the current
+// user's tasks at once and reports the outcome of every operation.
+func (bh *TaskBatchHandler) BatchUserTasks(c *gin.Context) {
+	user := bh.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to change tasks on behalf of other user")
+		return
+	}
+	runBatch(c, user.Username, bh.TaskUsecase.BatchByUser)
+}
+
+// BatchTasks is BatchUserTasks on the tasks of every user
+func (bh *TaskBatchHandler) BatchTasks(c *gin.Context) {
+	user := bh.UserUsecase.GetUserFromContext(c)
+	runBatch(c, user.Username, bh.TaskUsecase.Batch)
+}
+
+// runBatch checks every operation of the body on its own, so that an invalid
+// operation only fails the whole batch when it is atomic, then runs the valid
+// ones. The response is 200 OK whatever the outcome of the operations.
+func runBatch(c *gin.Context, actor string, run func([]domain.TaskOperation, bool, string) ([]domain.TaskOperationResult, error)) {
+	var request dto.TaskBatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+
+	results := make([]domain.TaskOperationResult, len(request.Operations))
+	var ops []domain.TaskOperation
+	var valid []int
+	for i, op := range request.Operations {
+		if err := validate.Struct(op); err != nil {
+			results[i].Err = validationError(err, "Operation has invalid fields")
+			continue
+		}
+		ops = append(ops, op.ToDomainTaskOperation())
+		valid = append(valid, i)
+	}
+	switch {
+	case request.Atomic && len(valid) < len(request.Operations):
+		for _, i := range valid {
+			results[i].Err = domain.ErrBatchAborted
+		}
+	case len(ops) > 0:
+		opResults, err := run(ops, request.Atomic, actor)
+		if err != nil {
+			fail(c, err, "Failed to run batch")
+			return
+		}
+		for j, i := range valid {
+			results[i] = opResults[j]
+		}
+	}
+
+	report := make([]dto.TaskOperationResponse, len(results))
+	for i, op := range request.Operations {
+		report[i] = dto.FromTaskOperationResultToResponse(i, op, results[i])
+	}
+	c.JSON(http.StatusOK, dto.FromTaskOperationResponsesToBatch(report, request.Atomic))
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+// heartbeatInterval is how often an idle event stream sends a comment, so
+// that proxies do not close the connection.
+const heartbeatInterval = 15 * time.Second
+
+type TaskEventHandler struct {
+	TaskEventUsecase domain.ITaskEventUseCase
+	UserUsecase      domain.IUserUseCase
+}
+
+// StreamUserTaskEvents streams the changes to the tasks the current user
+// created or is assigned to
+func (th *TaskEventHandler) StreamUserTaskEvents(c *gin.Context) {
+	user := th.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+	th.stream(c, user.Username)
+}
+
+// StreamTaskEvents streams the changes to all tasks
+func (th *TaskEventHandler) StreamTaskEvents(c *gin.Context) {
+	th.stream(c, "")
+}
+
+// stream writes the events as Server-Sent Events until the client goes away.
+// A client that reconnects with the Last-Event-ID header first gets the
+// events it missed, or a reset event when they are no longer available.
+func (th *TaskEventHandler) stream(c *gin.Context, username string) {
+	after, err := lastEventID(c)
+	if err != nil {
+		reject(c, domain.ErrValidation, "Last-Event-ID must be a non-negative integer")
+		return
+	}
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	events, complete := th.TaskEventUsecase.Watch(ctx, username, after)
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	if !complete {
+		// The empty ID makes the client forget its position, so that it does
+		// not ask for the missed events again when it reconnects.
+		fmt.Fprint(c.Writer, "id:\nevent: reset\ndata: {}\n\n")
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case streamed, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(dto.FromDomainEventToTaskEventResponse(&streamed.Event))
+			if err != nil {
+				log.Println("Failed to encode task event:", err)
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", streamed.Seq, streamed.Event.Type, data)
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// lastEventID is the position the client resumes from, zero when it starts
+// afresh. Browsers send it in the Last-Event-ID header when they reconnect;
+// the last_event_id query parameter is for the first connection.
+func lastEventID(c *gin.Context) (uint64, error) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
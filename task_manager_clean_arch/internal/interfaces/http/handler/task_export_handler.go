@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/export"
+)
+
+type TaskExportHandler struct {
+	TaskUsecase     domain.ITaskUseCase
+	WorkflowUsecase domain.IWorkflowUseCase
+	UserUsecase     domain.IUserUseCase
+}
+
+// ExportUserTasks sends the tasks the current user created as a CSV, JSON or
+// iCalendar file
+func (eh *TaskExportHandler) ExportUserTasks(c *gin.Context) {
+	user := eh.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+	eh.export(c, user.Username+"-tasks", func(writer export.TaskWriter) error {
+		tasks, err := eh.TaskUsecase.GetTasksByUser(user.Username)
+		if err != nil {
+			return err
+		}
+		for i := range tasks {
+			if err := writer.Write(&tasks[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ExportTasks sends every task as a CSV, JSON or iCalendar file. The tasks are
+// streamed as they are read, so the file is never held in memory.
+func (eh *TaskExportHandler) ExportTasks(c *gin.Context) {
+	eh.export(c, "tasks", func(writer export.TaskWriter) error {
+		return eh.TaskUsecase.ExportAll(func(task domain.Task) error {
+			return writer.Write(&task)
+		})
+	})
+}
+
+// export writes the tasks in the format asked for with the format query
+// parameter. Errors that happen before anything has been sent become error
+// responses; later ones can only cut the file short.
+func (eh *TaskExportHandler) export(c *gin.Context, name string, write func(export.TaskWriter) error) {
+	format := c.DefaultQuery("format", export.FormatJSON)
+	workflow, err := eh.WorkflowUsecase.Get()
+	if err != nil {
+		fail(c, err, "Failed to export tasks")
+		return
+	}
+	writer, err := export.NewTaskWriter(format, c.Writer, workflow)
+	if err != nil {
+		fail(c, err, "Failed to export tasks")
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", export.ContentType(format))
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + "." + format}))
+	header.Set("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	if err := write(writer); err != nil {
+		if !c.Writer.Written() {
+			header.Del("Content-Disposition")
+		}
+		fail(c, err, "Failed to export tasks")
+		return
+	}
+	if err := writer.Close(); err != nil {
+		fail(c, err, "Failed to export tasks")
+	}
+}
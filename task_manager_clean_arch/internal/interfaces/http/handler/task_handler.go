@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/yiheyistm/task_manager/internal/domain"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/patch"
 )
 
 type TaskHandler struct {
@@ -14,30 +18,78 @@ type TaskHandler struct {
 	UserUsecase domain.IUserUseCase
 }
 
-var validate = validator.New()
-
-// List all the tasks
+// List the tasks one page at a time
 func (th *TaskHandler) GetTasks(c *gin.Context) {
-	tasks, err := th.TaskUsecase.GetAll()
+	query, ok := bindTaskQuery(c)
+	if !ok {
+		return
+	}
+	page, err := th.TaskUsecase.ListTasks(query)
+	if err != nil {
+		fail(c, err, "Failed to retrieve tasks")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainTaskPageToResponse(page))
+}
+
+// Search the tasks of every user by title and description
+func (th *TaskHandler) SearchTasks(c *gin.Context) {
+	search, ok := bindTaskSearch(c)
+	if !ok {
+		return
+	}
+	matches, err := th.TaskUsecase.SearchTasks(search)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tasks"})
+		fail(c, err, "Failed to search tasks")
 		return
 	}
-	c.JSON(http.StatusOK, dto.FromDomainTaskToResponseList(tasks))
+	c.JSON(http.StatusOK, gin.H{"tasks": dto.FromDomainTaskMatchListToResponse(matches)})
+}
+
+// bindTaskSearch reads the parameters of a task search request, failing
+// with a validation error when the query is missing.
+func bindTaskSearch(c *gin.Context) (domain.TaskSearch, bool) {
+	var request dto.TaskSearchRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		invalid(c, err)
+		return domain.TaskSearch{}, false
+	}
+	request.Query = strings.TrimSpace(request.Query)
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return domain.TaskSearch{}, false
+	}
+	return request.ToDomainTaskSearch(), true
+}
+
+// bindTaskQuery reads the paging, sorting and filter parameters of a task
+// list request, failing with a validation error when they are invalid.
+func bindTaskQuery(c *gin.Context) (domain.TaskQuery, bool) {
+	var request dto.TaskQueryRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		invalid(c, err)
+		return domain.TaskQuery{}, false
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return domain.TaskQuery{}, false
+	}
+	return request.ToDomainTaskQuery(), true
 }
 
 // Get a specific task by ID
 func (th *TaskHandler) GetTask(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
+		reject(c, domain.ErrValidation, "Task ID is required")
 		return
 	}
 	task, err := th.TaskUsecase.GetById(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"message": "task not found"})
+		fail(c, err, "Failed to retrieve task")
 		return
 	}
+	setETag(c, task.Version)
 	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&task))
 }
 
@@ -46,61 +98,160 @@ func (th *TaskHandler) CreateTask(c *gin.Context) {
 	user := th.UserUsecase.GetUserFromContext(c)
 	var newTask dto.TaskRequest
 	if err := c.ShouldBindJSON(&newTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	if err := validate.Struct(newTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	newTask.CreatedBy = user.Username
-	err := th.TaskUsecase.Create(newTask.FromRequestToDomainTask())
+	task := newTask.FromRequestToDomainTask()
+	err := th.TaskUsecase.Create(task)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
+		fail(c, err, "Failed to create task")
 		return
 	}
-	c.JSON(http.StatusCreated, dto.FromDomainTaskToResponse(newTask.FromRequestToDomainTask()))
+	setETag(c, task.Version)
+	c.JSON(http.StatusCreated, dto.FromDomainTaskToResponse(task))
 }
 
 // Update a specific task by ID
 func (th *TaskHandler) UpdateTask(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
+		reject(c, domain.ErrValidation, "Task ID is required")
 		return
 	}
 
 	updatedTask := &dto.TaskRequest{}
 	if err := c.ShouldBindJSON(updatedTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	if err := validate.Struct(updatedTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
 	user := th.UserUsecase.GetUserFromContext(c)
 	updatedTask.CreatedBy = user.Username
-	err := th.TaskUsecase.Update(id, updatedTask.FromRequestToDomainTask())
+	task := updatedTask.FromRequestToDomainTask()
+	task.Version = version
+	err := th.TaskUsecase.Update(id, task, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to update task")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(task))
+}
+
+// Patch a specific task by ID, changing only the fields in the patch
+func (th *TaskHandler) PatchTask(c *gin.Context) {
+	id := c.Param("id")
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	task, err := th.TaskUsecase.GetById(id)
+	if err != nil {
+		fail(c, err, "Failed to retrieve task")
+		return
+	}
+	if version != 0 && version != task.Version {
+		fail(c, domain.ErrVersionConflict, "")
+		return
+	}
+	patched, ok := applyTaskPatch(c, &task)
+	if !ok {
+		return
+	}
+	update := patched.ToDomainTaskUpdate(&task)
+	update.Version = version
+	user := th.UserUsecase.GetUserFromContext(c)
+	updated, err := th.TaskUsecase.Patch(id, update, user.Username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to update task"})
+		fail(c, err, "Failed to update task")
 		return
 	}
-	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(updatedTask.FromRequestToDomainTask()))
+	setETag(c, updated.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&updated))
+}
+
+// setETag exposes the task version as a strong entity tag.
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatchVersion reads the expected task version from the If-Match header.
+// It returns zero when the header is absent or "*", and fails with a version
+// conflict when the entity tag cannot be one of ours.
+func ifMatchVersion(c *gin.Context) (int64, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	tag, err := strconv.Unquote(header)
+	if err != nil || header[0] != '"' {
+		fail(c, domain.ErrVersionConflict, "")
+		return 0, false
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		fail(c, domain.ErrVersionConflict, "")
+		return 0, false
+	}
+	return version, true
+}
+
+// applyTaskPatch applies the request body as a merge patch or JSON Patch,
+// depending on its content type, and validates the patched task.
+func applyTaskPatch(c *gin.Context, task *domain.Task) (*dto.TaskRequest, bool) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		invalid(c, err)
+		return nil, false
+	}
+	patched, err := dto.ApplyTaskPatch(task, body, c.ContentType())
+	switch {
+	case errors.Is(err, patch.ErrUnsupportedPatchType):
+		fail(c, err, "")
+		return nil, false
+	case errors.Is(err, patch.ErrTestFailed):
+		reject(c, domain.ErrConflict, err.Error())
+		return nil, false
+	case err != nil:
+		invalid(c, err)
+		return nil, false
+	}
+	if err := validate.Struct(patched); err != nil {
+		invalid(c, err)
+		return nil, false
+	}
+	return patched, true
 }
 
 // Delete a specific task
 func (th *TaskHandler) DeleteTask(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
+		reject(c, domain.ErrValidation, "Task ID is required")
 		return
 	}
 
-	err := th.TaskUsecase.Delete(id)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	user := th.UserUsecase.GetUserFromContext(c)
+	err := th.TaskUsecase.Delete(id, version, user.Username)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		fail(c, err, "Failed to delete task")
 		return
 	}
 
@@ -108,12 +259,27 @@ func (th *TaskHandler) DeleteTask(c *gin.Context) {
 
 }
 
-// Get task count by status
+// Get the changes made to a specific task, most recent first
+func (th *TaskHandler) GetTaskHistory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		reject(c, domain.ErrValidation, "Task ID is required")
+		return
+	}
+	history, err := th.TaskUsecase.GetHistory(id)
+	if err != nil {
+		fail(c, err, "Failed to retrieve task history")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"history": dto.FromDomainTaskHistoryToResponseList(history)})
+}
+
+// GetTaskCountByStatus counts all tasks by status, priority and tag
 func (th *TaskHandler) GetTaskCountByStatus(c *gin.Context) {
-	counts, err := th.TaskUsecase.GetTaskCountByStatus()
+	stats, err := th.TaskUsecase.GetTaskCountByStatus()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve task counts"})
+		fail(c, err, "Failed to retrieve task counts")
 		return
 	}
-	c.JSON(http.StatusOK, counts)
+	c.JSON(http.StatusOK, dto.FromDomainTaskStatsToResponse(stats))
 }
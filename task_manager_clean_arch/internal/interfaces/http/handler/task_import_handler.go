@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/importer"
+)
+
+const (
+	// maxImportSize is the largest import file, in bytes.
+	maxImportSize = 32 << 20
+	// maxImportTasks is how many tasks an import file can have.
+	maxImportTasks = 10000
+)
+
+type TaskImportHandler struct {
+	TaskUsecase domain.ITaskUseCase
+	UserUsecase domain.IUserUseCase
+}
+
+// ImportUserTasks creates tasks for the current user from a CSV or NDJSON
+// file and reports the outcome of every row. Rows are checked like the body
+// of CreateUserTask; with dry_run=true nothing is created.
+func (ih *TaskImportHandler) ImportUserTasks(c *gin.Context) {
+	user := ih.UserUsecase.GetUserFromContext(c)
+	if user.Username != c.Param("username") {
+		reject(c, domain.ErrForbidden, "You do not have permission to create tasks on behalf of other user")
+		return
+	}
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			reject(c, domain.ErrValidation, "dry_run must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	rows, err := importer.ReadTasks(c.ContentType(), body, maxImportTasks)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		reject(c, domain.ErrTooLarge, fmt.Sprintf("file is larger than %d bytes", maxImportSize))
+		return
+	}
+	if err != nil {
+		fail(c, err, "Failed to read import file")
+		return
+	}
+
+	errs := make([]error, len(rows))
+	tasks := make([]*domain.Task, len(rows))
+	var valid []*domain.Task
+	var validRows []int
+	for i, row := range rows {
+		if row.Err != nil {
+			errs[i] = row.Err
+			continue
+		}
+		if err := validate.Struct(row.Request); err != nil {
+			errs[i] = validationError(err, "Row has invalid fields")
+			continue
+		}
+		tasks[i] = row.Request.FromRequestToDomainTask()
+		valid = append(valid, tasks[i])
+		validRows = append(validRows, i)
+	}
+	taskErrs, err := ih.TaskUsecase.ImportByUser(user.Username, valid, dryRun)
+	if err != nil {
+		fail(c, err, "Failed to import tasks")
+		return
+	}
+	for j, i := range validRows {
+		errs[i] = taskErrs[j]
+	}
+
+	report := make([]dto.TaskImportRowResponse, len(rows))
+	for i, row := range rows {
+		report[i] = dto.FromImportedTaskToRowResponse(row.Line, tasks[i], errs[i], dryRun)
+	}
+	c.JSON(http.StatusOK, dto.FromImportRowsToResponse(report, dryRun))
+}
@@ -22,22 +22,22 @@ type UserHandler struct {
 func (uh *UserHandler) RegisterRequest(c *gin.Context) {
 	var newUser dto.UserRequest
 	if err := c.ShouldBindJSON(&newUser); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	if err := validate.Struct(newUser); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	existedUser, _ := uh.UserUsecase.GetByUsername(strings.ToLower(newUser.Username))
 	if existedUser != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Username already exists"})
+		reject(c, domain.ErrConflict, "Username already exists")
 		return
 	}
 
 	hashPassword, err := bcrypt.GenerateFromPassword([]byte(newUser.Password), bcrypt.DefaultCost)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		fail(c, err, "Failed to hash password")
 		return
 	}
 	user := domain.User{
@@ -49,7 +49,7 @@ func (uh *UserHandler) RegisterRequest(c *gin.Context) {
 
 	err = uh.UserUsecase.Insert(&user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register user"})
+		fail(c, err, "Failed to register user")
 		return
 	}
 	c.JSON(http.StatusCreated, dto.FromDomainUserToResponse(&user))
@@ -58,11 +58,11 @@ func (uh *UserHandler) RegisterRequest(c *gin.Context) {
 func (uh *UserHandler) LoginRequest(c *gin.Context) {
 	var loginRequest dto.LoginRequest
 	if err := c.ShouldBindJSON(&loginRequest); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	if loginRequest.Identifier == "" || loginRequest.Password == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Username/email and password are required"})
+		reject(c, domain.ErrValidation, "Username/email and password are required")
 		return
 	}
 	emailRegex := `^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`
@@ -77,31 +77,52 @@ func (uh *UserHandler) LoginRequest(c *gin.Context) {
 		user, err = uh.UserUsecase.GetByUsername(loginRequest.Identifier)
 	}
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		reject(c, domain.ErrUnauthorized, "Invalid credentials")
 		return
 	}
 
 	match := security.ValidatePassword(user.Password, loginRequest.Password)
 	if !match {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		reject(c, domain.ErrUnauthorized, "Invalid email or password")
 		return
 	}
 
 	response, err := uh.RefreshTokenUsecase.GenerateTokens(*user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		fail(c, err, "Failed to generate token")
 		return
 	}
 	c.JSON(http.StatusOK, dto.LoginResponse(response))
 }
 
+// Logout revokes the access token used for this request and the refresh
+// tokens issued with it
+func (uh *UserHandler) Logout(c *gin.Context) {
+	err := uh.RefreshTokenUsecase.Logout(c.GetString("jti"), c.GetString("fam"), c.GetTime("exp"))
+	if err != nil {
+		fail(c, err, "Failed to log out")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// LogoutAll revokes every token issued to the current user
+func (uh *UserHandler) LogoutAll(c *gin.Context) {
+	err := uh.RefreshTokenUsecase.LogoutAll(c.GetString("username"))
+	if err != nil {
+		fail(c, err, "Failed to log out from all sessions")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out from all sessions"})
+}
+
 // TODO: Implement get all users, update user, get all user tasks, etc.
 
 func (uh *UserHandler) GetAllUsers(c *gin.Context) {
 
 	users, err := uh.UserUsecase.GetAll()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		fail(c, err, "Failed to fetch users")
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"users": dto.FromDomainUserToResponseList(users)})
@@ -110,13 +131,13 @@ func (uh *UserHandler) GetAllUsers(c *gin.Context) {
 func (uh *UserHandler) GetUser(c *gin.Context) {
 	userName := c.Param("username")
 	if userName == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "User name is required"})
+		reject(c, domain.ErrValidation, "User name is required")
 		return
 	}
 
 	user, err := uh.UserUsecase.GetByUsername(userName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user"})
+		fail(c, err, "Failed to fetch user")
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"user": dto.FromDomainUserToResponse(user)})
@@ -128,15 +149,41 @@ func (uh *UserHandler) GetUserTasks(c *gin.Context) {
 	username := c.Param("username")
 	fmt.Println("User from context:", user, username, c.GetString("role"))
 	if user.Username != username {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to see details about this user"})
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
 		return
 	}
-	tasks, err := uh.TaskUsecase.GetTasksByUser(user.Username)
+	query, ok := bindTaskQuery(c)
+	if !ok {
+		return
+	}
+	query.CreatedBy = user.Username
+	page, err := uh.TaskUsecase.ListTasks(query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks for user"})
+		fail(c, err, "Failed to fetch tasks for user")
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"tasks": dto.FromDomainTaskToResponseList(tasks)})
+	c.JSON(http.StatusOK, dto.FromDomainTaskPageToResponse(page))
+}
+
+// SearchUserTasks searches the current user's tasks by title and description
+func (uh *UserHandler) SearchUserTasks(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+	search, ok := bindTaskSearch(c)
+	if !ok {
+		return
+	}
+	search.CreatedBy = user.Username
+	matches, err := uh.TaskUsecase.SearchTasks(search)
+	if err != nil {
+		fail(c, err, "Failed to search tasks for user")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tasks": dto.FromDomainTaskMatchListToResponse(matches)})
 }
 
 // GetUserTask
@@ -144,50 +191,76 @@ func (uh *UserHandler) GetUserTask(c *gin.Context) {
 	user := uh.UserUsecase.GetUserFromContext(c)
 	username := c.Param("username")
 	if user.Username != username {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to see details about this user"})
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
 		return
 	}
 
 	taskID := c.Param("id")
 	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
+		reject(c, domain.ErrValidation, "Task ID is required")
 		return
 	}
 
 	task, err := uh.TaskUsecase.GetByIdAndUser(taskID, user.Username)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"message": "task not found"})
+		fail(c, err, "Failed to fetch task")
 		return
 	}
+	setETag(c, task.Version)
 	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&task))
 }
 
+// GetUserTaskHistory lists the changes made to one of the current user's tasks
+func (uh *UserHandler) GetUserTaskHistory(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+
+	taskID := c.Param("id")
+	if taskID == "" {
+		reject(c, domain.ErrValidation, "Task ID is required")
+		return
+	}
+
+	history, err := uh.TaskUsecase.GetHistoryByIdAndUser(taskID, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch task history")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"history": dto.FromDomainTaskHistoryToResponseList(history)})
+}
+
 // CreateUserTask
 func (uh *UserHandler) CreateUserTask(c *gin.Context) {
 	user := uh.UserUsecase.GetUserFromContext(c)
 	username := c.Param("username")
 	if user.Username != username {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to create tasks on behalf of other user"})
+		reject(c, domain.ErrForbidden, "You do not have permission to create tasks on behalf of other user")
 		return
 	}
 
 	var newTask dto.TaskRequest
 	if err := c.ShouldBindJSON(&newTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	if err := validate.Struct(newTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	newTask.CreatedBy = user.Username
 
-	err := uh.TaskUsecase.Create(newTask.FromRequestToDomainTask())
+	task := newTask.FromRequestToDomainTask()
+	err := uh.TaskUsecase.Create(task)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
+		fail(c, err, "Failed to create task")
 		return
 	}
-	c.JSON(http.StatusCreated, dto.FromDomainTaskToResponse(newTask.FromRequestToDomainTask()))
+	setETag(c, task.Version)
+	c.JSON(http.StatusCreated, dto.FromDomainTaskToResponse(task))
 }
 
 // UpdateUserTask
@@ -195,32 +268,128 @@ func (uh *UserHandler) UpdateUserTask(c *gin.Context) {
 	user := uh.UserUsecase.GetUserFromContext(c)
 	username := c.Param("username")
 	if user.Username != username {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to update this task"})
+		reject(c, domain.ErrForbidden, "You do not have permission to update this task")
 		return
 	}
 
 	taskID := c.Param("id")
 	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
+		reject(c, domain.ErrValidation, "Task ID is required")
 		return
 	}
 
 	updatedTask := &dto.TaskRequest{}
 	if err := c.ShouldBindJSON(updatedTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
 		return
 	}
 	if err := validate.Struct(updatedTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		invalid(c, err)
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 	updatedTask.CreatedBy = user.Username
-	err := uh.TaskUsecase.UpdateByIdAndUser(taskID, updatedTask.FromRequestToDomainTask(), user.Username)
+	task := updatedTask.FromRequestToDomainTask()
+	task.Version = version
+	var err error
+	if scope := c.Query("scope"); scope != "" {
+		err = uh.TaskUsecase.UpdateOccurrenceByIdAndUser(taskID, task, scope, user.Username)
+	} else {
+		err = uh.TaskUsecase.UpdateByIdAndUser(taskID, task, user.Username)
+	}
+	if err != nil {
+		fail(c, err, "Failed to update task")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(task))
+}
+
+// PatchUserTask changes only the fields in the patch of one of the user's tasks
+func (uh *UserHandler) PatchUserTask(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to update this task")
+		return
+	}
+
+	taskID := c.Param("id")
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	task, err := uh.TaskUsecase.GetByIdAndUser(taskID, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch task")
+		return
+	}
+	if version != 0 && version != task.Version {
+		fail(c, domain.ErrVersionConflict, "")
+		return
+	}
+	patched, ok := applyTaskPatch(c, &task)
+	if !ok {
+		return
+	}
+	update := patched.ToDomainTaskUpdate(&task)
+	update.Version = version
+	updated, err := uh.TaskUsecase.PatchByIdAndUser(taskID, update, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to update task")
+		return
+	}
+	setETag(c, updated.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&updated))
+}
+
+// GetUserSubtasks lists the subtasks of a task the current user created or is
+// assigned to
+func (uh *UserHandler) GetUserSubtasks(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+
+	tasks, err := uh.TaskUsecase.GetSubtasksByIdAndUser(c.Param("id"), user.Username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task"})
+		fail(c, err, "Failed to fetch subtasks")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tasks": dto.FromDomainTaskToResponseList(tasks)})
+}
+
+// CreateUserSubtask adds a subtask to one of the current user's tasks
+func (uh *UserHandler) CreateUserSubtask(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to create tasks on behalf of other user")
+		return
+	}
+
+	var newTask dto.TaskRequest
+	if err := c.ShouldBindJSON(&newTask); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(newTask); err != nil {
+		invalid(c, err)
+		return
+	}
+
+	task := newTask.FromRequestToDomainTask()
+	if err := uh.TaskUsecase.CreateSubtask(c.Param("id"), task, user.Username); err != nil {
+		fail(c, err, "Failed to create subtask")
 		return
 	}
-	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(updatedTask.FromRequestToDomainTask()))
+	setETag(c, task.Version)
+	c.JSON(http.StatusCreated, dto.FromDomainTaskToResponse(task))
 }
 
 // DeleteUserTask
@@ -228,37 +397,214 @@ func (uh *UserHandler) DeleteUserTask(c *gin.Context) {
 	user := uh.UserUsecase.GetUserFromContext(c)
 	username := c.Param("username")
 	if user.Username != username {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to delete tasks on behalf of other user"})
+		reject(c, domain.ErrForbidden, "You do not have permission to delete tasks on behalf of other user")
 		return
 	}
 
 	taskID := c.Param("id")
 	if taskID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Task ID is required"})
+		reject(c, domain.ErrValidation, "Task ID is required")
 		return
 	}
 
-	err := uh.TaskUsecase.DeleteByIdAndUser(taskID, user.Username)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	err := uh.TaskUsecase.DeleteByIdAndUser(taskID, user.Username, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete task"})
+		fail(c, err, "Failed to delete task")
 		return
 	}
 	c.JSON(http.StatusNoContent, gin.H{"message": "Task deleted successfully"})
 }
 
+// GetAssignedTasks lists the tasks other users have assigned to the current user
+func (uh *UserHandler) GetAssignedTasks(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+	query, ok := bindTaskQuery(c)
+	if !ok {
+		return
+	}
+	query.Assignee = user.Username
+	page, err := uh.TaskUsecase.ListTasks(query)
+	if err != nil {
+		fail(c, err, "Failed to fetch assigned tasks")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainTaskPageToResponse(page))
+}
+
+// AssignUserTask assigns another user to one of the current user's tasks
+func (uh *UserHandler) AssignUserTask(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to assign users to this task")
+		return
+	}
+
+	var request dto.TaskAssigneeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+	assignee := strings.ToLower(request.Username)
+	if _, err := uh.UserUsecase.GetByUsername(assignee); err != nil {
+		fail(c, err, "Failed to fetch user")
+		return
+	}
+
+	task, err := uh.TaskUsecase.AssignByIdAndUser(c.Param("id"), assignee, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to assign user")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&task))
+}
+
+// UnassignUserTask removes an assignee from a task. Assignees can also use it
+// to remove themselves.
+func (uh *UserHandler) UnassignUserTask(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to unassign users from this task")
+		return
+	}
+
+	task, err := uh.TaskUsecase.UnassignByIdAndUser(c.Param("id"), c.Param("assignee"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to unassign user")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&task))
+}
+
+// AddUserTaskBlocker makes one of the current user's tasks wait for another task
+func (uh *UserHandler) AddUserTaskBlocker(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to change the blockers of this task")
+		return
+	}
+
+	var request dto.TaskBlockerRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+
+	task, err := uh.TaskUsecase.AddBlockerByIdAndUser(c.Param("id"), request.TaskID, user.Username)
+	if err != nil {
+		fail(c, err, "Failed to add blocker")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&task))
+}
+
+// RemoveUserTaskBlocker stops one of the current user's tasks from waiting for
+// another task
+func (uh *UserHandler) RemoveUserTaskBlocker(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to change the blockers of this task")
+		return
+	}
+
+	task, err := uh.TaskUsecase.RemoveBlockerByIdAndUser(c.Param("id"), c.Param("blockerId"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to remove blocker")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&task))
+}
+
+// GetNextUserTasks lists the current user's unfinished tasks in the order
+// their dependencies allow them to be worked on
+func (uh *UserHandler) GetNextUserTasks(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+
+	tasks, err := uh.TaskUsecase.GetNextTasksByUser(user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch next tasks")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tasks": dto.FromDomainTaskToResponseList(tasks)})
+}
+
+// GetUserTrash lists the current user's deleted tasks
+func (uh *UserHandler) GetUserTrash(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
+		return
+	}
+
+	tasks, err := uh.TaskUsecase.GetTrashByUser(user.Username)
+	if err != nil {
+		fail(c, err, "Failed to fetch deleted tasks")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tasks": dto.FromDomainTaskToResponseList(tasks)})
+}
+
+// RestoreUserTask takes one of the current user's tasks out of the trash
+func (uh *UserHandler) RestoreUserTask(c *gin.Context) {
+	user := uh.UserUsecase.GetUserFromContext(c)
+	username := c.Param("username")
+	if user.Username != username {
+		reject(c, domain.ErrForbidden, "You do not have permission to restore this task")
+		return
+	}
+
+	task, err := uh.TaskUsecase.RestoreByIdAndUser(c.Param("id"), user.Username)
+	if err != nil {
+		fail(c, err, "Failed to restore task")
+		return
+	}
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, dto.FromDomainTaskToResponse(&task))
+}
+
 // GetUserTaskStats
 func (uh *UserHandler) GetUserTaskStats(c *gin.Context) {
 	user := uh.UserUsecase.GetUserFromContext(c)
 	username := c.Param("username")
 	if user.Username != username {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to see details about this user"})
+		reject(c, domain.ErrForbidden, "You do not have permission to see details about this user")
 		return
 	}
 
 	stats, err := uh.TaskUsecase.GetTaskStatsByUser(user.Username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch task stats"})
+		fail(c, err, "Failed to fetch task stats")
 		return
 	}
-	c.JSON(http.StatusOK, stats)
+	c.JSON(http.StatusOK, dto.FromDomainTaskStatsToResponse(stats))
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/yiheyistm/task_manager/internal/domain"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/middleware"
 	"github.com/yiheyistm/task_manager/mocks/mocks_domain"
 	"github.com/yiheyistm/task_manager/mocks/mocks_security"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -82,7 +83,7 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
+		serve(c, s.handler.RegisterRequest)
 
 		s.Equal(http.StatusCreated, w.Code)
 		var response dto.UserResponse
@@ -100,12 +101,12 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
+		serve(c, s.handler.RegisterRequest)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 		s.resetMocks()
 	})
 
@@ -123,12 +124,13 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
+		serve(c, s.handler.RegisterRequest)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "Field validation")
+		s.Equal("Request has invalid fields", response["detail"])
+		s.NotEmpty(response["errors"])
 		s.resetMocks()
 	})
 
@@ -150,11 +152,11 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
-		s.Equal(http.StatusBadRequest, w.Code)
+		serve(c, s.handler.RegisterRequest)
+		s.Equal(http.StatusConflict, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Username already exists", response["error"])
+		s.Equal("Username already exists", response["detail"])
 		s.mockUserUsecase.AssertCalled(s.T(), "GetByUsername", strings.ToLower(userRequest.Username))
 		s.resetMocks()
 	})
@@ -189,12 +191,12 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
+		serve(c, s.handler.RegisterRequest)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to register user", response["error"])
+		s.Equal("Failed to register user", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -225,7 +227,7 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.LoginResponse
@@ -259,7 +261,7 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.LoginResponse
@@ -276,12 +278,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 		s.resetMocks()
 	})
 
@@ -297,12 +299,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Username/email and password are required", response["message"])
+		s.Equal("Username/email and password are required", response["detail"])
 		s.resetMocks()
 	})
 
@@ -321,12 +323,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusUnauthorized, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Invalid credentials", response["error"])
+		s.Equal("Invalid credentials", response["detail"])
 		s.resetMocks()
 	})
 
@@ -352,12 +354,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusUnauthorized, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Invalid email or password", response["error"])
+		s.Equal("Invalid email or password", response["detail"])
 		s.resetMocks()
 	})
 
@@ -386,12 +388,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to generate token", response["error"])
+		s.Equal("Failed to generate token", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -410,7 +412,7 @@ func (s *UserHandlerSuite) TestGetAllUsers() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.GetAllUsers(c)
+		serve(c, s.handler.GetAllUsers)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response gin.H
@@ -427,12 +429,12 @@ func (s *UserHandlerSuite) TestGetAllUsers() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.GetAllUsers(c)
+		serve(c, s.handler.GetAllUsers)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to fetch users", response["error"])
+		s.Equal("Failed to fetch users", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -449,7 +451,7 @@ func (s *UserHandlerSuite) TestGetUser() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUser(c)
+		serve(c, s.handler.GetUser)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response gin.H
@@ -465,12 +467,12 @@ func (s *UserHandlerSuite) TestGetUser() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: ""}}
 
-		s.handler.GetUser(c)
+		serve(c, s.handler.GetUser)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("User name is required", response["error"])
+		s.Equal("User name is required", response["detail"])
 		s.resetMocks()
 	})
 
@@ -483,12 +485,12 @@ func (s *UserHandlerSuite) TestGetUser() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUser(c)
+		serve(c, s.handler.GetUser)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to fetch user", response["error"])
+		s.Equal("Failed to fetch user", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -501,7 +503,7 @@ func (s *UserHandlerSuite) TestGetUserTasks() {
 			{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: time.Now()},
 		}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetTasksByUser", "abebe").Return(tasks, nil)
+		s.mockTaskUsecase.On("ListTasks", domain.TaskQuery{CreatedBy: "abebe"}).Return(domain.TaskPage{Tasks: tasks, Total: 1, Page: 1, Limit: 20}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks", nil)
 		w := httptest.NewRecorder()
@@ -509,12 +511,13 @@ func (s *UserHandlerSuite) TestGetUserTasks() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTasks(c)
+		serve(c, s.handler.GetUserTasks)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
 		s.Len(response["tasks"], 1)
+		s.Equal(float64(1), response["total"])
 		s.resetMocks()
 	})
 
@@ -528,19 +531,19 @@ func (s *UserHandlerSuite) TestGetUserTasks() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTasks(c)
+		serve(c, s.handler.GetUserTasks)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to see details about this user", response["error"])
+		s.Equal("You do not have permission to see details about this user", response["detail"])
 		s.resetMocks()
 	})
 
 	s.Run("FetchError", func() {
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetTasksByUser", "abebe").Return(nil, errors.New("fetch failed"))
+		s.mockTaskUsecase.On("ListTasks", domain.TaskQuery{CreatedBy: "abebe"}).Return(domain.TaskPage{}, errors.New("fetch failed"))
 
 		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks", nil)
 		w := httptest.NewRecorder()
@@ -548,12 +551,12 @@ func (s *UserHandlerSuite) TestGetUserTasks() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTasks(c)
+		serve(c, s.handler.GetUserTasks)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to fetch tasks for user", response["error"])
+		s.Equal("Failed to fetch tasks for user", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -574,7 +577,7 @@ func (s *UserHandlerSuite) TestGetUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: id.Hex()}}
 
-		s.handler.GetUserTask(c)
+		serve(c, s.handler.GetUserTask)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.TaskResponse
@@ -597,12 +600,12 @@ func (s *UserHandlerSuite) TestGetUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.GetUserTask(c)
+		serve(c, s.handler.GetUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to see details about this user", response["error"])
+		s.Equal("You do not have permission to see details about this user", response["detail"])
 		s.resetMocks()
 	})
 
@@ -616,19 +619,19 @@ func (s *UserHandlerSuite) TestGetUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: ""}}
 
-		s.handler.GetUserTask(c)
+		serve(c, s.handler.GetUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
 		s.resetMocks()
 	})
 
 	s.Run("TaskNotFound", func() {
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetByIdAndUser", "1", "abebe").Return(domain.Task{}, errors.New("task not found"))
+		s.mockTaskUsecase.On("GetByIdAndUser", "1", "abebe").Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found"))
 
 		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/1", nil)
 		w := httptest.NewRecorder()
@@ -636,12 +639,12 @@ func (s *UserHandlerSuite) TestGetUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.GetUserTask(c)
+		serve(c, s.handler.GetUserTask)
 
 		s.Equal(http.StatusNotFound, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("task not found", response["message"])
+		s.Equal("task not found", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -675,7 +678,7 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusCreated, w.Code)
 		var response dto.TaskResponse
@@ -699,12 +702,12 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to create tasks on behalf of other user", response["error"])
+		s.Equal("You do not have permission to create tasks on behalf of other user", response["detail"])
 		s.resetMocks()
 	})
 
@@ -719,12 +722,12 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 		s.resetMocks()
 	})
 
@@ -743,12 +746,13 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "Field validation")
+		s.Equal("Request has invalid fields", response["detail"])
+		s.NotEmpty(response["errors"])
 		s.resetMocks()
 	})
 
@@ -779,12 +783,12 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to create task", response["error"])
+		s.Equal("Failed to create task", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -820,7 +824,7 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: id.Hex()}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.TaskResponse
@@ -843,12 +847,12 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to update this task", response["error"])
+		s.Equal("You do not have permission to update this task", response["detail"])
 		s.resetMocks()
 	})
 
@@ -864,12 +868,12 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: ""}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
 		s.resetMocks()
 	})
 
@@ -884,12 +888,12 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 		s.resetMocks()
 	})
 
@@ -906,12 +910,13 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 		fmt.Println("Running validation error test", c)
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "Field validation")
+		s.Equal("Request has invalid fields", response["detail"])
+		s.NotEmpty(response["errors"])
 		s.resetMocks()
 	})
 
@@ -941,12 +946,12 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to update task", response["error"])
+		s.Equal("Failed to update task", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -957,7 +962,7 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		id := primitive.NewObjectID()
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("DeleteByIdAndUser", id.Hex(), "abebe").Return(nil)
+		s.mockTaskUsecase.On("DeleteByIdAndUser", id.Hex(), "abebe", int64(0)).Return(nil)
 
 		req := httptest.NewRequest(http.MethodDelete, "/users/abebe/tasks/"+id.Hex(), nil)
 		w := httptest.NewRecorder()
@@ -965,7 +970,7 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: id.Hex()}}
 
-		s.handler.DeleteUserTask(c)
+		serve(c, s.handler.DeleteUserTask)
 
 		s.Equal(http.StatusNoContent, w.Code)
 		s.resetMocks()
@@ -981,12 +986,12 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.DeleteUserTask(c)
+		serve(c, s.handler.DeleteUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to delete tasks on behalf of other user", response["error"])
+		s.Equal("You do not have permission to delete tasks on behalf of other user", response["detail"])
 		s.resetMocks()
 	})
 
@@ -1000,19 +1005,19 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: ""}}
 
-		s.handler.DeleteUserTask(c)
+		serve(c, s.handler.DeleteUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
 		s.resetMocks()
 	})
 
 	s.Run("DeleteError", func() {
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("DeleteByIdAndUser", "1", "abebe").Return(errors.New("delete failed"))
+		s.mockTaskUsecase.On("DeleteByIdAndUser", "1", "abebe", int64(0)).Return(errors.New("delete failed"))
 
 		req := httptest.NewRequest(http.MethodDelete, "/users/abebe/tasks/1", nil)
 		w := httptest.NewRecorder()
@@ -1020,12 +1025,12 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.DeleteUserTask(c)
+		serve(c, s.handler.DeleteUserTask)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to delete task", response["error"])
+		s.Equal("Failed to delete task", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -1034,9 +1039,9 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 func (s *UserHandlerSuite) TestGetUserTaskStats() {
 	s.Run("Success", func() {
 		user := &domain.User{Username: "abebe"}
-		stats := []domain.StatusCount{
-			{Status: "pending", Count: 5},
-			{Status: "completed", Count: 3},
+		stats := domain.TaskStats{
+			ByStatus:   []domain.StatusCount{{Status: "pending", Count: 5}, {Status: "completed", Count: 3}},
+			ByPriority: []domain.PriorityCount{{Priority: "high", Count: 8}},
 		}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
 		s.mockTaskUsecase.On("GetTaskStatsByUser", "abebe").Return(stats, nil)
@@ -1047,12 +1052,14 @@ func (s *UserHandlerSuite) TestGetUserTaskStats() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTaskStats(c)
+		serve(c, s.handler.GetUserTaskStats)
 
 		s.Equal(http.StatusOK, w.Code)
-		var response []domain.StatusCount
+		var response dto.TaskStatsResponse
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal(stats, response)
+		s.Equal([]dto.StatusCountResponse{{Status: "pending", Count: 5}, {Status: "completed", Count: 3}}, response.ByStatus)
+		s.Equal([]dto.PriorityCountResponse{{Priority: "high", Count: 8}}, response.ByPriority)
+		s.Equal([]dto.TagCountResponse{}, response.ByTag)
 		s.resetMocks()
 	})
 
@@ -1066,19 +1073,19 @@ func (s *UserHandlerSuite) TestGetUserTaskStats() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTaskStats(c)
+		serve(c, s.handler.GetUserTaskStats)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to see details about this user", response["error"])
+		s.Equal("You do not have permission to see details about this user", response["detail"])
 		s.resetMocks()
 	})
 
 	s.Run("FetchError", func() {
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetTaskStatsByUser", "abebe").Return(nil, errors.New("stats fetch failed"))
+		s.mockTaskUsecase.On("GetTaskStatsByUser", "abebe").Return(domain.TaskStats{}, errors.New("stats fetch failed"))
 
 		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/stats", nil)
 		w := httptest.NewRecorder()
@@ -1086,12 +1093,12 @@ func (s *UserHandlerSuite) TestGetUserTaskStats() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTaskStats(c)
+		serve(c, s.handler.GetUserTaskStats)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to fetch task stats", response["error"])
+		s.Equal("Failed to fetch task stats", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -1104,3 +1111,10 @@ func (s *UserHandlerSuite) resetMocks() {
 	s.mockRefreshTokenUsecase.ExpectedCalls = nil
 	s.mockRefreshTokenUsecase.Calls = nil
 }
+
+// serve calls the handler and then renders the error it recorded, if any,
+// the way middleware.ErrorHandler does once the handler returns.
+func serve(c *gin.Context, handle gin.HandlerFunc) {
+	handle(c)
+	middleware.ErrorHandler()(c)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+type WebhookHandler struct {
+	WebhookUsecase domain.IWebhookUseCase
+	UserUsecase    domain.IUserUseCase
+}
+
+// GetWebhooks lists all webhooks
+func (wh *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := wh.WebhookUsecase.GetAll()
+	if err != nil {
+		fail(c, err, "Failed to fetch webhooks")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": dto.FromDomainWebhookToResponseList(webhooks)})
+}
+
+// GetWebhook
+func (wh *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhook, err := wh.WebhookUsecase.GetById(c.Param("id"))
+	if err != nil {
+		fail(c, err, "Failed to fetch webhook")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainWebhookToResponse(&webhook))
+}
+
+// CreateWebhook subscribes a URL to events. The response is the only one
+// that carries the secret.
+func (wh *WebhookHandler) CreateWebhook(c *gin.Context) {
+	user := wh.UserUsecase.GetUserFromContext(c)
+	var request dto.WebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+	webhook := request.ToDomainWebhook()
+	webhook.CreatedBy = user.Username
+	if err := wh.WebhookUsecase.Create(webhook); err != nil {
+		fail(c, err, "Failed to create webhook")
+		return
+	}
+	response := dto.FromDomainWebhookToResponse(webhook)
+	response.Secret = webhook.Secret
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateWebhook replaces the URL, events and state of a webhook
+func (wh *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var request dto.WebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+	webhook := request.ToDomainWebhook()
+	if err := wh.WebhookUsecase.Update(c.Param("id"), webhook); err != nil {
+		fail(c, err, "Failed to update webhook")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainWebhookToResponse(webhook))
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (wh *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := wh.WebhookUsecase.Delete(c.Param("id")); err != nil {
+		fail(c, err, "Failed to delete webhook")
+		return
+	}
+	c.JSON(http.StatusNoContent, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// GetWebhookDeliveries lists the latest deliveries of a webhook
+func (wh *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	deliveries, err := wh.WebhookUsecase.GetDeliveries(c.Param("id"))
+	if err != nil {
+		fail(c, err, "Failed to fetch webhook deliveries")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deliveries": dto.FromDomainWebhookDeliveryToResponseList(deliveries)})
+}
+
+// RedeliverWebhook queues the event of a delivery again
+func (wh *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	delivery, err := wh.WebhookUsecase.Redeliver(c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		fail(c, err, "Failed to redeliver webhook")
+		return
+	}
+	c.JSON(http.StatusAccepted, dto.FromDomainWebhookDeliveryToResponse(&delivery))
+}
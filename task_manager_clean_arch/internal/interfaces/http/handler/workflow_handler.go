@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+type WorkflowHandler struct {
+	WorkflowUsecase domain.IWorkflowUseCase
+	UserUsecase     domain.IUserUseCase
+}
+
+// GetWorkflow returns the statuses tasks can have and the allowed changes
+// between them
+func (wh *WorkflowHandler) GetWorkflow(c *gin.Context) {
+	workflow, err := wh.WorkflowUsecase.Get()
+	if err != nil {
+		fail(c, err, "Failed to fetch workflow")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainWorkflowToResponse(&workflow))
+}
+
+// UpdateWorkflow replaces the workflow
+func (wh *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
+	user := wh.UserUsecase.GetUserFromContext(c)
+	var request dto.WorkflowRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		invalid(c, err)
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		invalid(c, err)
+		return
+	}
+	workflow := request.ToDomainWorkflow()
+	workflow.UpdatedBy = user.Username
+	if err := wh.WorkflowUsecase.Update(workflow); err != nil {
+		fail(c, err, "Failed to update workflow")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromDomainWorkflowToResponse(workflow))
+}
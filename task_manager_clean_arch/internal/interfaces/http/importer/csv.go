@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/export"
+)
+
+// listSeparator separates the tags in a single column, as in exports.
+const listSeparator = ";"
+
+// readCSV maps the columns to task fields by the names in the header row.
+// Columns it does not know, such as the id of an exported file, are ignored.
+func readCSV(r io.Reader, maxRows int) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, domain.NewError(domain.ErrValidation, "the file has no header row")
+	}
+	if err != nil {
+		return nil, readError(err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		// Spreadsheets often start UTF-8 files with a byte order mark.
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, domain.NewError(domain.ErrValidation, "the header row must have a title column")
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if len(rows) == maxRows {
+			return nil, errTooManyRows(maxRows)
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, Row{Line: parseErr.StartLine, Err: domain.NewError(domain.ErrValidation, parseErr.Err.Error())})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, csvRow(record, columns, line))
+	}
+}
+
+// readError reports a malformed header row as a validation error.
+func readError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return domain.NewError(domain.ErrValidation, "the header row is malformed: "+parseErr.Err.Error())
+	}
+	return err
+}
+
+func csvRow(record []string, columns map[string]int, line int) Row {
+	value := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return export.UnescapeCell(strings.TrimSpace(record[i]))
+	}
+	row := Row{
+		Line: line,
+		Request: dto.TaskRequest{
+			Title:       value("title"),
+			Description: value("description"),
+			Status:      value("status"),
+			Priority:    value("priority"),
+			Tags:        splitList(value("tags")),
+			RRule:       value("rrule"),
+		},
+	}
+	if dueDate := value("due_date"); dueDate != "" {
+		parsed, err := parseDate(dueDate)
+		if err != nil {
+			row.Err = &domain.Error{
+				Kind:    domain.ErrValidation,
+				Message: "Row has invalid fields",
+				Fields:  []domain.FieldError{{Field: "due_date", Message: "must be an RFC 3339 time like 2030-01-31T09:00:00Z or a date like 2030-01-31"}},
+			}
+		}
+		row.Request.DueDate = parsed
+	}
+	return row
+}
+
+// parseDate reads an RFC 3339 time, or a date as midnight UTC.
+func parseDate(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
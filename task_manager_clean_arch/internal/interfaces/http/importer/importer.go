@@ -0,0 +1,42 @@
+// Package importer reads the tasks of a bulk import from a CSV or NDJSON
+// file. Every row becomes a task request, or an error reported for that row
+// alone.
+package importer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+)
+
+const (
+	MediaTypeCSV    = "text/csv"
+	MediaTypeNDJSON = "application/x-ndjson"
+)
+
+// Row is one task of an import file.
+type Row struct {
+	// Line is the line of the file the row starts on, counting from 1.
+	Line    int
+	Request dto.TaskRequest
+	// Err is set when the row could not be read.
+	Err error
+}
+
+// ReadTasks reads the rows of a file of the media type. Files with more
+// than maxRows rows are rejected as a whole.
+func ReadTasks(mediaType string, r io.Reader, maxRows int) ([]Row, error) {
+	switch mediaType {
+	case MediaTypeCSV:
+		return readCSV(r, maxRows)
+	case MediaTypeNDJSON, "application/ndjson":
+		return readNDJSON(r, maxRows)
+	}
+	return nil, domain.NewError(domain.ErrUnsupportedType, "imports must be "+MediaTypeCSV+" or "+MediaTypeNDJSON)
+}
+
+func errTooManyRows(maxRows int) error {
+	return domain.NewError(domain.ErrTooLarge, fmt.Sprintf("an import can have at most %d tasks", maxRows))
+}
@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// maxLineSize is the longest line of an NDJSON file, in bytes.
+const maxLineSize = 1 << 20
+
+// readNDJSON reads one task request per line. Blank lines are skipped.
+func readNDJSON(r io.Reader, maxRows int) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	var rows []Row
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, errTooManyRows(maxRows)
+		}
+		row := Row{Line: line}
+		if err := json.Unmarshal(text, &row.Request); err != nil {
+			row.Err = domain.NewError(domain.ErrValidation, "invalid JSON: "+err.Error())
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+		return nil, domain.NewError(domain.ErrTooLarge, fmt.Sprintf("lines can be at most %d bytes long", maxLineSize))
+	} else if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
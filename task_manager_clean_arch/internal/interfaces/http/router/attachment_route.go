@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+)
+
+func AttachmentRoutes(env *config.Env, repos *persistence.Repositories, protectedGroup *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	maxSize := int64(env.AttachmentMaxSizeMB) << 20
+	attachmentHandler := handler.AttachmentHandler{
+		AttachmentUsecase: usecase.NewAttachmentUseCase(repos.Attachments, repos.Task, repos.Blobs, maxSize, env.AttachmentAllowedTypes),
+		UserUsecase:       usecase.NewUserUseCase(repos.User, events),
+		MaxUploadSize:     maxSize,
+	}
+	protectedGroup.GET("/users/:username/tasks/:id/attachments", attachmentHandler.GetTaskAttachments)
+	protectedGroup.POST("/users/:username/tasks/:id/attachments", attachmentHandler.UploadTaskAttachment)
+	protectedGroup.GET("/users/:username/tasks/:id/attachments/:attachmentId", attachmentHandler.DownloadTaskAttachment)
+	protectedGroup.DELETE("/users/:username/tasks/:id/attachments/:attachmentId", attachmentHandler.DeleteTaskAttachment)
+}
@@ -7,12 +7,12 @@ import (
 	"github.com/yiheyistm/task_manager/internal/infrastructure/security"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
 	"github.com/yiheyistm/task_manager/internal/usecase"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func AuthRoutes(env *config.Env, db mongo.Database, group *gin.RouterGroup) {
-	ur := persistence.NewUserRepository(db, env.DBUserCollection)
-	tr := persistence.NewTaskRepository(db, env.DBTaskCollection)
+func AuthRoutes(env *config.Env, repos *persistence.Repositories, group *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	ur := repos.User
+	tr := repos.Task
 	refreshTokenRepo := security.NewJWTService(
 		env.AccessTokenSecret,
 		env.RefreshTokenSecret,
@@ -20,9 +20,9 @@ func AuthRoutes(env *config.Env, db mongo.Database, group *gin.RouterGroup) {
 		env.RefreshTokenExpiryHour,
 	)
 	userHandler := handler.UserHandler{
-		RefreshTokenUsecase: usecase.NewRefreshTokenUsecase(ur, refreshTokenRepo),
-		TaskUsecase:         usecase.NewTaskUseCase(tr),
-		UserUsecase:         usecase.NewUserUseCase(ur),
+		RefreshTokenUsecase: usecase.NewRefreshTokenUsecase(ur, refreshTokenRepo, repos.RefreshTokens, repos.TokenDenylist),
+		TaskUsecase:         usecase.NewTaskUseCase(tr, repos.TaskHistory, repos.Workflow, events),
+		UserUsecase:         usecase.NewUserUseCase(ur, events),
 	}
 	group.POST("/users/register", userHandler.RegisterRequest)
 	group.POST("/users/login", userHandler.LoginRequest)
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+)
+
+func CommentRoutes(env *config.Env, repos *persistence.Repositories, protectedGroup *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	commentHandler := handler.CommentHandler{
+		CommentUsecase: usecase.NewCommentUseCase(repos.Comments, repos.Task, repos.TaskHistory, repos.User),
+		UserUsecase:    usecase.NewUserUseCase(repos.User, events),
+	}
+	protectedGroup.GET("/users/:username/tasks/:id/comments", commentHandler.GetTaskComments)
+	protectedGroup.POST("/users/:username/tasks/:id/comments", commentHandler.CreateTaskComment)
+	protectedGroup.PUT("/users/:username/tasks/:id/comments/:commentId", commentHandler.UpdateTaskComment)
+	protectedGroup.DELETE("/users/:username/tasks/:id/comments/:commentId", commentHandler.DeleteTaskComment)
+	protectedGroup.GET("/users/:username/tasks/:id/activity", commentHandler.GetTaskActivity)
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+)
+
+func ProjectRoutes(env *config.Env, repos *persistence.Repositories, group *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	projectHandler := handler.ProjectHandler{
+		ProjectUsecase: usecase.NewProjectUseCase(repos.Project, repos.Task, repos.User, usecase.NewTaskUseCase(repos.Task, repos.TaskHistory, repos.Workflow, events)),
+		UserUsecase:    usecase.NewUserUseCase(repos.User, events),
+	}
+	group.GET("/projects", projectHandler.GetProjects)
+	group.POST("/projects", projectHandler.CreateProject)
+	group.GET("/projects/:id", projectHandler.GetProject)
+	group.PUT("/projects/:id", projectHandler.UpdateProject)
+	group.DELETE("/projects/:id", projectHandler.DeleteProject)
+	group.PUT("/projects/:id/members/:username", projectHandler.SetProjectMember)
+	group.DELETE("/projects/:id/members/:username", projectHandler.RemoveProjectMember)
+	group.GET("/projects/:id/tasks", projectHandler.GetProjectTasks)
+	group.POST("/projects/:id/tasks", projectHandler.CreateProjectTask)
+	group.GET("/projects/:id/tasks/:taskId", projectHandler.GetProjectTask)
+	group.PATCH("/projects/:id/tasks/:taskId", projectHandler.PatchProjectTask)
+	group.DELETE("/projects/:id/tasks/:taskId", projectHandler.DeleteProjectTask)
+}
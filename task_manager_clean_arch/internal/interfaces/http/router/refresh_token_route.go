@@ -7,11 +7,10 @@ import (
 	"github.com/yiheyistm/task_manager/internal/infrastructure/security"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
 	"github.com/yiheyistm/task_manager/internal/usecase"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func RefreshTokenRoutes(env *config.Env, db mongo.Database, group *gin.RouterGroup) {
-	ur := persistence.NewUserRepository(db, env.DBUserCollection)
+func RefreshTokenRoutes(env *config.Env, repos *persistence.Repositories, group *gin.RouterGroup) {
+	ur := repos.User
 	refreshTokenRepo := security.NewJWTService(
 		env.AccessTokenSecret,
 		env.RefreshTokenSecret,
@@ -19,7 +18,7 @@ func RefreshTokenRoutes(env *config.Env, db mongo.Database, group *gin.RouterGro
 		env.RefreshTokenExpiryHour,
 	)
 	userHandler := handler.RefreshTokenHandler{
-		RefreshTokenUsecase: usecase.NewRefreshTokenUsecase(ur, usecase.NewRefreshTokenUsecase(ur, refreshTokenRepo)),
+		RefreshTokenUsecase: usecase.NewRefreshTokenUsecase(ur, refreshTokenRepo, repos.RefreshTokens, repos.TokenDenylist),
 	}
 	group.POST("/users/refresh", userHandler.RefreshToken)
 }
@@ -1,25 +1,74 @@
 package router
 
 import (
+	"log"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/security"
 	"github.com/yiheyistm/task_manager/internal/interfaces/middleware"
-
-	"go.mongodb.org/mongo-driver/mongo"
+	"github.com/yiheyistm/task_manager/internal/usecase"
 )
 
-func SetupRouter(env *config.Env, db mongo.Database) *gin.Engine {
+func SetupRouter(env *config.Env, repos *persistence.Repositories) *gin.Engine {
 	r := gin.Default()
+	// Only the configured proxies may set the client IP that requests are
+	// rate limited by.
+	if err := r.SetTrustedProxies(env.TrustedProxies); err != nil {
+		log.Println("Invalid TRUSTED_PROXIES, trusting no proxy:", err)
+		_ = r.SetTrustedProxies(nil)
+	}
+	r.Use(middleware.ErrorHandler())
 	api := r.Group("/api/v1")
+	refreshTokenUsecase := usecase.NewRefreshTokenUsecase(
+		repos.User,
+		security.NewJWTService(
+			env.AccessTokenSecret,
+			env.RefreshTokenSecret,
+			env.AccessTokenExpiryHour,
+			env.RefreshTokenExpiryHour,
+		),
+		repos.RefreshTokens,
+		repos.TokenDenylist,
+	)
+	publicGroup := api.Group("/")
+	publicGroup.Use(middleware.RateLimitMiddleware(repos.RateLimits, "public", rateLimit(env.RateLimitPublicPerMinute, env.RateLimitPublicBurst)))
 	authGroup := api.Group("/")
-	authGroup.Use(middleware.AuthMiddleware(env.AccessTokenSecret))
+	// The client IP is limited before the token is checked, so that requests
+	// with invalid tokens are throttled too, and the user after.
+	authGroup.Use(
+		middleware.RateLimitMiddleware(repos.RateLimits, "ip", rateLimit(env.RateLimitIPPerMinute, env.RateLimitIPBurst)),
+		middleware.AuthMiddleware(env.AccessTokenSecret, refreshTokenUsecase),
+		middleware.RateLimitMiddleware(repos.RateLimits, "user", rateLimit(env.RateLimitUserPerMinute, env.RateLimitUserBurst)),
+	)
 	adminGroup := authGroup.Group("/")
 	adminGroup.Use(middleware.AdminOnlyMiddleware())
 
-	AuthRoutes(env, db, api)
-	UserRoutes(env, db, authGroup, adminGroup)
-	TaskRoutes(env, db, adminGroup)
-	RefreshTokenRoutes(env, db, api)
+	AuthRoutes(env, repos, publicGroup)
+	UserRoutes(env, repos, authGroup, adminGroup)
+	TaskRoutes(env, repos, adminGroup)
+	ProjectRoutes(env, repos, authGroup)
+	WorkflowRoutes(env, repos, authGroup, adminGroup)
+	WebhookRoutes(env, repos, adminGroup)
+	TaskEventRoutes(env, repos, authGroup, adminGroup)
+	TaskExportRoutes(env, repos, authGroup, adminGroup)
+	TaskImportRoutes(env, repos, authGroup)
+	TaskBatchRoutes(env, repos, authGroup, adminGroup)
+	CommentRoutes(env, repos, authGroup)
+	AttachmentRoutes(env, repos, authGroup)
+	RefreshTokenRoutes(env, repos, publicGroup)
 
 	return r
 }
+
+// rateLimit is the limit of a route group: a burst of requests, then
+// perMinute requests a minute. A zero turns the limit off.
+func rateLimit(perMinute, burst int) domain.RateLimit {
+	if perMinute <= 0 || burst <= 0 {
+		return domain.RateLimit{}
+	}
+	return domain.RateLimit{Burst: burst, Every: time.Minute / time.Duration(perMinute)}
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+)
+
+func TaskBatchRoutes(env *config.Env, repos *persistence.Repositories, protectedGroup *gin.RouterGroup, adminGroup *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	taskBatchHandler := handler.TaskBatchHandler{
+		TaskUsecase: usecase.NewTaskUseCase(repos.Task, repos.TaskHistory, repos.Workflow, events),
+		UserUsecase: usecase.NewUserUseCase(repos.User, events),
+	}
+	protectedGroup.POST("/users/:username/tasks/batch", taskBatchHandler.BatchUserTasks)
+	adminGroup.POST("/tasks/batch", taskBatchHandler.BatchTasks)
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+)
+
+func TaskEventRoutes(env *config.Env, repos *persistence.Repositories, protectedGroup *gin.RouterGroup, adminGroup *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	taskEventHandler := handler.TaskEventHandler{
+		TaskEventUsecase: usecase.NewTaskEventUseCase(repos.Events),
+		UserUsecase:      usecase.NewUserUseCase(repos.User, events),
+	}
+	protectedGroup.GET("/users/:username/tasks/events", taskEventHandler.StreamUserTaskEvents)
+	adminGroup.GET("/tasks/events", taskEventHandler.StreamTaskEvents)
+}
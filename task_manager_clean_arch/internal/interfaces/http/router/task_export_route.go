@@ -0,0 +1,20 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+)
+
+func TaskExportRoutes(env *config.Env, repos *persistence.Repositories, protectedGroup *gin.RouterGroup, adminGroup *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	taskExportHandler := handler.TaskExportHandler{
+		TaskUsecase:     usecase.NewTaskUseCase(repos.Task, repos.TaskHistory, repos.Workflow, events),
+		WorkflowUsecase: usecase.NewWorkflowUseCase(repos.Workflow),
+		UserUsecase:     usecase.NewUserUseCase(repos.User, events),
+	}
+	protectedGroup.GET("/users/:username/tasks/export", taskExportHandler.ExportUserTasks)
+	adminGroup.GET("/tasks/export", taskExportHandler.ExportTasks)
+}
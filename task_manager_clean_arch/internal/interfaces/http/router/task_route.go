@@ -6,20 +6,23 @@ import (
 	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
 	"github.com/yiheyistm/task_manager/internal/usecase"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func TaskRoutes(env *config.Env, db mongo.Database, group *gin.RouterGroup) {
-	tr := persistence.NewTaskRepository(db, env.DBTaskCollection)
-	ur := persistence.NewUserRepository(db, env.DBUserCollection)
+func TaskRoutes(env *config.Env, repos *persistence.Repositories, group *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	tr := repos.Task
+	ur := repos.User
 	taskHandler := handler.TaskHandler{
-		TaskUsecase: usecase.NewTaskUseCase(tr),
-		UserUsecase: usecase.NewUserUseCase(ur),
+		TaskUsecase: usecase.NewTaskUseCase(tr, repos.TaskHistory, repos.Workflow, events),
+		UserUsecase: usecase.NewUserUseCase(ur, events),
 	}
 	group.GET("/tasks", taskHandler.GetTasks)
 	group.GET("/tasks/stats", taskHandler.GetTaskCountByStatus)
+	group.GET("/tasks/search", taskHandler.SearchTasks)
 	group.GET("/tasks/:id", taskHandler.GetTask)
+	group.GET("/tasks/:id/history", taskHandler.GetTaskHistory)
 	group.POST("/tasks", taskHandler.CreateTask)
 	group.PUT("/tasks/:id", taskHandler.UpdateTask)
+	group.PATCH("/tasks/:id", taskHandler.PatchTask)
 	group.DELETE("/tasks/:id", taskHandler.DeleteTask)
 }
@@ -7,12 +7,12 @@ import (
 	"github.com/yiheyistm/task_manager/internal/infrastructure/security"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
 	"github.com/yiheyistm/task_manager/internal/usecase"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func UserRoutes(env *config.Env, db mongo.Database, protectedGroup *gin.RouterGroup, adminGroup *gin.RouterGroup) {
-	ur := persistence.NewUserRepository(db, env.DBUserCollection)
-	tr := persistence.NewTaskRepository(db, env.DBTaskCollection)
+func UserRoutes(env *config.Env, repos *persistence.Repositories, protectedGroup *gin.RouterGroup, adminGroup *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	ur := repos.User
+	tr := repos.Task
 	refreshTokenRepo := security.NewJWTService(
 		env.AccessTokenSecret,
 		env.RefreshTokenSecret,
@@ -20,16 +20,31 @@ func UserRoutes(env *config.Env, db mongo.Database, protectedGroup *gin.RouterGr
 		env.RefreshTokenExpiryHour,
 	)
 	userHandler := handler.UserHandler{
-		RefreshTokenUsecase: usecase.NewRefreshTokenUsecase(ur, refreshTokenRepo),
-		TaskUsecase:         usecase.NewTaskUseCase(tr),
-		UserUsecase:         usecase.NewUserUseCase(ur),
+		RefreshTokenUsecase: usecase.NewRefreshTokenUsecase(ur, refreshTokenRepo, repos.RefreshTokens, repos.TokenDenylist),
+		TaskUsecase:         usecase.NewTaskUseCase(tr, repos.TaskHistory, repos.Workflow, events),
+		UserUsecase:         usecase.NewUserUseCase(ur, events),
 	}
+	protectedGroup.POST("/users/logout", userHandler.Logout)
+	protectedGroup.POST("/users/logout-all", userHandler.LogoutAll)
 	adminGroup.GET("/users", userHandler.GetAllUsers)
 	adminGroup.GET("/users/:username", userHandler.GetUser)
 	protectedGroup.GET("/users/:username/tasks", userHandler.GetUserTasks)
 	protectedGroup.GET("/users/:username/tasks/:id", userHandler.GetUserTask)
+	protectedGroup.GET("/users/:username/tasks/:id/history", userHandler.GetUserTaskHistory)
 	protectedGroup.POST("/users/:username/tasks", userHandler.CreateUserTask)
 	protectedGroup.PUT("/users/:username/tasks/:id", userHandler.UpdateUserTask)
+	protectedGroup.PATCH("/users/:username/tasks/:id", userHandler.PatchUserTask)
 	protectedGroup.DELETE("/users/:username/tasks/:id", userHandler.DeleteUserTask)
+	protectedGroup.GET("/users/:username/tasks/:id/subtasks", userHandler.GetUserSubtasks)
+	protectedGroup.POST("/users/:username/tasks/:id/subtasks", userHandler.CreateUserSubtask)
 	protectedGroup.GET("/users/:username/tasks/stats", userHandler.GetUserTaskStats)
+	protectedGroup.GET("/users/:username/tasks/search", userHandler.SearchUserTasks)
+	protectedGroup.GET("/users/:username/tasks/trash", userHandler.GetUserTrash)
+	protectedGroup.POST("/users/:username/tasks/:id/restore", userHandler.RestoreUserTask)
+	protectedGroup.GET("/users/:username/tasks/assigned", userHandler.GetAssignedTasks)
+	protectedGroup.POST("/users/:username/tasks/:id/assignees", userHandler.AssignUserTask)
+	protectedGroup.DELETE("/users/:username/tasks/:id/assignees/:assignee", userHandler.UnassignUserTask)
+	protectedGroup.GET("/users/:username/tasks/next", userHandler.GetNextUserTasks)
+	protectedGroup.POST("/users/:username/tasks/:id/blockers", userHandler.AddUserTaskBlocker)
+	protectedGroup.DELETE("/users/:username/tasks/:id/blockers/:blockerId", userHandler.RemoveUserTaskBlocker)
 }
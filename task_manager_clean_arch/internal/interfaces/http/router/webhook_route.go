@@ -0,0 +1,27 @@
+package router
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/webhook"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+)
+
+func WebhookRoutes(env *config.Env, repos *persistence.Repositories, adminGroup *gin.RouterGroup) {
+	events := usecase.NewEventPublisher(repos.Webhooks, repos.WebhookDeliveries, repos.Events)
+	webhookHandler := handler.WebhookHandler{
+		WebhookUsecase: usecase.NewWebhookUseCase(repos.Webhooks, repos.WebhookDeliveries, webhook.NewHTTPSender(10*time.Second)),
+		UserUsecase:    usecase.NewUserUseCase(repos.User, events),
+	}
+	adminGroup.GET("/webhooks", webhookHandler.GetWebhooks)
+	adminGroup.POST("/webhooks", webhookHandler.CreateWebhook)
+	adminGroup.GET("/webhooks/:id", webhookHandler.GetWebhook)
+	adminGroup.PUT("/webhooks/:id", webhookHandler.UpdateWebhook)
+	adminGroup.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+	adminGroup.GET("/webhooks/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+	adminGroup.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverWebhook)
+}
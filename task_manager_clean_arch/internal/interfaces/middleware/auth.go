@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
 	"time"
 
@@ -9,22 +8,21 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/yiheyistm/task_manager/internal/domain"
 )
 
-func AuthMiddleware(secret string) gin.HandlerFunc {
+func AuthMiddleware(secret string, refreshTokenUsecase domain.IRefreshTokenUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort()
+			abortWithError(c, domain.ErrUnauthorized, "Authorization header is required")
 			return
 		}
 
 		// Remove "Bearer " prefix
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Bearer token is required on Authorization header"})
-			c.Abort()
+			abortWithError(c, domain.ErrUnauthorized, "Bearer token is required on Authorization header")
 			return
 		}
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -34,24 +32,38 @@ func AuthMiddleware(secret string) gin.HandlerFunc {
 			return []byte(secret), nil
 		})
 		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
+			abortWithError(c, domain.ErrUnauthorized, "Invalid token claims")
 			return
 		}
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
+			abortWithError(c, domain.ErrUnauthorized, "Invalid token claims")
 			return
 		}
-		if exp, ok := claims["exp"].(float64); ok {
+		exp, ok := claims["exp"].(float64)
+		if ok {
 			if time.Unix(int64(exp), 0).Before(time.Now()) {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token expired"})
+				abortWithError(c, domain.ErrUnauthorized, "Token expired")
 				return
 			}
 		}
+		jti, _ := claims["jti"].(string)
+		username, _ := claims["username"].(string)
+		version, _ := claims["ver"].(float64)
+		if jti == "" {
+			abortWithError(c, domain.ErrUnauthorized, "Invalid token claims")
+			return
+		}
+		revoked, err := refreshTokenUsecase.IsAccessTokenRevoked(jti, username, int(version))
+		if err != nil || revoked {
+			abortWithError(c, domain.ErrUnauthorized, "Token has been revoked")
+			return
+		}
 		c.Set("username", claims["username"])
 		c.Set("role", claims["role"])
+		c.Set("jti", jti)
+		c.Set("fam", claims["fam"])
+		c.Set("exp", time.Unix(int64(exp), 0))
 		c.Next()
 	}
 }
@@ -60,8 +72,7 @@ func AdminOnlyMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role := c.GetString("role")
 		if role != "admin" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
-			c.Abort()
+			abortWithError(c, domain.ErrForbidden, "Admin access required")
 			return
 		}
 		c.Next()
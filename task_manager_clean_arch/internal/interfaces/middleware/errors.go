@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/patch"
+)
+
+// ErrorHandler writes the last error a handler recorded with c.Error as an
+// application/problem+json response. Errors of the domain kinds keep their
+// message as the detail; anything else is a 500 whose detail is the string
+// set as the error's meta, if any.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		ginErr := c.Errors.Last()
+		status := StatusFor(ginErr.Err)
+		detail, _ := ginErr.Meta.(string)
+		var fields []domain.FieldError
+		var domainErr *domain.Error
+		if errors.As(ginErr.Err, &domainErr) {
+			detail = domainErr.Message
+			fields = domainErr.Fields
+		} else if status != http.StatusInternalServerError {
+			detail = ginErr.Err.Error()
+		}
+		problem := dto.FromDomainErrorToProblem(status, detail, fields, c.Request.URL.Path)
+		// gin keeps a Content-Type that is already set.
+		c.Header("Content-Type", dto.ProblemContentType)
+		c.JSON(status, problem)
+	}
+}
+
+// StatusFor maps an error to the HTTP status it should be reported with.
+func StatusFor(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, patch.ErrUnsupportedPatchType), errors.Is(err, domain.ErrUnsupportedType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, domain.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrTooManyRequests):
+		return http.StatusTooManyRequests
+	case errors.Is(err, domain.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// abortWithError stops the request and leaves the response to ErrorHandler.
+func abortWithError(c *gin.Context, kind error, message string) {
+	c.Error(domain.NewError(kind, message))
+	c.Abort()
+}
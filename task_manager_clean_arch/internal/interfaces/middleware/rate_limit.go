@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// RateLimitMiddleware throttles the requests of a route group with a token
+// bucket per client: the user set by AuthMiddleware, or the client IP on
+// routes without authentication. The scope keeps the buckets of different
+// groups apart. When several limiters run on a route, the RateLimit headers
+// show the bucket with the fewest tokens left, or the one that refused the
+// request. A store that fails lets the request through, so that an
+// outage of the store does not take the API down with it.
+func RateLimitMiddleware(store domain.RateLimitStore, scope string, limit domain.RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limit.Enabled() {
+			c.Next()
+			return
+		}
+		key := scope + ":ip:" + c.ClientIP()
+		if username := c.GetString("username"); username != "" {
+			key = scope + ":user:" + username
+		}
+		result, err := store.Take(c.Request.Context(), key, limit, time.Now())
+		if err != nil {
+			log.Println("Failed to check the rate limit:", err)
+			c.Next()
+			return
+		}
+		if !result.Allowed || tighterThanReported(c, result.Remaining) {
+			c.Header("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			c.Header("RateLimit-Reset", seconds(result.Reset))
+		}
+		if !result.Allowed {
+			retryAfter := seconds(max(result.RetryAfter, time.Second))
+			c.Header("Retry-After", retryAfter)
+			abortWithError(c, domain.ErrTooManyRequests, "Too many requests, retry after "+retryAfter+" seconds")
+			return
+		}
+		c.Next()
+	}
+}
+
+// tighterThanReported tells whether a bucket with the remaining tokens is
+// tighter than the one an earlier limiter of the route reported, if any.
+// Routes can run several limiters, and the headers should show the one the
+// client runs into first.
+func tighterThanReported(c *gin.Context, remaining int) bool {
+	reported, err := strconv.Atoi(c.Writer.Header().Get("RateLimit-Remaining"))
+	return err != nil || remaining < reported
+}
+
+// seconds rounds a duration up to whole seconds, as the rate limit headers
+// expect.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// attachmentUploadTimeout bounds storing the content of an attachment,
+	// which takes longer than the other operations.
+	attachmentUploadTimeout = time.Minute
+	// maxFilenameLength is the longest attachment filename, in characters.
+	maxFilenameLength = 255
+	// sniffLength is how much of the content is used to detect its type.
+	sniffLength = 512
+)
+
+var (
+	errAttachmentNotFound = domain.NewError(domain.ErrNotFound, "attachment not found")
+	errEmptyFilename      = domain.NewError(domain.ErrValidation, "file name cannot be empty")
+	errFilenameTooLong    = domain.NewError(domain.ErrValidation, "file name is too long")
+	errEmptyAttachment    = domain.NewError(domain.ErrValidation, "file cannot be empty")
+	errCannotDeleteFile   = domain.NewError(domain.ErrForbidden, "only the uploader or the task creator can delete an attachment")
+)
+
+type AttachmentUseCase struct {
+	attachmentRepo domain.AttachmentRepository
+	taskRepo       domain.TaskRepository
+	blobs          domain.BlobStore
+	maxSize        int64
+	allowedTypes   []string
+}
+
+// NewAttachmentUseCase accepts files of up to maxSize bytes whose detected
+// media type is one of allowedTypes.
+func NewAttachmentUseCase(attachmentRepo domain.AttachmentRepository, taskRepo domain.TaskRepository, blobs domain.BlobStore, maxSize int64, allowedTypes []string) domain.IAttachmentUseCase {
+	return &AttachmentUseCase{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		blobs:          blobs,
+		maxSize:        maxSize,
+		allowedTypes:   allowedTypes,
+	}
+}
+
+// attachment loads an attachment of the task.
+func (uc *AttachmentUseCase) attachment(ctx context.Context, task domain.Task, id string) (domain.Attachment, error) {
+	attachment, err := uc.attachmentRepo.GetById(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if attachment.TaskID != task.ID {
+		return domain.Attachment{}, errAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (uc *AttachmentUseCase) GetByTaskAndUser(taskID, username string) ([]domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if _, err := visibleTask(ctx, uc.taskRepo, taskID, username); err != nil {
+		return nil, err
+	}
+	return uc.attachmentRepo.GetByTask(ctx, taskID)
+}
+
+// CreateByTaskAndUser detects the media type from the content rather than
+// trusting the one given by the client.
+func (uc *AttachmentUseCase) CreateByTaskAndUser(taskID string, attachment *domain.Attachment, content io.Reader, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), attachmentUploadTimeout)
+	defer cancel()
+	if attachment == nil || content == nil {
+		return domain.NewError(domain.ErrValidation, "attachment cannot be nil")
+	}
+	task, err := visibleTask(ctx, uc.taskRepo, taskID, username)
+	if err != nil {
+		return err
+	}
+	filename, err := cleanFilename(attachment.Filename)
+	if err != nil {
+		return err
+	}
+	if attachment.Size > uc.maxSize {
+		return uc.errTooLarge()
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	head = head[:n]
+	if n == 0 {
+		return errEmptyAttachment
+	}
+	contentType := http.DetectContentType(head)
+	if mediaType, _, _ := mime.ParseMediaType(contentType); !slices.Contains(uc.allowedTypes, mediaType) {
+		return domain.NewError(domain.ErrUnsupportedType, fmt.Sprintf("files of type %s cannot be attached", mediaType))
+	}
+
+	id := primitive.NewObjectID()
+	// One byte more than allowed is read to tell a file of exactly the
+	// maximum size from a larger one.
+	limited := io.LimitReader(io.MultiReader(bytes.NewReader(head), content), uc.maxSize+1)
+	size, err := uc.blobs.Put(ctx, id.Hex(), limited)
+	if err != nil {
+		uc.deleteBlob(ctx, id.Hex())
+		return err
+	}
+	if size > uc.maxSize {
+		uc.deleteBlob(ctx, id.Hex())
+		return uc.errTooLarge()
+	}
+
+	attachment.ID = id
+	attachment.TaskID = task.ID
+	attachment.Filename = filename
+	attachment.ContentType = contentType
+	attachment.Size = size
+	attachment.UploadedBy = username
+	attachment.CreatedAt = time.Now()
+	if err := uc.attachmentRepo.Create(ctx, attachment); err != nil {
+		uc.deleteBlob(ctx, id.Hex())
+		return err
+	}
+	return nil
+}
+
+func (uc *AttachmentUseCase) OpenByIdAndUser(taskID, id, username string) (domain.Attachment, io.ReadCloser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	task, err := visibleTask(ctx, uc.taskRepo, taskID, username)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	attachment, err := uc.attachment(ctx, task, id)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	content, err := uc.blobs.Open(ctx, attachment.ID.Hex())
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (uc *AttachmentUseCase) DeleteByIdAndUser(taskID, id, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	task, err := visibleTask(ctx, uc.taskRepo, taskID, username)
+	if err != nil {
+		return err
+	}
+	attachment, err := uc.attachment(ctx, task, id)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy != username && task.CreatedBy != username {
+		return errCannotDeleteFile
+	}
+	return uc.delete(ctx, attachment)
+}
+
+// PurgeOrphans deletes the attachments of the tasks that have been purged
+// from the trash. Attachments of trashed tasks are kept, so that restoring a
+// task restores its attachments.
+func (uc *AttachmentUseCase) PurgeOrphans() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	taskIDs, err := uc.attachmentRepo.GetTaskIds(ctx)
+	if err != nil || len(taskIDs) == 0 {
+		return 0, err
+	}
+	existing, err := uc.taskRepo.GetExistingIds(ctx, taskIDs)
+	if err != nil {
+		return 0, err
+	}
+	var purged int64
+	for _, taskID := range taskIDs {
+		if slices.Contains(existing, taskID) {
+			continue
+		}
+		attachments, err := uc.attachmentRepo.GetByTask(ctx, taskID.Hex())
+		if err != nil {
+			return purged, err
+		}
+		for _, attachment := range attachments {
+			if err := uc.delete(ctx, attachment); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// delete removes the attachment before its content, so that an attachment is
+// never listed without content.
+func (uc *AttachmentUseCase) delete(ctx context.Context, attachment domain.Attachment) error {
+	if err := uc.attachmentRepo.Delete(ctx, attachment.ID.Hex()); err != nil {
+		return err
+	}
+	uc.deleteBlob(ctx, attachment.ID.Hex())
+	return nil
+}
+
+// deleteBlob removes content that is no longer referenced. A failure only
+// leaves an unused blob behind, so it is logged rather than returned.
+func (uc *AttachmentUseCase) deleteBlob(ctx context.Context, key string) {
+	if err := uc.blobs.Delete(ctx, key); err != nil {
+		log.Println("Failed to delete attachment content:", err)
+	}
+}
+
+func (uc *AttachmentUseCase) errTooLarge() error {
+	return domain.NewError(domain.ErrTooLarge, fmt.Sprintf("file is larger than %d bytes", uc.maxSize))
+}
+
+// cleanFilename keeps the last element of a path given as the filename and
+// drops control characters.
+func cleanFilename(filename string) (string, error) {
+	filename = path.Base(strings.ReplaceAll(filename, `\`, "/"))
+	filename = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename))
+	if filename == "" || filename == "." || filename == "/" || filename == ".." {
+		return "", errEmptyFilename
+	}
+	if utf8.RuneCountInString(filename) > maxFilenameLength {
+		return "", errFilenameTooLong
+	}
+	return filename, nil
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	errCommentNotFound  = domain.NewError(domain.ErrNotFound, "comment not found")
+	errEmptyComment     = domain.NewError(domain.ErrValidation, "comment body cannot be empty")
+	errCommentTooLong   = domain.NewError(domain.ErrValidation, "comment body is too long")
+	errNotCommentAuthor = domain.NewError(domain.ErrForbidden, "only the author can edit a comment")
+	errCannotDelete     = domain.NewError(domain.ErrForbidden, "only the author or the task creator can delete a comment")
+)
+
+type CommentUseCase struct {
+	commentRepo domain.CommentRepository
+	taskRepo    domain.TaskRepository
+	historyRepo domain.TaskHistoryRepository
+	userRepo    domain.UserRepository
+}
+
+func NewCommentUseCase(commentRepo domain.CommentRepository, taskRepo domain.TaskRepository, historyRepo domain.TaskHistoryRepository, userRepo domain.UserRepository) domain.ICommentUseCase {
+	return &CommentUseCase{
+		commentRepo: commentRepo,
+		taskRepo:    taskRepo,
+		historyRepo: historyRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// comment loads a comment made on the task.
+func (uc *CommentUseCase) comment(ctx context.Context, task domain.Task, id string) (domain.Comment, error) {
+	comment, err := uc.commentRepo.GetById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if comment.TaskID != task.ID {
+		return domain.Comment{}, errCommentNotFound
+	}
+	return comment, nil
+}
+
+// prepareBody sanitizes a comment body and finds the users it mentions.
+func (uc *CommentUseCase) prepareBody(ctx context.Context, body string) (string, []string, error) {
+	body = strings.TrimSpace(domain.SanitizeMarkdown(body))
+	if body == "" {
+		return "", nil, errEmptyComment
+	}
+	if utf8.RuneCountInString(body) > domain.MaxCommentLength {
+		return "", nil, errCommentTooLong
+	}
+	mentions := []string{}
+	for _, username := range domain.MentionedUsernames(body) {
+		_, err := uc.userRepo.GetByUsername(ctx, username)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		mentions = append(mentions, username)
+	}
+	return body, mentions, nil
+}
+
+func (uc *CommentUseCase) GetByTaskAndUser(taskID, username string) ([]domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if _, err := visibleTask(ctx, uc.taskRepo, taskID, username); err != nil {
+		return nil, err
+	}
+	return uc.commentRepo.GetByTask(ctx, taskID)
+}
+
+func (uc *CommentUseCase) CreateByTaskAndUser(taskID string, comment *domain.Comment, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if comment == nil {
+		return domain.NewError(domain.ErrValidation, "comment cannot be nil")
+	}
+	task, err := visibleTask(ctx, uc.taskRepo, taskID, username)
+	if err != nil {
+		return err
+	}
+	body, mentions, err := uc.prepareBody(ctx, comment.Body)
+	if err != nil {
+		return err
+	}
+	comment.ID = primitive.NilObjectID
+	comment.TaskID = task.ID
+	comment.Author = username
+	comment.Body = body
+	comment.Mentions = mentions
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = time.Time{}
+	return uc.commentRepo.Create(ctx, comment)
+}
+
+func (uc *CommentUseCase) UpdateByIdAndUser(taskID, id, body, username string) (domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	task, err := visibleTask(ctx, uc.taskRepo, taskID, username)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	comment, err := uc.comment(ctx, task, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if comment.Author != username {
+		return domain.Comment{}, errNotCommentAuthor
+	}
+	comment.Body, comment.Mentions, err = uc.prepareBody(ctx, body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	comment.UpdatedAt = time.Now()
+	if err := uc.commentRepo.Update(ctx, &comment); err != nil {
+		return domain.Comment{}, err
+	}
+	return comment, nil
+}
+
+func (uc *CommentUseCase) DeleteByIdAndUser(taskID, id, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	task, err := visibleTask(ctx, uc.taskRepo, taskID, username)
+	if err != nil {
+		return err
+	}
+	comment, err := uc.comment(ctx, task, id)
+	if err != nil {
+		return err
+	}
+	if comment.Author != username && task.CreatedBy != username {
+		return errCannotDelete
+	}
+	return uc.commentRepo.Delete(ctx, id)
+}
+
+// GetActivityByTaskAndUser merges the comments on a task with the changes to
+// its status. Creating a task counts as a status change from "".
+func (uc *CommentUseCase) GetActivityByTaskAndUser(taskID, username string) ([]domain.Activity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if _, err := visibleTask(ctx, uc.taskRepo, taskID, username); err != nil {
+		return nil, err
+	}
+	comments, err := uc.commentRepo.GetByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := uc.historyRepo.GetByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := []domain.Activity{}
+	for i := range comments {
+		activity = append(activity, domain.Activity{
+			Kind:    domain.ActivityComment,
+			At:      comments[i].CreatedAt,
+			Actor:   comments[i].Author,
+			Comment: &comments[i],
+		})
+	}
+	for _, entry := range history {
+		for _, change := range entry.Changes {
+			if change.Field != "status" {
+				continue
+			}
+			activity = append(activity, domain.Activity{
+				Kind:  domain.ActivityStatusChange,
+				At:    entry.At,
+				Actor: entry.Actor,
+				From:  change.From,
+				To:    change.To,
+			})
+		}
+	}
+	slices.SortStableFunc(activity, func(a, b domain.Activity) int { return b.At.Compare(a.At) })
+	return activity, nil
+}
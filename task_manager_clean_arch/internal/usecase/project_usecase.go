@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// ProjectUseCase checks the user's role in a project before acting on it.
+// Project tasks are written through the task use case, so that their changes
+// are recorded in the task history like any other.
+type ProjectUseCase struct {
+	projectRepo domain.ProjectRepository
+	taskRepo    domain.TaskRepository
+	userRepo    domain.UserRepository
+	taskUsecase domain.ITaskUseCase
+}
+
+func NewProjectUseCase(projectRepo domain.ProjectRepository, taskRepo domain.TaskRepository, userRepo domain.UserRepository, taskUsecase domain.ITaskUseCase) domain.IProjectUseCase {
+	return &ProjectUseCase{
+		projectRepo: projectRepo,
+		taskRepo:    taskRepo,
+		userRepo:    userRepo,
+		taskUsecase: taskUsecase,
+	}
+}
+
+var (
+	errProjectNotVisible  = domain.NewError(domain.ErrNotFound, "project not found")
+	errProjectOwnerOnly   = domain.NewError(domain.ErrForbidden, "only project owners can do this")
+	errProjectEditorOnly  = domain.NewError(domain.ErrForbidden, "viewers cannot change project tasks")
+	errTaskNotInProject   = domain.NewError(domain.ErrNotFound, "task not found in project")
+	errLastProjectOwner   = domain.NewError(domain.ErrConflict, "a project must keep at least one owner")
+	errProjectHasTasks    = domain.NewError(domain.ErrConflict, "project still has tasks")
+	errEmptyProjectFields = domain.NewError(domain.ErrValidation, "project ID and username cannot be empty")
+)
+
+// Create stores a new project with its creator as the only owner.
+func (uc *ProjectUseCase) Create(project *domain.Project) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if project == nil {
+		return domain.NewError(domain.ErrValidation, "project cannot be nil")
+	}
+	if strings.TrimSpace(project.Name) == "" || project.CreatedBy == "" {
+		return domain.NewError(domain.ErrValidation, "project name and creator cannot be empty")
+	}
+	project.CreatedAt = time.Now()
+	project.Members = []domain.ProjectMember{{Username: project.CreatedBy, Role: domain.ProjectOwner}}
+	return uc.projectRepo.Create(ctx, project)
+}
+
+// authorize loads a project the user is a member of. Other users get the
+// same error as for a missing project.
+func (uc *ProjectUseCase) authorize(ctx context.Context, id, username string) (domain.Project, error) {
+	if id == "" || username == "" {
+		return domain.Project{}, errEmptyProjectFields
+	}
+	project, err := uc.projectRepo.GetById(ctx, id)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if project.RoleOf(username) == "" {
+		return domain.Project{}, errProjectNotVisible
+	}
+	return project, nil
+}
+
+func (uc *ProjectUseCase) GetByIdAndUser(id, username string) (domain.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return uc.authorize(ctx, id, username)
+}
+
+func (uc *ProjectUseCase) GetByUser(username string) ([]domain.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if username == "" {
+		return nil, domain.NewError(domain.ErrValidation, "username cannot be empty")
+	}
+	return uc.projectRepo.GetByMember(ctx, username)
+}
+
+// UpdateByIdAndUser changes the name and description of a project the user
+// owns.
+func (uc *ProjectUseCase) UpdateByIdAndUser(id string, project *domain.Project, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if project == nil {
+		return domain.NewError(domain.ErrValidation, "project cannot be nil")
+	}
+	if strings.TrimSpace(project.Name) == "" {
+		return domain.NewError(domain.ErrValidation, "project name cannot be empty")
+	}
+	stored, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return err
+	}
+	if stored.RoleOf(username) != domain.ProjectOwner {
+		return errProjectOwnerOnly
+	}
+	if err := uc.projectRepo.Update(ctx, id, project); err != nil {
+		return err
+	}
+	project.ID = stored.ID
+	project.CreatedBy = stored.CreatedBy
+	project.CreatedAt = stored.CreatedAt
+	project.Members = stored.Members
+	return nil
+}
+
+// DeleteByIdAndUser deletes a project the user owns. Projects that still
+// have tasks cannot be deleted, counting the tasks in the trash, which would
+// otherwise be restored into a missing project.
+func (uc *ProjectUseCase) DeleteByIdAndUser(id, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	project, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return err
+	}
+	if project.RoleOf(username) != domain.ProjectOwner {
+		return errProjectOwnerOnly
+	}
+	count, err := uc.taskRepo.CountByProject(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errProjectHasTasks
+	}
+	return uc.projectRepo.Delete(ctx, id)
+}
+
+// SetMember adds a member to a project the user owns, or changes the role of
+// an existing member. Only registered users can be members.
+func (uc *ProjectUseCase) SetMember(id string, member domain.ProjectMember, username string) (domain.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if member.Username == "" {
+		return domain.Project{}, domain.NewError(domain.ErrValidation, "member username cannot be empty")
+	}
+	if !domain.IsProjectRole(member.Role) {
+		return domain.Project{}, domain.NewError(domain.ErrValidation, "role must be one of owner, editor or viewer")
+	}
+	project, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if project.RoleOf(username) != domain.ProjectOwner {
+		return domain.Project{}, errProjectOwnerOnly
+	}
+	if project.RoleOf(member.Username) == domain.ProjectOwner && member.Role != domain.ProjectOwner && project.Owners() == 1 {
+		return domain.Project{}, errLastProjectOwner
+	}
+	if _, err := uc.userRepo.GetByUsername(ctx, member.Username); err != nil {
+		return domain.Project{}, err
+	}
+	return uc.projectRepo.SetMember(ctx, id, member)
+}
+
+// RemoveMember removes a member from the project. Owners can remove anyone
+// and other members can remove themselves.
+func (uc *ProjectUseCase) RemoveMember(id, member, username string) (domain.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	project, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if member != username && project.RoleOf(username) != domain.ProjectOwner {
+		return domain.Project{}, errProjectOwnerOnly
+	}
+	role := project.RoleOf(member)
+	if role == "" {
+		return domain.Project{}, domain.NewError(domain.ErrNotFound, "user is not a member of the project")
+	}
+	if role == domain.ProjectOwner && project.Owners() == 1 {
+		return domain.Project{}, errLastProjectOwner
+	}
+	return uc.projectRepo.RemoveMember(ctx, id, member)
+}
+
+// ListTasks lists the tasks of a project the user is a member of.
+func (uc *ProjectUseCase) ListTasks(id string, query domain.TaskQuery, username string) (domain.TaskPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	project, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	query.ProjectID = project.ID
+	return uc.taskUsecase.ListTasks(query)
+}
+
+func (uc *ProjectUseCase) GetTask(id, taskID, username string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	project, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return uc.projectTask(project, taskID)
+}
+
+// CreateTask adds a task created by the user to the project.
+func (uc *ProjectUseCase) CreateTask(id string, task *domain.Task, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if task == nil {
+		return domain.NewError(domain.ErrValidation, "task cannot be nil")
+	}
+	project, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return err
+	}
+	if !project.CanEditTasks(username) {
+		return errProjectEditorOnly
+	}
+	task.ProjectID = project.ID
+	task.CreatedBy = username
+	return uc.taskUsecase.Create(task)
+}
+
+func (uc *ProjectUseCase) PatchTask(id, taskID string, update domain.TaskUpdate, username string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	project, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !project.CanEditTasks(username) {
+		return domain.Task{}, errProjectEditorOnly
+	}
+	if _, err := uc.projectTask(project, taskID); err != nil {
+		return domain.Task{}, err
+	}
+	return uc.taskUsecase.Patch(taskID, update, username)
+}
+
+// DeleteTask moves a project task to its creator's trash.
+func (uc *ProjectUseCase) DeleteTask(id, taskID string, version int64, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	project, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return err
+	}
+	if !project.CanEditTasks(username) {
+		return errProjectEditorOnly
+	}
+	if _, err := uc.projectTask(project, taskID); err != nil {
+		return err
+	}
+	return uc.taskUsecase.Delete(taskID, version, username)
+}
+
+// projectTask loads a task that belongs to the project.
+func (uc *ProjectUseCase) projectTask(project domain.Project, taskID string) (domain.Task, error) {
+	task, err := uc.taskUsecase.GetById(taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if task.ProjectID != project.ID {
+		return domain.Task{}, errTaskNotInProject
+	}
+	return task, nil
+}
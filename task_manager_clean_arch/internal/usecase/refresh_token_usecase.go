@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -9,14 +10,18 @@ import (
 )
 
 type refreshTokenUsecase struct {
-	userRepository   domain.UserRepository
-	refreshTokenRepo domain.RefreshTokenRepository
+	userRepository    domain.UserRepository
+	refreshTokenRepo  domain.RefreshTokenRepository
+	refreshTokenStore domain.RefreshTokenStore
+	tokenDenylist     domain.TokenDenylist
 }
 
-func NewRefreshTokenUsecase(userRepository domain.UserRepository, refreshTokenRepo domain.RefreshTokenRepository) domain.IRefreshTokenUsecase {
+func NewRefreshTokenUsecase(userRepository domain.UserRepository, refreshTokenRepo domain.RefreshTokenRepository, refreshTokenStore domain.RefreshTokenStore, tokenDenylist domain.TokenDenylist) domain.IRefreshTokenUsecase {
 	return &refreshTokenUsecase{
-		userRepository:   userRepository,
-		refreshTokenRepo: refreshTokenRepo,
+		userRepository:    userRepository,
+		refreshTokenRepo:  refreshTokenRepo,
+		refreshTokenStore: refreshTokenStore,
+		tokenDenylist:     tokenDenylist,
 	}
 }
 
@@ -26,8 +31,138 @@ func (rtu *refreshTokenUsecase) GetByUsername(username string) (*domain.User, er
 	return rtu.userRepository.GetByUsername(ctx, username)
 }
 
+// GenerateTokens issues a token pair for a fresh login and records its
+// refresh token as the first member of a new family.
 func (rtu *refreshTokenUsecase) GenerateTokens(user domain.User) (domain.RefreshToken, error) {
-	return rtu.refreshTokenRepo.GenerateTokens(user)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	tokens, err := rtu.refreshTokenRepo.GenerateTokens(user)
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if err := rtu.saveSession(ctx, tokens.RefreshToken); err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return tokens, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token
+// can be used once; presenting a used token again revokes its whole family.
+func (rtu *refreshTokenUsecase) Refresh(refreshToken string) (domain.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	claims, err := rtu.refreshTokenRepo.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	session, err := rtu.refreshTokenStore.GetByID(ctx, jti)
+	if err != nil {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	if session.Revoked {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	marked, err := rtu.refreshTokenStore.MarkUsed(ctx, session.ID)
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if !marked {
+		if err := rtu.refreshTokenStore.RevokeFamily(ctx, session.FamilyID); err != nil {
+			return domain.RefreshToken{}, err
+		}
+		return domain.RefreshToken{}, domain.ErrRefreshTokenReused
+	}
+
+	user, err := rtu.userRepository.GetByUsername(ctx, session.Username)
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	// Tokens issued before a "log out everywhere" carry an older version.
+	if version, _ := claims["ver"].(float64); int(version) != user.TokenVersion {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	tokens, err := rtu.refreshTokenRepo.RotateTokens(*user, session.FamilyID)
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if err := rtu.saveSession(ctx, tokens.RefreshToken); err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return tokens, nil
+}
+
+// Logout revokes the access token with the given id until it expires, along
+// with every refresh token issued from the same login.
+func (rtu *refreshTokenUsecase) Logout(jti string, familyID string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if jti == "" {
+		return domain.NewError(domain.ErrValidation, "token ID cannot be empty")
+	}
+	if err := rtu.tokenDenylist.Add(ctx, jti, expiresAt); err != nil {
+		return err
+	}
+	if familyID == "" {
+		return nil
+	}
+	return rtu.refreshTokenStore.RevokeFamily(ctx, familyID)
+}
+
+// LogoutAll invalidates every access and refresh token issued to the user so far.
+func (rtu *refreshTokenUsecase) LogoutAll(username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if username == "" {
+		return domain.NewError(domain.ErrValidation, "username cannot be empty")
+	}
+	return rtu.userRepository.IncrementTokenVersion(ctx, username)
+}
+
+// IsAccessTokenRevoked reports whether an access token was logged out or
+// issued before the user's last "log out everywhere".
+func (rtu *refreshTokenUsecase) IsAccessTokenRevoked(jti string, username string, version int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	denied, err := rtu.tokenDenylist.Contains(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+	if denied {
+		return true, nil
+	}
+	user, err := rtu.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return false, err
+	}
+	return user.TokenVersion != version, nil
+}
+
+// saveSession persists the server-side record of a freshly issued refresh token.
+func (rtu *refreshTokenUsecase) saveSession(ctx context.Context, refreshToken string) error {
+	claims, err := rtu.refreshTokenRepo.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+	jti, _ := claims["jti"].(string)
+	familyID, _ := claims["fam"].(string)
+	username, _ := claims["username"].(string)
+	if jti == "" || familyID == "" {
+		return errors.New("refresh token is missing jti or family")
+	}
+	session := &domain.RefreshSession{
+		ID:        jti,
+		FamilyID:  familyID,
+		Username:  username,
+		CreatedAt: time.Now(),
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		session.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+	return rtu.refreshTokenStore.Save(ctx, session)
 }
 
 func (rtu *refreshTokenUsecase) ValidateRefreshToken(token string) (jwt.MapClaims, error) {
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// reminderCatchUp is how far back the first run after a start looks for
+// tasks that became overdue while the server was down.
+const reminderCatchUp = 24 * time.Hour
+
+type ReminderUseCase struct {
+	taskRepo     domain.TaskRepository
+	reminderRepo domain.ReminderRepository
+	workflowRepo domain.WorkflowRepository
+	userRepo     domain.UserRepository
+	notifier     domain.Notifier
+	window       time.Duration
+	// mu serializes runs, and lastRun is when the last one that read the
+	// tasks started.
+	mu      sync.Mutex
+	lastRun time.Time
+}
+
+// NewReminderUseCase sends reminders for the tasks due within the window and
+// for the tasks that are overdue. A window of zero only sends overdue
+// reminders.
+func NewReminderUseCase(taskRepo domain.TaskRepository, reminderRepo domain.ReminderRepository, workflowRepo domain.WorkflowRepository, userRepo domain.UserRepository, notifier domain.Notifier, window time.Duration) domain.IReminderUseCase {
+	return &ReminderUseCase{
+		taskRepo:     taskRepo,
+		reminderRepo: reminderRepo,
+		workflowRepo: workflowRepo,
+		userRepo:     userRepo,
+		notifier:     notifier,
+		window:       window,
+	}
+}
+
+// SendReminders claims each reminder before sending it, so a reminder is
+// sent at most once even with several instances running. A reminder that
+// fails to be claimed or sent is logged and not tried again.
+//
+// Only the tasks due since the last run are read, as the ones due before
+// already got their overdue reminder. That keeps each run bounded by the
+// window rather than by every overdue task ever left unfinished.
+func (uc *ReminderUseCase) SendReminders() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	workflow, err := currentWorkflow(ctx, uc.workflowRepo)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	since := uc.lastRun
+	if since.IsZero() {
+		since = now.Add(-max(uc.window, reminderCatchUp))
+	}
+	tasks, err := uc.taskRepo.GetDueBetween(ctx, since, now.Add(uc.window), workflow.Final())
+	if err != nil {
+		return 0, err
+	}
+	uc.lastRun = now
+	sent := 0
+	for _, task := range tasks {
+		kind := domain.ReminderDueSoon
+		if task.IsOverdue(now, workflow.Final()) {
+			kind = domain.ReminderOverdue
+			task.Overdue = true
+		}
+		claimed, err := uc.reminderRepo.Claim(ctx, task.ID, kind, task.DueDate)
+		if err != nil {
+			log.Printf("Failed to claim the %s reminder of task %s: %v", kind, task.ID.Hex(), err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		reminder := domain.Reminder{Kind: kind, Task: task, Recipients: uc.recipients(ctx, task)}
+		if err := uc.notifier.Notify(ctx, reminder); err != nil {
+			log.Printf("Failed to send the %s reminder of task %s: %v", kind, task.ID.Hex(), err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// recipients are the creator and the assignees of the task. A user that
+// cannot be looked up is still reminded, without an email address.
+func (uc *ReminderUseCase) recipients(ctx context.Context, task domain.Task) []domain.ReminderRecipient {
+	usernames := append([]string{task.CreatedBy}, task.Assignees...)
+	recipients := make([]domain.ReminderRecipient, 0, len(usernames))
+	for _, username := range usernames {
+		recipient := domain.ReminderRecipient{Username: username}
+		if user, err := uc.userRepo.GetByUsername(ctx, username); err == nil && user != nil {
+			recipient.Email = user.Email
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+const (
+	// maxBatchOperations is how many operations a batch can have.
+	maxBatchOperations = 100
+	// batchTimeout bounds a batch, which reads and checks every task before
+	// writing them.
+	batchTimeout = time.Minute
+)
+
+var (
+	errEmptyBatch         = domain.NewError(domain.ErrValidation, "a batch needs at least one operation")
+	errBatchTooLarge      = domain.NewError(domain.ErrValidation, fmt.Sprintf("a batch cannot have more than %d operations", maxBatchOperations))
+	errUnknownOperation   = domain.NewError(domain.ErrValidation, "operation must be one of update, status or delete")
+	errEmptyOperation     = domain.NewError(domain.ErrValidation, "an update has to change at least one field")
+	errNotStatusChange    = domain.NewError(domain.ErrValidation, "a status change can only set the status")
+	errDuplicateOperation = domain.NewError(domain.ErrValidation, "a task can only appear once in a batch")
+)
+
+// batchStep is an operation that passed its checks, with the write that
+// applies it and the next occurrence it leads to.
+type batchStep struct {
+	index  int
+	before domain.Task
+	next   *domain.Task
+	write  domain.TaskWrite
+}
+
+func (uc *TaskUseCase) Batch(ops []domain.TaskOperation, atomic bool, actor string) ([]domain.TaskOperationResult, error) {
+	return uc.batch(ops, atomic, actor, false)
+}
+
+func (uc *TaskUseCase) BatchByUser(ops []domain.TaskOperation, atomic bool, username string) ([]domain.TaskOperationResult, error) {
+	if username == "" {
+		return nil, domain.NewError(domain.ErrValidation, "username cannot be empty")
+	}
+	return uc.batch(ops, atomic, username, true)
+}
+
+// batch checks every operation like the single task endpoints do, then makes
+// the writes of the ones that pass in one bulk write. The operations are all
+// checked against the tasks as they were before the batch, so completing a
+// task and its last pending subtask takes two batches. Every write replaces
+// the version that was checked: a task changed in the meantime is reported
+// as a conflict rather than overwritten.
+func (uc *TaskUseCase) batch(ops []domain.TaskOperation, atomic bool, actor string, byUser bool) ([]domain.TaskOperationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+	if len(ops) == 0 {
+		return nil, errEmptyBatch
+	}
+	if len(ops) > maxBatchOperations {
+		return nil, errBatchTooLarge
+	}
+
+	results := make([]domain.TaskOperationResult, len(ops))
+	var steps []batchStep
+	seen := make(map[string]bool, len(ops))
+	for i, op := range ops {
+		if seen[op.ID] {
+			results[i].Err = errDuplicateOperation
+			continue
+		}
+		seen[op.ID] = true
+		step, err := uc.planOperation(ctx, op, actor, byUser)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		step.index = i
+		steps = append(steps, step)
+	}
+	if atomic && len(steps) < len(ops) {
+		for _, step := range steps {
+			results[step.index].Err = domain.ErrBatchAborted
+		}
+		return results, nil
+	}
+	if len(steps) == 0 {
+		return results, nil
+	}
+
+	writes := make([]domain.TaskWrite, len(steps))
+	for j, step := range steps {
+		writes[j] = step.write
+	}
+	errs, err := uc.taskRepo.BulkWrite(ctx, writes, atomic)
+	if err != nil {
+		return nil, err
+	}
+	var updated []domain.Task
+	var updatedIndexes []int
+	for j, step := range steps {
+		if errs[j] != nil {
+			results[step.index].Err = errs[j]
+			continue
+		}
+		if step.write.Delete {
+			after := trashed(step.before)
+			uc.record(ctx, domain.TaskDeleted, actor, step.before, after)
+			results[step.index].Task = after
+			continue
+		}
+		after := step.write.Update.Apply(step.before)
+		after.Version++
+		uc.record(ctx, domain.TaskUpdated, actor, step.before, after)
+		uc.createOccurrence(ctx, step.next, actor)
+		updated = append(updated, after)
+		updatedIndexes = append(updatedIndexes, step.index)
+	}
+	uc.annotate(ctx, updated)
+	for j, i := range updatedIndexes {
+		results[i].Task = updated[j]
+	}
+	return results, nil
+}
+
+// planOperation checks an operation and prepares the write that applies it.
+// Users may only change the status of the tasks they are assigned to, and
+// only delete the tasks they created.
+func (uc *TaskUseCase) planOperation(ctx context.Context, op domain.TaskOperation, actor string, byUser bool) (batchStep, error) {
+	if op.ID == "" {
+		return batchStep{}, domain.NewError(domain.ErrValidation, "task ID cannot be empty")
+	}
+	update := op.Update
+	switch op.Kind {
+	case domain.TaskOpUpdate:
+		if err := prepareUpdate(&update); err != nil {
+			return batchStep{}, err
+		}
+		if update.IsEmpty() {
+			return batchStep{}, errEmptyOperation
+		}
+	case domain.TaskOpStatus:
+		if update.Status == nil || !update.OnlyStatus() {
+			return batchStep{}, errNotStatusChange
+		}
+	case domain.TaskOpDelete:
+	default:
+		return batchStep{}, errUnknownOperation
+	}
+
+	var before domain.Task
+	var err error
+	if byUser {
+		before, err = uc.authorize(ctx, op.ID, actor)
+	} else {
+		before, err = uc.taskRepo.GetById(ctx, op.ID)
+	}
+	if err != nil {
+		return batchStep{}, err
+	}
+	if update.Version != 0 && update.Version != before.Version {
+		return batchStep{}, domain.ErrVersionConflict
+	}
+
+	step := batchStep{before: before}
+	if op.Kind == domain.TaskOpDelete {
+		if byUser && before.CreatedBy != actor {
+			return batchStep{}, domain.NewError(domain.ErrForbidden, "only the task creator or an admin can delete a task")
+		}
+		if err := uc.checkNoSubtasks(ctx, before); err != nil {
+			return batchStep{}, err
+		}
+		step.write = domain.TaskWrite{ID: before.ID, Update: domain.TaskUpdate{Version: before.Version}, Delete: true}
+		return step, nil
+	}
+	if byUser && before.CreatedBy != actor && !update.OnlyStatus() {
+		return batchStep{}, domain.NewError(domain.ErrForbidden, "assignees can only change the status of a task")
+	}
+	if update.Status != nil {
+		if err := uc.checkStatus(ctx, before, update.Status); err != nil {
+			return batchStep{}, err
+		}
+	}
+	if step.next, err = uc.recurPatch(ctx, before, &update); err != nil {
+		return batchStep{}, err
+	}
+	update.Version = before.Version
+	step.write = domain.TaskWrite{ID: before.ID, Update: update}
+	return step, nil
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	errBlockerNotByCreator = domain.NewError(domain.ErrForbidden, "only the task creator can change its blockers")
+	errSelfBlocker         = domain.NewError(domain.ErrValidation, "a task cannot block itself")
+	errDependencyCycle     = domain.NewError(domain.ErrConflict, "the blocker depends on the task, adding it would create a cycle")
+)
+
+// AddBlockerByIdAndUser makes a task the user created wait for another task
+// the user can see. Adding a blocker that is already there changes nothing.
+func (uc *TaskUseCase) AddBlockerByIdAndUser(id, blockerID, username string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" || blockerID == "" || username == "" {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID, blocker ID and username cannot be empty")
+	}
+	before, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if before.CreatedBy != username {
+		return domain.Task{}, errBlockerNotByCreator
+	}
+	if blockerID == id {
+		return domain.Task{}, errSelfBlocker
+	}
+	blocker, err := uc.authorize(ctx, blockerID, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if before.IsBlockedBy(blocker.ID) {
+		uc.annotateTask(ctx, &before)
+		return before, nil
+	}
+	cycle, err := uc.dependsOn(ctx, blocker, before.ID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if cycle {
+		return domain.Task{}, errDependencyCycle
+	}
+	after, err := uc.taskRepo.AddBlocker(ctx, id, blocker.ID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	uc.record(ctx, domain.TaskUpdated, username, before, after)
+	uc.annotateTask(ctx, &after)
+	return after, nil
+}
+
+// RemoveBlockerByIdAndUser stops a task the user created from waiting for
+// another task.
+func (uc *TaskUseCase) RemoveBlockerByIdAndUser(id, blockerID, username string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" || blockerID == "" || username == "" {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID, blocker ID and username cannot be empty")
+	}
+	before, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if before.CreatedBy != username {
+		return domain.Task{}, errBlockerNotByCreator
+	}
+	objectID, err := primitive.ObjectIDFromHex(blockerID)
+	if err != nil || !before.IsBlockedBy(objectID) {
+		return domain.Task{}, domain.NewError(domain.ErrNotFound, "task is not blocked by that task")
+	}
+	after, err := uc.taskRepo.RemoveBlocker(ctx, id, objectID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	uc.record(ctx, domain.TaskUpdated, username, before, after)
+	uc.annotateTask(ctx, &after)
+	return after, nil
+}
+
+// dependsOn reports whether the task waits for the target, directly or
+// through its blockers. Blockers that no longer exist are skipped.
+func (uc *TaskUseCase) dependsOn(ctx context.Context, task domain.Task, target primitive.ObjectID) (bool, error) {
+	visited := map[primitive.ObjectID]bool{task.ID: true}
+	queue := slices.Clone(task.BlockedBy)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == target {
+			return true, nil
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		blocker, err := uc.taskRepo.GetById(ctx, id.Hex())
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		queue = append(queue, blocker.BlockedBy...)
+	}
+	return false, nil
+}
+
+// GetNextTasksByUser lists the user's unfinished tasks in an order they can
+// be worked through: every task comes after the tasks blocking it, and among
+// the tasks that are ready at the same time the one due first comes first.
+func (uc *TaskUseCase) GetNextTasksByUser(username string) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if username == "" {
+		return nil, domain.NewError(domain.ErrValidation, "username cannot be empty")
+	}
+	tasks, err := uc.taskRepo.GetByUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := currentWorkflow(ctx, uc.workflowRepo)
+	if err != nil {
+		return nil, err
+	}
+	unfinished := slices.DeleteFunc(tasks, func(task domain.Task) bool { return task.Status == workflow.Final() })
+	uc.annotate(ctx, unfinished)
+	return orderByDependencies(unfinished), nil
+}
+
+// orderByDependencies sorts the tasks topologically with Kahn's algorithm,
+// only counting the blockers that are among the tasks. Tasks caught in a
+// cycle, which AddBlockerByIdAndUser prevents but concurrent writes could
+// still create, come last.
+func orderByDependencies(tasks []domain.Task) []domain.Task {
+	byID := make(map[primitive.ObjectID]domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	waiting := make(map[primitive.ObjectID]int)
+	unblocks := make(map[primitive.ObjectID][]primitive.ObjectID)
+	var ready []domain.Task
+	for _, task := range tasks {
+		for _, blocker := range task.BlockedBy {
+			if _, ok := byID[blocker]; ok {
+				waiting[task.ID]++
+				unblocks[blocker] = append(unblocks[blocker], task.ID)
+			}
+		}
+		if waiting[task.ID] == 0 {
+			ready = append(ready, task)
+		}
+	}
+
+	ordered := make([]domain.Task, 0, len(tasks))
+	for len(ready) > 0 {
+		slices.SortFunc(ready, compareDueFirst)
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, next)
+		for _, id := range unblocks[next.ID] {
+			waiting[id]--
+			if waiting[id] == 0 {
+				ready = append(ready, byID[id])
+			}
+		}
+	}
+	if len(ordered) < len(tasks) {
+		var rest []domain.Task
+		for _, task := range tasks {
+			if waiting[task.ID] > 0 {
+				rest = append(rest, task)
+			}
+		}
+		slices.SortFunc(rest, compareDueFirst)
+		ordered = append(ordered, rest...)
+	}
+	return ordered
+}
+
+func compareDueFirst(a, b domain.Task) int {
+	return cmp.Or(a.DueDate.Compare(b.DueDate), bytes.Compare(a.ID[:], b.ID[:]))
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+type TaskEventUseCase struct {
+	bus domain.EventBus
+}
+
+func NewTaskEventUseCase(bus domain.EventBus) domain.ITaskEventUseCase {
+	return &TaskEventUseCase{bus: bus}
+}
+
+// Watch subscribes to the task events the user can see. Whether a user can
+// see an event is decided by the task as it is after the change, so a user
+// who is unassigned from a task does not get that update.
+func (uc *TaskEventUseCase) Watch(ctx context.Context, username string, after uint64) (<-chan domain.StreamEvent, bool) {
+	return uc.bus.Subscribe(ctx, after, func(event domain.Event) bool {
+		if event.Task == nil {
+			return false
+		}
+		return username == "" || event.Task.CanView(username)
+	})
+}
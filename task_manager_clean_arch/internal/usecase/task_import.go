@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+const (
+	// importBatchSize is how many tasks ImportByUser inserts at a time.
+	importBatchSize = 500
+	// importTimeout bounds an import, which may create thousands of tasks.
+	importTimeout = 10 * time.Minute
+)
+
+// ImportByUser runs the checks of Create on every task, then inserts the ones
+// that pass in batches. When a batch fails, the tasks that were not inserted
+// yet are reported with its error and the import stops; the tasks created
+// before stay.
+func (uc *TaskUseCase) ImportByUser(username string, tasks []*domain.Task, dryRun bool) ([]error, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+	if username == "" {
+		return nil, domain.NewError(domain.ErrValidation, "username cannot be empty")
+	}
+	workflow, err := currentWorkflow(ctx, uc.workflowRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(tasks))
+	var valid []int
+	for i, task := range tasks {
+		if task == nil {
+			errs[i] = domain.NewError(domain.ErrValidation, "task cannot be nil")
+			continue
+		}
+		task.CreatedBy = username
+		// The version tells the inserted tasks from the others.
+		task.Version = 0
+		if errs[i] = prepareTask(task); errs[i] != nil {
+			continue
+		}
+		if errs[i] = checkNewStatus(task, workflow); errs[i] != nil {
+			continue
+		}
+		valid = append(valid, i)
+	}
+	if dryRun {
+		return errs, nil
+	}
+
+	now := time.Now()
+	for start := 0; start < len(valid); start += importBatchSize {
+		indexes := valid[start:min(start+importBatchSize, len(valid))]
+		batch := make([]*domain.Task, len(indexes))
+		for j, i := range indexes {
+			batch[j] = tasks[i]
+		}
+		err := uc.taskRepo.CreateMany(ctx, batch)
+		for _, task := range batch {
+			if task.Version == 0 {
+				break
+			}
+			uc.record(ctx, domain.TaskCreated, username, domain.Task{}, *task)
+			task.Overdue = task.IsOverdue(now, workflow.Final())
+		}
+		if err != nil {
+			for _, i := range valid[start:] {
+				if tasks[i].Version == 0 {
+					errs[i] = err
+				}
+			}
+			break
+		}
+	}
+	return errs, nil
+}
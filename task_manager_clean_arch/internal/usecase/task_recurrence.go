@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	errRecurringSubtask = domain.NewError(domain.ErrValidation, "subtasks cannot recur")
+	errInvalidScope     = domain.NewError(domain.ErrValidation, "scope must be this or future")
+	errScopeChangesRule = domain.NewError(domain.ErrValidation, "the rrule can only be changed for all future occurrences")
+)
+
+// prepareRecurrence checks the rule of a task about to be stored and writes
+// it in canonical form. A task given a rule starts a new series; carryRecurrence
+// keeps the series of a task that already recurs.
+func prepareRecurrence(task *domain.Task) error {
+	if task.Recurrence == nil || task.Recurrence.Rule == "" {
+		task.Recurrence = nil
+		return nil
+	}
+	if !task.ParentID.IsZero() {
+		return errRecurringSubtask
+	}
+	rule, err := domain.ParseRRule(task.Recurrence.Rule)
+	if err != nil {
+		return err
+	}
+	task.Recurrence = &domain.Recurrence{Rule: rule.String(), Occurrence: 1}
+	return nil
+}
+
+// ruleOf is the rule of a recurrence, empty when there is none.
+func ruleOf(recurrence *domain.Recurrence) string {
+	if recurrence == nil {
+		return ""
+	}
+	return recurrence.Rule
+}
+
+// carryRecurrence keeps the series of a recurring task across a change. An
+// edit of this occurrence only saves the fields the next occurrence is to be
+// created with, so that the edit is not passed on; an edit of future
+// occurrences is passed on, also to fields saved by an earlier edit of this
+// occurrence.
+func carryRecurrence(before domain.Task, after *domain.Task, scope string) error {
+	if scope == domain.ScopeThisOccurrence && ruleOf(after.Recurrence) != ruleOf(before.Recurrence) {
+		return errScopeChangesRule
+	}
+	if after.Recurrence == nil {
+		return nil
+	}
+	if !before.ParentID.IsZero() {
+		return errRecurringSubtask
+	}
+	if before.Recurrence == nil {
+		return nil
+	}
+	recurrence := *before.Recurrence
+	recurrence.Rule = after.Recurrence.Rule
+	switch {
+	case scope == domain.ScopeThisOccurrence && recurrence.Template == nil:
+		template := domain.TemplateOf(before)
+		recurrence.Template = &template
+	case scope != domain.ScopeThisOccurrence && recurrence.Template != nil:
+		template := recurrence.Template.Carry(before, *after)
+		recurrence.Template = &template
+	}
+	after.Recurrence = &recurrence
+	return nil
+}
+
+// recur carries the recurrence of a task over to its changed version and,
+// when the change finishes the task, prepares its next occurrence. The next
+// occurrence is linked from the changed task, which still has to be written,
+// and created by createOccurrence once it has been.
+func (uc *TaskUseCase) recur(ctx context.Context, before domain.Task, after *domain.Task, scope string) (*domain.Task, error) {
+	if err := carryRecurrence(before, after, scope); err != nil {
+		return nil, err
+	}
+	if after.Recurrence == nil || !after.Recurrence.NextID.IsZero() || after.Status == before.Status {
+		return nil, nil
+	}
+	workflow, err := currentWorkflow(ctx, uc.workflowRepo)
+	if err != nil {
+		return nil, err
+	}
+	if after.Status != workflow.Final() {
+		return nil, nil
+	}
+	rule, err := domain.ParseRRule(after.Recurrence.Rule)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Count > 0 && after.Recurrence.Occurrence >= rule.Count {
+		return nil, nil
+	}
+	template := domain.TemplateOf(*after)
+	dueDate, ok := rule.Next(template.DueDate)
+	if !ok {
+		return nil, nil
+	}
+	checklist := slices.Clone(template.Checklist)
+	for i := range checklist {
+		checklist[i].Done = false
+	}
+	next := &domain.Task{
+		ID:          primitive.NewObjectID(),
+		Title:       template.Title,
+		CreatedBy:   after.CreatedBy,
+		Description: template.Description,
+		DueDate:     dueDate,
+		Status:      workflow.Initial(),
+		Priority:    template.Priority,
+		Tags:        template.Tags,
+		Assignees:   after.Assignees,
+		ProjectID:   after.ProjectID,
+		Checklist:   checklist,
+		Recurrence: &domain.Recurrence{
+			Rule:       after.Recurrence.Rule,
+			SeriesID:   before.SeriesID(),
+			Occurrence: after.Recurrence.Occurrence + 1,
+		},
+	}
+	after.Recurrence.NextID = next.ID
+	return next, nil
+}
+
+// recurPatch is recur for a partial update, which always applies to future
+// occurrences. The recurrence of the changed task is added to the update.
+func (uc *TaskUseCase) recurPatch(ctx context.Context, before domain.Task, update *domain.TaskUpdate) (*domain.Task, error) {
+	after := update.Apply(before)
+	next, err := uc.recur(ctx, before, &after, domain.ScopeFutureOccurrences)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case after.Recurrence != nil:
+		update.Recurrence = after.Recurrence
+	case before.Recurrence != nil:
+		update.Recurrence = &domain.Recurrence{}
+	}
+	return next, nil
+}
+
+// createOccurrence creates the next occurrence prepared by recur. The task
+// it follows has already been written, so a failure is logged rather than
+// returned.
+func (uc *TaskUseCase) createOccurrence(ctx context.Context, next *domain.Task, actor string) {
+	if next == nil {
+		return
+	}
+	if err := uc.taskRepo.Create(ctx, next); err != nil {
+		log.Println("Failed to create the next occurrence:", err)
+		return
+	}
+	uc.record(ctx, domain.TaskCreated, actor, domain.Task{}, *next)
+}
@@ -3,17 +3,33 @@ package usecase
 import (
 	"context"
 	"errors"
+	"html"
+	"log"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// exportBatchSize is how many tasks ExportAll reads at a time.
+	exportBatchSize = 500
+	// exportTimeout bounds an export, which streams every task to a client
+	// that may be slow to read them.
+	exportTimeout = 10 * time.Minute
 )
 
 type TaskUseCase struct {
-	taskRepo domain.TaskRepository
+	taskRepo     domain.TaskRepository
+	historyRepo  domain.TaskHistoryRepository
+	workflowRepo domain.WorkflowRepository
+	events       domain.EventPublisher
 }
 
-func NewTaskUseCase(taskRepo domain.TaskRepository) domain.ITaskUseCase {
-	return &TaskUseCase{taskRepo: taskRepo}
+func NewTaskUseCase(taskRepo domain.TaskRepository, historyRepo domain.TaskHistoryRepository, workflowRepo domain.WorkflowRepository, events domain.EventPublisher) domain.ITaskUseCase {
+	return &TaskUseCase{taskRepo: taskRepo, historyRepo: historyRepo, workflowRepo: workflowRepo, events: events}
 }
 func (uc *TaskUseCase) GetAll() ([]domain.Task, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -22,9 +38,26 @@ func (uc *TaskUseCase) GetAll() ([]domain.Task, error) {
 	if err != nil {
 		return nil, err
 	}
+	uc.annotate(ctx, tasks)
 	return tasks, nil
 }
 
+// ExportAll annotates the tasks a batch at a time, so that an export of a
+// large collection does not keep all of it in memory.
+func (uc *TaskUseCase) ExportAll(fn func(domain.Task) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	return uc.taskRepo.GetAllInBatches(ctx, exportBatchSize, func(tasks []domain.Task) error {
+		uc.annotate(ctx, tasks)
+		for _, task := range tasks {
+			if err := fn(task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (uc *TaskUseCase) GetById(id string) (domain.Task, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
@@ -32,44 +65,86 @@ func (uc *TaskUseCase) GetById(id string) (domain.Task, error) {
 	if err != nil {
 		return domain.Task{}, err
 	}
+	uc.annotateTask(ctx, &task)
 	return task, nil
 }
 func (uc *TaskUseCase) Create(task *domain.Task) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if task == nil {
-		return errors.New("task cannot be nil")
+		return domain.NewError(domain.ErrValidation, "task cannot be nil")
 	}
-	err := uc.taskRepo.Create(ctx, task)
+	if err := prepareTask(task); err != nil {
+		return err
+	}
+	workflow, err := currentWorkflow(ctx, uc.workflowRepo)
 	if err != nil {
 		return err
 	}
+	if err := checkNewStatus(task, workflow); err != nil {
+		return err
+	}
+	err = uc.taskRepo.Create(ctx, task)
+	if err != nil {
+		return err
+	}
+	uc.record(ctx, domain.TaskCreated, task.CreatedBy, domain.Task{}, *task)
+	task.Overdue = task.IsOverdue(time.Now(), workflow.Final())
 	return nil
 }
 
-func (uc *TaskUseCase) Update(id string, task *domain.Task) error {
+// Update replaces the task on behalf of the actor.
+func (uc *TaskUseCase) Update(id string, task *domain.Task, actor string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if task == nil {
-		return errors.New("task cannot be nil")
+		return domain.NewError(domain.ErrValidation, "task cannot be nil")
+	}
+	if err := prepareTask(task); err != nil {
+		return err
+	}
+	before, err := uc.taskRepo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.checkStatus(ctx, before, &task.Status); err != nil {
+		return err
 	}
-	err := uc.taskRepo.Update(ctx, id, task)
+	next, err := uc.recur(ctx, before, task, domain.ScopeFutureOccurrences)
 	if err != nil {
 		return err
 	}
+	err = uc.taskRepo.Update(ctx, id, task)
+	if err != nil {
+		return err
+	}
+	uc.record(ctx, domain.TaskUpdated, actor, before, *task)
+	uc.createOccurrence(ctx, next, actor)
+	uc.annotateTask(ctx, task)
 	return nil
 }
 
-func (uc *TaskUseCase) Delete(id string) error {
+// Delete moves the task to the trash on behalf of the actor. A non-zero
+// version makes the delete fail with domain.ErrVersionConflict if the task
+// has changed since that version.
+func (uc *TaskUseCase) Delete(id string, version int64, actor string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if id == "" {
-		return errors.New("task ID cannot be empty")
+		return domain.NewError(domain.ErrValidation, "task ID cannot be empty")
 	}
-	err := uc.taskRepo.Delete(ctx, id)
+	before, err := uc.taskRepo.GetById(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := uc.checkNoSubtasks(ctx, before); err != nil {
+		return err
+	}
+	err = uc.taskRepo.Delete(ctx, id, version)
+	if err != nil {
+		return err
+	}
+	uc.record(ctx, domain.TaskDeleted, actor, before, trashed(before))
 	return nil
 }
 
@@ -77,32 +152,33 @@ func (uc *TaskUseCase) GetTasksByUser(username string) ([]domain.Task, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if username == "" {
-		return nil, errors.New("username cannot be empty")
+		return nil, domain.NewError(domain.ErrValidation, "username cannot be empty")
 	}
 	tasks, err := uc.taskRepo.GetByUser(ctx, username)
 	if err != nil {
 		return nil, err
 	}
+	uc.annotate(ctx, tasks)
 	return tasks, nil
 }
-func (uc *TaskUseCase) GetTaskStatsByUser(username string) ([]domain.StatusCount, error) {
+func (uc *TaskUseCase) GetTaskStatsByUser(username string) (domain.TaskStats, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if username == "" {
-		return nil, errors.New("username cannot be empty")
+		return domain.TaskStats{}, domain.NewError(domain.ErrValidation, "username cannot be empty")
 	}
 	stats, err := uc.taskRepo.GetTaskStatsByUser(ctx, username)
 	if err != nil {
-		return nil, err
+		return domain.TaskStats{}, err
 	}
 	return stats, nil
 }
-func (uc *TaskUseCase) GetTaskCountByStatus() ([]domain.StatusCount, error) {
+func (uc *TaskUseCase) GetTaskCountByStatus() (domain.TaskStats, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	stats, err := uc.taskRepo.GetTaskCountByStatus(ctx)
 	if err != nil {
-		return nil, err
+		return domain.TaskStats{}, err
 	}
 	return stats, nil
 }
@@ -111,38 +187,706 @@ func (uc *TaskUseCase) GetByIdAndUser(id, username string) (domain.Task, error)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if id == "" || username == "" {
-		return domain.Task{}, errors.New("task ID and username cannot be empty")
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
+	}
+	task, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	uc.annotateTask(ctx, &task)
+	return task, nil
+}
+
+var errTaskNotVisible = domain.NewError(domain.ErrNotFound, "task not found or not owned by user")
+
+// authorize loads a task for a user who created it or is assigned to it.
+func (uc *TaskUseCase) authorize(ctx context.Context, id, username string) (domain.Task, error) {
+	return visibleTask(ctx, uc.taskRepo, id, username)
+}
+
+// visibleTask loads a task for a user who created it or is assigned to it.
+// Other users get the same error as for a missing task, so that they cannot
+// tell which task IDs exist. Every use case that acts on a task on behalf of
+// a user goes through it, so that they all follow the same rules.
+func visibleTask(ctx context.Context, taskRepo domain.TaskRepository, id, username string) (domain.Task, error) {
+	if id == "" || username == "" {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
+	}
+	task, err := taskRepo.GetById(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && !task.CanView(username)) {
+		return domain.Task{}, errTaskNotVisible
 	}
-	task, err := uc.taskRepo.GetByIdAndUser(ctx, id, username)
 	if err != nil {
 		return domain.Task{}, err
 	}
 	return task, nil
 }
 func (uc *TaskUseCase) UpdateByIdAndUser(id string, task *domain.Task, username string) error {
+	return uc.UpdateOccurrenceByIdAndUser(id, task, domain.ScopeFutureOccurrences, username)
+}
+
+// UpdateOccurrenceByIdAndUser replaces a task the user created. For a
+// recurring task the scope says whether the edit also applies to the
+// occurrences created after it.
+func (uc *TaskUseCase) UpdateOccurrenceByIdAndUser(id string, task *domain.Task, scope, username string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if id == "" || username == "" {
-		return errors.New("task ID and username cannot be empty")
+		return domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
+	}
+	if scope != domain.ScopeThisOccurrence && scope != domain.ScopeFutureOccurrences {
+		return errInvalidScope
 	}
 	if task == nil {
-		return errors.New("task cannot be nil")
+		return domain.NewError(domain.ErrValidation, "task cannot be nil")
+	}
+	if err := prepareTask(task); err != nil {
+		return err
+	}
+	before, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return err
+	}
+	if before.CreatedBy != username {
+		return domain.NewError(domain.ErrForbidden, "assignees can only change the status of a task")
+	}
+	if err := uc.checkStatus(ctx, before, &task.Status); err != nil {
+		return err
+	}
+	next, err := uc.recur(ctx, before, task, scope)
+	if err != nil {
+		return err
+	}
+	err = uc.taskRepo.UpdateByIdAndUser(ctx, id, task, username)
+	if err != nil {
+		return err
+	}
+	uc.record(ctx, domain.TaskUpdated, username, before, *task)
+	uc.createOccurrence(ctx, next, username)
+	uc.annotateTask(ctx, task)
+	return nil
+}
+
+// Patch changes only the fields set in the update on behalf of the actor
+// and returns the resulting task. An empty update leaves the task untouched.
+func (uc *TaskUseCase) Patch(id string, update domain.TaskUpdate, actor string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID cannot be empty")
+	}
+	if err := prepareUpdate(&update); err != nil {
+		return domain.Task{}, err
+	}
+	before, err := uc.taskRepo.GetById(ctx, id)
+	if update.IsEmpty() {
+		return uc.unchanged(ctx, before, err, update.Version)
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if update.Status != nil {
+		if err := uc.checkStatus(ctx, before, update.Status); err != nil {
+			return domain.Task{}, err
+		}
+	}
+	next, err := uc.recurPatch(ctx, before, &update)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	after, err := uc.taskRepo.Patch(ctx, id, update)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	uc.record(ctx, domain.TaskUpdated, actor, before, after)
+	uc.createOccurrence(ctx, next, actor)
+	uc.annotateTask(ctx, &after)
+	return after, nil
+}
+
+// PatchByIdAndUser patches a task the user created or, for a status change
+// only, a task the user is assigned to.
+func (uc *TaskUseCase) PatchByIdAndUser(id string, update domain.TaskUpdate, username string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" || username == "" {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
+	}
+	if err := prepareUpdate(&update); err != nil {
+		return domain.Task{}, err
+	}
+	before, err := uc.authorize(ctx, id, username)
+	if update.IsEmpty() {
+		return uc.unchanged(ctx, before, err, update.Version)
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if before.CreatedBy != username && !update.OnlyStatus() {
+		return domain.Task{}, domain.NewError(domain.ErrForbidden, "assignees can only change the status of a task")
+	}
+	if update.Status != nil {
+		if err := uc.checkStatus(ctx, before, update.Status); err != nil {
+			return domain.Task{}, err
+		}
+	}
+	next, err := uc.recurPatch(ctx, before, &update)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	var after domain.Task
+	if before.CreatedBy == username {
+		after, err = uc.taskRepo.PatchByIdAndUser(ctx, id, update, username)
+	} else {
+		after, err = uc.taskRepo.Patch(ctx, id, update)
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+	uc.record(ctx, domain.TaskUpdated, username, before, after)
+	uc.createOccurrence(ctx, next, username)
+	uc.annotateTask(ctx, &after)
+	return after, nil
+}
+
+// unchanged returns the task read for an empty patch, reporting a conflict
+// when it no longer has the expected non-zero version.
+func (uc *TaskUseCase) unchanged(ctx context.Context, task domain.Task, err error, version int64) (domain.Task, error) {
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if version != 0 && task.Version != version {
+		return domain.Task{}, domain.ErrVersionConflict
+	}
+	uc.annotateTask(ctx, &task)
+	return task, nil
+}
+
+var (
+	errInvalidPriority     = domain.NewError(domain.ErrValidation, "priority must be one of low, medium, high or urgent")
+	errEmptyChecklistItem  = domain.NewError(domain.ErrValidation, "checklist items cannot be empty")
+	errPendingSubtasks     = domain.NewError(domain.ErrConflict, "a task cannot be completed while it has pending subtasks")
+	errTaskHasSubtasks     = domain.NewError(domain.ErrConflict, "a task with subtasks cannot be deleted, delete its subtasks first")
+	errSubtaskOfSubtask    = domain.NewError(domain.ErrValidation, "subtasks cannot have subtasks of their own")
+	errSubtaskNotByCreator = domain.NewError(domain.ErrForbidden, "only the task creator can add subtasks")
+)
+
+// prepareTask defaults the priority of a task about to be stored, checks it
+// and normalizes the tags, checklist and recurrence rule.
+func prepareTask(task *domain.Task) error {
+	if task.Priority == "" {
+		task.Priority = domain.PriorityMedium
 	}
-	err := uc.taskRepo.UpdateByIdAndUser(ctx, id, task, username)
+	if !domain.IsPriority(task.Priority) {
+		return errInvalidPriority
+	}
+	task.Tags = domain.NormalizeTags(task.Tags)
+	if err := trimChecklist(task.Checklist); err != nil {
+		return err
+	}
+	return prepareRecurrence(task)
+}
+
+// checkNewStatus starts a new task without a status in the first status of
+// the workflow, and checks that a task can start in the status it has.
+func checkNewStatus(task *domain.Task, workflow domain.Workflow) error {
+	if task.Status == "" {
+		task.Status = workflow.Initial()
+	}
+	return workflow.CheckMove("", task.Status)
+}
+
+// trimChecklist trims the text of the checklist items, none of which may be
+// left empty.
+func trimChecklist(items []domain.ChecklistItem) error {
+	for i := range items {
+		items[i].Text = strings.TrimSpace(items[i].Text)
+		if items[i].Text == "" {
+			return errEmptyChecklistItem
+		}
+	}
+	return nil
+}
+
+// prepareUpdate checks the priority of an update and normalizes its tags,
+// checklist and recurrence rule. An empty priority resets the task to the
+// default one.
+func prepareUpdate(update *domain.TaskUpdate) error {
+	if update.Priority != nil && *update.Priority == "" {
+		priority := domain.PriorityMedium
+		update.Priority = &priority
+	}
+	if update.Priority != nil && !domain.IsPriority(*update.Priority) {
+		return errInvalidPriority
+	}
+	if update.Tags != nil {
+		tags := domain.NormalizeTags(*update.Tags)
+		update.Tags = &tags
+	}
+	if update.Checklist != nil {
+		if err := trimChecklist(*update.Checklist); err != nil {
+			return err
+		}
+	}
+	if update.Recurrence != nil && update.Recurrence.Rule != "" {
+		rule, err := domain.ParseRRule(update.Recurrence.Rule)
+		if err != nil {
+			return err
+		}
+		update.Recurrence = &domain.Recurrence{Rule: rule.String(), Occurrence: 1}
+	}
+	return nil
+}
+
+// checkStatus checks that the workflow allows the task to move to the status
+// and that a task only reaches the final status once its subtasks have. An
+// empty status keeps the current one.
+func (uc *TaskUseCase) checkStatus(ctx context.Context, before domain.Task, status *string) error {
+	if *status == "" {
+		*status = before.Status
+	}
+	if *status == before.Status {
+		return nil
+	}
+	workflow, err := currentWorkflow(ctx, uc.workflowRepo)
+	if err != nil {
+		return err
+	}
+	if err := workflow.CheckMove(before.Status, *status); err != nil {
+		return err
+	}
+	if *status != workflow.Final() || !before.ParentID.IsZero() {
+		return nil
+	}
+	counts, err := uc.taskRepo.CountSubtasksByStatus(ctx, []primitive.ObjectID{before.ID})
 	if err != nil {
 		return err
 	}
+	for _, count := range counts[before.ID] {
+		if count.Status != workflow.Final() {
+			return errPendingSubtasks
+		}
+	}
+	return nil
+}
+
+// checkNoSubtasks keeps a task with subtasks out of the trash, so that its
+// subtasks are not left without a parent.
+func (uc *TaskUseCase) checkNoSubtasks(ctx context.Context, task domain.Task) error {
+	if !task.ParentID.IsZero() {
+		return nil
+	}
+	counts, err := uc.taskRepo.CountSubtasksByStatus(ctx, []primitive.ObjectID{task.ID})
+	if err != nil {
+		return err
+	}
+	if len(counts[task.ID]) > 0 {
+		return errTaskHasSubtasks
+	}
 	return nil
 }
-func (uc *TaskUseCase) DeleteByIdAndUser(id, username string) error {
+
+// annotateTask is annotate for a single task.
+func (uc *TaskUseCase) annotateTask(ctx context.Context, task *domain.Task) {
+	tasks := []domain.Task{*task}
+	uc.annotate(ctx, tasks)
+	task.Subtasks, task.Blocked, task.Overdue = tasks[0].Subtasks, tasks[0].Blocked, tasks[0].Overdue
+}
+
+// annotate fills in the fields of the tasks that are not stored: the subtask
+// counts and whether the tasks are blocked or overdue. They only add to what
+// is returned, so a failure to get them is logged rather than returned.
+func (uc *TaskUseCase) annotate(ctx context.Context, tasks []domain.Task) {
+	now := time.Now()
+	var parentIDs, blockerIDs []primitive.ObjectID
+	pastDue := false
+	for _, task := range tasks {
+		if task.ParentID.IsZero() {
+			parentIDs = append(parentIDs, task.ID)
+		}
+		blockerIDs = append(blockerIDs, task.BlockedBy...)
+		pastDue = pastDue || task.IsOverdue(now, "")
+	}
+	if len(parentIDs) == 0 && len(blockerIDs) == 0 && !pastDue {
+		return
+	}
+	counts, statuses, err := uc.lookUpRelated(ctx, parentIDs, blockerIDs)
+	if err != nil {
+		log.Println("Failed to annotate tasks:", err)
+		return
+	}
+	if len(counts) == 0 && len(statuses) == 0 && !pastDue {
+		return
+	}
+	workflow, err := currentWorkflow(ctx, uc.workflowRepo)
+	if err != nil {
+		log.Println("Failed to annotate tasks:", err)
+		return
+	}
+	for i := range tasks {
+		for _, count := range counts[tasks[i].ID] {
+			tasks[i].Subtasks.Total += count.Count
+			if count.Status == workflow.Final() {
+				tasks[i].Subtasks.Done += count.Count
+			}
+		}
+		tasks[i].Blocked = isBlocked(tasks[i], statuses, workflow)
+		tasks[i].Overdue = tasks[i].IsOverdue(now, workflow.Final())
+	}
+}
+
+// lookUpRelated counts the subtasks of the parents by status and gets the
+// status of the blockers, skipping the queries that have nothing to look up.
+func (uc *TaskUseCase) lookUpRelated(ctx context.Context, parentIDs, blockerIDs []primitive.ObjectID) (map[primitive.ObjectID][]domain.StatusCount, map[primitive.ObjectID]string, error) {
+	var counts map[primitive.ObjectID][]domain.StatusCount
+	var statuses map[primitive.ObjectID]string
+	var err error
+	if len(parentIDs) > 0 {
+		if counts, err = uc.taskRepo.CountSubtasksByStatus(ctx, parentIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(blockerIDs) > 0 {
+		if statuses, err = uc.taskRepo.GetStatuses(ctx, blockerIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+	return counts, statuses, nil
+}
+
+// isBlocked reports whether one of the task's blockers is known and not yet
+// in the final status of the workflow.
+func isBlocked(task domain.Task, statuses map[primitive.ObjectID]string, workflow domain.Workflow) bool {
+	for _, id := range task.BlockedBy {
+		if status, ok := statuses[id]; ok && status != workflow.Final() {
+			return true
+		}
+	}
+	return false
+}
+
+func (uc *TaskUseCase) DeleteByIdAndUser(id, username string, version int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if id == "" || username == "" {
-		return errors.New("task ID and username cannot be empty")
+		return domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
 	}
-	err := uc.taskRepo.DeleteByIdAndUser(ctx, id, username)
+	before, err := uc.authorize(ctx, id, username)
 	if err != nil {
 		return err
 	}
+	if before.CreatedBy != username {
+		return domain.NewError(domain.ErrForbidden, "only the task creator or an admin can delete a task")
+	}
+	if err := uc.checkNoSubtasks(ctx, before); err != nil {
+		return err
+	}
+	err = uc.taskRepo.DeleteByIdAndUser(ctx, id, username, version)
+	if err != nil {
+		return err
+	}
+	uc.record(ctx, domain.TaskDeleted, username, before, trashed(before))
 	return nil
 }
+
+// trashed is the task as it is once moved to the trash.
+func trashed(task domain.Task) domain.Task {
+	task.DeletedAt = time.Now()
+	task.Version++
+	return task
+}
+
+// record adds a change to the task's history and publishes it to the
+// webhooks. The change itself has already been stored, so a failure to
+// record it is logged rather than returned.
+func (uc *TaskUseCase) record(ctx context.Context, action, actor string, before, after domain.Task) {
+	history := &domain.TaskHistory{
+		TaskID:  after.ID,
+		Owner:   after.CreatedBy,
+		Action:  action,
+		Actor:   actor,
+		At:      time.Now(),
+		Version: after.Version,
+		Changes: domain.DiffTasks(before, after),
+	}
+	if err := uc.historyRepo.Add(ctx, history); err != nil {
+		log.Println("Failed to record task history:", err)
+	}
+	uc.events.Publish(ctx, domain.Event{Type: taskEventType(action), At: history.At, Actor: actor, Task: &after})
+}
+
+// taskEventType is the type of the event published for a change recorded in
+// the history. A restore counts as an update.
+func taskEventType(action string) string {
+	switch action {
+	case domain.TaskCreated:
+		return domain.EventTaskCreated
+	case domain.TaskDeleted:
+		return domain.EventTaskDeleted
+	}
+	return domain.EventTaskUpdated
+}
+
+// GetHistory returns the changes made to a task, most recent first.
+func (uc *TaskUseCase) GetHistory(id string) ([]domain.TaskHistory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" {
+		return nil, domain.NewError(domain.ErrValidation, "task ID cannot be empty")
+	}
+	history, err := uc.historyRepo.GetByTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		// Tell a task without recorded changes apart from a missing one.
+		if _, err := uc.taskRepo.GetById(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return history, nil
+}
+
+func (uc *TaskUseCase) GetHistoryByIdAndUser(id, username string) ([]domain.TaskHistory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" || username == "" {
+		return nil, domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
+	}
+	history, err := uc.historyRepo.GetByTaskAndUser(ctx, id, username)
+	if err != nil || len(history) > 0 {
+		return history, err
+	}
+	// Assignees see the whole history of a task shared with them.
+	if _, err := uc.authorize(ctx, id, username); err != nil {
+		return nil, err
+	}
+	return uc.historyRepo.GetByTask(ctx, id)
+}
+
+func (uc *TaskUseCase) GetTrashByUser(username string) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if username == "" {
+		return nil, domain.NewError(domain.ErrValidation, "username cannot be empty")
+	}
+	return uc.taskRepo.GetTrashByUser(ctx, username)
+}
+
+func (uc *TaskUseCase) RestoreByIdAndUser(id, username string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" || username == "" {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
+	}
+	task, err := uc.taskRepo.RestoreByIdAndUser(ctx, id, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	// The deletion time is gone by now, so the restore is recorded without
+	// field changes.
+	uc.record(ctx, domain.TaskRestored, username, task, task)
+	uc.annotateTask(ctx, &task)
+	return task, nil
+}
+
+// AssignByIdAndUser shares a task the user created with the assignee.
+// Assigning a user who is already assigned changes nothing.
+func (uc *TaskUseCase) AssignByIdAndUser(id, assignee, username string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" || assignee == "" || username == "" {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID, assignee and username cannot be empty")
+	}
+	before, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if before.CreatedBy != username {
+		return domain.Task{}, domain.NewError(domain.ErrForbidden, "only the task creator can assign users")
+	}
+	if assignee == before.CreatedBy {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "the task creator cannot be assigned to the task")
+	}
+	if before.IsAssignee(assignee) {
+		uc.annotateTask(ctx, &before)
+		return before, nil
+	}
+	after, err := uc.taskRepo.AddAssignee(ctx, id, assignee)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	uc.record(ctx, domain.TaskUpdated, username, before, after)
+	uc.annotateTask(ctx, &after)
+	return after, nil
+}
+
+// UnassignByIdAndUser stops sharing a task with the assignee. The creator can
+// unassign anyone and an assignee can unassign themselves.
+func (uc *TaskUseCase) UnassignByIdAndUser(id, assignee, username string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" || assignee == "" || username == "" {
+		return domain.Task{}, domain.NewError(domain.ErrValidation, "task ID, assignee and username cannot be empty")
+	}
+	before, err := uc.authorize(ctx, id, username)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if before.CreatedBy != username && assignee != username {
+		return domain.Task{}, domain.NewError(domain.ErrForbidden, "only the task creator can unassign other users")
+	}
+	if !before.IsAssignee(assignee) {
+		return domain.Task{}, domain.NewError(domain.ErrNotFound, "user is not assigned to the task")
+	}
+	after, err := uc.taskRepo.RemoveAssignee(ctx, id, assignee)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	uc.record(ctx, domain.TaskUpdated, username, before, after)
+	uc.annotateTask(ctx, &after)
+	return after, nil
+}
+
+// PurgeTrash permanently removes the tasks that have been in the trash for
+// longer than the retention and returns how many were removed.
+func (uc *TaskUseCase) PurgeTrash(retention time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if retention < 0 {
+		return 0, domain.NewError(domain.ErrValidation, "trash retention cannot be negative")
+	}
+	return uc.taskRepo.PurgeDeletedBefore(ctx, time.Now().Add(-retention))
+}
+
+// CreateSubtask adds a subtask to a task the user created. The subtask
+// belongs to the same project as its parent.
+func (uc *TaskUseCase) CreateSubtask(parentID string, task *domain.Task, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if parentID == "" || username == "" {
+		return domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
+	}
+	if task == nil {
+		return domain.NewError(domain.ErrValidation, "task cannot be nil")
+	}
+	parent, err := uc.authorize(ctx, parentID, username)
+	if err != nil {
+		return err
+	}
+	if parent.CreatedBy != username {
+		return errSubtaskNotByCreator
+	}
+	if !parent.ParentID.IsZero() {
+		return errSubtaskOfSubtask
+	}
+	task.ParentID = parent.ID
+	task.ProjectID = parent.ProjectID
+	task.CreatedBy = username
+	return uc.Create(task)
+}
+
+// GetSubtasksByIdAndUser lists the subtasks of a task the user created or is
+// assigned to, oldest first.
+func (uc *TaskUseCase) GetSubtasksByIdAndUser(id, username string) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if id == "" || username == "" {
+		return nil, domain.NewError(domain.ErrValidation, "task ID and username cannot be empty")
+	}
+	if _, err := uc.authorize(ctx, id, username); err != nil {
+		return nil, err
+	}
+	return uc.taskRepo.GetSubtasks(ctx, id)
+}
+
+// ListTasks returns one page of tasks matching the query, falling back to the
+// default page size and capping the limit at MaxTaskPageLimit.
+func (uc *TaskUseCase) ListTasks(query domain.TaskQuery) (domain.TaskPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	switch query.Sort {
+	case "", domain.SortDueDateAsc, domain.SortDueDateDesc, domain.SortTitleAsc, domain.SortTitleDesc:
+	default:
+		return domain.TaskPage{}, domain.NewError(domain.ErrValidation, "invalid sort order")
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 {
+		query.Limit = domain.DefaultTaskPageLimit
+	} else if query.Limit > domain.MaxTaskPageLimit {
+		query.Limit = domain.MaxTaskPageLimit
+	}
+	page, err := uc.taskRepo.Find(ctx, query)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	uc.annotate(ctx, page.Tasks)
+	return page, nil
+}
+
+// SearchTasks finds the tasks whose title or description contain the search
+// words, best matches first, and highlights the words in the matched fields.
+func (uc *TaskUseCase) SearchTasks(search domain.TaskSearch) ([]domain.TaskMatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	search.Text = strings.TrimSpace(search.Text)
+	terms := search.Terms()
+	if len(terms) == 0 {
+		return nil, domain.NewError(domain.ErrValidation, "search query cannot be empty")
+	}
+	if search.Limit < 1 {
+		search.Limit = domain.DefaultTaskPageLimit
+	} else if search.Limit > domain.MaxTaskPageLimit {
+		search.Limit = domain.MaxTaskPageLimit
+	}
+	matches, err := uc.taskRepo.Search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]domain.Task, len(matches))
+	for i := range matches {
+		tasks[i] = matches[i].Task
+	}
+	uc.annotate(ctx, tasks)
+	for i := range matches {
+		matches[i].Task = tasks[i]
+	}
+
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	// Words starting with a term are highlighted so that stemmed matches
+	// such as "meetings" for "meeting" are marked too.
+	pattern := regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}])((?:` + strings.Join(quoted, "|") + `)[\p{L}\p{N}]*)`)
+	for i := range matches {
+		matches[i].Highlights = make(map[string]string)
+		for field, text := range map[string]string{"title": matches[i].Task.Title, "description": matches[i].Task.Description} {
+			if pattern.MatchString(text) {
+				matches[i].Highlights[field] = highlight(pattern, text)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// highlight wraps the words matched by the second group of the pattern in
+// <em> tags. The text is escaped around the tags, since highlights are meant
+// to be rendered as HTML; escaping it before matching would let a term match
+// inside an entity such as &lt;.
+func highlight(pattern *regexp.Regexp, text string) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[4], match[5]
+		b.WriteString(html.EscapeString(text[last:start]))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(text[start:end]))
+		b.WriteString("</em>")
+		last = end
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,10 +10,11 @@ import (
 
 type UserUseCase struct {
 	userRepo domain.UserRepository
+	events   domain.EventPublisher
 }
 
-func NewUserUseCase(userRepo domain.UserRepository) domain.IUserUseCase {
-	return &UserUseCase{userRepo: userRepo}
+func NewUserUseCase(userRepo domain.UserRepository, events domain.EventPublisher) domain.IUserUseCase {
+	return &UserUseCase{userRepo: userRepo, events: events}
 }
 
 func (uc *UserUseCase) GetAll() ([]domain.User, error) {
@@ -49,12 +49,14 @@ func (uc *UserUseCase) Insert(user *domain.User) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	if user == nil {
-		return errors.New("user cannot be nil")
+		return domain.NewError(domain.ErrValidation, "user cannot be nil")
 	}
 	err := uc.userRepo.Insert(ctx, user)
 	if err != nil {
 		return err
 	}
+	registered := *user
+	uc.events.Publish(ctx, domain.Event{Type: domain.EventUserRegistered, Actor: user.Username, User: &registered})
 	return nil
 }
 
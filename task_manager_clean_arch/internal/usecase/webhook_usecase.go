@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// webhookLease is how long a claimed delivery is kept from other workers.
+	// It has to be longer than the sender's timeout.
+	webhookLease = time.Minute
+	// maxDeliveriesPerRun bounds the deliveries attempted by one DeliverDue.
+	maxDeliveriesPerRun = 100
+	// deliveryLogLimit is how many deliveries GetDeliveries returns.
+	deliveryLogLimit = 50
+)
+
+var (
+	errInvalidWebhookURL   = domain.NewError(domain.ErrValidation, "webhook url must be an absolute http or https URL")
+	errNoWebhookEvents     = domain.NewError(domain.ErrValidation, "webhook must subscribe to at least one event")
+	errUnknownWebhookEvent = domain.NewError(domain.ErrValidation, "unknown webhook event")
+	errDeliveryNotFound    = domain.NewError(domain.ErrNotFound, "webhook delivery not found")
+)
+
+type WebhookUseCase struct {
+	webhookRepo  domain.WebhookRepository
+	deliveryRepo domain.WebhookDeliveryRepository
+	sender       domain.WebhookSender
+}
+
+func NewWebhookUseCase(webhookRepo domain.WebhookRepository, deliveryRepo domain.WebhookDeliveryRepository, sender domain.WebhookSender) domain.IWebhookUseCase {
+	return &WebhookUseCase{webhookRepo: webhookRepo, deliveryRepo: deliveryRepo, sender: sender}
+}
+
+func (uc *WebhookUseCase) GetAll() ([]domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return uc.webhookRepo.GetAll(ctx)
+}
+
+func (uc *WebhookUseCase) GetById(id string) (domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return uc.webhookRepo.GetById(ctx, id)
+}
+
+// Create adds the webhook, generating a secret when none is given.
+func (uc *WebhookUseCase) Create(webhook *domain.Webhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if webhook == nil {
+		return domain.NewError(domain.ErrValidation, "webhook cannot be nil")
+	}
+	if err := prepareWebhook(webhook); err != nil {
+		return err
+	}
+	if webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+	webhook.CreatedAt = time.Now()
+	return uc.webhookRepo.Create(ctx, webhook)
+}
+
+// Update replaces the URL, events and state of the webhook. The secret is
+// kept unless a new one is given.
+func (uc *WebhookUseCase) Update(id string, webhook *domain.Webhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if webhook == nil {
+		return domain.NewError(domain.ErrValidation, "webhook cannot be nil")
+	}
+	if err := prepareWebhook(webhook); err != nil {
+		return err
+	}
+	current, err := uc.webhookRepo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = current.Secret
+	}
+	if err := uc.webhookRepo.Update(ctx, id, webhook); err != nil {
+		return err
+	}
+	webhook.ID, webhook.CreatedBy, webhook.CreatedAt = current.ID, current.CreatedBy, current.CreatedAt
+	return nil
+}
+
+// Delete removes the webhook along with its deliveries.
+func (uc *WebhookUseCase) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if err := uc.webhookRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return uc.deliveryRepo.DeleteByWebhook(ctx, id)
+}
+
+// GetDeliveries lists the latest deliveries of the webhook, most recent
+// first.
+func (uc *WebhookUseCase) GetDeliveries(id string) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if _, err := uc.webhookRepo.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.deliveryRepo.GetByWebhook(ctx, id, deliveryLogLimit)
+}
+
+// Redeliver queues the event of one of the webhook's deliveries again. The
+// new delivery carries the same event, so receivers can tell it apart from a
+// new one by the event ID.
+func (uc *WebhookUseCase) Redeliver(id, deliveryID string) (domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	webhook, err := uc.webhookRepo.GetById(ctx, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	delivery, err := uc.deliveryRepo.GetById(ctx, deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if delivery.WebhookID != webhook.ID {
+		return domain.WebhookDelivery{}, errDeliveryNotFound
+	}
+	redelivery := newDelivery(webhook, delivery.Event)
+	if err := uc.deliveryRepo.Create(ctx, &redelivery); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return redelivery, nil
+}
+
+// DeliverDue claims the deliveries that are due one at a time and attempts
+// them, until none is left or maxDeliveriesPerRun have been attempted.
+func (uc *WebhookUseCase) DeliverDue() (int, error) {
+	delivered := 0
+	for range maxDeliveriesPerRun {
+		attempted, succeeded, err := uc.deliverNext()
+		if err != nil || !attempted {
+			return delivered, err
+		}
+		if succeeded {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// deliverNext attempts the next due delivery. A failed attempt is scheduled
+// again after WebhookBackoff, until MaxWebhookAttempts have been made.
+func (uc *WebhookUseCase) deliverNext() (bool, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookLease)
+	defer cancel()
+	delivery, err := uc.deliveryRepo.ClaimDue(ctx, time.Now(), webhookLease)
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	webhook, err := uc.webhookRepo.GetById(ctx, delivery.WebhookID.Hex())
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		delivery.Status, delivery.LastError = domain.DeliveryFailed, "webhook no longer exists"
+	case err != nil:
+		return false, false, err
+	case !webhook.Active:
+		delivery.Status, delivery.LastError = domain.DeliveryFailed, "webhook is inactive"
+	default:
+		delivery.Attempts++
+		delivery.LastStatusCode, err = uc.sender.Send(ctx, webhook, delivery)
+		now := time.Now()
+		switch {
+		case err == nil:
+			delivery.Status, delivery.LastError, delivery.DeliveredAt = domain.DeliverySucceeded, "", now
+		case delivery.Attempts >= domain.MaxWebhookAttempts:
+			delivery.Status, delivery.LastError = domain.DeliveryFailed, err.Error()
+		default:
+			delivery.LastError, delivery.NextAttemptAt = err.Error(), now.Add(domain.WebhookBackoff(delivery.Attempts))
+		}
+	}
+	if err := uc.deliveryRepo.Update(ctx, &delivery); err != nil {
+		return true, false, err
+	}
+	return true, delivery.Status == domain.DeliverySucceeded, nil
+}
+
+// prepareWebhook checks the URL and events of a webhook and drops duplicate
+// events.
+func prepareWebhook(webhook *domain.Webhook) error {
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return errInvalidWebhookURL
+	}
+	if len(webhook.Events) == 0 {
+		return errNoWebhookEvents
+	}
+	var events []string
+	for _, event := range webhook.Events {
+		if !domain.IsEventType(event) {
+			return errUnknownWebhookEvent
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	webhook.Events = events
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newDelivery is a delivery of the event to the webhook, due right away.
+func newDelivery(webhook domain.Webhook, event domain.Event) domain.WebhookDelivery {
+	now := time.Now()
+	return domain.WebhookDelivery{
+		ID:            primitive.NewObjectID(),
+		WebhookID:     webhook.ID,
+		Event:         event,
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+type EventPublisher struct {
+	webhookRepo  domain.WebhookRepository
+	deliveryRepo domain.WebhookDeliveryRepository
+	bus          domain.EventBus
+}
+
+func NewEventPublisher(webhookRepo domain.WebhookRepository, deliveryRepo domain.WebhookDeliveryRepository, bus domain.EventBus) domain.EventPublisher {
+	return &EventPublisher{webhookRepo: webhookRepo, deliveryRepo: deliveryRepo, bus: bus}
+}
+
+// Publish passes the event on to the subscribers of the bus and queues a
+// delivery of it for every active webhook subscribed to it. The change the
+// event reports has already been made, so failures are logged rather than
+// returned.
+func (p *EventPublisher) Publish(ctx context.Context, event domain.Event) {
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	p.bus.Publish(ctx, event)
+	webhooks, err := p.webhookRepo.GetByEvent(ctx, event.Type)
+	if err != nil {
+		log.Println("Failed to publish event:", err)
+		return
+	}
+	for _, webhook := range webhooks {
+		delivery := newDelivery(webhook, event)
+		if err := p.deliveryRepo.Create(ctx, &delivery); err != nil {
+			log.Println("Failed to queue webhook delivery:", err)
+		}
+	}
+}
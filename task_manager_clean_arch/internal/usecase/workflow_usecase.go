@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+type WorkflowUseCase struct {
+	workflowRepo domain.WorkflowRepository
+}
+
+func NewWorkflowUseCase(workflowRepo domain.WorkflowRepository) domain.IWorkflowUseCase {
+	return &WorkflowUseCase{workflowRepo: workflowRepo}
+}
+
+// currentWorkflow returns the configured workflow, or the default one when
+// none has been saved yet.
+func currentWorkflow(ctx context.Context, workflowRepo domain.WorkflowRepository) (domain.Workflow, error) {
+	workflow, err := workflowRepo.Get(ctx)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.DefaultWorkflow(), nil
+	}
+	if err != nil {
+		return domain.Workflow{}, err
+	}
+	return workflow, nil
+}
+
+func (uc *WorkflowUseCase) Get() (domain.Workflow, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return currentWorkflow(ctx, uc.workflowRepo)
+}
+
+// Update replaces the workflow. Tasks keep their status even when it is no
+// longer part of the new workflow.
+func (uc *WorkflowUseCase) Update(workflow *domain.Workflow) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if workflow == nil {
+		return domain.NewError(domain.ErrValidation, "workflow cannot be nil")
+	}
+	if err := workflow.Validate(); err != nil {
+		return err
+	}
+	if workflow.Transitions == nil {
+		workflow.Transitions = map[string][]string{}
+	}
+	workflow.UpdatedAt = time.Now()
+	return uc.workflowRepo.Save(ctx, workflow)
+}
@@ -0,0 +1,64 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// EventBus is an autogenerated mock type for the EventBus type
+type EventBus struct {
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: _a0, _a1
+func (_m *EventBus) Publish(_a0 context.Context, _a1 domain.Event) {
+	_m.Called(_a0, _a1)
+}
+
+// Subscribe provides a mock function with given fields: ctx, after, filter
+func (_m *EventBus) Subscribe(ctx context.Context, after uint64, filter func(domain.Event) bool) (<-chan domain.StreamEvent, bool) {
+	ret := _m.Called(ctx, after, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan domain.StreamEvent
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, func(domain.Event) bool) (<-chan domain.StreamEvent, bool)); ok {
+		return rf(ctx, after, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, func(domain.Event) bool) <-chan domain.StreamEvent); ok {
+		r0 = rf(ctx, after, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.StreamEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, func(domain.Event) bool) bool); ok {
+		r1 = rf(ctx, after, filter)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// NewEventBus creates a new instance of EventBus. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventBus(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventBus {
+	mock := &EventBus{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
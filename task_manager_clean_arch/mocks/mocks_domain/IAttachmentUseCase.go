@@ -0,0 +1,161 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	io "io"
+
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAttachmentUseCase is an autogenerated mock type for the IAttachmentUseCase type
+type IAttachmentUseCase struct {
+	mock.Mock
+}
+
+// CreateByTaskAndUser provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *IAttachmentUseCase) CreateByTaskAndUser(_a0 string, _a1 *domain.Attachment, _a2 io.Reader, _a3 string) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateByTaskAndUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *domain.Attachment, io.Reader, string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IAttachmentUseCase) DeleteByIdAndUser(_a0 string, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByIdAndUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByTaskAndUser provides a mock function with given fields: _a0, _a1
+func (_m *IAttachmentUseCase) GetByTaskAndUser(_a0 string, _a1 string) ([]domain.Attachment, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTaskAndUser")
+	}
+
+	var r0 []domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]domain.Attachment, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []domain.Attachment); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IAttachmentUseCase) OpenByIdAndUser(_a0 string, _a1 string, _a2 string) (domain.Attachment, io.ReadCloser, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenByIdAndUser")
+	}
+
+	var r0 domain.Attachment
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (domain.Attachment, io.ReadCloser, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) domain.Attachment); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) io.ReadCloser); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, string) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PurgeOrphans provides a mock function with no fields
+func (_m *IAttachmentUseCase) PurgeOrphans() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeOrphans")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIAttachmentUseCase creates a new instance of IAttachmentUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAttachmentUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAttachmentUseCase {
+	mock := &IAttachmentUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
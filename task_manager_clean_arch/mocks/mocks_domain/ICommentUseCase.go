@@ -0,0 +1,151 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// ICommentUseCase is an autogenerated mock type for the ICommentUseCase type
+type ICommentUseCase struct {
+	mock.Mock
+}
+
+// CreateByTaskAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ICommentUseCase) CreateByTaskAndUser(_a0 string, _a1 *domain.Comment, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateByTaskAndUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *domain.Comment, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ICommentUseCase) DeleteByIdAndUser(_a0 string, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByIdAndUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActivityByTaskAndUser provides a mock function with given fields: _a0, _a1
+func (_m *ICommentUseCase) GetActivityByTaskAndUser(_a0 string, _a1 string) ([]domain.Activity, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivityByTaskAndUser")
+	}
+
+	var r0 []domain.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]domain.Activity, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []domain.Activity); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Activity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTaskAndUser provides a mock function with given fields: _a0, _a1
+func (_m *ICommentUseCase) GetByTaskAndUser(_a0 string, _a1 string) ([]domain.Comment, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTaskAndUser")
+	}
+
+	var r0 []domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]domain.Comment, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []domain.Comment); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByIdAndUser provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ICommentUseCase) UpdateByIdAndUser(_a0 string, _a1 string, _a2 string, _a3 string) (domain.Comment, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateByIdAndUser")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string) (domain.Comment, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, string) domain.Comment); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewICommentUseCase creates a new instance of ICommentUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewICommentUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ICommentUseCase {
+	mock := &ICommentUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
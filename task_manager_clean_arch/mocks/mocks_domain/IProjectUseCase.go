@@ -0,0 +1,315 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// IProjectUseCase is an autogenerated mock type for the IProjectUseCase type
+type IProjectUseCase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: _a0
+func (_m *IProjectUseCase) Create(_a0 *domain.Project) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Project) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTask provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IProjectUseCase) CreateTask(_a0 string, _a1 *domain.Task, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *domain.Task, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByIdAndUser provides a mock function with given fields: _a0, _a1
+func (_m *IProjectUseCase) DeleteByIdAndUser(_a0 string, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByIdAndUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTask provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *IProjectUseCase) DeleteTask(_a0 string, _a1 string, _a2 int64, _a3 string) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int64, string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByIdAndUser provides a mock function with given fields: _a0, _a1
+func (_m *IProjectUseCase) GetByIdAndUser(_a0 string, _a1 string) (domain.Project, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIdAndUser")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (domain.Project, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) domain.Project); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUser provides a mock function with given fields: _a0
+func (_m *IProjectUseCase) GetByUser(_a0 string) ([]domain.Project, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUser")
+	}
+
+	var r0 []domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]domain.Project, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) []domain.Project); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTask provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IProjectUseCase) GetTask(_a0 string, _a1 string, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTask")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTasks provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IProjectUseCase) ListTasks(_a0 string, _a1 domain.TaskQuery, _a2 string) (domain.TaskPage, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTasks")
+	}
+
+	var r0 domain.TaskPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.TaskQuery, string) (domain.TaskPage, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.TaskQuery, string) domain.TaskPage); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.TaskPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.TaskQuery, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchTask provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *IProjectUseCase) PatchTask(_a0 string, _a1 string, _a2 domain.TaskUpdate, _a3 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTask")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, domain.TaskUpdate, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, domain.TaskUpdate, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, domain.TaskUpdate, string) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IProjectUseCase) RemoveMember(_a0 string, _a1 string, _a2 string) (domain.Project, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (domain.Project, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) domain.Project); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetMember provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IProjectUseCase) SetMember(_a0 string, _a1 domain.ProjectMember, _a2 string) (domain.Project, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMember")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.ProjectMember, string) (domain.Project, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.ProjectMember, string) domain.Project); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.ProjectMember, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IProjectUseCase) UpdateByIdAndUser(_a0 string, _a1 *domain.Project, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateByIdAndUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *domain.Project, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIProjectUseCase creates a new instance of IProjectUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIProjectUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IProjectUseCase {
+	mock := &IProjectUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
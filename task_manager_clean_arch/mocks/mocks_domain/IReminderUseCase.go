@@ -0,0 +1,52 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import mock "github.com/stretchr/testify/mock"
+
+// IReminderUseCase is an autogenerated mock type for the IReminderUseCase type
+type IReminderUseCase struct {
+	mock.Mock
+}
+
+// SendReminders provides a mock function with no fields
+func (_m *IReminderUseCase) SendReminders() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendReminders")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIReminderUseCase creates a new instance of IReminderUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIReminderUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IReminderUseCase {
+	mock := &IReminderUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// ITaskEventUseCase is an autogenerated mock type for the ITaskEventUseCase type
+type ITaskEventUseCase struct {
+	mock.Mock
+}
+
+// Watch provides a mock function with given fields: ctx, username, after
+func (_m *ITaskEventUseCase) Watch(ctx context.Context, username string, after uint64) (<-chan domain.StreamEvent, bool) {
+	ret := _m.Called(ctx, username, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 <-chan domain.StreamEvent
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) (<-chan domain.StreamEvent, bool)); ok {
+		return rf(ctx, username, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) <-chan domain.StreamEvent); ok {
+		r0 = rf(ctx, username, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.StreamEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) bool); ok {
+		r1 = rf(ctx, username, after)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// NewITaskEventUseCase creates a new instance of ITaskEventUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITaskEventUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITaskEventUseCase {
+	mock := &ITaskEventUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,8 @@ package mocks_domain
 import (
 	mock "github.com/stretchr/testify/mock"
 	domain "github.com/yiheyistm/task_manager/internal/domain"
+
+	time "time"
 )
 
 // ITaskUseCase is an autogenerated mock type for the ITaskUseCase type
@@ -12,6 +14,122 @@ type ITaskUseCase struct {
 	mock.Mock
 }
 
+// AddBlockerByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) AddBlockerByIdAndUser(_a0 string, _a1 string, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBlockerByIdAndUser")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssignByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) AssignByIdAndUser(_a0 string, _a1 string, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignByIdAndUser")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Batch provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) Batch(_a0 []domain.TaskOperation, _a1 bool, _a2 string) ([]domain.TaskOperationResult, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 []domain.TaskOperationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]domain.TaskOperation, bool, string) ([]domain.TaskOperationResult, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func([]domain.TaskOperation, bool, string) []domain.TaskOperationResult); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskOperationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]domain.TaskOperation, bool, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchByUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) BatchByUser(_a0 []domain.TaskOperation, _a1 bool, _a2 string) ([]domain.TaskOperationResult, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchByUser")
+	}
+
+	var r0 []domain.TaskOperationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]domain.TaskOperation, bool, string) ([]domain.TaskOperationResult, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func([]domain.TaskOperation, bool, string) []domain.TaskOperationResult); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskOperationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]domain.TaskOperation, bool, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: _a0
 func (_m *ITaskUseCase) Create(_a0 *domain.Task) error {
 	ret := _m.Called(_a0)
@@ -30,17 +148,35 @@ func (_m *ITaskUseCase) Create(_a0 *domain.Task) error {
 	return r0
 }
 
-// Delete provides a mock function with given fields: _a0
-func (_m *ITaskUseCase) Delete(_a0 string) error {
-	ret := _m.Called(_a0)
+// CreateSubtask provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) CreateSubtask(_a0 string, _a1 *domain.Task, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubtask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *domain.Task, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) Delete(_a0 string, _a1 int64, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, int64, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -48,17 +184,35 @@ func (_m *ITaskUseCase) Delete(_a0 string) error {
 	return r0
 }
 
-// DeleteByIdAndUser provides a mock function with given fields: _a0, _a1
-func (_m *ITaskUseCase) DeleteByIdAndUser(_a0 string, _a1 string) error {
-	ret := _m.Called(_a0, _a1)
+// DeleteByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) DeleteByIdAndUser(_a0 string, _a1 string, _a2 int64) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteByIdAndUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(string, string, int64) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportAll provides a mock function with given fields: _a0
+func (_m *ITaskUseCase) ExportAll(_a0 func(domain.Task) error) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(domain.Task) error) error); ok {
+		r0 = rf(_a0)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -152,25 +306,143 @@ func (_m *ITaskUseCase) GetByIdAndUser(_a0 string, _a1 string) (domain.Task, err
 	return r0, r1
 }
 
+// GetHistory provides a mock function with given fields: _a0
+func (_m *ITaskUseCase) GetHistory(_a0 string) ([]domain.TaskHistory, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []domain.TaskHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]domain.TaskHistory, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) []domain.TaskHistory); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistoryByIdAndUser provides a mock function with given fields: _a0, _a1
+func (_m *ITaskUseCase) GetHistoryByIdAndUser(_a0 string, _a1 string) ([]domain.TaskHistory, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistoryByIdAndUser")
+	}
+
+	var r0 []domain.TaskHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]domain.TaskHistory, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []domain.TaskHistory); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNextTasksByUser provides a mock function with given fields: _a0
+func (_m *ITaskUseCase) GetNextTasksByUser(_a0 string) ([]domain.Task, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextTasksByUser")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]domain.Task, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) []domain.Task); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubtasksByIdAndUser provides a mock function with given fields: _a0, _a1
+func (_m *ITaskUseCase) GetSubtasksByIdAndUser(_a0 string, _a1 string) ([]domain.Task, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubtasksByIdAndUser")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]domain.Task, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []domain.Task); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskCountByStatus provides a mock function with no fields
-func (_m *ITaskUseCase) GetTaskCountByStatus() ([]domain.StatusCount, error) {
+func (_m *ITaskUseCase) GetTaskCountByStatus() (domain.TaskStats, error) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTaskCountByStatus")
 	}
 
-	var r0 []domain.StatusCount
+	var r0 domain.TaskStats
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]domain.StatusCount, error)); ok {
+	if rf, ok := ret.Get(0).(func() (domain.TaskStats, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() []domain.StatusCount); ok {
+	if rf, ok := ret.Get(0).(func() domain.TaskStats); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.StatusCount)
-		}
+		r0 = ret.Get(0).(domain.TaskStats)
 	}
 
 	if rf, ok := ret.Get(1).(func() error); ok {
@@ -183,24 +455,22 @@ func (_m *ITaskUseCase) GetTaskCountByStatus() ([]domain.StatusCount, error) {
 }
 
 // GetTaskStatsByUser provides a mock function with given fields: _a0
-func (_m *ITaskUseCase) GetTaskStatsByUser(_a0 string) ([]domain.StatusCount, error) {
+func (_m *ITaskUseCase) GetTaskStatsByUser(_a0 string) (domain.TaskStats, error) {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTaskStatsByUser")
 	}
 
-	var r0 []domain.StatusCount
+	var r0 domain.TaskStats
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]domain.StatusCount, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (domain.TaskStats, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(string) []domain.StatusCount); ok {
+	if rf, ok := ret.Get(0).(func(string) domain.TaskStats); ok {
 		r0 = rf(_a0)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.StatusCount)
-		}
+		r0 = ret.Get(0).(domain.TaskStats)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -242,17 +512,303 @@ func (_m *ITaskUseCase) GetTasksByUser(_a0 string) ([]domain.Task, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: _a0, _a1
-func (_m *ITaskUseCase) Update(_a0 string, _a1 *domain.Task) error {
+// GetTrashByUser provides a mock function with given fields: _a0
+func (_m *ITaskUseCase) GetTrashByUser(_a0 string) ([]domain.Task, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashByUser")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]domain.Task, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) []domain.Task); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportByUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) ImportByUser(_a0 string, _a1 []*domain.Task, _a2 bool) ([]error, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportByUser")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []*domain.Task, bool) ([]error, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, []*domain.Task, bool) []error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []*domain.Task, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTasks provides a mock function with given fields: _a0
+func (_m *ITaskUseCase) ListTasks(_a0 domain.TaskQuery) (domain.TaskPage, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTasks")
+	}
+
+	var r0 domain.TaskPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.TaskQuery) (domain.TaskPage, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(domain.TaskQuery) domain.TaskPage); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(domain.TaskPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.TaskQuery) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Patch provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) Patch(_a0 string, _a1 domain.TaskUpdate, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.TaskUpdate, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.TaskUpdate, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.TaskUpdate, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) PatchByIdAndUser(_a0 string, _a1 domain.TaskUpdate, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchByIdAndUser")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, domain.TaskUpdate, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, domain.TaskUpdate, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, domain.TaskUpdate, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: _a0
+func (_m *ITaskUseCase) PurgeTrash(_a0 time.Duration) (int64, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Duration) (int64, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(time.Duration) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Duration) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveBlockerByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) RemoveBlockerByIdAndUser(_a0 string, _a1 string, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBlockerByIdAndUser")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreByIdAndUser provides a mock function with given fields: _a0, _a1
+func (_m *ITaskUseCase) RestoreByIdAndUser(_a0 string, _a1 string) (domain.Task, error) {
 	ret := _m.Called(_a0, _a1)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreByIdAndUser")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchTasks provides a mock function with given fields: _a0
+func (_m *ITaskUseCase) SearchTasks(_a0 domain.TaskSearch) ([]domain.TaskMatch, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTasks")
+	}
+
+	var r0 []domain.TaskMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.TaskSearch) ([]domain.TaskMatch, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(domain.TaskSearch) []domain.TaskMatch); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskMatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.TaskSearch) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnassignByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) UnassignByIdAndUser(_a0 string, _a1 string, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignByIdAndUser")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITaskUseCase) Update(_a0 string, _a1 *domain.Task, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, *domain.Task) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(string, *domain.Task, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -278,6 +834,24 @@ func (_m *ITaskUseCase) UpdateByIdAndUser(_a0 string, _a1 *domain.Task, _a2 stri
 	return r0
 }
 
+// UpdateOccurrenceByIdAndUser provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ITaskUseCase) UpdateOccurrenceByIdAndUser(_a0 string, _a1 *domain.Task, _a2 string, _a3 string) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOccurrenceByIdAndUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *domain.Task, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewITaskUseCase creates a new instance of ITaskUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewITaskUseCase(t interface {
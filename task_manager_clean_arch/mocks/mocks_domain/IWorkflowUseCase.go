@@ -0,0 +1,73 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// IWorkflowUseCase is an autogenerated mock type for the IWorkflowUseCase type
+type IWorkflowUseCase struct {
+	mock.Mock
+}
+
+// Get provides a mock function with no fields
+func (_m *IWorkflowUseCase) Get() (domain.Workflow, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 domain.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (domain.Workflow, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() domain.Workflow); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.Workflow)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: _a0
+func (_m *IWorkflowUseCase) Update(_a0 *domain.Workflow) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Workflow) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIWorkflowUseCase creates a new instance of IWorkflowUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWorkflowUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWorkflowUseCase {
+	mock := &IWorkflowUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+
+	time "time"
+)
+
+// RateLimitStore is an autogenerated mock type for the RateLimitStore type
+type RateLimitStore struct {
+	mock.Mock
+}
+
+// Take provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *RateLimitStore) Take(_a0 context.Context, _a1 string, _a2 domain.RateLimit, _a3 time.Time) (domain.RateLimitResult, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 domain.RateLimitResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.RateLimit, time.Time) (domain.RateLimitResult, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.RateLimit, time.Time) domain.RateLimitResult); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(domain.RateLimitResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.RateLimit, time.Time) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRateLimitStore creates a new instance of RateLimitStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimitStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimitStore {
+	mock := &RateLimitStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
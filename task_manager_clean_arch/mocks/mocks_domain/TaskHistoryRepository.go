@@ -0,0 +1,107 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// TaskHistoryRepository is an autogenerated mock type for the TaskHistoryRepository type
+type TaskHistoryRepository struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: _a0, _a1
+func (_m *TaskHistoryRepository) Add(_a0 context.Context, _a1 *domain.TaskHistory) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskHistory) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByTask provides a mock function with given fields: _a0, _a1
+func (_m *TaskHistoryRepository) GetByTask(_a0 context.Context, _a1 string) ([]domain.TaskHistory, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTask")
+	}
+
+	var r0 []domain.TaskHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.TaskHistory, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.TaskHistory); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTaskAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskHistoryRepository) GetByTaskAndUser(_a0 context.Context, _a1 string, _a2 string) ([]domain.TaskHistory, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTaskAndUser")
+	}
+
+	var r0 []domain.TaskHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.TaskHistory, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.TaskHistory); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTaskHistoryRepository creates a new instance of TaskHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskHistoryRepository {
+	mock := &TaskHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,10 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	domain "github.com/yiheyistm/task_manager/internal/domain"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
 )
 
 // TaskRepository is an autogenerated mock type for the TaskRepository type
@@ -14,6 +18,150 @@ type TaskRepository struct {
 	mock.Mock
 }
 
+// AddAssignee provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) AddAssignee(_a0 context.Context, _a1 string, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAssignee")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddBlocker provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) AddBlocker(_a0 context.Context, _a1 string, _a2 primitive.ObjectID) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBlocker")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.ObjectID) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.ObjectID) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkWrite provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) BulkWrite(_a0 context.Context, _a1 []domain.TaskWrite, _a2 bool) ([]error, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkWrite")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.TaskWrite, bool) ([]error, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.TaskWrite, bool) []error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.TaskWrite, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByProject provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) CountByProject(_a0 context.Context, _a1 primitive.ObjectID) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByProject")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountSubtasksByStatus provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) CountSubtasksByStatus(_a0 context.Context, _a1 []primitive.ObjectID) (map[primitive.ObjectID][]domain.StatusCount, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSubtasksByStatus")
+	}
+
+	var r0 map[primitive.ObjectID][]domain.StatusCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) (map[primitive.ObjectID][]domain.StatusCount, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) map[primitive.ObjectID][]domain.StatusCount); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[primitive.ObjectID][]domain.StatusCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: _a0, _a1
 func (_m *TaskRepository) Create(_a0 context.Context, _a1 *domain.Task) error {
 	ret := _m.Called(_a0, _a1)
@@ -32,16 +180,16 @@ func (_m *TaskRepository) Create(_a0 context.Context, _a1 *domain.Task) error {
 	return r0
 }
 
-// Delete provides a mock function with given fields: _a0, _a1
-func (_m *TaskRepository) Delete(_a0 context.Context, _a1 string) error {
+// CreateMany provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) CreateMany(_a0 context.Context, _a1 []*domain.Task) error {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for CreateMany")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Task) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -50,16 +198,16 @@ func (_m *TaskRepository) Delete(_a0 context.Context, _a1 string) error {
 	return r0
 }
 
-// DeleteByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
-func (_m *TaskRepository) DeleteByIdAndUser(_a0 context.Context, _a1 string, _a2 string) error {
+// Delete provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) Delete(_a0 context.Context, _a1 string, _a2 int64) error {
 	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteByIdAndUser")
+		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
 		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
@@ -68,6 +216,52 @@ func (_m *TaskRepository) DeleteByIdAndUser(_a0 context.Context, _a1 string, _a2
 	return r0
 }
 
+// DeleteByIdAndUser provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *TaskRepository) DeleteByIdAndUser(_a0 context.Context, _a1 string, _a2 string, _a3 int64) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByIdAndUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) Find(_a0 context.Context, _a1 domain.TaskQuery) (domain.TaskPage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Find")
+	}
+
+	var r0 domain.TaskPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskQuery) (domain.TaskPage, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskQuery) domain.TaskPage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(domain.TaskPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TaskQuery) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: _a0
 func (_m *TaskRepository) GetAll(_a0 context.Context) ([]domain.Task, error) {
 	ret := _m.Called(_a0)
@@ -98,6 +292,24 @@ func (_m *TaskRepository) GetAll(_a0 context.Context) ([]domain.Task, error) {
 	return r0, r1
 }
 
+// GetAllInBatches provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) GetAllInBatches(_a0 context.Context, _a1 int, _a2 func([]domain.Task) error) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllInBatches")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func([]domain.Task) error) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetById provides a mock function with given fields: _a0, _a1
 func (_m *TaskRepository) GetById(_a0 context.Context, _a1 string) (domain.Task, error) {
 	ret := _m.Called(_a0, _a1)
@@ -184,25 +396,143 @@ func (_m *TaskRepository) GetByUser(_a0 context.Context, _a1 string) ([]domain.T
 	return r0, r1
 }
 
+// GetDueBetween provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *TaskRepository) GetDueBetween(_a0 context.Context, _a1 time.Time, _a2 time.Time, _a3 string) ([]domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueBetween")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, string) ([]domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, string) []domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, string) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExistingIds provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) GetExistingIds(_a0 context.Context, _a1 []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExistingIds")
+	}
+
+	var r0 []primitive.ObjectID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) ([]primitive.ObjectID, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) []primitive.ObjectID); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]primitive.ObjectID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatuses provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) GetStatuses(_a0 context.Context, _a1 []primitive.ObjectID) (map[primitive.ObjectID]string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatuses")
+	}
+
+	var r0 map[primitive.ObjectID]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) (map[primitive.ObjectID]string, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.ObjectID) map[primitive.ObjectID]string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[primitive.ObjectID]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubtasks provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) GetSubtasks(_a0 context.Context, _a1 string) ([]domain.Task, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubtasks")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Task, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskCountByStatus provides a mock function with given fields: _a0
-func (_m *TaskRepository) GetTaskCountByStatus(_a0 context.Context) ([]domain.StatusCount, error) {
+func (_m *TaskRepository) GetTaskCountByStatus(_a0 context.Context) (domain.TaskStats, error) {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTaskCountByStatus")
 	}
 
-	var r0 []domain.StatusCount
+	var r0 domain.TaskStats
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.StatusCount, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.TaskStats, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.StatusCount); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) domain.TaskStats); ok {
 		r0 = rf(_a0)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.StatusCount)
-		}
+		r0 = ret.Get(0).(domain.TaskStats)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -215,23 +545,51 @@ func (_m *TaskRepository) GetTaskCountByStatus(_a0 context.Context) ([]domain.St
 }
 
 // GetTaskStatsByUser provides a mock function with given fields: _a0, _a1
-func (_m *TaskRepository) GetTaskStatsByUser(_a0 context.Context, _a1 string) ([]domain.StatusCount, error) {
+func (_m *TaskRepository) GetTaskStatsByUser(_a0 context.Context, _a1 string) (domain.TaskStats, error) {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTaskStatsByUser")
 	}
 
-	var r0 []domain.StatusCount
+	var r0 domain.TaskStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.TaskStats, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.TaskStats); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(domain.TaskStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrashByUser provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) GetTrashByUser(_a0 context.Context, _a1 string) ([]domain.Task, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashByUser")
+	}
+
+	var r0 []domain.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.StatusCount, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Task, error)); ok {
 		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.StatusCount); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.StatusCount)
+			r0 = ret.Get(0).([]domain.Task)
 		}
 	}
 
@@ -244,6 +602,204 @@ func (_m *TaskRepository) GetTaskStatsByUser(_a0 context.Context, _a1 string) ([
 	return r0, r1
 }
 
+// Patch provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) Patch(_a0 context.Context, _a1 string, _a2 domain.TaskUpdate) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TaskUpdate) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TaskUpdate) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.TaskUpdate) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchByIdAndUser provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *TaskRepository) PatchByIdAndUser(_a0 context.Context, _a1 string, _a2 domain.TaskUpdate, _a3 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchByIdAndUser")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TaskUpdate, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TaskUpdate, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.TaskUpdate, string) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedBefore provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) PurgeDeletedBefore(_a0 context.Context, _a1 time.Time) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveAssignee provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) RemoveAssignee(_a0 context.Context, _a1 string, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAssignee")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveBlocker provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) RemoveBlocker(_a0 context.Context, _a1 string, _a2 primitive.ObjectID) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBlocker")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.ObjectID) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.ObjectID) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, primitive.ObjectID) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreByIdAndUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TaskRepository) RestoreByIdAndUser(_a0 context.Context, _a1 string, _a2 string) (domain.Task, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreByIdAndUser")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Task, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Task); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: _a0, _a1
+func (_m *TaskRepository) Search(_a0 context.Context, _a1 domain.TaskSearch) ([]domain.TaskMatch, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.TaskMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskSearch) ([]domain.TaskMatch, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskSearch) []domain.TaskMatch); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskMatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TaskSearch) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: _a0, _a1, _a2
 func (_m *TaskRepository) Update(_a0 context.Context, _a1 string, _a2 *domain.Task) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -16,13 +16,9 @@ type UserRepository struct {
 	mock.Mock
 }
 
-func (_m *UserRepository) EXPECT() {
-	panic("unimplemented")
-}
-
-// GetAll provides a mock function with given fields: ctx
-func (_m *UserRepository) GetAll(ctx context.Context) ([]domain.User, error) {
-	ret := _m.Called(ctx)
+// GetAll provides a mock function with given fields: _a0
+func (_m *UserRepository) GetAll(_a0 context.Context) ([]domain.User, error) {
+	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
@@ -31,10 +27,10 @@ func (_m *UserRepository) GetAll(ctx context.Context) ([]domain.User, error) {
 	var r0 []domain.User
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.User, error)); ok {
-		return rf(ctx)
+		return rf(_a0)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context) []domain.User); ok {
-		r0 = rf(ctx)
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.User)
@@ -42,7 +38,7 @@ func (_m *UserRepository) GetAll(ctx context.Context) ([]domain.User, error) {
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -50,46 +46,51 @@ func (_m *UserRepository) GetAll(ctx context.Context) ([]domain.User, error) {
 	return r0, r1
 }
 
-func (_m *UserRepository) GetUser(ctx context.Context, key, value string) (*domain.User, error) {
-	ret := _m.Called(ctx, key, value)
+// GetByEmail provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) GetByEmail(_a0 context.Context, _a1 string) (*domain.User, error) {
+	ret := _m.Called(_a0, _a1)
+
 	if len(ret) == 0 {
-		panic("no return value specified for GetUser")
+		panic("no return value specified for GetByEmail")
 	}
+
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
-		return rf(ctx, key, value)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
-		r0 = rf(ctx, key, value)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, key, value)
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
+
 	return r0, r1
 }
 
-// GetByEmail provides a mock function with given fields: ctx, email
-func (_m *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	ret := _m.Called(ctx, email)
+// GetByUsername provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) GetByUsername(_a0 context.Context, _a1 string) (*domain.User, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByEmail")
+		panic("no return value specified for GetByUsername")
 	}
 
 	var r0 *domain.User
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return rf(ctx, email)
+		return rf(_a0, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = rf(ctx, email)
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
@@ -97,7 +98,7 @@ func (_m *UserRepository) GetByEmail(ctx context.Context, email string) (*domain
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, email)
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,29 +106,29 @@ func (_m *UserRepository) GetByEmail(ctx context.Context, email string) (*domain
 	return r0, r1
 }
 
-// GetByUsername provides a mock function with given fields: ctx, username
-func (_m *UserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
-	ret := _m.Called(ctx, username)
+// GetUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserRepository) GetUser(_a0 context.Context, _a1 string, _a2 string) (*domain.User, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUsername")
+		panic("no return value specified for GetUser")
 	}
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, username)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -155,9 +156,27 @@ func (_m *UserRepository) GetUserFromContext(c *gin.Context) *domain.User {
 	return r0
 }
 
-// Insert provides a mock function with given fields: ctx, user
-func (_m *UserRepository) Insert(ctx context.Context, user *domain.User) error {
-	ret := _m.Called(ctx, user)
+// IncrementTokenVersion provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) IncrementTokenVersion(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementTokenVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Insert provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) Insert(_a0 context.Context, _a1 *domain.User) error {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Insert")
@@ -165,7 +184,7 @@ func (_m *UserRepository) Insert(ctx context.Context, user *domain.User) error {
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
-		r0 = rf(ctx, user)
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
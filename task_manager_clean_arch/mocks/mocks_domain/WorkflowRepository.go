@@ -0,0 +1,75 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks_domain
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// WorkflowRepository is an autogenerated mock type for the WorkflowRepository type
+type WorkflowRepository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: _a0
+func (_m *WorkflowRepository) Get(_a0 context.Context) (domain.Workflow, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 domain.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.Workflow, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.Workflow); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(domain.Workflow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: _a0, _a1
+func (_m *WorkflowRepository) Save(_a0 context.Context, _a1 *domain.Workflow) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Workflow) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWorkflowRepository creates a new instance of WorkflowRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWorkflowRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WorkflowRepository {
+	mock := &WorkflowRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 	domain "github.com/yiheyistm/task_manager/internal/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // IRefreshTokenUsecase is an autogenerated mock type for the IRefreshTokenUsecase type
@@ -17,6 +19,7 @@ type IRefreshTokenUsecase struct {
 // GenerateTokens provides a mock function with given fields: user
 func (_m *IRefreshTokenUsecase) GenerateTokens(user domain.User) (domain.RefreshToken, error) {
 	ret := _m.Called(user)
+
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateTokens")
 	}
@@ -71,6 +74,98 @@ func (_m *IRefreshTokenUsecase) GetByUsername(_a0 string) (*domain.User, error)
 	return r0, r1
 }
 
+// IsAccessTokenRevoked provides a mock function with given fields: jti, username, version
+func (_m *IRefreshTokenUsecase) IsAccessTokenRevoked(jti string, username string, version int) (bool, error) {
+	ret := _m.Called(jti, username, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAccessTokenRevoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) (bool, error)); ok {
+		return rf(jti, username, version)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) bool); ok {
+		r0 = rf(jti, username, version)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(jti, username, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: jti, familyID, expiresAt
+func (_m *IRefreshTokenUsecase) Logout(jti string, familyID string, expiresAt time.Time) error {
+	ret := _m.Called(jti, familyID, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) error); ok {
+		r0 = rf(jti, familyID, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogoutAll provides a mock function with given fields: username
+func (_m *IRefreshTokenUsecase) LogoutAll(username string) error {
+	ret := _m.Called(username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: refreshToken
+func (_m *IRefreshTokenUsecase) Refresh(refreshToken string) (domain.RefreshToken, error) {
+	ret := _m.Called(refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 domain.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (domain.RefreshToken, error)); ok {
+		return rf(refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(string) domain.RefreshToken); ok {
+		r0 = rf(refreshToken)
+	} else {
+		r0 = ret.Get(0).(domain.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateRefreshToken provides a mock function with given fields: token
 func (_m *IRefreshTokenUsecase) ValidateRefreshToken(token string) (jwt.MapClaims, error) {
 	ret := _m.Called(token)
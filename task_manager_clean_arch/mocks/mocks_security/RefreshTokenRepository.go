@@ -42,6 +42,34 @@ func (_m *RefreshTokenRepository) GenerateTokens(user domain.User) (domain.Refre
 	return r0, r1
 }
 
+// RotateTokens provides a mock function with given fields: user, familyID
+func (_m *RefreshTokenRepository) RotateTokens(user domain.User, familyID string) (domain.RefreshToken, error) {
+	ret := _m.Called(user, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateTokens")
+	}
+
+	var r0 domain.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.User, string) (domain.RefreshToken, error)); ok {
+		return rf(user, familyID)
+	}
+	if rf, ok := ret.Get(0).(func(domain.User, string) domain.RefreshToken); ok {
+		r0 = rf(user, familyID)
+	} else {
+		r0 = ret.Get(0).(domain.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.User, string) error); ok {
+		r1 = rf(user, familyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateRefreshToken provides a mock function with given fields: token
 func (_m *RefreshTokenRepository) ValidateRefreshToken(token string) (jwt.MapClaims, error) {
 	ret := _m.Called(token)
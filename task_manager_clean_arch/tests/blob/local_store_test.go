@@ -0,0 +1,87 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/blob"
+)
+
+// LocalStoreSuite defines the test suite for LocalStore
+type LocalStoreSuite struct {
+	suite.Suite
+	dir   string
+	store domain.BlobStore
+	ctx   context.Context
+}
+
+// SetupTest creates a store in a directory that does not exist yet
+func (s *LocalStoreSuite) SetupTest() {
+	s.dir = filepath.Join(s.T().TempDir(), "attachments")
+	s.store = blob.NewLocalStore(s.dir)
+	s.ctx = context.Background()
+}
+
+// TestLocalStoreSuite runs the test suite
+func TestLocalStoreSuite(t *testing.T) {
+	suite.Run(t, new(LocalStoreSuite))
+}
+
+func (s *LocalStoreSuite) read(key string) string {
+	content, err := s.store.Open(s.ctx, key)
+	s.Require().NoError(err)
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	s.Require().NoError(err)
+	return string(data)
+}
+
+// TestPutOpenDelete tests storing, reading, replacing and deleting a blob
+func (s *LocalStoreSuite) TestPutOpenDelete() {
+	size, err := s.store.Put(s.ctx, "6ad2c956dad4023b2650e81d", strings.NewReader("hello world"))
+	s.NoError(err)
+	s.Equal(int64(11), size)
+	s.Equal("hello world", s.read("6ad2c956dad4023b2650e81d"))
+
+	_, err = s.store.Put(s.ctx, "6ad2c956dad4023b2650e81d", strings.NewReader("bye"))
+	s.NoError(err)
+	s.Equal("bye", s.read("6ad2c956dad4023b2650e81d"))
+	entries, _ := os.ReadDir(s.dir)
+	s.Len(entries, 1, "temporary files are removed")
+
+	s.NoError(s.store.Delete(s.ctx, "6ad2c956dad4023b2650e81d"))
+	_, err = s.store.Open(s.ctx, "6ad2c956dad4023b2650e81d")
+	s.ErrorIs(err, domain.ErrNotFound)
+	s.NoError(s.store.Delete(s.ctx, "6ad2c956dad4023b2650e81d"), "deleting twice is not an error")
+}
+
+// TestInvalidKey tests that keys cannot leave the directory
+func (s *LocalStoreSuite) TestInvalidKey() {
+	for _, key := range []string{"", "../escape", "a/b", `a\\b`, ".hidden"} {
+		_, err := s.store.Put(s.ctx, key, strings.NewReader("x"))
+		s.ErrorIs(err, domain.ErrValidation, key)
+		_, err = s.store.Open(s.ctx, key)
+		s.ErrorIs(err, domain.ErrValidation, key)
+		s.ErrorIs(s.store.Delete(s.ctx, key), domain.ErrValidation, key)
+	}
+	_, err := os.Stat(filepath.Join(filepath.Dir(s.dir), "escape"))
+	s.True(os.IsNotExist(err))
+}
+
+// TestCancelledPut tests that a cancelled upload leaves nothing behind
+func (s *LocalStoreSuite) TestCancelledPut() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+
+	_, err := s.store.Put(ctx, "cancelled", strings.NewReader("hello"))
+
+	s.ErrorIs(err, context.Canceled)
+	entries, _ := os.ReadDir(s.dir)
+	s.Empty(entries)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// CommentSuite defines the test suite for comment bodies
+type CommentSuite struct {
+	suite.Suite
+}
+
+// TestCommentSuite runs the test suite
+func TestCommentSuite(t *testing.T) {
+	suite.Run(t, new(CommentSuite))
+}
+
+// TestMentionedUsernames tests which @mentions are found in a comment body
+func (s *CommentSuite) TestMentionedUsernames() {
+	cases := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"None", "Looks good to me", nil},
+		{"Start", "@abebe please review", []string{"abebe"}},
+		{"Several", "cc @abebe, @kebede_2 and (@almaz)", []string{"abebe", "kebede_2", "almaz"}},
+		{"Repeated", "@abebe @abebe", []string{"abebe"}},
+		{"EndOfSentence", "Thanks @abebe.", []string{"abebe"}},
+		{"Dotted", "ask @a.b-c first", []string{"a.b-c"}},
+		{"Email", "mail abebe@example.com", nil},
+		{"DoubleAt", "@@abebe", nil},
+		{"Markdown", "**@abebe** see `x`", []string{"abebe"}},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.want, domain.MentionedUsernames(tc.body))
+		})
+	}
+}
+
+// TestSanitizeMarkdown tests that raw HTML and unsafe links are removed
+func (s *CommentSuite) TestSanitizeMarkdown() {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"Plain", "**Done**, see _notes_\n\n- one\n- two", "**Done**, see _notes_\n\n- one\n- two"},
+		{"Script", "hi <script>alert(1)</script>", "hi alert(1)"},
+		{"Attributes", `<img/src=x onerror=alert(1)>text`, "text"},
+		{"NestedTag", "<scr<script>ipt>alert(1)</script>", "alert(1)"},
+		{"Comment", "a<!-- hidden -->b", "ab"},
+		{"Comparison", "1 < 2 and 3 > 2", "1 < 2 and 3 > 2"},
+		{"SafeLink", "[docs](https://example.com/a_(b))", "[docs](https://example.com/a_(b))"},
+		{"RelativeLink", "[task](/tasks/1)", "[task](/tasks/1)"},
+		{"Mailto", "[mail](mailto:abebe@example.com)", "[mail](mailto:abebe@example.com)"},
+		{"JavascriptLink", "[x](javascript:alert(1))", "[x](#)"},
+		{"JavascriptImage", "![x](data:image/png;base64,AAAA)", "![x](#)"},
+		{"AngleBrackets", "[x](<java\tscript:alert(1)>)", "[x]()"},
+		{"AngleBracketScheme", "[x](<JavaScript:alert(1)>)", "[x](#)"},
+		{"Entities", "[x]: jav&#x61;script:alert(1)", "[x]: #"},
+		{"Autolink", "<javascript:alert(1)> <https://example.com>", "# <https://example.com>"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.want, domain.SanitizeMarkdown(tc.body))
+		})
+	}
+}
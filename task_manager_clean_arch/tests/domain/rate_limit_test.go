@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// RateLimitSuite defines the test suite for the token bucket
+type RateLimitSuite struct {
+	suite.Suite
+	limit domain.RateLimit
+	now   time.Time
+}
+
+// SetupTest uses a bucket of 3 tokens that regains one every 10 seconds
+func (s *RateLimitSuite) SetupTest() {
+	s.limit = domain.RateLimit{Burst: 3, Every: 10 * time.Second}
+	s.now = time.Date(2030, 1, 2, 9, 30, 0, 0, time.UTC)
+}
+
+// TestRateLimitSuite runs the test suite
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitSuite))
+}
+
+// TestEnabled tests that a zero burst or interval turns the limit off
+func (s *RateLimitSuite) TestEnabled() {
+	s.True(s.limit.Enabled())
+	s.False(domain.RateLimit{Burst: 3}.Enabled())
+	s.False(domain.RateLimit{Every: time.Second}.Enabled())
+}
+
+// TestTake tests taking tokens and refilling the bucket
+func (s *RateLimitSuite) TestTake() {
+	s.Run("NewBucketIsFull", func() {
+		bucket, result := s.limit.Take(domain.TokenBucket{}, s.now)
+
+		s.True(result.Allowed)
+		s.Equal(2, result.Remaining)
+		s.Equal(10*time.Second, result.Reset)
+		s.Equal(domain.TokenBucket{Tokens: 2, At: s.now}, bucket)
+	})
+
+	s.Run("Empty", func() {
+		bucket := domain.TokenBucket{}
+		for range 3 {
+			bucket, _ = s.limit.Take(bucket, s.now)
+		}
+
+		bucket, result := s.limit.Take(bucket, s.now.Add(4*time.Second))
+
+		s.False(result.Allowed)
+		s.Equal(0, result.Remaining)
+		s.Equal(6*time.Second, result.RetryAfter)
+		s.Equal(26*time.Second, result.Reset)
+		s.InDelta(0.4, bucket.Tokens, 1e-9)
+	})
+
+	s.Run("Refills", func() {
+		bucket := domain.TokenBucket{Tokens: 0, At: s.now}
+
+		_, result := s.limit.Take(bucket, s.now.Add(25*time.Second))
+
+		s.True(result.Allowed)
+		s.Equal(1, result.Remaining)
+	})
+
+	s.Run("RefillsUpToBurst", func() {
+		bucket := domain.TokenBucket{Tokens: 1, At: s.now}
+
+		_, result := s.limit.Take(bucket, s.now.Add(time.Hour))
+
+		s.Equal(2, result.Remaining)
+	})
+
+	s.Run("ClockGoesBack", func() {
+		bucket := domain.TokenBucket{Tokens: 0.5, At: s.now}
+
+		_, result := s.limit.Take(bucket, s.now.Add(-time.Minute))
+
+		s.False(result.Allowed)
+		s.Equal(5*time.Second, result.RetryAfter)
+	})
+}
+
+// TestFullAt tests when a bucket is full again
+func (s *RateLimitSuite) TestFullAt() {
+	s.Equal(s.now.Add(25*time.Second), s.limit.FullAt(domain.TokenBucket{Tokens: 0.5, At: s.now}))
+	s.Equal(s.now, s.limit.FullAt(domain.TokenBucket{Tokens: 3, At: s.now}))
+}
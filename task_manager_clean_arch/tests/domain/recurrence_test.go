@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+)
+
+// RecurrenceSuite defines the test suite for recurrence rules
+type RecurrenceSuite struct {
+	suite.Suite
+}
+
+// TestRecurrenceSuite runs the test suite
+func TestRecurrenceSuite(t *testing.T) {
+	suite.Run(t, new(RecurrenceSuite))
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+// TestParseRRule tests the ParseRRule function and the canonical form of rules
+func (s *RecurrenceSuite) TestParseRRule() {
+	cases := []struct {
+		name string
+		rule string
+		want string
+	}{
+		{"Weekly", "FREQ=WEEKLY", "FREQ=WEEKLY"},
+		{"Prefix", "RRULE:freq=weekly;byday=mo,we", "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{"PartOrder", "COUNT=5;BYMONTHDAY=1,-1;INTERVAL=2;FREQ=MONTHLY", "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=1,-1;COUNT=5"},
+		{"DefaultInterval", "FREQ=DAILY;INTERVAL=1", "FREQ=DAILY"},
+		{"UntilDate", "FREQ=DAILY;UNTIL=20301231", "FREQ=DAILY;UNTIL=20301231T235959Z"},
+		{"NumberedDay", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			rule, err := domain.ParseRRule(tc.rule)
+
+			s.NoError(err)
+			s.Equal(tc.want, rule.String())
+		})
+	}
+
+	invalid := []struct {
+		name string
+		rule string
+	}{
+		{"Empty", ""},
+		{"NoFreq", "INTERVAL=2"},
+		{"UnknownFreq", "FREQ=HOURLY"},
+		{"Unsupported", "FREQ=MONTHLY;BYSETPOS=-1"},
+		{"Twice", "FREQ=DAILY;FREQ=WEEKLY"},
+		{"CountAndUntil", "FREQ=DAILY;COUNT=3;UNTIL=20301231"},
+		{"ZeroInterval", "FREQ=DAILY;INTERVAL=0"},
+		{"BadWeekday", "FREQ=WEEKLY;BYDAY=XX"},
+		{"NumberedWeeklyDay", "FREQ=WEEKLY;BYDAY=1MO"},
+		{"WeeklyMonthDay", "FREQ=WEEKLY;BYMONTHDAY=1"},
+		{"YearlyDayWithoutMonth", "FREQ=YEARLY;BYDAY=MO"},
+		{"BadUntil", "FREQ=DAILY;UNTIL=tomorrow"},
+	}
+	for _, tc := range invalid {
+		s.Run(tc.name, func() {
+			_, err := domain.ParseRRule(tc.rule)
+
+			s.ErrorIs(err, domain.ErrValidation)
+		})
+	}
+}
+
+// TestNext tests the occurrences computed by the Next method
+func (s *RecurrenceSuite) TestNext() {
+	cases := []struct {
+		name  string
+		rule  string
+		after time.Time
+		want  time.Time
+	}{
+		{"Daily", "FREQ=DAILY;INTERVAL=3", date(2030, 1, 30), date(2030, 2, 2)},
+		{"DailyOnWeekdays", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", date(2030, 1, 4), date(2030, 1, 7)},
+		{"Weekly", "FREQ=WEEKLY", date(2030, 1, 2), date(2030, 1, 9)},
+		{"WeeklySameWeek", "FREQ=WEEKLY;BYDAY=MO,TH", date(2030, 1, 7), date(2030, 1, 10)},
+		{"EveryOtherWeek", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", date(2030, 1, 10), date(2030, 1, 21)},
+		{"Monthly", "FREQ=MONTHLY", date(2030, 1, 15), date(2030, 2, 15)},
+		{"MonthlySkipsShortMonths", "FREQ=MONTHLY", date(2030, 1, 31), date(2030, 3, 31)},
+		{"LastDayOfMonth", "FREQ=MONTHLY;BYMONTHDAY=-1", date(2030, 1, 31), date(2030, 2, 28)},
+		{"LastFridayOfMonth", "FREQ=MONTHLY;BYDAY=-1FR", date(2030, 1, 25), date(2030, 2, 22)},
+		{"Quarterly", "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1", date(2030, 1, 1), date(2030, 4, 1)},
+		{"Yearly", "FREQ=YEARLY", date(2028, 2, 29), date(2032, 2, 29)},
+		{"Thanksgiving", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", date(2030, 11, 28), date(2031, 11, 27)},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			rule, err := domain.ParseRRule(tc.rule)
+			s.Require().NoError(err)
+
+			next, ok := rule.Next(tc.after)
+
+			s.True(ok)
+			s.Equal(tc.want, next)
+		})
+	}
+
+	s.Run("PastUntil", func() {
+		rule, err := domain.ParseRRule("FREQ=WEEKLY;UNTIL=20300110")
+		s.Require().NoError(err)
+
+		_, ok := rule.Next(date(2030, 1, 7))
+		s.False(ok)
+		next, ok := rule.Next(date(2030, 1, 2))
+		s.True(ok)
+		s.Equal(date(2030, 1, 9), next)
+	})
+
+	s.Run("NoMatchingDate", func() {
+		rule, err := domain.ParseRRule("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30")
+		s.Require().NoError(err)
+
+		_, ok := rule.Next(date(2030, 1, 1))
+
+		s.False(ok)
+	})
+}
@@ -84,6 +84,10 @@ func (s *TaskMapperSuite) TestdFromDomainTaskToResponse() {
 			DueDate:     time.Now().Add(24 * time.Hour),
 			Status:      "pending",
 			CreatedBy:   "Abebe",
+			Tags:        []string{},
+			Checklist:   []dto.ChecklistItemResponse{},
+			BlockedBy:   []string{},
+			Assignees:   []string{},
 		}
 
 		result := dto.FromDomainTaskToResponse(domainTask)
@@ -107,6 +111,10 @@ func (s *TaskMapperSuite) TestdFromDomainTaskToResponse() {
 			DueDate:     time.Time{},
 			Status:      "",
 			CreatedBy:   "",
+			Tags:        []string{},
+			Checklist:   []dto.ChecklistItemResponse{},
+			BlockedBy:   []string{},
+			Assignees:   []string{},
 		}
 
 		result := dto.FromDomainTaskToResponse(domainTask)
@@ -117,7 +125,11 @@ func (s *TaskMapperSuite) TestdFromDomainTaskToResponse() {
 	s.Run("NilInput", func() {
 		var domainTask domain.Task
 		expectedResponse := &dto.TaskResponse{
-			ID: "000000000000000000000000",
+			ID:        "000000000000000000000000",
+			Tags:      []string{},
+			Checklist: []dto.ChecklistItemResponse{},
+			BlockedBy: []string{},
+			Assignees: []string{},
 		}
 
 		result := dto.FromDomainTaskToResponse(&domainTask)
@@ -157,6 +169,10 @@ func (s *TaskMapperSuite) TestFromDomainTaskToResponseList() {
 				DueDate:     time.Now().Add(24 * time.Hour),
 				Status:      "pending",
 				CreatedBy:   "Abebe",
+				Tags:        []string{},
+				Checklist:   []dto.ChecklistItemResponse{},
+				BlockedBy:   []string{},
+				Assignees:   []string{},
 			},
 			{
 				ID:          taskID2.Hex(),
@@ -165,6 +181,10 @@ func (s *TaskMapperSuite) TestFromDomainTaskToResponseList() {
 				DueDate:     time.Now().Add(24 * time.Hour),
 				Status:      "completed",
 				CreatedBy:   "Kebede",
+				Tags:        []string{},
+				Checklist:   []dto.ChecklistItemResponse{},
+				BlockedBy:   []string{},
+				Assignees:   []string{},
 			},
 		}
 
@@ -191,3 +211,112 @@ func (s *TaskMapperSuite) TestFromDomainTaskToResponseList() {
 		s.Equal(expectedResponses, result)
 	})
 }
+
+// TestToDomainTaskUpdate tests ApplyTaskPatch together with ToDomainTaskUpdate
+func (s *TaskMapperSuite) TestToDomainTaskUpdate() {
+	dueDate := time.Date(2025, 7, 30, 17, 0, 0, 0, time.UTC)
+	task := &domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: dueDate}
+
+	s.Run("ChangedFieldsOnly", func() {
+		patched, err := dto.ApplyTaskPatch(task, []byte(`{"status":"completed","due_date":"2025-08-01T09:00:00Z","created_by":"kebede"}`), "application/merge-patch+json")
+		s.NoError(err)
+
+		update := patched.ToDomainTaskUpdate(task)
+
+		s.Nil(update.Title)
+		s.Nil(update.Description)
+		s.Equal("completed", *update.Status)
+		s.True(update.DueDate.Equal(time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)))
+	})
+
+	s.Run("SameDueDateInOtherZone", func() {
+		patched, err := dto.ApplyTaskPatch(task, []byte(`{"due_date":"2025-07-30T20:00:00+03:00"}`), "application/merge-patch+json")
+		s.NoError(err)
+
+		s.True(patched.ToDomainTaskUpdate(task).IsEmpty())
+	})
+
+	s.Run("TickChecklistItem", func() {
+		task := *task
+		task.Checklist = []domain.ChecklistItem{{Text: "Grind beans"}, {Text: "Boil water"}}
+		patched, err := dto.ApplyTaskPatch(&task, []byte(`[{"op":"replace","path":"/checklist/1/done","value":true}]`), "application/json-patch+json")
+		s.NoError(err)
+
+		update := patched.ToDomainTaskUpdate(&task)
+
+		s.Require().NotNil(update.Checklist)
+		s.Equal([]domain.ChecklistItem{{Text: "Grind beans"}, {Text: "Boil water", Done: true}}, *update.Checklist)
+		s.False(update.OnlyStatus())
+	})
+
+	s.Run("RemoveRRule", func() {
+		task := *task
+		task.Recurrence = &domain.Recurrence{Rule: "FREQ=WEEKLY", Occurrence: 1}
+		patched, err := dto.ApplyTaskPatch(&task, []byte(`{"rrule":null}`), "application/merge-patch+json")
+		s.NoError(err)
+
+		update := patched.ToDomainTaskUpdate(&task)
+
+		s.Equal(&domain.Recurrence{}, update.Recurrence)
+	})
+
+	s.Run("WrongType", func() {
+		_, err := dto.ApplyTaskPatch(task, []byte(`{"title":42}`), "application/merge-patch+json")
+
+		s.Error(err)
+	})
+}
+
+// TestCompletion tests the completion percentage of task responses
+func (s *TaskMapperSuite) TestCompletion() {
+	s.Run("SubtasksAndChecklist", func() {
+		task := &domain.Task{
+			Checklist: []domain.ChecklistItem{{Text: "Grind beans", Done: true}, {Text: "Boil water"}},
+			Subtasks:  domain.SubtaskCount{Total: 4, Done: 3},
+		}
+
+		response := dto.FromDomainTaskToResponse(task)
+
+		s.Require().NotNil(response.Completion)
+		s.Equal(66, *response.Completion)
+		s.Equal(dto.SubtaskCountResponse{Total: 4, Done: 3}, response.Subtasks)
+	})
+
+	s.Run("NothingToComplete", func() {
+		response := dto.FromDomainTaskToResponse(&domain.Task{Title: "Buy Coffee"})
+
+		s.Nil(response.Completion)
+	})
+}
+
+// TestRecurrence tests how the recurrence of a task is mapped
+func (s *TaskMapperSuite) TestRecurrence() {
+	s.Run("Request", func() {
+		request := dto.TaskRequest{Title: "Water plants", RRule: "FREQ=WEEKLY"}
+
+		task := request.FromRequestToDomainTask()
+
+		s.Equal(&domain.Recurrence{Rule: "FREQ=WEEKLY"}, task.Recurrence)
+	})
+
+	s.Run("FirstOccurrence", func() {
+		task := &domain.Task{ID: primitive.NewObjectID(), Recurrence: &domain.Recurrence{Rule: "FREQ=WEEKLY", Occurrence: 1}}
+
+		response := dto.FromDomainTaskToResponse(task)
+
+		s.Equal(&dto.RecurrenceResponse{RRule: "FREQ=WEEKLY", SeriesID: task.ID.Hex(), Occurrence: 1}, response.Recurrence)
+	})
+
+	s.Run("LaterOccurrence", func() {
+		seriesID, nextID := primitive.NewObjectID(), primitive.NewObjectID()
+		task := &domain.Task{ID: primitive.NewObjectID(), Recurrence: &domain.Recurrence{Rule: "FREQ=DAILY", SeriesID: seriesID, Occurrence: 3, NextID: nextID}}
+
+		response := dto.FromDomainTaskToResponse(task)
+
+		s.Equal(&dto.RecurrenceResponse{RRule: "FREQ=DAILY", SeriesID: seriesID.Hex(), Occurrence: 3, NextID: nextID.Hex()}, response.Recurrence)
+	})
+
+	s.Run("NotRecurring", func() {
+		s.Nil(dto.FromDomainTaskToResponse(&domain.Task{Title: "Buy Coffee"}).Recurrence)
+	})
+}
@@ -0,0 +1,172 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/export"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskWriterSuite defines the test suite for the export writers
+type TaskWriterSuite struct {
+	suite.Suite
+	workflow domain.Workflow
+	tasks    []domain.Task
+}
+
+// SetupTest builds the tasks written by every test
+func (s *TaskWriterSuite) SetupTest() {
+	s.workflow = domain.Workflow{Statuses: []string{"todo", "doing", "done"}}
+	s.tasks = []domain.Task{
+		{
+			ID:          primitive.NewObjectID(),
+			Title:       "Buy Coffee, beans; not ground",
+			Description: "From Merkato\nAsk for Yirgacheffe",
+			DueDate:     time.Date(2030, 1, 2, 9, 30, 0, 0, time.UTC),
+			Status:      "todo",
+			Priority:    domain.PriorityUrgent,
+			Tags:        []string{"home", "errands"},
+			Assignees:   []string{"kebede"},
+			CreatedBy:   "abebe",
+			Version:     2,
+		},
+		{ID: primitive.NewObjectID(), Title: "Roast Coffee", Status: "doing", Priority: domain.PriorityLow, CreatedBy: "abebe", Version: 1},
+		{ID: primitive.NewObjectID(), Title: "=HYPERLINK(\"http://example.com\")", Status: "done", CreatedBy: "abebe", Version: 1},
+	}
+}
+
+// TestTaskWriterSuite runs the test suite
+func TestTaskWriterSuite(t *testing.T) {
+	suite.Run(t, new(TaskWriterSuite))
+}
+
+func (s *TaskWriterSuite) export(format string, tasks []domain.Task) string {
+	var out bytes.Buffer
+	writer, err := export.NewTaskWriter(format, &out, s.workflow)
+	s.Require().NoError(err)
+	for i := range tasks {
+		s.Require().NoError(writer.Write(&tasks[i]))
+	}
+	s.Require().NoError(writer.Close())
+	return out.String()
+}
+
+// TestUnknownFormat tests that only the supported formats are accepted
+func (s *TaskWriterSuite) TestUnknownFormat() {
+	_, err := export.NewTaskWriter("xml", &bytes.Buffer{}, s.workflow)
+
+	s.True(errors.Is(err, domain.ErrValidation))
+}
+
+// TestCSV tests that every task is a row after the header
+func (s *TaskWriterSuite) TestCSV() {
+	s.Run("Success", func() {
+		records, err := csv.NewReader(strings.NewReader(s.export(export.FormatCSV, s.tasks))).ReadAll()
+
+		s.Require().NoError(err)
+		s.Require().Len(records, 4)
+		s.Equal([]string{"id", "title", "description", "status", "priority", "due_date", "tags", "assignees", "created_by", "project_id", "parent_id", "blocked", "overdue", "version"}, records[0])
+		s.Equal([]string{
+			s.tasks[0].ID.Hex(), "Buy Coffee, beans; not ground", "From Merkato\nAsk for Yirgacheffe", "todo", "urgent",
+			"2030-01-02T09:30:00Z", "home;errands", "kebede", "abebe", "", "", "false", "false", "2",
+		}, records[1])
+		s.Equal("", records[2][5])
+	})
+
+	s.Run("EscapesFormulas", func() {
+		records, err := csv.NewReader(strings.NewReader(s.export(export.FormatCSV, s.tasks))).ReadAll()
+
+		s.Require().NoError(err)
+		s.Equal(`'=HYPERLINK("http://example.com")`, records[3][1])
+	})
+
+	s.Run("EscapesEveryTextColumn", func() {
+		task := domain.Task{
+			ID:        primitive.NewObjectID(),
+			Title:     "Brew",
+			Status:    "=cmd",
+			Assignees: []string{"@evil", "kebede"},
+			CreatedBy: "+abebe",
+			Version:   1,
+		}
+		records, err := csv.NewReader(strings.NewReader(s.export(export.FormatCSV, []domain.Task{task}))).ReadAll()
+
+		s.Require().NoError(err)
+		s.Equal("'=cmd", records[1][3])
+		s.Equal("'@evil;kebede", records[1][7])
+		s.Equal("'+abebe", records[1][8])
+	})
+
+	s.Run("Empty", func() {
+		s.Equal("id,title,description,status,priority,due_date,tags,assignees,created_by,project_id,parent_id,blocked,overdue,version\n", s.export(export.FormatCSV, nil))
+	})
+}
+
+// TestJSON tests that the tasks are an array of task responses
+func (s *TaskWriterSuite) TestJSON() {
+	s.Run("Success", func() {
+		var tasks []map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(s.export(export.FormatJSON, s.tasks)), &tasks))
+
+		s.Require().Len(tasks, 3)
+		s.Equal(s.tasks[0].ID.Hex(), tasks[0]["id"])
+		s.Equal("2030-01-02T09:30:00Z", tasks[0]["due_date"])
+		s.Equal([]any{"home", "errands"}, tasks[0]["tags"])
+	})
+
+	s.Run("Empty", func() {
+		s.JSONEq("[]", s.export(export.FormatJSON, nil))
+	})
+}
+
+// TestICS tests that every task is a to-do of the calendar
+func (s *TaskWriterSuite) TestICS() {
+	s.Run("Success", func() {
+		calendar := s.export(export.FormatICS, s.tasks)
+
+		s.True(strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		s.True(strings.HasSuffix(calendar, "END:VCALENDAR\r\n"))
+		s.Equal(3, strings.Count(calendar, "BEGIN:VTODO\r\n"))
+		s.Contains(calendar, "UID:"+s.tasks[0].ID.Hex()+"@task-manager\r\n")
+		s.Contains(calendar, "SUMMARY:Buy Coffee\\, beans\\; not ground\r\n")
+		s.Contains(calendar, "DESCRIPTION:From Merkato\\nAsk for Yirgacheffe\r\n")
+		s.Contains(calendar, "DUE:20300102T093000Z\r\n")
+		s.Contains(calendar, "PRIORITY:1\r\n")
+		s.Contains(calendar, "CATEGORIES:home,errands\r\n")
+		s.Equal(1, strings.Count(calendar, "DUE:"))
+	})
+
+	s.Run("MapsStatuses", func() {
+		calendar := s.export(export.FormatICS, s.tasks)
+
+		needsAction := strings.Index(calendar, "STATUS:NEEDS-ACTION")
+		inProcess := strings.Index(calendar, "STATUS:IN-PROCESS")
+		completed := strings.Index(calendar, "STATUS:COMPLETED")
+		s.True(needsAction >= 0 && needsAction < inProcess && inProcess < completed)
+	})
+
+	s.Run("FoldsLongLines", func() {
+		task := domain.Task{ID: primitive.NewObjectID(), Title: strings.Repeat("é", 100), Status: "todo"}
+
+		calendar := s.export(export.FormatICS, []domain.Task{task})
+
+		var summary strings.Builder
+		for _, line := range strings.Split(calendar, "\r\n") {
+			s.LessOrEqual(len(line), 75)
+			if strings.HasPrefix(line, "SUMMARY:") {
+				summary.WriteString(line)
+			} else if summary.Len() > 0 && strings.HasPrefix(line, " ") {
+				summary.WriteString(line[1:])
+			}
+		}
+		s.Equal("SUMMARY:"+task.Title, summary.String())
+	})
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AttachmentHandlerSuite defines the test suite for AttachmentHandler
+type AttachmentHandlerSuite struct {
+	suite.Suite
+	mockAttachmentUsecase *mocks_domain.IAttachmentUseCase
+	mockUserUsecase       *mocks_domain.IUserUseCase
+	handler               *handler.AttachmentHandler
+	attachment            domain.Attachment
+	params                gin.Params
+}
+
+// SetupTest initializes the mocks and handler before each test. Files of up
+// to 1 KiB are accepted.
+func (s *AttachmentHandlerSuite) SetupTest() {
+	s.mockAttachmentUsecase = mocks_domain.NewIAttachmentUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.AttachmentHandler{
+		AttachmentUsecase: s.mockAttachmentUsecase,
+		UserUsecase:       s.mockUserUsecase,
+		MaxUploadSize:     1 << 10,
+	}
+	s.attachment = domain.Attachment{
+		ID:          primitive.NewObjectID(),
+		TaskID:      primitive.NewObjectID(),
+		Filename:    "résumé notes.txt",
+		ContentType: "text/plain; charset=utf-8",
+		Size:        11,
+		UploadedBy:  "abebe",
+		CreatedAt:   time.Now(),
+	}
+	s.params = gin.Params{
+		{Key: "username", Value: "abebe"},
+		{Key: "id", Value: s.attachment.TaskID.Hex()},
+		{Key: "attachmentId", Value: s.attachment.ID.Hex()},
+	}
+	s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"}).Maybe()
+}
+
+// TestAttachmentHandlerSuite runs the test suite
+func TestAttachmentHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentHandlerSuite))
+}
+
+func (s *AttachmentHandlerSuite) request(method string, body io.Reader, contentType string) (*gin.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/users/abebe/tasks/1/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = s.params
+	return c, w
+}
+
+// upload builds a multipart request sending the content as the file field.
+func (s *AttachmentHandlerSuite) upload(field, filename, content string) (*gin.Context, *httptest.ResponseRecorder) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile(field, filename)
+	part.Write([]byte(content))
+	form.Close()
+	return s.request(http.MethodPost, &body, form.FormDataContentType())
+}
+
+// TestUploadTaskAttachment tests the UploadTaskAttachment method
+func (s *AttachmentHandlerSuite) TestUploadTaskAttachment() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockAttachmentUsecase.On("CreateByTaskAndUser", s.attachment.TaskID.Hex(), mock.MatchedBy(func(a *domain.Attachment) bool {
+			return a.Filename == "notes.txt" && a.Size == 11
+		}), mock.Anything, "abebe").Run(func(args mock.Arguments) {
+			content, _ := io.ReadAll(args.Get(2).(io.Reader))
+			s.Equal("hello world", string(content))
+			*args.Get(1).(*domain.Attachment) = s.attachment
+		}).Return(nil)
+		c, w := s.upload("file", "notes.txt", "hello world")
+
+		serve(c, s.handler.UploadTaskAttachment)
+
+		s.Equal(http.StatusCreated, w.Code)
+		var response dto.AttachmentResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(s.attachment.ID.Hex(), response.ID)
+		s.Equal(int64(11), response.Size)
+	})
+
+	s.Run("MissingFile", func() {
+		s.SetupTest()
+		c, w := s.upload("document", "notes.txt", "hello world")
+
+		serve(c, s.handler.UploadTaskAttachment)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	s.Run("NotMultipart", func() {
+		s.SetupTest()
+		c, w := s.request(http.MethodPost, strings.NewReader(`{"file":"x"}`), "application/json")
+
+		serve(c, s.handler.UploadTaskAttachment)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	s.Run("BodyTooLarge", func() {
+		s.SetupTest()
+		c, w := s.upload("file", "big.txt", strings.Repeat("a", 2<<20))
+
+		serve(c, s.handler.UploadTaskAttachment)
+
+		s.Equal(http.StatusRequestEntityTooLarge, w.Code)
+		s.mockAttachmentUsecase.AssertNotCalled(s.T(), "CreateByTaskAndUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("OtherUser", func() {
+		s.SetupTest()
+		s.params[0].Value = "kebede"
+		c, w := s.upload("file", "notes.txt", "hello world")
+
+		serve(c, s.handler.UploadTaskAttachment)
+
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+}
+
+// TestDownloadTaskAttachment tests that the content is sent as a download
+func (s *AttachmentHandlerSuite) TestDownloadTaskAttachment() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockAttachmentUsecase.On("OpenByIdAndUser", s.attachment.TaskID.Hex(), s.attachment.ID.Hex(), "abebe").
+			Return(s.attachment, io.NopCloser(strings.NewReader("hello world")), nil)
+		c, w := s.request(http.MethodGet, nil, "")
+
+		serve(c, s.handler.DownloadTaskAttachment)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("hello world", w.Body.String())
+		s.Equal("text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		s.Equal("11", w.Header().Get("Content-Length"))
+		s.Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+		s.Equal(`attachment; filename*=utf-8''r%C3%A9sum%C3%A9%20notes.txt`, w.Header().Get("Content-Disposition"))
+	})
+
+	s.Run("NotFound", func() {
+		s.SetupTest()
+		s.mockAttachmentUsecase.On("OpenByIdAndUser", mock.Anything, mock.Anything, "abebe").
+			Return(domain.Attachment{}, nil, domain.NewError(domain.ErrNotFound, "attachment not found"))
+		c, w := s.request(http.MethodGet, nil, "")
+
+		serve(c, s.handler.DownloadTaskAttachment)
+
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+// TestGetTaskAttachments tests the GetTaskAttachments method
+func (s *AttachmentHandlerSuite) TestGetTaskAttachments() {
+	s.SetupTest()
+	s.mockAttachmentUsecase.On("GetByTaskAndUser", s.attachment.TaskID.Hex(), "abebe").Return([]domain.Attachment{s.attachment}, nil)
+	c, w := s.request(http.MethodGet, nil, "")
+
+	serve(c, s.handler.GetTaskAttachments)
+
+	s.Equal(http.StatusOK, w.Code)
+	var response struct {
+		Attachments []dto.AttachmentResponse `json:"attachments"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	s.Require().Len(response.Attachments, 1)
+	s.Equal(s.attachment.Filename, response.Attachments[0].Filename)
+}
+
+// TestDeleteTaskAttachment tests the DeleteTaskAttachment method
+func (s *AttachmentHandlerSuite) TestDeleteTaskAttachment() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockAttachmentUsecase.On("DeleteByIdAndUser", s.attachment.TaskID.Hex(), s.attachment.ID.Hex(), "abebe").Return(nil)
+		c, w := s.request(http.MethodDelete, nil, "")
+
+		serve(c, s.handler.DeleteTaskAttachment)
+
+		s.Equal(http.StatusNoContent, w.Code)
+	})
+
+	s.Run("Forbidden", func() {
+		s.SetupTest()
+		s.mockAttachmentUsecase.On("DeleteByIdAndUser", mock.Anything, mock.Anything, "abebe").
+			Return(domain.NewError(domain.ErrForbidden, "only the uploader or the task creator can delete an attachment"))
+		c, w := s.request(http.MethodDelete, nil, "")
+
+		serve(c, s.handler.DeleteTaskAttachment)
+
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CommentHandlerSuite defines the test suite for CommentHandler
+type CommentHandlerSuite struct {
+	suite.Suite
+	mockCommentUsecase *mocks_domain.ICommentUseCase
+	mockUserUsecase    *mocks_domain.IUserUseCase
+	handler            *handler.CommentHandler
+	comment            domain.Comment
+	params             gin.Params
+}
+
+// SetupTest initializes the mocks and handler before each test
+func (s *CommentHandlerSuite) SetupTest() {
+	s.mockCommentUsecase = mocks_domain.NewICommentUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.CommentHandler{
+		CommentUsecase: s.mockCommentUsecase,
+		UserUsecase:    s.mockUserUsecase,
+	}
+	s.comment = domain.Comment{
+		ID:        primitive.NewObjectID(),
+		TaskID:    primitive.NewObjectID(),
+		Author:    "abebe",
+		Body:      "Ask @kebede",
+		Mentions:  []string{"kebede"},
+		CreatedAt: time.Now(),
+	}
+	s.params = gin.Params{
+		{Key: "username", Value: "abebe"},
+		{Key: "id", Value: s.comment.TaskID.Hex()},
+		{Key: "commentId", Value: s.comment.ID.Hex()},
+	}
+	s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"}).Maybe()
+}
+
+// TestCommentHandlerSuite runs the test suite
+func TestCommentHandlerSuite(t *testing.T) {
+	suite.Run(t, new(CommentHandlerSuite))
+}
+
+func (s *CommentHandlerSuite) request(method, body string, params gin.Params) (*gin.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/users/abebe/tasks/1/comments", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = params
+	return c, w
+}
+
+// TestCreateTaskComment tests the CreateTaskComment method
+func (s *CommentHandlerSuite) TestCreateTaskComment() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockCommentUsecase.On("CreateByTaskAndUser", s.comment.TaskID.Hex(), mock.MatchedBy(func(c *domain.Comment) bool {
+			return c.Body == "Ask @kebede"
+		}), "abebe").Run(func(args mock.Arguments) {
+			*args.Get(1).(*domain.Comment) = s.comment
+		}).Return(nil)
+		c, w := s.request(http.MethodPost, `{"body":"Ask @kebede"}`, s.params)
+
+		serve(c, s.handler.CreateTaskComment)
+
+		s.Equal(http.StatusCreated, w.Code)
+		var response dto.CommentResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(s.comment.ID.Hex(), response.ID)
+		s.Equal([]string{"kebede"}, response.Mentions)
+		s.Nil(response.UpdatedAt)
+	})
+
+	s.Run("OtherUser", func() {
+		s.SetupTest()
+		params := gin.Params{{Key: "username", Value: "kebede"}, {Key: "id", Value: s.comment.TaskID.Hex()}}
+		c, w := s.request(http.MethodPost, `{"body":"hi"}`, params)
+
+		serve(c, s.handler.CreateTaskComment)
+
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+
+	cases := []struct {
+		name string
+		body string
+	}{
+		{"MissingBody", `{}`},
+		{"TooLong", `{"body":"` + strings.Repeat("a", domain.MaxCommentLength+1) + `"}`},
+		{"Malformed", `{"body":`},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			c, w := s.request(http.MethodPost, tc.body, s.params)
+
+			serve(c, s.handler.CreateTaskComment)
+
+			s.Equal(http.StatusBadRequest, w.Code)
+			s.mockCommentUsecase.AssertNotCalled(s.T(), "CreateByTaskAndUser", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// TestUpdateTaskComment tests the UpdateTaskComment method
+func (s *CommentHandlerSuite) TestUpdateTaskComment() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		edited := s.comment
+		edited.Body = "Edited"
+		edited.UpdatedAt = time.Now()
+		s.mockCommentUsecase.On("UpdateByIdAndUser", s.comment.TaskID.Hex(), s.comment.ID.Hex(), "Edited", "abebe").Return(edited, nil)
+		c, w := s.request(http.MethodPut, `{"body":"Edited"}`, s.params)
+
+		serve(c, s.handler.UpdateTaskComment)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.CommentResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("Edited", response.Body)
+		s.NotNil(response.UpdatedAt)
+	})
+
+	s.Run("NotAuthor", func() {
+		s.SetupTest()
+		s.mockCommentUsecase.On("UpdateByIdAndUser", mock.Anything, mock.Anything, "Edited", "abebe").Return(domain.Comment{}, domain.NewError(domain.ErrForbidden, "only the author can edit a comment"))
+		c, w := s.request(http.MethodPut, `{"body":"Edited"}`, s.params)
+
+		serve(c, s.handler.UpdateTaskComment)
+
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+}
+
+// TestDeleteTaskComment tests the DeleteTaskComment method
+func (s *CommentHandlerSuite) TestDeleteTaskComment() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockCommentUsecase.On("DeleteByIdAndUser", s.comment.TaskID.Hex(), s.comment.ID.Hex(), "abebe").Return(nil)
+		c, w := s.request(http.MethodDelete, "", s.params)
+
+		serve(c, s.handler.DeleteTaskComment)
+
+		s.Equal(http.StatusNoContent, w.Code)
+	})
+
+	s.Run("NotFound", func() {
+		s.SetupTest()
+		s.mockCommentUsecase.On("DeleteByIdAndUser", mock.Anything, mock.Anything, "abebe").Return(domain.NewError(domain.ErrNotFound, "comment not found"))
+		c, w := s.request(http.MethodDelete, "", s.params)
+
+		serve(c, s.handler.DeleteTaskComment)
+
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+// TestGetTaskActivity tests that status changes carry their old and new
+// status, even when the old one is empty
+func (s *CommentHandlerSuite) TestGetTaskActivity() {
+	s.SetupTest()
+	s.mockCommentUsecase.On("GetActivityByTaskAndUser", s.comment.TaskID.Hex(), "abebe").Return([]domain.Activity{
+		{Kind: domain.ActivityComment, At: s.comment.CreatedAt, Actor: "abebe", Comment: &s.comment},
+		{Kind: domain.ActivityStatusChange, At: s.comment.CreatedAt.Add(-time.Hour), Actor: "abebe", To: "pending"},
+	}, nil)
+	c, w := s.request(http.MethodGet, "", s.params)
+
+	serve(c, s.handler.GetTaskActivity)
+
+	s.Equal(http.StatusOK, w.Code)
+	var response struct {
+		Activity []dto.ActivityResponse `json:"activity"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	s.Require().Len(response.Activity, 2)
+	s.Equal(s.comment.ID.Hex(), response.Activity[0].Comment.ID)
+	s.Nil(response.Activity[0].From)
+	s.Nil(response.Activity[1].Comment)
+	s.Require().NotNil(response.Activity[1].From)
+	s.Equal("", *response.Activity[1].From)
+	s.Equal("pending", *response.Activity[1].To)
+}
+
+// TestGetTaskComments tests the GetTaskComments method
+func (s *CommentHandlerSuite) TestGetTaskComments() {
+	s.SetupTest()
+	s.mockCommentUsecase.On("GetByTaskAndUser", s.comment.TaskID.Hex(), "abebe").Return(nil, nil)
+	c, w := s.request(http.MethodGet, "", s.params)
+
+	serve(c, s.handler.GetTaskComments)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"comments":[]}`, w.Body.String())
+}
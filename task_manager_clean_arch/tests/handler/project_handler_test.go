@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProjectHandlerSuite defines the test suite for ProjectHandler
+type ProjectHandlerSuite struct {
+	suite.Suite
+	mockProjectUsecase *mocks_domain.IProjectUseCase
+	mockUserUsecase    *mocks_domain.IUserUseCase
+	handler            *handler.ProjectHandler
+	user               *domain.User
+}
+
+// SetupTest initializes the mocks and handler before each test
+func (s *ProjectHandlerSuite) SetupTest() {
+	s.mockProjectUsecase = mocks_domain.NewIProjectUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.ProjectHandler{
+		ProjectUsecase: s.mockProjectUsecase,
+		UserUsecase:    s.mockUserUsecase,
+	}
+	s.user = &domain.User{Username: "abebe"}
+}
+
+// TestProjectHandlerSuite runs the test suite
+func TestProjectHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ProjectHandlerSuite))
+}
+
+func (s *ProjectHandlerSuite) request(method, target, body string, params gin.Params) (*gin.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = params
+	return c, w
+}
+
+// TestCreateProject tests the CreateProject method
+func (s *ProjectHandlerSuite) TestCreateProject() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		s.mockProjectUsecase.On("Create", mock.MatchedBy(func(p *domain.Project) bool {
+			return p.Name == "Merkato" && p.CreatedBy == "abebe"
+		})).Run(func(args mock.Arguments) {
+			project := args.Get(0).(*domain.Project)
+			project.ID = primitive.NewObjectID()
+			project.Members = []domain.ProjectMember{{Username: "abebe", Role: domain.ProjectOwner}}
+		}).Return(nil)
+		c, w := s.request(http.MethodPost, "/projects", `{"name":"Merkato"}`, nil)
+
+		serve(c, s.handler.CreateProject)
+
+		s.Equal(http.StatusCreated, w.Code)
+		var response dto.ProjectResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("Merkato", response.Name)
+		s.Equal([]dto.ProjectMemberResponse{{Username: "abebe", Role: "owner"}}, response.Members)
+	})
+
+	s.Run("MissingName", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		c, w := s.request(http.MethodPost, "/projects", `{}`, nil)
+
+		serve(c, s.handler.CreateProject)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+// TestGetProject tests the GetProject method
+func (s *ProjectHandlerSuite) TestGetProject() {
+	s.Run("NotMember", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		s.mockProjectUsecase.On("GetByIdAndUser", "1", "abebe").Return(domain.Project{}, domain.NewError(domain.ErrNotFound, "project not found"))
+		c, w := s.request(http.MethodGet, "/projects/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		serve(c, s.handler.GetProject)
+
+		s.Equal(http.StatusNotFound, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("project not found", response["detail"])
+	})
+}
+
+// TestSetProjectMember tests the SetProjectMember method
+func (s *ProjectHandlerSuite) TestSetProjectMember() {
+	params := gin.Params{{Key: "id", Value: "1"}, {Key: "username", Value: "Kebede"}}
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		project := domain.Project{Name: "Merkato", Members: []domain.ProjectMember{
+			{Username: "abebe", Role: domain.ProjectOwner}, {Username: "kebede", Role: domain.ProjectEditor},
+		}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		s.mockUserUsecase.On("GetByUsername", "kebede").Return(&domain.User{Username: "kebede"}, nil)
+		s.mockProjectUsecase.On("SetMember", "1", domain.ProjectMember{Username: "kebede", Role: domain.ProjectEditor}, "abebe").Return(project, nil)
+		c, w := s.request(http.MethodPut, "/projects/1/members/Kebede", `{"role":"editor"}`, params)
+
+		serve(c, s.handler.SetProjectMember)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.ProjectResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Len(response.Members, 2)
+	})
+
+	s.Run("InvalidRole", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		c, w := s.request(http.MethodPut, "/projects/1/members/Kebede", `{"role":"admin"}`, params)
+
+		serve(c, s.handler.SetProjectMember)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	s.Run("UnknownUser", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		s.mockUserUsecase.On("GetByUsername", "kebede").Return(nil, domain.NewError(domain.ErrNotFound, "user not found"))
+		c, w := s.request(http.MethodPut, "/projects/1/members/Kebede", `{"role":"viewer"}`, params)
+
+		serve(c, s.handler.SetProjectMember)
+
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+// TestGetProjectTasks tests the GetProjectTasks method
+func (s *ProjectHandlerSuite) TestGetProjectTasks() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		projectID := primitive.NewObjectID()
+		tasks := []domain.Task{{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "kebede", ProjectID: projectID}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		s.mockProjectUsecase.On("ListTasks", projectID.Hex(), domain.TaskQuery{Status: "pending"}, "abebe").
+			Return(domain.TaskPage{Tasks: tasks, Total: 1, Page: 1, Limit: 20}, nil)
+		c, w := s.request(http.MethodGet, "/projects/"+projectID.Hex()+"/tasks?status=pending", "", gin.Params{{Key: "id", Value: projectID.Hex()}})
+
+		serve(c, s.handler.GetProjectTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.TaskPageResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Len(response.Tasks, 1)
+		s.Equal(projectID.Hex(), response.Tasks[0].ProjectID)
+	})
+}
+
+// TestCreateProjectTask tests the CreateProjectTask method
+func (s *ProjectHandlerSuite) TestCreateProjectTask() {
+	s.Run("Viewer", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		s.mockProjectUsecase.On("CreateTask", "1", mock.Anything, "abebe").
+			Return(domain.NewError(domain.ErrForbidden, "viewers cannot change project tasks"))
+		body := `{"title":"Buy Coffee","due_date":"2030-01-01T00:00:00Z","status":"pending"}`
+		c, w := s.request(http.MethodPost, "/projects/1/tasks", body, gin.Params{{Key: "id", Value: "1"}})
+
+		serve(c, s.handler.CreateProjectTask)
+
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+}
+
+// TestPatchProjectTask tests the PatchProjectTask method
+func (s *ProjectHandlerSuite) TestPatchProjectTask() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		status := "completed"
+		dueDate := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", CreatedBy: "kebede", DueDate: dueDate, Version: 2}
+		patched := task
+		patched.Status = status
+		patched.Version = 3
+		params := gin.Params{{Key: "id", Value: "1"}, {Key: "taskId", Value: task.ID.Hex()}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		s.mockProjectUsecase.On("GetTask", "1", task.ID.Hex(), "abebe").Return(task, nil)
+		s.mockProjectUsecase.On("PatchTask", "1", task.ID.Hex(), domain.TaskUpdate{Status: &status, Version: 2}, "abebe").Return(patched, nil)
+		c, w := s.request(http.MethodPatch, "/projects/1/tasks/"+task.ID.Hex(), `{"status":"completed"}`, params)
+		c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+		c.Request.Header.Set("If-Match", `"2"`)
+
+		serve(c, s.handler.PatchProjectTask)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal(`"3"`, w.Header().Get("ETag"))
+	})
+}
+
+// TestDeleteProject tests the DeleteProject method
+func (s *ProjectHandlerSuite) TestDeleteProject() {
+	s.Run("HasTasks", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(s.user)
+		s.mockProjectUsecase.On("DeleteByIdAndUser", "1", "abebe").Return(domain.NewError(domain.ErrConflict, "project still has tasks"))
+		c, w := s.request(http.MethodDelete, "/projects/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		serve(c, s.handler.DeleteProject)
+
+		s.Equal(http.StatusConflict, w.Code)
+	})
+}
@@ -10,7 +10,6 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/suite"
 	"github.com/yiheyistm/task_manager/internal/domain"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
@@ -42,21 +41,10 @@ func TestRefreshTokenHandlerSuite(t *testing.T) {
 func (s *RefreshTokenHandlerSuite) TestRefreshToken() {
 	s.Run("Success", func() {
 		refreshTokenRequest := dto.RefreshTokenRequest{RefreshToken: "valid_refresh_token"}
-		claims := jwt.MapClaims{"username": "abebe"}
-		user := &domain.User{ID: "1", Username: "abebe", Email: "abebe@example.com", Role: "user"}
 		tokens := domain.RefreshToken{AccessToken: "new_access_token", RefreshToken: "new_refresh_token"}
-		s.mockRefreshTokenUsecase.On("ValidateRefreshToken", refreshTokenRequest.RefreshToken).Return(claims, nil)
-		s.mockRefreshTokenUsecase.On("GetByUsername", "abebe").Return(user, nil)
-		s.mockRefreshTokenUsecase.On("GenerateTokens", *user).Return(tokens, nil)
+		s.mockRefreshTokenUsecase.On("Refresh", refreshTokenRequest.RefreshToken).Return(tokens, nil)
 
-		body, _ := json.Marshal(refreshTokenRequest)
-		req := httptest.NewRequest(http.MethodPost, "/refresh-token", bytes.NewReader(body))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Request = req
-
-		s.handler.RefreshToken(c)
+		w := s.performRefresh(refreshTokenRequest)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.RefreshTokenResponse
@@ -72,80 +60,65 @@ func (s *RefreshTokenHandlerSuite) TestRefreshToken() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RefreshToken(c)
+		serve(c, s.handler.RefreshToken)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["error"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 	})
 	s.resetMocks()
 	s.Run("InvalidRefreshToken", func() {
 		refreshTokenRequest := dto.RefreshTokenRequest{RefreshToken: "invalid_refresh_token"}
-		s.mockRefreshTokenUsecase.On("ValidateRefreshToken", refreshTokenRequest.RefreshToken).Return(jwt.MapClaims{}, errors.New("invalid refresh token"))
-
-		body, _ := json.Marshal(refreshTokenRequest)
-		req := httptest.NewRequest(http.MethodPost, "/refresh-token", bytes.NewReader(body))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Request = req
+		s.mockRefreshTokenUsecase.On("Refresh", refreshTokenRequest.RefreshToken).Return(domain.RefreshToken{}, domain.ErrInvalidRefreshToken)
 
-		s.handler.RefreshToken(c)
+		w := s.performRefresh(refreshTokenRequest)
 
 		s.Equal(http.StatusUnauthorized, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("invalid refresh token", response["error"])
+		s.Equal("invalid refresh token", response["detail"])
 	})
 
 	s.resetMocks()
-	s.Run("UserNotFound", func() {
-		refreshTokenRequest := dto.RefreshTokenRequest{RefreshToken: "valid_refresh_token"}
-		claims := jwt.MapClaims{"username": "abebe"}
-		s.mockRefreshTokenUsecase.On("ValidateRefreshToken", refreshTokenRequest.RefreshToken).Return(claims, nil)
-		s.mockRefreshTokenUsecase.On("GetByUsername", "abebe").Return(nil, errors.New("user not found"))
+	s.Run("ReusedRefreshToken", func() {
+		refreshTokenRequest := dto.RefreshTokenRequest{RefreshToken: "used_refresh_token"}
+		s.mockRefreshTokenUsecase.On("Refresh", refreshTokenRequest.RefreshToken).Return(domain.RefreshToken{}, domain.ErrRefreshTokenReused)
 
-		body, _ := json.Marshal(refreshTokenRequest)
-		req := httptest.NewRequest(http.MethodPost, "/refresh-token", bytes.NewReader(body))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Request = req
-
-		s.handler.RefreshToken(c)
+		w := s.performRefresh(refreshTokenRequest)
 
 		s.Equal(http.StatusUnauthorized, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("user not found", response["error"])
+		s.Equal(domain.ErrRefreshTokenReused.Error(), response["detail"])
 	})
 	s.resetMocks()
 
 	s.Run("TokenGenerationError", func() {
 		refreshTokenRequest := dto.RefreshTokenRequest{RefreshToken: "valid_refresh_token"}
-		claims := jwt.MapClaims{"username": "abebe"}
-		user := &domain.User{ID: "1", Username: "abebe", Email: "abebe@example.com", Role: "user"}
-		s.mockRefreshTokenUsecase.On("ValidateRefreshToken", refreshTokenRequest.RefreshToken).Return(claims, nil)
-		s.mockRefreshTokenUsecase.On("GetByUsername", "abebe").Return(user, nil)
-		s.mockRefreshTokenUsecase.On("GenerateTokens", *user).Return(domain.RefreshToken{}, errors.New("token generation failed"))
-
-		body, _ := json.Marshal(refreshTokenRequest)
-		req := httptest.NewRequest(http.MethodPost, "/refresh-token", bytes.NewReader(body))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Request = req
+		s.mockRefreshTokenUsecase.On("Refresh", refreshTokenRequest.RefreshToken).Return(domain.RefreshToken{}, errors.New("token generation failed"))
 
-		s.handler.RefreshToken(c)
+		w := s.performRefresh(refreshTokenRequest)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("token generation failed", response["error"])
+		s.Equal("Failed to refresh token", response["detail"])
 	})
 }
 
+func (s *RefreshTokenHandlerSuite) performRefresh(request dto.RefreshTokenRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/refresh-token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	serve(c, s.handler.RefreshToken)
+	return w
+}
+
 func (s *RefreshTokenHandlerSuite) resetMocks() {
 	s.mockRefreshTokenUsecase.Calls = nil
 	s.mockRefreshTokenUsecase.ExpectedCalls = nil
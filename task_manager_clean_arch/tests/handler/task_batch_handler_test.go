@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskBatchHandlerSuite defines the test suite for TaskBatchHandler
+type TaskBatchHandlerSuite struct {
+	suite.Suite
+	mockTaskUsecase *mocks_domain.ITaskUseCase
+	mockUserUsecase *mocks_domain.IUserUseCase
+	handler         *handler.TaskBatchHandler
+}
+
+// SetupTest initializes the mocks and handler before each test
+func (s *TaskBatchHandlerSuite) SetupTest() {
+	s.mockTaskUsecase = mocks_domain.NewITaskUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.TaskBatchHandler{
+		TaskUsecase: s.mockTaskUsecase,
+		UserUsecase: s.mockUserUsecase,
+	}
+	s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"}).Maybe()
+}
+
+// TestTaskBatchHandlerSuite runs the test suite
+func TestTaskBatchHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TaskBatchHandlerSuite))
+}
+
+func (s *TaskBatchHandlerSuite) request(username, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/users/"+username+"/tasks/batch", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "username", Value: username}}
+	return c, w
+}
+
+func (s *TaskBatchHandlerSuite) report(w *httptest.ResponseRecorder) dto.TaskBatchResponse {
+	var report dto.TaskBatchResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+	return report
+}
+
+// TestBatchUserTasks tests the BatchUserTasks method
+func (s *TaskBatchHandlerSuite) TestBatchUserTasks() {
+	id := primitive.NewObjectID()
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("BatchByUser", mock.MatchedBy(func(ops []domain.TaskOperation) bool {
+			return len(ops) == 2 && ops[0].Kind == domain.TaskOpStatus && *ops[0].Update.Status == "completed" &&
+				ops[0].Update.Version == 3 && ops[1].Kind == domain.TaskOpDelete
+		}), false, "abebe").Return([]domain.TaskOperationResult{
+			{Task: domain.Task{ID: id, Title: "Buy Coffee", Status: "completed", Version: 4}},
+			{Err: domain.NewError(domain.ErrNotFound, "task not found or not owned by user")},
+		}, nil)
+		c, w := s.request("abebe", `{"operations":[
+			{"op":"status","id":"`+id.Hex()+`","status":"completed","version":3},
+			{"op":"delete","id":"`+primitive.NewObjectID().Hex()+`"},
+			{"op":"update","id":"`+id.Hex()+`","priority":"whenever"}
+		]}`)
+
+		serve(c, s.handler.BatchUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		report := s.report(w)
+		s.Equal(3, report.Total)
+		s.Equal(1, report.Succeeded)
+		s.Equal(2, report.Failed)
+		s.Require().Len(report.Results, 3)
+		s.Equal("applied", report.Results[0].Status)
+		s.Equal("completed", report.Results[0].Task.Status)
+		s.Equal(int64(4), report.Results[0].Task.Version)
+		s.Equal("failed", report.Results[1].Status)
+		s.Equal("task not found or not owned by user", report.Results[1].Error)
+		s.Equal(2, report.Results[2].Index)
+		s.Equal("priority", report.Results[2].Errors[0].Field)
+	})
+
+	s.Run("AtomicWithInvalidOperation", func() {
+		s.SetupTest()
+		c, w := s.request("abebe", `{"atomic":true,"operations":[
+			{"op":"status","id":"`+id.Hex()+`","status":"completed"},
+			{"op":"archive","id":"`+id.Hex()+`"}
+		]}`)
+
+		serve(c, s.handler.BatchUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		report := s.report(w)
+		s.True(report.Atomic)
+		s.Equal(2, report.Failed)
+		s.Equal("operation was not applied because another operation in the batch failed", report.Results[0].Error)
+		s.mockTaskUsecase.AssertNotCalled(s.T(), "BatchByUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("HidesInternalErrors", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("BatchByUser", mock.Anything, false, "abebe").Return([]domain.TaskOperationResult{{Err: errors.New("connection reset by peer")}}, nil)
+		c, w := s.request("abebe", `{"operations":[{"op":"delete","id":"`+id.Hex()+`"}]}`)
+
+		serve(c, s.handler.BatchUserTasks)
+
+		s.Equal("The operation could not be applied", s.report(w).Results[0].Error)
+	})
+
+	s.Run("EmptyBatch", func() {
+		s.SetupTest()
+		c, w := s.request("abebe", `{"operations":[]}`)
+
+		serve(c, s.handler.BatchUserTasks)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	s.Run("OtherUser", func() {
+		s.SetupTest()
+		c, w := s.request("kebede", `{"operations":[{"op":"delete","id":"`+id.Hex()+`"}]}`)
+
+		serve(c, s.handler.BatchUserTasks)
+
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+
+	s.Run("UseCaseError", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("BatchByUser", mock.Anything, true, "abebe").Return(nil, errors.New("database error"))
+		c, w := s.request("abebe", `{"atomic":true,"operations":[{"op":"delete","id":"`+id.Hex()+`"}]}`)
+
+		serve(c, s.handler.BatchUserTasks)
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+	})
+}
+
+// TestBatchTasks tests the BatchTasks method
+func (s *TaskBatchHandlerSuite) TestBatchTasks() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		id := primitive.NewObjectID()
+		s.mockTaskUsecase.On("Batch", mock.Anything, true, "abebe").Return([]domain.TaskOperationResult{{Task: domain.Task{ID: id, Title: "Buy Beans", Version: 2}}}, nil)
+		c, w := s.request("", `{"atomic":true,"operations":[{"op":"update","id":"`+id.Hex()+`","title":"Buy Beans"}]}`)
+
+		serve(c, s.handler.BatchTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		report := s.report(w)
+		s.Equal(1, report.Succeeded)
+		s.Equal("Buy Beans", report.Results[0].Task.Title)
+	})
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskEventHandlerSuite defines the test suite for TaskEventHandler
+type TaskEventHandlerSuite struct {
+	suite.Suite
+	mockTaskEventUsecase *mocks_domain.ITaskEventUseCase
+	mockUserUsecase      *mocks_domain.IUserUseCase
+	handler              *handler.TaskEventHandler
+}
+
+// SetupTest initializes the mocks and handler before each test
+func (s *TaskEventHandlerSuite) SetupTest() {
+	s.mockTaskEventUsecase = mocks_domain.NewITaskEventUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.TaskEventHandler{
+		TaskEventUsecase: s.mockTaskEventUsecase,
+		UserUsecase:      s.mockUserUsecase,
+	}
+}
+
+// TestTaskEventHandlerSuite runs the test suite
+func TestTaskEventHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TaskEventHandlerSuite))
+}
+
+// streamEvents serves a streaming request and waits for the stream to end.
+func (s *TaskEventHandlerSuite) streamEvents(c *gin.Context, handle gin.HandlerFunc) {
+	done := make(chan struct{})
+	go func() {
+		serve(c, handle)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.FailNow("the stream did not end")
+	}
+}
+
+func (s *TaskEventHandlerSuite) request(target string, params gin.Params, headers ...string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	for i := 0; i+1 < len(headers); i += 2 {
+		c.Request.Header.Set(headers[i], headers[i+1])
+	}
+	c.Params = params
+	return c, w
+}
+
+// watch makes the use case return the events on a closed channel.
+func (s *TaskEventHandlerSuite) watch(username string, after uint64, complete bool, events ...domain.StreamEvent) {
+	ch := make(chan domain.StreamEvent, len(events))
+	for _, streamed := range events {
+		ch <- streamed
+	}
+	close(ch)
+	s.mockTaskEventUsecase.On("Watch", mock.Anything, username, after).Return((<-chan domain.StreamEvent)(ch), complete)
+}
+
+// TestStreamUserTaskEvents tests the StreamUserTaskEvents method
+func (s *TaskEventHandlerSuite) TestStreamUserTaskEvents() {
+	task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe", Version: 1}
+	event := domain.StreamEvent{Seq: 5, Event: domain.Event{ID: primitive.NewObjectID(), Type: domain.EventTaskCreated, Actor: "abebe", Task: &task}}
+	params := gin.Params{{Key: "username", Value: "abebe"}}
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"})
+		s.watch("abebe", 0, true, event)
+		c, w := s.request("/users/abebe/tasks/events", params)
+
+		s.streamEvents(c, s.handler.StreamUserTaskEvents)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("text/event-stream", w.Header().Get("Content-Type"))
+		s.Equal("no-cache", w.Header().Get("Cache-Control"))
+		body := w.Body.String()
+		s.True(strings.HasPrefix(body, "id: 5\nevent: task.created\ndata: {"), body)
+		s.Contains(body, `"id":"`+event.Event.ID.Hex()+`"`)
+		s.Contains(body, `"title":"Buy Coffee"`)
+		s.True(strings.HasSuffix(body, "}\n\n"))
+	})
+
+	s.Run("Resume", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"})
+		s.watch("abebe", 4, true, event)
+		c, w := s.request("/users/abebe/tasks/events", params, "Last-Event-ID", "4")
+
+		s.streamEvents(c, s.handler.StreamUserTaskEvents)
+
+		s.True(strings.HasPrefix(w.Body.String(), "id: 5\n"))
+	})
+
+	s.Run("ResumeFromQuery", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"})
+		s.watch("abebe", 4, true)
+		c, _ := s.request("/users/abebe/tasks/events?last_event_id=4", params)
+
+		s.streamEvents(c, s.handler.StreamUserTaskEvents)
+	})
+
+	s.Run("Missed", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"})
+		s.watch("abebe", 1, false, event)
+		c, w := s.request("/users/abebe/tasks/events", params, "Last-Event-ID", "1")
+
+		s.streamEvents(c, s.handler.StreamUserTaskEvents)
+
+		s.True(strings.HasPrefix(w.Body.String(), "id:\nevent: reset\ndata: {}\n\nid: 5\n"))
+	})
+
+	s.Run("InvalidLastEventID", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"})
+		c, w := s.request("/users/abebe/tasks/events", params, "Last-Event-ID", "abc")
+
+		serve(c, s.handler.StreamUserTaskEvents)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	s.Run("OtherUser", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "kebede"})
+		c, w := s.request("/users/abebe/tasks/events", params)
+
+		serve(c, s.handler.StreamUserTaskEvents)
+
+		s.Equal(http.StatusForbidden, w.Code)
+		s.mockTaskEventUsecase.AssertNotCalled(s.T(), "Watch", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("ClientGone", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"})
+		s.mockTaskEventUsecase.On("Watch", mock.Anything, "abebe", uint64(0)).Return((<-chan domain.StreamEvent)(make(chan domain.StreamEvent)), true)
+		c, _ := s.request("/users/abebe/tasks/events", params)
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		cancel()
+
+		s.streamEvents(c, s.handler.StreamUserTaskEvents)
+	})
+}
+
+// TestStreamTaskEvents tests that the admin stream watches all tasks
+func (s *TaskEventHandlerSuite) TestStreamTaskEvents() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.watch("", 0, true)
+		c, w := s.request("/tasks/events", nil)
+
+		s.streamEvents(c, s.handler.StreamTaskEvents)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("text/event-stream", w.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskExportHandlerSuite defines the test suite for TaskExportHandler
+type TaskExportHandlerSuite struct {
+	suite.Suite
+	mockTaskUsecase     *mocks_domain.ITaskUseCase
+	mockWorkflowUsecase *mocks_domain.IWorkflowUseCase
+	mockUserUsecase     *mocks_domain.IUserUseCase
+	handler             *handler.TaskExportHandler
+	tasks               []domain.Task
+}
+
+// SetupTest initializes the mocks and handler before each test
+func (s *TaskExportHandlerSuite) SetupTest() {
+	s.mockTaskUsecase = mocks_domain.NewITaskUseCase(s.T())
+	s.mockWorkflowUsecase = mocks_domain.NewIWorkflowUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.TaskExportHandler{
+		TaskUsecase:     s.mockTaskUsecase,
+		WorkflowUsecase: s.mockWorkflowUsecase,
+		UserUsecase:     s.mockUserUsecase,
+	}
+	s.tasks = []domain.Task{
+		{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe", Version: 1},
+		{ID: primitive.NewObjectID(), Title: "Sell Spices", Status: "completed", CreatedBy: "kebede", Version: 1},
+	}
+	s.mockWorkflowUsecase.On("Get").Return(domain.DefaultWorkflow(), nil).Maybe()
+	s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"}).Maybe()
+}
+
+// TestTaskExportHandlerSuite runs the test suite
+func TestTaskExportHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TaskExportHandlerSuite))
+}
+
+func (s *TaskExportHandlerSuite) request(target, username string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	c.Params = gin.Params{{Key: "username", Value: username}}
+	return c, w
+}
+
+// TestExportUserTasks tests the ExportUserTasks method
+func (s *TaskExportHandlerSuite) TestExportUserTasks() {
+	s.Run("CSV", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("GetTasksByUser", "abebe").Return(s.tasks[:1], nil)
+		c, w := s.request("/users/abebe/tasks/export?format=csv", "abebe")
+
+		serve(c, s.handler.ExportUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		s.Equal("attachment; filename=abebe-tasks.csv", w.Header().Get("Content-Disposition"))
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		s.Require().Len(lines, 2)
+		s.True(strings.HasPrefix(lines[1], s.tasks[0].ID.Hex()+",Buy Coffee,,pending,"))
+	})
+
+	s.Run("DefaultsToJSON", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("GetTasksByUser", "abebe").Return(s.tasks[:1], nil)
+		c, w := s.request("/users/abebe/tasks/export", "abebe")
+
+		serve(c, s.handler.ExportUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("attachment; filename=abebe-tasks.json", w.Header().Get("Content-Disposition"))
+		var tasks []map[string]any
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &tasks))
+		s.Equal(s.tasks[0].ID.Hex(), tasks[0]["id"])
+	})
+
+	s.Run("UnknownFormat", func() {
+		s.SetupTest()
+		c, w := s.request("/users/abebe/tasks/export?format=xml", "abebe")
+
+		serve(c, s.handler.ExportUserTasks)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.mockTaskUsecase.AssertNotCalled(s.T(), "GetTasksByUser", mock.Anything)
+	})
+
+	s.Run("OtherUser", func() {
+		s.SetupTest()
+		c, w := s.request("/users/kebede/tasks/export", "kebede")
+
+		serve(c, s.handler.ExportUserTasks)
+
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+
+	s.Run("UsecaseError", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("GetTasksByUser", "abebe").Return(nil, errors.New("database error"))
+		c, w := s.request("/users/abebe/tasks/export?format=ics", "abebe")
+
+		serve(c, s.handler.ExportUserTasks)
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+		s.Empty(w.Header().Get("Content-Disposition"))
+		s.Contains(w.Header().Get("Content-Type"), "application/problem+json")
+	})
+}
+
+// TestExportTasks tests that the admin export streams every task
+func (s *TaskExportHandlerSuite) TestExportTasks() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("ExportAll", mock.Anything).Return(func(fn func(domain.Task) error) error {
+			for _, task := range s.tasks {
+				if err := fn(task); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		c, w := s.request("/tasks/export?format=ics", "")
+
+		serve(c, s.handler.ExportTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+		s.Equal("attachment; filename=tasks.ics", w.Header().Get("Content-Disposition"))
+		s.Equal(2, strings.Count(w.Body.String(), "BEGIN:VTODO"))
+		s.Contains(w.Body.String(), "STATUS:NEEDS-ACTION")
+		s.Contains(w.Body.String(), "STATUS:COMPLETED")
+	})
+
+	s.Run("FailsAfterStreaming", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("ExportAll", mock.Anything).Return(func(fn func(domain.Task) error) error {
+			if err := fn(s.tasks[0]); err != nil {
+				return err
+			}
+			return errors.New("cursor lost")
+		})
+		c, w := s.request("/tasks/export?format=json", "")
+
+		serve(c, s.handler.ExportTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), s.tasks[0].ID.Hex())
+		s.False(json.Valid(w.Body.Bytes()), "an export that failed must not look complete")
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/yiheyistm/task_manager/internal/domain"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
 	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	"github.com/yiheyistm/task_manager/internal/interfaces/middleware"
 	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -54,36 +55,107 @@ func (s *TaskHandlerSuite) TestGetTasks() {
 			{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: dueDate},
 			{ID: primitive.NewObjectID(), Title: "Sell Spices", Description: "Trade in Merkato", Status: "completed", CreatedBy: "kebede", DueDate: dueDate},
 		}
-		s.mockTaskUsecase.On("GetAll").Return(tasks, nil)
+		s.mockTaskUsecase.On("ListTasks", domain.TaskQuery{}).Return(domain.TaskPage{Tasks: tasks, Total: 2, Page: 1, Limit: 20}, nil)
 		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
-		s.handler.GetTasks(c)
+		serve(c, s.handler.GetTasks)
 
 		s.Equal(http.StatusOK, w.Code)
-		var response []dto.TaskResponse
+		var response dto.TaskPageResponse
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Len(response, 2)
-		s.Equal(tasks[0].Title, response[0].Title)
-		s.Equal(tasks[1].Title, response[1].Title)
+		s.Len(response.Tasks, 2)
+		s.Equal(tasks[0].Title, response.Tasks[0].Title)
+		s.Equal(tasks[1].Title, response.Tasks[1].Title)
+		s.Equal(int64(2), response.Total)
+		s.Equal(1, response.Page)
+		s.Equal(1, response.TotalPages)
 	})
 	s.resetMocks()
 
 	s.Run("FetchError", func() {
-		s.mockTaskUsecase.On("GetAll").Return(nil, errors.New("fetch failed"))
+		s.mockTaskUsecase.On("ListTasks", domain.TaskQuery{}).Return(domain.TaskPage{}, errors.New("fetch failed"))
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.GetTasks(c)
+		serve(c, s.handler.GetTasks)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to retrieve tasks", response["error"])
+		s.Equal("Failed to retrieve tasks", response["detail"])
+	})
+	s.resetMocks()
+
+	s.Run("InvalidQuery", func() {
+		for _, query := range []string{"limit=500", "sort=created_by", "priority=critical", "page=-1", "due_after=yesterday"} {
+			req := httptest.NewRequest(http.MethodGet, "/tasks?"+query, nil)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			serve(c, s.handler.GetTasks)
+
+			s.Equal(http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
+
+// TestSearchTasks tests the SearchTasks method
+func (s *TaskHandlerSuite) TestSearchTasks() {
+	s.Run("Success", func() {
+		matches := []domain.TaskMatch{
+			{Task: domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee"}, Score: 1.5, Highlights: map[string]string{"title": "Buy <em>Coffee</em>"}},
+		}
+		s.mockTaskUsecase.On("SearchTasks", domain.TaskSearch{Text: "coffee", Limit: 5}).Return(matches, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=coffee&limit=5", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+
+		serve(c, s.handler.SearchTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response struct {
+			Tasks []dto.TaskResponse `json:"tasks"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Len(response.Tasks, 1)
+		s.Equal(1.5, response.Tasks[0].Score)
+		s.Equal("Buy <em>Coffee</em>", response.Tasks[0].Highlights["title"])
+	})
+	s.resetMocks()
+
+	s.Run("MissingQuery", func() {
+		req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=%20", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+
+		serve(c, s.handler.SearchTasks)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		var response dto.ProblemResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal([]dto.FieldProblemResponse{{Field: "q", Message: "is required"}}, response.Errors)
+	})
+
+	s.Run("SearchError", func() {
+		s.mockTaskUsecase.On("SearchTasks", mock.Anything).Return(nil, errors.New("text index required"))
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/search?q=coffee", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+
+		serve(c, s.handler.SearchTasks)
+
+		s.Equal(http.StatusInternalServerError, w.Code)
 	})
 	s.resetMocks()
 }
@@ -91,7 +163,7 @@ func (s *TaskHandlerSuite) TestGetTasks() {
 // TestGetTask tests the GetTask method
 func (s *TaskHandlerSuite) TestGetTask() {
 	s.Run("Success", func() {
-		task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe"}
+		task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", Version: 3}
 		s.mockTaskUsecase.On("GetById", "1").Return(task, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks/1", nil)
@@ -100,12 +172,14 @@ func (s *TaskHandlerSuite) TestGetTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		s.handler.GetTask(c)
+		serve(c, s.handler.GetTask)
 
 		s.Equal(http.StatusOK, w.Code)
+		s.Equal(`"3"`, w.Header().Get("ETag"))
 		var response dto.TaskResponse
 		json.Unmarshal(w.Body.Bytes(), &response)
 		s.Equal(task.Title, response.Title)
+		s.Equal(int64(3), response.Version)
 	})
 	s.resetMocks()
 
@@ -116,17 +190,17 @@ func (s *TaskHandlerSuite) TestGetTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: ""}}
 
-		s.handler.GetTask(c)
+		serve(c, s.handler.GetTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
 	})
 	s.resetMocks()
 
 	s.Run("TaskNotFound", func() {
-		s.mockTaskUsecase.On("GetById", "1").Return(domain.Task{}, errors.New("task not found"))
+		s.mockTaskUsecase.On("GetById", "1").Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found"))
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks/1", nil)
 		w := httptest.NewRecorder()
@@ -134,12 +208,12 @@ func (s *TaskHandlerSuite) TestGetTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		s.handler.GetTask(c)
+		serve(c, s.handler.GetTask)
 
 		s.Equal(http.StatusNotFound, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("task not found", response["message"])
+		s.Equal("task not found", response["detail"])
 	})
 	s.resetMocks()
 }
@@ -167,7 +241,7 @@ func (s *TaskHandlerSuite) TestCreateTask() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.CreateTask(c)
+		serve(c, s.handler.CreateTask)
 
 		s.Equal(http.StatusCreated, w.Code)
 		var response dto.TaskResponse
@@ -186,12 +260,12 @@ func (s *TaskHandlerSuite) TestCreateTask() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.CreateTask(c)
+		serve(c, s.handler.CreateTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 	})
 	s.resetMocks()
 	s.Run("ValidationError", func() {
@@ -206,12 +280,13 @@ func (s *TaskHandlerSuite) TestCreateTask() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.CreateTask(c)
+		serve(c, s.handler.CreateTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "Field validation")
+		s.Equal("Request has invalid fields", response["detail"])
+		s.NotEmpty(response["errors"])
 	})
 	s.resetMocks()
 	s.Run("CreateError", func() {
@@ -235,12 +310,12 @@ func (s *TaskHandlerSuite) TestCreateTask() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.CreateTask(c)
+		serve(c, s.handler.CreateTask)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to create task", response["error"])
+		s.Equal("Failed to create task", response["detail"])
 	})
 }
 
@@ -258,7 +333,7 @@ func (s *TaskHandlerSuite) TestUpdateTask() {
 		}
 		task := taskRequest.FromRequestToDomainTask()
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("Update", "1", task).Return(nil)
+		s.mockTaskUsecase.On("Update", "1", task, "abebe").Return(nil)
 
 		body, _ := json.Marshal(taskRequest)
 		req := httptest.NewRequest(http.MethodPut, "/tasks/1", bytes.NewReader(body))
@@ -268,7 +343,7 @@ func (s *TaskHandlerSuite) TestUpdateTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		s.handler.UpdateTask(c)
+		serve(c, s.handler.UpdateTask)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.TaskResponse
@@ -291,12 +366,12 @@ func (s *TaskHandlerSuite) TestUpdateTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: ""}}
 
-		s.handler.UpdateTask(c)
+		serve(c, s.handler.UpdateTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
 	})
 	s.resetMocks()
 
@@ -311,12 +386,12 @@ func (s *TaskHandlerSuite) TestUpdateTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		s.handler.UpdateTask(c)
+		serve(c, s.handler.UpdateTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 	})
 	s.resetMocks()
 
@@ -333,12 +408,13 @@ func (s *TaskHandlerSuite) TestUpdateTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		s.handler.UpdateTask(c)
+		serve(c, s.handler.UpdateTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "Field validation")
+		s.Equal("Request has invalid fields", response["detail"])
+		s.NotEmpty(response["errors"])
 	})
 	s.resetMocks()
 
@@ -354,7 +430,7 @@ func (s *TaskHandlerSuite) TestUpdateTask() {
 		}
 		task := taskRequest.FromRequestToDomainTask()
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("Update", "1", task).Return(errors.New("update failed"))
+		s.mockTaskUsecase.On("Update", "1", task, "abebe").Return(errors.New("update failed"))
 
 		body, _ := json.Marshal(taskRequest)
 		req := httptest.NewRequest(http.MethodPut, "/tasks/1", bytes.NewReader(body))
@@ -364,19 +440,169 @@ func (s *TaskHandlerSuite) TestUpdateTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		s.handler.UpdateTask(c)
+		serve(c, s.handler.UpdateTask)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to update task", response["message"])
+		s.Equal("Failed to update task", response["detail"])
+	})
+}
+
+// TestPatchTask tests the PatchTask method
+func (s *TaskHandlerSuite) TestPatchTask() {
+	dueDate := time.Date(2025, 7, 30, 17, 0, 0, 0, time.UTC)
+	id := primitive.NewObjectID()
+	task := domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: dueDate}
+	patchTask := func(body, contentType string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+id.Hex(), strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "id", Value: id.Hex()}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"}).Maybe()
+		serve(c, s.handler.PatchTask)
+		return w
+	}
+
+	s.Run("MergePatch", func() {
+		status := "completed"
+		updated := task
+		updated.Status = status
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(task, nil)
+		s.mockTaskUsecase.On("Patch", id.Hex(), domain.TaskUpdate{Status: &status}, "admin").Return(updated, nil)
+
+		w := patchTask(`{"status":"completed"}`, "application/merge-patch+json")
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.TaskResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("completed", response.Status)
+		s.Equal(task.Description, response.Description)
+	})
+	s.resetMocks()
+
+	s.Run("JSONPatch", func() {
+		title := "Buy Spices"
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(task, nil)
+		s.mockTaskUsecase.On("Patch", id.Hex(), domain.TaskUpdate{Title: &title}, "admin").Return(task, nil)
+
+		w := patchTask(`[{"op":"test","path":"/status","value":"pending"},{"op":"replace","path":"/title","value":"Buy Spices"}]`, "application/json-patch+json")
+
+		s.Equal(http.StatusOK, w.Code)
+	})
+	s.resetMocks()
+
+	s.Run("OwnerIsNotPatched", func() {
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(task, nil)
+		s.mockTaskUsecase.On("Patch", id.Hex(), domain.TaskUpdate{}, "admin").Return(task, nil)
+
+		w := patchTask(`{"created_by":"kebede"}`, "application/merge-patch+json")
+
+		s.Equal(http.StatusOK, w.Code)
+	})
+	s.resetMocks()
+
+	s.Run("InvalidResult", func() {
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(task, nil)
+
+		w := patchTask(`{"title":null}`, "application/merge-patch+json")
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+	s.resetMocks()
+
+	s.Run("TestOperationFails", func() {
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(task, nil)
+
+		w := patchTask(`[{"op":"test","path":"/status","value":"completed"}]`, "application/json-patch+json")
+
+		s.Equal(http.StatusConflict, w.Code)
+	})
+	s.resetMocks()
+
+	s.Run("UnsupportedMediaType", func() {
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(task, nil)
+
+		w := patchTask(`status=completed`, "application/x-www-form-urlencoded")
+
+		s.Equal(http.StatusUnsupportedMediaType, w.Code)
+	})
+	s.resetMocks()
+
+	s.Run("TaskNotFound", func() {
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found"))
+
+		w := patchTask(`{"status":"completed"}`, "application/merge-patch+json")
+
+		s.Equal(http.StatusNotFound, w.Code)
 	})
+	s.resetMocks()
+
+	versioned := task
+	versioned.Version = 2
+	patchIfMatch := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+id.Hex(), strings.NewReader(`{"status":"completed"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", etag)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "id", Value: id.Hex()}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"}).Maybe()
+		serve(c, s.handler.PatchTask)
+		return w
+	}
+
+	s.Run("IfMatch", func() {
+		status := "completed"
+		updated := versioned
+		updated.Status = status
+		updated.Version = 3
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(versioned, nil)
+		s.mockTaskUsecase.On("Patch", id.Hex(), domain.TaskUpdate{Status: &status, Version: 2}, "admin").Return(updated, nil)
+
+		w := patchIfMatch(`"2"`)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal(`"3"`, w.Header().Get("ETag"))
+	})
+	s.resetMocks()
+
+	s.Run("IfMatchStale", func() {
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(versioned, nil)
+
+		w := patchIfMatch(`"1"`)
+
+		s.Equal(http.StatusPreconditionFailed, w.Code)
+	})
+	s.resetMocks()
+
+	s.Run("ConcurrentWrite", func() {
+		status := "completed"
+		s.mockTaskUsecase.On("GetById", id.Hex()).Return(versioned, nil)
+		s.mockTaskUsecase.On("Patch", id.Hex(), domain.TaskUpdate{Status: &status, Version: 2}, "admin").Return(domain.Task{}, domain.ErrVersionConflict)
+
+		w := patchIfMatch(`"2"`)
+
+		s.Equal(http.StatusPreconditionFailed, w.Code)
+	})
+	s.resetMocks()
+
+	s.Run("IfMatchMalformed", func() {
+		w := patchIfMatch(`W/"2"`)
+
+		s.Equal(http.StatusPreconditionFailed, w.Code)
+	})
+	s.resetMocks()
 }
 
 // TestDeleteTask tests the DeleteTask method
 func (s *TaskHandlerSuite) TestDeleteTask() {
 	s.Run("Success", func() {
-		s.mockTaskUsecase.On("Delete", "1").Return(nil)
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		s.mockTaskUsecase.On("Delete", "1", int64(0), "admin").Return(nil)
 
 		req := httptest.NewRequest(http.MethodDelete, "/tasks/1", nil)
 		w := httptest.NewRecorder()
@@ -384,7 +610,7 @@ func (s *TaskHandlerSuite) TestDeleteTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		s.handler.DeleteTask(c)
+		serve(c, s.handler.DeleteTask)
 
 		s.Equal(http.StatusNoContent, w.Code)
 	})
@@ -397,72 +623,168 @@ func (s *TaskHandlerSuite) TestDeleteTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: ""}}
 
-		s.handler.DeleteTask(c)
+		serve(c, s.handler.DeleteTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
+	})
+	s.resetMocks()
+
+	s.Run("TaskNotFound", func() {
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		s.mockTaskUsecase.On("Delete", "1", int64(0), "admin").Return(domain.NewError(domain.ErrNotFound, "task not found or already deleted"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/1", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		serve(c, s.handler.DeleteTask)
+
+		s.Equal(http.StatusNotFound, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("task not found or already deleted", response["detail"])
 	})
 	s.resetMocks()
 
 	s.Run("DeleteError", func() {
-		s.mockTaskUsecase.On("Delete", "1").Return(errors.New("delete failed"))
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		s.mockTaskUsecase.On("Delete", "1", int64(0), "admin").Return(errors.New("connection reset"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/tasks/1", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		serve(c, s.handler.DeleteTask)
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+		s.Equal("application/problem+json", w.Header().Get("Content-Type"))
+		var response dto.ProblemResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(dto.ProblemResponse{Type: "about:blank", Title: "Internal Server Error", Status: 500, Detail: "Failed to delete task", Instance: "/tasks/1"}, response)
+	})
+	s.resetMocks()
+
+	s.Run("IfMatchStale", func() {
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		s.mockTaskUsecase.On("Delete", "1", int64(4), "admin").Return(domain.ErrVersionConflict)
 
 		req := httptest.NewRequest(http.MethodDelete, "/tasks/1", nil)
+		req.Header.Set("If-Match", `"4"`)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		s.handler.DeleteTask(c)
+		serve(c, s.handler.DeleteTask)
+
+		s.Equal(http.StatusPreconditionFailed, w.Code)
+	})
+}
+
+// TestGetTaskHistory tests the GetTaskHistory method
+func (s *TaskHandlerSuite) TestGetTaskHistory() {
+	id := primitive.NewObjectID()
+	getHistory := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/tasks/"+id.Hex()+"/history", nil)
+		c.Params = gin.Params{{Key: "id", Value: id.Hex()}}
+		serve(c, s.handler.GetTaskHistory)
+		return w
+	}
+
+	s.Run("Success", func() {
+		history := []domain.TaskHistory{{
+			ID:      primitive.NewObjectID(),
+			TaskID:  id,
+			Action:  domain.TaskUpdated,
+			Actor:   "admin",
+			Version: 2,
+			Changes: []domain.TaskChange{{Field: "status", From: "pending", To: "completed"}},
+		}}
+		s.mockTaskUsecase.On("GetHistory", id.Hex()).Return(history, nil)
+
+		w := getHistory()
+
+		s.Equal(http.StatusOK, w.Code)
+		var response struct {
+			History []dto.TaskHistoryResponse `json:"history"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Len(response.History, 1)
+		s.Equal("admin", response.History[0].Actor)
+		s.Equal([]dto.TaskChangeResponse{{Field: "status", From: "pending", To: "completed"}}, response.History[0].Changes)
+	})
+	s.resetMocks()
+
+	s.Run("TaskNotFound", func() {
+		s.mockTaskUsecase.On("GetHistory", id.Hex()).Return(nil, domain.NewError(domain.ErrNotFound, "task not found"))
+
+		w := getHistory()
 
 		s.Equal(http.StatusNotFound, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("delete failed", response["message"])
+		s.Equal("task not found", response["detail"])
 	})
+	s.resetMocks()
 }
 
 // TestGetTaskCountByStatus tests the GetTaskCountByStatus method
 func (s *TaskHandlerSuite) TestGetTaskCountByStatus() {
 	s.Run("Success", func() {
-		counts := []domain.StatusCount{
-			{Status: "pending", Count: 5},
-			{Status: "completed", Count: 3},
+		stats := domain.TaskStats{
+			ByStatus: []domain.StatusCount{{Status: "pending", Count: 5}, {Status: "completed", Count: 3}},
+			ByTag:    []domain.TagCount{{Tag: "market", Count: 4}},
 		}
-		s.mockTaskUsecase.On("GetTaskCountByStatus").Return(counts, nil)
+		s.mockTaskUsecase.On("GetTaskCountByStatus").Return(stats, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks/status", nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.GetTaskCountByStatus(c)
+		serve(c, s.handler.GetTaskCountByStatus)
 
 		s.Equal(http.StatusOK, w.Code)
-		var response []domain.StatusCount
+		var response dto.TaskStatsResponse
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal(counts, response)
+		s.Equal([]dto.StatusCountResponse{{Status: "pending", Count: 5}, {Status: "completed", Count: 3}}, response.ByStatus)
+		s.Equal([]dto.PriorityCountResponse{}, response.ByPriority)
+		s.Equal([]dto.TagCountResponse{{Tag: "market", Count: 4}}, response.ByTag)
 	})
 	s.resetMocks()
 	s.Run("FetchError", func() {
-		s.mockTaskUsecase.On("GetTaskCountByStatus").Return(nil, errors.New("fetch failed"))
+		s.mockTaskUsecase.On("GetTaskCountByStatus").Return(domain.TaskStats{}, errors.New("fetch failed"))
 
 		req := httptest.NewRequest(http.MethodGet, "/tasks/status", nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.GetTaskCountByStatus(c)
+		serve(c, s.handler.GetTaskCountByStatus)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to retrieve task counts", response["error"])
+		s.Equal("Failed to retrieve task counts", response["detail"])
 	})
 }
 
+// serve calls the handler and then renders the error it recorded, if any,
+// the way middleware.ErrorHandler does once the handler returns.
+func serve(c *gin.Context, handle gin.HandlerFunc) {
+	handle(c)
+	middleware.ErrorHandler()(c)
+}
+
 func (s *TaskHandlerSuite) resetMocks() {
 	s.mockUserUsecase.ExpectedCalls = nil
 	s.mockUserUsecase.Calls = nil
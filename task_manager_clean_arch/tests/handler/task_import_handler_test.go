@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskImportHandlerSuite defines the test suite for TaskImportHandler
+type TaskImportHandlerSuite struct {
+	suite.Suite
+	mockTaskUsecase *mocks_domain.ITaskUseCase
+	mockUserUsecase *mocks_domain.IUserUseCase
+	handler         *handler.TaskImportHandler
+}
+
+// SetupTest initializes the mocks and handler before each test
+func (s *TaskImportHandlerSuite) SetupTest() {
+	s.mockTaskUsecase = mocks_domain.NewITaskUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.TaskImportHandler{
+		TaskUsecase: s.mockTaskUsecase,
+		UserUsecase: s.mockUserUsecase,
+	}
+	s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"}).Maybe()
+}
+
+// TestTaskImportHandlerSuite runs the test suite
+func TestTaskImportHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TaskImportHandlerSuite))
+}
+
+func (s *TaskImportHandlerSuite) request(target, username, contentType, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", contentType)
+	c.Params = gin.Params{{Key: "username", Value: username}}
+	return c, w
+}
+
+// created gives the imported tasks an ID, like the use case does.
+func created(username string, tasks []*domain.Task, dryRun bool) ([]error, error) {
+	errs := make([]error, len(tasks))
+	for i, task := range tasks {
+		if task.Title == "Sell Spices" {
+			errs[i] = domain.NewError(domain.ErrValidation, "status must be one of pending, completed")
+			continue
+		}
+		if !dryRun {
+			task.ID = primitive.NewObjectID()
+		}
+	}
+	return errs, nil
+}
+
+// TestImportUserTasks tests the ImportUserTasks method
+func (s *TaskImportHandlerSuite) TestImportUserTasks() {
+	file := "title,due_date,priority\n" +
+		"Buy Coffee,2030-01-31,high\n" +
+		"Roast Coffee,2030-01-31,whatever\n" +
+		"Sell Spices,2030-01-31,\n" +
+		"Grind Coffee,,\n"
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("ImportByUser", "abebe", mock.MatchedBy(func(tasks []*domain.Task) bool {
+			return len(tasks) == 2 && tasks[0].Title == "Buy Coffee" && tasks[0].Priority == "high" && tasks[1].Title == "Sell Spices"
+		}), false).Return(created)
+		c, w := s.request("/users/abebe/tasks/import", "abebe", "text/csv", file)
+
+		serve(c, s.handler.ImportUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var report dto.TaskImportResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+		s.False(report.DryRun)
+		s.Equal(4, report.Total)
+		s.Equal(1, report.Succeeded)
+		s.Equal(3, report.Failed)
+		s.Require().Len(report.Rows, 4)
+		s.Equal(dto.TaskImportRowResponse{Line: 2, Status: "created", ID: report.Rows[0].ID}, report.Rows[0])
+		s.NotEmpty(report.Rows[0].ID)
+		s.Equal("failed", report.Rows[1].Status)
+		s.Equal("priority", report.Rows[1].Errors[0].Field)
+		s.Equal("status must be one of pending, completed", report.Rows[2].Error)
+		s.Equal(5, report.Rows[3].Line)
+		s.Equal("due_date", report.Rows[3].Errors[0].Field)
+	})
+
+	s.Run("DryRun", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("ImportByUser", "abebe", mock.Anything, true).Return(created)
+		c, w := s.request("/users/abebe/tasks/import?dry_run=true", "abebe", "text/csv; charset=utf-8", file)
+
+		serve(c, s.handler.ImportUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var report dto.TaskImportResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+		s.True(report.DryRun)
+		s.Equal("valid", report.Rows[0].Status)
+		s.Empty(report.Rows[0].ID)
+	})
+
+	s.Run("NDJSON", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("ImportByUser", "abebe", mock.Anything, false).Return(created)
+		c, w := s.request("/users/abebe/tasks/import", "abebe", "application/x-ndjson", `{"title":"Buy Coffee","due_date":"2030-01-31T09:00:00Z"}`+"\n"+`not json`)
+
+		serve(c, s.handler.ImportUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var report dto.TaskImportResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+		s.Equal("created", report.Rows[0].Status)
+		s.Equal("failed", report.Rows[1].Status)
+		s.Contains(report.Rows[1].Error, "invalid JSON")
+	})
+
+	s.Run("HidesInternalErrors", func() {
+		s.SetupTest()
+		s.mockTaskUsecase.On("ImportByUser", "abebe", mock.Anything, false).Return([]error{errors.New("connection reset by peer")}, nil)
+		c, w := s.request("/users/abebe/tasks/import", "abebe", "text/csv", "title,due_date\nBuy Coffee,2030-01-31\n")
+
+		serve(c, s.handler.ImportUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.NotContains(w.Body.String(), "connection reset")
+		s.Contains(w.Body.String(), `"status":"failed"`)
+	})
+
+	s.Run("UnsupportedType", func() {
+		s.SetupTest()
+		c, w := s.request("/users/abebe/tasks/import", "abebe", "application/json", "[]")
+
+		serve(c, s.handler.ImportUserTasks)
+
+		s.Equal(http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	s.Run("InvalidDryRun", func() {
+		s.SetupTest()
+		c, w := s.request("/users/abebe/tasks/import?dry_run=maybe", "abebe", "text/csv", file)
+
+		serve(c, s.handler.ImportUserTasks)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	s.Run("OtherUser", func() {
+		s.SetupTest()
+		c, w := s.request("/users/kebede/tasks/import", "kebede", "text/csv", file)
+
+		serve(c, s.handler.ImportUserTasks)
+
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+}
@@ -83,7 +83,7 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
+		serve(c, s.handler.RegisterRequest)
 
 		s.Equal(http.StatusCreated, w.Code)
 		var response dto.UserResponse
@@ -101,12 +101,12 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
+		serve(c, s.handler.RegisterRequest)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 		s.resetMocks()
 	})
 
@@ -124,12 +124,13 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
+		serve(c, s.handler.RegisterRequest)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "Field validation")
+		s.Equal("Request has invalid fields", response["detail"])
+		s.NotEmpty(response["errors"])
 		s.resetMocks()
 	})
 
@@ -151,11 +152,11 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
-		s.Equal(http.StatusBadRequest, w.Code)
+		serve(c, s.handler.RegisterRequest)
+		s.Equal(http.StatusConflict, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Username already exists", response["error"])
+		s.Equal("Username already exists", response["detail"])
 		s.mockUserUsecase.AssertCalled(s.T(), "GetByUsername", strings.ToLower(userRequest.Username))
 		s.resetMocks()
 	})
@@ -190,12 +191,12 @@ func (s *UserHandlerSuite) TestRegisterRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.RegisterRequest(c)
+		serve(c, s.handler.RegisterRequest)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to register user", response["error"])
+		s.Equal("Failed to register user", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -226,7 +227,7 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.LoginResponse
@@ -260,7 +261,7 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.LoginResponse
@@ -277,12 +278,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 		s.resetMocks()
 	})
 
@@ -298,12 +299,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Username/email and password are required", response["message"])
+		s.Equal("Username/email and password are required", response["detail"])
 		s.resetMocks()
 	})
 
@@ -322,12 +323,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusUnauthorized, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Invalid credentials", response["error"])
+		s.Equal("Invalid credentials", response["detail"])
 		s.resetMocks()
 	})
 
@@ -353,12 +354,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusUnauthorized, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Invalid email or password", response["error"])
+		s.Equal("Invalid email or password", response["detail"])
 		s.resetMocks()
 	})
 
@@ -387,12 +388,12 @@ func (s *UserHandlerSuite) TestLoginRequest() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.LoginRequest(c)
+		serve(c, s.handler.LoginRequest)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to generate token", response["error"])
+		s.Equal("Failed to generate token", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -411,7 +412,7 @@ func (s *UserHandlerSuite) TestGetAllUsers() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.GetAllUsers(c)
+		serve(c, s.handler.GetAllUsers)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response gin.H
@@ -428,12 +429,12 @@ func (s *UserHandlerSuite) TestGetAllUsers() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 
-		s.handler.GetAllUsers(c)
+		serve(c, s.handler.GetAllUsers)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to fetch users", response["error"])
+		s.Equal("Failed to fetch users", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -450,7 +451,7 @@ func (s *UserHandlerSuite) TestGetUser() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUser(c)
+		serve(c, s.handler.GetUser)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response gin.H
@@ -466,12 +467,12 @@ func (s *UserHandlerSuite) TestGetUser() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: ""}}
 
-		s.handler.GetUser(c)
+		serve(c, s.handler.GetUser)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("User name is required", response["error"])
+		s.Equal("User name is required", response["detail"])
 		s.resetMocks()
 	})
 
@@ -484,12 +485,12 @@ func (s *UserHandlerSuite) TestGetUser() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUser(c)
+		serve(c, s.handler.GetUser)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to fetch user", response["error"])
+		s.Equal("Failed to fetch user", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -502,7 +503,7 @@ func (s *UserHandlerSuite) TestGetUserTasks() {
 			{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: time.Now()},
 		}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetTasksByUser", "abebe").Return(tasks, nil)
+		s.mockTaskUsecase.On("ListTasks", domain.TaskQuery{CreatedBy: "abebe"}).Return(domain.TaskPage{Tasks: tasks, Total: 1, Page: 1, Limit: 20}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks", nil)
 		w := httptest.NewRecorder()
@@ -510,12 +511,13 @@ func (s *UserHandlerSuite) TestGetUserTasks() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTasks(c)
+		serve(c, s.handler.GetUserTasks)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
 		s.Len(response["tasks"], 1)
+		s.Equal(float64(1), response["total"])
 		s.resetMocks()
 	})
 
@@ -529,19 +531,19 @@ func (s *UserHandlerSuite) TestGetUserTasks() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTasks(c)
+		serve(c, s.handler.GetUserTasks)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to see details about this user", response["error"])
+		s.Equal("You do not have permission to see details about this user", response["detail"])
 		s.resetMocks()
 	})
 
 	s.Run("FetchError", func() {
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetTasksByUser", "abebe").Return(nil, errors.New("fetch failed"))
+		s.mockTaskUsecase.On("ListTasks", domain.TaskQuery{CreatedBy: "abebe"}).Return(domain.TaskPage{}, errors.New("fetch failed"))
 
 		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks", nil)
 		w := httptest.NewRecorder()
@@ -549,12 +551,115 @@ func (s *UserHandlerSuite) TestGetUserTasks() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTasks(c)
+		serve(c, s.handler.GetUserTasks)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to fetch tasks for user", response["error"])
+		s.Equal("Failed to fetch tasks for user", response["detail"])
+		s.resetMocks()
+	})
+
+	s.Run("QueryParameters", func() {
+		user := &domain.User{Username: "abebe"}
+		dueBefore := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+		query := domain.TaskQuery{CreatedBy: "abebe", Status: "pending", DueBefore: dueBefore, Sort: "-due_date", Page: 2, Limit: 5}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("ListTasks", mock.MatchedBy(func(q domain.TaskQuery) bool {
+			return q.CreatedBy == query.CreatedBy && q.Status == query.Status && q.DueBefore.Equal(dueBefore) &&
+				q.Sort == query.Sort && q.Page == query.Page && q.Limit == query.Limit
+		})).Return(domain.TaskPage{Total: 6, Page: 2, Limit: 5, NextCursor: "next"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks?status=pending&due_before=2025-08-01T00:00:00Z&sort=-due_date&page=2&limit=5", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.GetUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.TaskPageResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(int64(6), response.Total)
+		s.Equal(2, response.TotalPages)
+		s.Equal("next", response.NextCursor)
+		s.Empty(response.Tasks)
+		s.resetMocks()
+	})
+
+	s.Run("InvalidCursor", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("ListTasks", domain.TaskQuery{CreatedBy: "abebe", Cursor: "bogus"}).Return(domain.TaskPage{}, domain.ErrInvalidCursor)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks?cursor=bogus", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.GetUserTasks)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.resetMocks()
+	})
+}
+
+// TestSearchUserTasks tests the SearchUserTasks method
+func (s *UserHandlerSuite) TestSearchUserTasks() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		matches := []domain.TaskMatch{{Task: domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe"}, Score: 1}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("SearchTasks", domain.TaskSearch{Text: "coffee", CreatedBy: "abebe"}).Return(matches, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/search?q=coffee", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.SearchUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Len(response["tasks"], 1)
+		s.resetMocks()
+	})
+
+	s.Run("PermissionDenied", func() {
+		user := &domain.User{Username: "kebede"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/search?q=coffee", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.SearchUserTasks)
+
+		s.Equal(http.StatusForbidden, w.Code)
+		s.resetMocks()
+	})
+
+	s.Run("NoResults", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("SearchTasks", mock.Anything).Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/search?q=tea", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.SearchUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.JSONEq(`{"tasks":[]}`, w.Body.String())
 		s.resetMocks()
 	})
 }
@@ -575,7 +680,7 @@ func (s *UserHandlerSuite) TestGetUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: id.Hex()}}
 
-		s.handler.GetUserTask(c)
+		serve(c, s.handler.GetUserTask)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.TaskResponse
@@ -598,12 +703,12 @@ func (s *UserHandlerSuite) TestGetUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.GetUserTask(c)
+		serve(c, s.handler.GetUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to see details about this user", response["error"])
+		s.Equal("You do not have permission to see details about this user", response["detail"])
 		s.resetMocks()
 	})
 
@@ -617,19 +722,19 @@ func (s *UserHandlerSuite) TestGetUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: ""}}
 
-		s.handler.GetUserTask(c)
+		serve(c, s.handler.GetUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
 		s.resetMocks()
 	})
 
 	s.Run("TaskNotFound", func() {
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetByIdAndUser", "1", "abebe").Return(domain.Task{}, errors.New("task not found"))
+		s.mockTaskUsecase.On("GetByIdAndUser", "1", "abebe").Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found"))
 
 		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/1", nil)
 		w := httptest.NewRecorder()
@@ -637,12 +742,12 @@ func (s *UserHandlerSuite) TestGetUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.GetUserTask(c)
+		serve(c, s.handler.GetUserTask)
 
 		s.Equal(http.StatusNotFound, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("task not found", response["message"])
+		s.Equal("task not found", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -676,7 +781,7 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusCreated, w.Code)
 		var response dto.TaskResponse
@@ -700,12 +805,12 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to create tasks on behalf of other user", response["error"])
+		s.Equal("You do not have permission to create tasks on behalf of other user", response["detail"])
 		s.resetMocks()
 	})
 
@@ -720,12 +825,12 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 		s.resetMocks()
 	})
 
@@ -744,12 +849,13 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "Field validation")
+		s.Equal("Request has invalid fields", response["detail"])
+		s.NotEmpty(response["errors"])
 		s.resetMocks()
 	})
 
@@ -780,12 +886,12 @@ func (s *UserHandlerSuite) TestCreateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.CreateUserTask(c)
+		serve(c, s.handler.CreateUserTask)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to create task", response["error"])
+		s.Equal("Failed to create task", response["detail"])
 		s.resetMocks()
 	})
 }
@@ -821,7 +927,7 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: id.Hex()}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusOK, w.Code)
 		var response dto.TaskResponse
@@ -844,12 +950,12 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to update this task", response["error"])
+		s.Equal("You do not have permission to update this task", response["detail"])
 		s.resetMocks()
 	})
 
@@ -865,12 +971,12 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: ""}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
 		s.resetMocks()
 	})
 
@@ -885,12 +991,12 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "invalid character")
+		s.Contains(response["detail"], "invalid character")
 		s.resetMocks()
 	})
 
@@ -907,12 +1013,13 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 		fmt.Println("Running validation error test", c)
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Contains(response["message"], "Field validation")
+		s.Equal("Request has invalid fields", response["detail"])
+		s.NotEmpty(response["errors"])
 		s.resetMocks()
 	})
 
@@ -942,12 +1049,135 @@ func (s *UserHandlerSuite) TestUpdateUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.UpdateUserTask(c)
+		serve(c, s.handler.UpdateUserTask)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to update task", response["error"])
+		s.Equal("Failed to update task", response["detail"])
+		s.resetMocks()
+	})
+
+	s.Run("TaskNotFound", func() {
+		user := &domain.User{Username: "abebe"}
+		task := dto.TaskRequest{Title: "Buy Coffee", Status: "completed", DueDate: time.Now().Add(24 * time.Hour)}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("UpdateByIdAndUser", "1", mock.Anything, "abebe").Return(domain.NewError(domain.ErrNotFound, "failed to update task or task not found for user"))
+		body, _ := json.Marshal(task)
+		req := httptest.NewRequest(http.MethodPut, "/users/abebe/tasks/1", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+		serve(c, s.handler.UpdateUserTask)
+
+		s.Equal(http.StatusNotFound, w.Code)
+		s.resetMocks()
+	})
+
+	s.Run("ThisOccurrence", func() {
+		user := &domain.User{Username: "abebe"}
+		id := primitive.NewObjectID()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("UpdateOccurrenceByIdAndUser", id.Hex(), mock.MatchedBy(func(t *domain.Task) bool {
+			return t.Recurrence != nil && t.Recurrence.Rule == "FREQ=WEEKLY"
+		}), domain.ScopeThisOccurrence, "abebe").Run(func(args mock.Arguments) {
+			task := args.Get(1).(*domain.Task)
+			task.ID = id
+			task.Recurrence = &domain.Recurrence{Rule: "FREQ=WEEKLY", Occurrence: 1}
+		}).Return(nil)
+
+		body := `{"title":"Water plants","due_date":"2030-01-02T09:00:00Z","rrule":"FREQ=WEEKLY"}`
+		req := httptest.NewRequest(http.MethodPut, "/users/abebe/tasks/1?scope=this", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: id.Hex()}}
+
+		serve(c, s.handler.UpdateUserTask)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.TaskResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Require().NotNil(response.Recurrence)
+		s.Equal(id.Hex(), response.Recurrence.SeriesID)
+		s.resetMocks()
+	})
+
+	s.Run("InvalidScope", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("UpdateOccurrenceByIdAndUser", "1", mock.Anything, "all", "abebe").
+			Return(domain.NewError(domain.ErrValidation, "scope must be this or future"))
+
+		body := `{"title":"Water plants","due_date":"2030-01-02T09:00:00Z"}`
+		req := httptest.NewRequest(http.MethodPut, "/users/abebe/tasks/1?scope=all", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+		serve(c, s.handler.UpdateUserTask)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.resetMocks()
+	})
+}
+
+// TestPatchUserTask tests the PatchUserTask method
+func (s *UserHandlerSuite) TestPatchUserTask() {
+	id := primitive.NewObjectID()
+	task := domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: time.Date(2025, 7, 30, 17, 0, 0, 0, time.UTC)}
+	patchTask := func(username string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/users/"+username+"/tasks/"+id.Hex(), strings.NewReader(`{"description":null}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: username}, {Key: "id", Value: id.Hex()}}
+		serve(c, s.handler.PatchUserTask)
+		return w
+	}
+
+	s.Run("Success", func() {
+		description := ""
+		updated := task
+		updated.Description = ""
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"})
+		s.mockTaskUsecase.On("GetByIdAndUser", id.Hex(), "abebe").Return(task, nil)
+		s.mockTaskUsecase.On("PatchByIdAndUser", id.Hex(), domain.TaskUpdate{Description: &description}, "abebe").Return(updated, nil)
+
+		w := patchTask("abebe")
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.TaskResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Empty(response.Description)
+		s.Equal(task.Title, response.Title)
+		s.resetMocks()
+	})
+
+	s.Run("PermissionDenied", func() {
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "kebede"})
+
+		w := patchTask("abebe")
+
+		s.Equal(http.StatusForbidden, w.Code)
+		s.resetMocks()
+	})
+
+	s.Run("PatchError", func() {
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "abebe"})
+		s.mockTaskUsecase.On("GetByIdAndUser", id.Hex(), "abebe").Return(task, nil)
+		s.mockTaskUsecase.On("PatchByIdAndUser", id.Hex(), mock.Anything, "abebe").Return(domain.Task{}, errors.New("database error"))
+
+		w := patchTask("abebe")
+
+		s.Equal(http.StatusInternalServerError, w.Code)
 		s.resetMocks()
 	})
 }
@@ -958,7 +1188,7 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		id := primitive.NewObjectID()
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("DeleteByIdAndUser", id.Hex(), "abebe").Return(nil)
+		s.mockTaskUsecase.On("DeleteByIdAndUser", id.Hex(), "abebe", int64(0)).Return(nil)
 
 		req := httptest.NewRequest(http.MethodDelete, "/users/abebe/tasks/"+id.Hex(), nil)
 		w := httptest.NewRecorder()
@@ -966,7 +1196,7 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: id.Hex()}}
 
-		s.handler.DeleteUserTask(c)
+		serve(c, s.handler.DeleteUserTask)
 
 		s.Equal(http.StatusNoContent, w.Code)
 		s.resetMocks()
@@ -982,12 +1212,12 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.DeleteUserTask(c)
+		serve(c, s.handler.DeleteUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to delete tasks on behalf of other user", response["error"])
+		s.Equal("You do not have permission to delete tasks on behalf of other user", response["detail"])
 		s.resetMocks()
 	})
 
@@ -1001,19 +1231,19 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: ""}}
 
-		s.handler.DeleteUserTask(c)
+		serve(c, s.handler.DeleteUserTask)
 
 		s.Equal(http.StatusBadRequest, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Task ID is required", response["error"])
+		s.Equal("Task ID is required", response["detail"])
 		s.resetMocks()
 	})
 
 	s.Run("DeleteError", func() {
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("DeleteByIdAndUser", "1", "abebe").Return(errors.New("delete failed"))
+		s.mockTaskUsecase.On("DeleteByIdAndUser", "1", "abebe", int64(0)).Return(errors.New("delete failed"))
 
 		req := httptest.NewRequest(http.MethodDelete, "/users/abebe/tasks/1", nil)
 		w := httptest.NewRecorder()
@@ -1021,39 +1251,105 @@ func (s *UserHandlerSuite) TestDeleteUserTask() {
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.DeleteUserTask(c)
+		serve(c, s.handler.DeleteUserTask)
 
 		s.Equal(http.StatusInternalServerError, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to delete task", response["error"])
+		s.Equal("Failed to delete task", response["detail"])
 		s.resetMocks()
 	})
 }
 
-// TestGetUserTaskStats tests the GetUserTaskStats method
-func (s *UserHandlerSuite) TestGetUserTaskStats() {
+// TestGetUserTaskHistory tests the GetUserTaskHistory method
+func (s *UserHandlerSuite) TestGetUserTaskHistory() {
 	s.Run("Success", func() {
 		user := &domain.User{Username: "abebe"}
-		stats := []domain.StatusCount{
-			{Status: "pending", Count: 5},
-			{Status: "completed", Count: 3},
+		id := primitive.NewObjectID()
+		history := []domain.TaskHistory{{ID: primitive.NewObjectID(), TaskID: id, Action: domain.TaskCreated, Actor: "abebe", Version: 1}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("GetHistoryByIdAndUser", id.Hex(), "abebe").Return(history, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/"+id.Hex()+"/history", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: id.Hex()}}
+
+		serve(c, s.handler.GetUserTaskHistory)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response struct {
+			History []dto.TaskHistoryResponse `json:"history"`
 		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Len(response.History, 1)
+		s.Equal(domain.TaskCreated, response.History[0].Action)
+		s.Equal(id.Hex(), response.History[0].TaskID)
+		s.NotNil(response.History[0].Changes)
+		s.resetMocks()
+	})
+
+	s.Run("PermissionDenied", func() {
+		user := &domain.User{Username: "kebede"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetTaskStatsByUser", "abebe").Return(stats, nil)
 
-		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/stats", nil)
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/1/history", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+		serve(c, s.handler.GetUserTaskHistory)
+
+		s.Equal(http.StatusForbidden, w.Code)
+		s.resetMocks()
+	})
+
+	s.Run("TaskNotFound", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("GetHistoryByIdAndUser", "1", "abebe").
+			Return(nil, domain.NewError(domain.ErrNotFound, "task not found or not owned by user"))
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/1/history", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+		serve(c, s.handler.GetUserTaskHistory)
+
+		s.Equal(http.StatusNotFound, w.Code)
+		s.resetMocks()
+	})
+}
+
+// TestGetUserTrash tests the GetUserTrash method
+func (s *UserHandlerSuite) TestGetUserTrash() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		deletedAt := time.Now().Truncate(time.Second)
+		trash := []domain.Task{{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe", DeletedAt: deletedAt}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("GetTrashByUser", "abebe").Return(trash, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/trash", nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTaskStats(c)
+		serve(c, s.handler.GetUserTrash)
 
 		s.Equal(http.StatusOK, w.Code)
-		var response []domain.StatusCount
+		var response struct {
+			Tasks []dto.TaskResponse `json:"tasks"`
+		}
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal(stats, response)
+		s.Len(response.Tasks, 1)
+		s.NotNil(response.Tasks[0].DeletedAt)
+		s.True(deletedAt.Equal(*response.Tasks[0].DeletedAt))
 		s.resetMocks()
 	})
 
@@ -1061,47 +1357,644 @@ func (s *UserHandlerSuite) TestGetUserTaskStats() {
 		user := &domain.User{Username: "kebede"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
 
-		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/stats", nil)
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/trash", nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
 		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
 
-		s.handler.GetUserTaskStats(c)
+		serve(c, s.handler.GetUserTrash)
+
+		s.Equal(http.StatusForbidden, w.Code)
+		s.resetMocks()
+	})
+}
+
+// TestRestoreUserTask tests the RestoreUserTask method
+func (s *UserHandlerSuite) TestRestoreUserTask() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe", Version: 3}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("RestoreByIdAndUser", task.ID.Hex(), "abebe").Return(task, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/"+task.ID.Hex()+"/restore", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: task.ID.Hex()}}
+
+		serve(c, s.handler.RestoreUserTask)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal(`"3"`, w.Header().Get("ETag"))
+		var response dto.TaskResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(task.Title, response.Title)
+		s.Nil(response.DeletedAt)
+		s.resetMocks()
+	})
+
+	s.Run("PermissionDenied", func() {
+		user := &domain.User{Username: "kebede"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/restore", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+		serve(c, s.handler.RestoreUserTask)
 
 		s.Equal(http.StatusForbidden, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("You do not have permission to see details about this user", response["error"])
+		s.Equal("You do not have permission to restore this task", response["detail"])
 		s.resetMocks()
 	})
 
-	s.Run("FetchError", func() {
+	s.Run("NotInTrash", func() {
 		user := &domain.User{Username: "abebe"}
 		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
-		s.mockTaskUsecase.On("GetTaskStatsByUser", "abebe").Return(nil, errors.New("stats fetch failed"))
+		s.mockTaskUsecase.On("RestoreByIdAndUser", "1", "abebe").
+			Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found in trash"))
 
-		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/stats", nil)
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/restore", nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
-		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
 
-		s.handler.GetUserTaskStats(c)
+		serve(c, s.handler.RestoreUserTask)
 
-		s.Equal(http.StatusInternalServerError, w.Code)
+		s.Equal(http.StatusNotFound, w.Code)
 		var response gin.H
 		json.Unmarshal(w.Body.Bytes(), &response)
-		s.Equal("Failed to fetch task stats", response["error"])
+		s.Equal("task not found in trash", response["detail"])
 		s.resetMocks()
 	})
 }
 
-func (s *UserHandlerSuite) resetMocks() {
-	s.mockUserUsecase.ExpectedCalls = nil
-	s.mockUserUsecase.Calls = nil
-	s.mockTaskUsecase.ExpectedCalls = nil
-	s.mockTaskUsecase.Calls = nil
-	s.mockRefreshTokenUsecase.ExpectedCalls = nil
-	s.mockRefreshTokenUsecase.Calls = nil
+// TestGetAssignedTasks tests the GetAssignedTasks method
+func (s *UserHandlerSuite) TestGetAssignedTasks() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "kebede"}
+		tasks := []domain.Task{{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe", Assignees: []string{"kebede"}}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("ListTasks", domain.TaskQuery{Assignee: "kebede"}).Return(domain.TaskPage{Tasks: tasks, Total: 1, Page: 1, Limit: 20}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/kebede/tasks/assigned", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "kebede"}}
+
+		serve(c, s.handler.GetAssignedTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.TaskPageResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Len(response.Tasks, 1)
+		s.Equal([]string{"kebede"}, response.Tasks[0].Assignees)
+		s.resetMocks()
+	})
+
+	s.Run("PermissionDenied", func() {
+		user := &domain.User{Username: "kebede"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/assigned", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.GetAssignedTasks)
+
+		s.Equal(http.StatusForbidden, w.Code)
+		s.resetMocks()
+	})
+}
+
+// TestAssignUserTask tests the AssignUserTask method
+func (s *UserHandlerSuite) TestAssignUserTask() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe", Assignees: []string{"kebede"}, Version: 2}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockUserUsecase.On("GetByUsername", "kebede").Return(&domain.User{Username: "kebede"}, nil)
+		s.mockTaskUsecase.On("AssignByIdAndUser", task.ID.Hex(), "kebede", "abebe").Return(task, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/"+task.ID.Hex()+"/assignees", strings.NewReader(`{"username":"Kebede"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: task.ID.Hex()}}
+
+		serve(c, s.handler.AssignUserTask)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal(`"2"`, w.Header().Get("ETag"))
+		var response dto.TaskResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal([]string{"kebede"}, response.Assignees)
+		s.resetMocks()
+	})
+
+	s.Run("MissingUsername", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/assignees", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+		serve(c, s.handler.AssignUserTask)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.resetMocks()
+	})
+
+	s.Run("UnknownUser", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockUserUsecase.On("GetByUsername", "almaz").Return(nil, domain.NewError(domain.ErrNotFound, "user not found"))
+
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/assignees", strings.NewReader(`{"username":"almaz"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+		serve(c, s.handler.AssignUserTask)
+
+		s.Equal(http.StatusNotFound, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("user not found", response["detail"])
+		s.resetMocks()
+	})
+
+	s.Run("NotCreator", func() {
+		user := &domain.User{Username: "kebede"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockUserUsecase.On("GetByUsername", "almaz").Return(&domain.User{Username: "almaz"}, nil)
+		s.mockTaskUsecase.On("AssignByIdAndUser", "1", "almaz", "kebede").
+			Return(domain.Task{}, domain.NewError(domain.ErrForbidden, "only the task creator can assign users"))
+
+		req := httptest.NewRequest(http.MethodPost, "/users/kebede/tasks/1/assignees", strings.NewReader(`{"username":"almaz"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "kebede"}, {Key: "id", Value: "1"}}
+
+		serve(c, s.handler.AssignUserTask)
+
+		s.Equal(http.StatusForbidden, w.Code)
+		s.resetMocks()
+	})
+}
+
+// TestUnassignUserTask tests the UnassignUserTask method
+func (s *UserHandlerSuite) TestUnassignUserTask() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "kebede"}
+		task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe", Version: 3}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("UnassignByIdAndUser", task.ID.Hex(), "kebede", "kebede").Return(task, nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/users/kebede/tasks/"+task.ID.Hex()+"/assignees/kebede", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "kebede"}, {Key: "id", Value: task.ID.Hex()}, {Key: "assignee", Value: "kebede"}}
+
+		serve(c, s.handler.UnassignUserTask)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.TaskResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal([]string{}, response.Assignees)
+		s.resetMocks()
+	})
+
+	s.Run("NotAssigned", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("UnassignByIdAndUser", "1", "almaz", "abebe").
+			Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "user is not assigned to the task"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/users/abebe/tasks/1/assignees/almaz", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}, {Key: "assignee", Value: "almaz"}}
+
+		serve(c, s.handler.UnassignUserTask)
+
+		s.Equal(http.StatusNotFound, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("user is not assigned to the task", response["detail"])
+		s.resetMocks()
+	})
+}
+
+// TestGetUserSubtasks tests the GetUserSubtasks method
+func (s *UserHandlerSuite) TestGetUserSubtasks() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		parentID := primitive.NewObjectID()
+		subtasks := []domain.Task{{ID: primitive.NewObjectID(), Title: "Book hotel", CreatedBy: "abebe", ParentID: parentID}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("GetSubtasksByIdAndUser", parentID.Hex(), "abebe").Return(subtasks, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/"+parentID.Hex()+"/subtasks", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: parentID.Hex()}}
+
+		serve(c, s.handler.GetUserSubtasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response struct {
+			Tasks []dto.TaskResponse `json:"tasks"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Len(response.Tasks, 1)
+		s.Equal(parentID.Hex(), response.Tasks[0].ParentID)
+		s.resetMocks()
+	})
+}
+
+// TestCreateUserSubtask tests the CreateUserSubtask method
+func (s *UserHandlerSuite) TestCreateUserSubtask() {
+	params := gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		parentID := primitive.NewObjectID()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("CreateSubtask", "1", mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Title == "Book hotel" && len(task.Checklist) == 2
+		}), "abebe").Run(func(args mock.Arguments) {
+			task := args.Get(1).(*domain.Task)
+			task.ID = primitive.NewObjectID()
+			task.ParentID = parentID
+			task.Version = 1
+		}).Return(nil)
+
+		body := `{"title":"Book hotel","due_date":"2030-01-01T00:00:00Z","checklist":[{"text":"Compare prices","done":true},{"text":"Pay"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/subtasks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = params
+
+		serve(c, s.handler.CreateUserSubtask)
+
+		s.Equal(http.StatusCreated, w.Code)
+		var response dto.TaskResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(parentID.Hex(), response.ParentID)
+		s.Len(response.Checklist, 2)
+		s.Require().NotNil(response.Completion)
+		s.Equal(50, *response.Completion)
+		s.resetMocks()
+	})
+
+	s.Run("EmptyChecklistItem", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+
+		body := `{"title":"Book hotel","due_date":"2030-01-01T00:00:00Z","checklist":[{"text":""}]}`
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/subtasks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = params
+
+		serve(c, s.handler.CreateUserSubtask)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.resetMocks()
+	})
+
+	s.Run("SubtaskOfSubtask", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("CreateSubtask", "1", mock.Anything, "abebe").
+			Return(domain.NewError(domain.ErrValidation, "subtasks cannot have subtasks of their own"))
+
+		body := `{"title":"Book hotel","due_date":"2030-01-01T00:00:00Z"}`
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/subtasks", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = params
+
+		serve(c, s.handler.CreateUserSubtask)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("subtasks cannot have subtasks of their own", response["detail"])
+		s.resetMocks()
+	})
+}
+
+// TestGetUserTaskStats tests the GetUserTaskStats method
+func (s *UserHandlerSuite) TestGetUserTaskStats() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		stats := domain.TaskStats{
+			ByStatus:   []domain.StatusCount{{Status: "pending", Count: 5}, {Status: "completed", Count: 3}},
+			ByPriority: []domain.PriorityCount{{Priority: "high", Count: 8}},
+		}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("GetTaskStatsByUser", "abebe").Return(stats, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/stats", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.GetUserTaskStats)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.TaskStatsResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal([]dto.StatusCountResponse{{Status: "pending", Count: 5}, {Status: "completed", Count: 3}}, response.ByStatus)
+		s.Equal([]dto.PriorityCountResponse{{Priority: "high", Count: 8}}, response.ByPriority)
+		s.Equal([]dto.TagCountResponse{}, response.ByTag)
+		s.resetMocks()
+	})
+
+	s.Run("PermissionDenied", func() {
+		user := &domain.User{Username: "kebede"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/stats", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.GetUserTaskStats)
+
+		s.Equal(http.StatusForbidden, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("You do not have permission to see details about this user", response["detail"])
+		s.resetMocks()
+	})
+
+	s.Run("FetchError", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("GetTaskStatsByUser", "abebe").Return(domain.TaskStats{}, errors.New("stats fetch failed"))
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/stats", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.GetUserTaskStats)
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("Failed to fetch task stats", response["detail"])
+		s.resetMocks()
+	})
+}
+
+// TestLogout tests the Logout method
+func (s *UserHandlerSuite) TestLogout() {
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	s.Run("Success", func() {
+		s.mockRefreshTokenUsecase.On("Logout", "access-jti", "fam-1", expiresAt).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/users/logout", nil)
+		c.Set("jti", "access-jti")
+		c.Set("fam", "fam-1")
+		c.Set("exp", expiresAt)
+
+		serve(c, s.handler.Logout)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("Logged out successfully", response["message"])
+		s.resetMocks()
+	})
+
+	s.Run("LogoutError", func() {
+		s.mockRefreshTokenUsecase.On("Logout", "access-jti", "fam-1", expiresAt).Return(errors.New("database error"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/users/logout", nil)
+		c.Set("jti", "access-jti")
+		c.Set("fam", "fam-1")
+		c.Set("exp", expiresAt)
+
+		serve(c, s.handler.Logout)
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("Failed to log out", response["detail"])
+		s.resetMocks()
+	})
+}
+
+// TestLogoutAll tests the LogoutAll method
+func (s *UserHandlerSuite) TestLogoutAll() {
+	s.Run("Success", func() {
+		s.mockRefreshTokenUsecase.On("LogoutAll", "abebe").Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/users/logout-all", nil)
+		c.Set("username", "abebe")
+
+		serve(c, s.handler.LogoutAll)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("Logged out from all sessions", response["message"])
+		s.resetMocks()
+	})
+
+	s.Run("LogoutAllError", func() {
+		s.mockRefreshTokenUsecase.On("LogoutAll", "abebe").Return(errors.New("user not found"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/users/logout-all", nil)
+		c.Set("username", "abebe")
+
+		serve(c, s.handler.LogoutAll)
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+		s.resetMocks()
+	})
+}
+
+func (s *UserHandlerSuite) resetMocks() {
+	s.mockUserUsecase.ExpectedCalls = nil
+	s.mockUserUsecase.Calls = nil
+	s.mockTaskUsecase.ExpectedCalls = nil
+	s.mockTaskUsecase.Calls = nil
+	s.mockRefreshTokenUsecase.ExpectedCalls = nil
+	s.mockRefreshTokenUsecase.Calls = nil
+}
+
+// TestAddUserTaskBlocker tests the AddUserTaskBlocker method
+func (s *UserHandlerSuite) TestAddUserTaskBlocker() {
+	params := gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}}
+
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		blockerID := primitive.NewObjectID()
+		task := domain.Task{ID: primitive.NewObjectID(), Title: "Roast Coffee", BlockedBy: []primitive.ObjectID{blockerID}, Blocked: true, Version: 2}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("AddBlockerByIdAndUser", "1", blockerID.Hex(), "abebe").Return(task, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/blockers", strings.NewReader(`{"task_id":"`+blockerID.Hex()+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = params
+
+		serve(c, s.handler.AddUserTaskBlocker)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal(`"2"`, w.Header().Get("ETag"))
+		var response dto.TaskResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal([]string{blockerID.Hex()}, response.BlockedBy)
+		s.True(response.Blocked)
+		s.resetMocks()
+	})
+
+	s.Run("Cycle", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("AddBlockerByIdAndUser", "1", "2", "abebe").
+			Return(domain.Task{}, domain.NewError(domain.ErrConflict, "the blocker depends on the task, adding it would create a cycle"))
+
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/blockers", strings.NewReader(`{"task_id":"2"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = params
+
+		serve(c, s.handler.AddUserTaskBlocker)
+
+		s.Equal(http.StatusConflict, w.Code)
+		s.resetMocks()
+	})
+
+	s.Run("MissingTaskID", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/abebe/tasks/1/blockers", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = params
+
+		serve(c, s.handler.AddUserTaskBlocker)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.resetMocks()
+	})
+}
+
+// TestRemoveUserTaskBlocker tests the RemoveUserTaskBlocker method
+func (s *UserHandlerSuite) TestRemoveUserTaskBlocker() {
+	s.Run("NotBlocked", func() {
+		user := &domain.User{Username: "abebe"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("RemoveBlockerByIdAndUser", "1", "2", "abebe").
+			Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "task is not blocked by that task"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/users/abebe/tasks/1/blockers/2", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}, {Key: "id", Value: "1"}, {Key: "blockerId", Value: "2"}}
+
+		serve(c, s.handler.RemoveUserTaskBlocker)
+
+		s.Equal(http.StatusNotFound, w.Code)
+		s.resetMocks()
+	})
+}
+
+// TestGetNextUserTasks tests the GetNextUserTasks method
+func (s *UserHandlerSuite) TestGetNextUserTasks() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe"}
+		tasks := []domain.Task{{ID: primitive.NewObjectID(), Title: "Buy Coffee"}, {ID: primitive.NewObjectID(), Title: "Roast Coffee", Blocked: true}}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+		s.mockTaskUsecase.On("GetNextTasksByUser", "abebe").Return(tasks, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/next", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.GetNextUserTasks)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response struct {
+			Tasks []dto.TaskResponse `json:"tasks"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Require().Len(response.Tasks, 2)
+		s.Equal("Buy Coffee", response.Tasks[0].Title)
+		s.True(response.Tasks[1].Blocked)
+		s.resetMocks()
+	})
+
+	s.Run("OtherUser", func() {
+		user := &domain.User{Username: "kebede"}
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(user)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/abebe/tasks/next", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "username", Value: "abebe"}}
+
+		serve(c, s.handler.GetNextUserTasks)
+
+		s.Equal(http.StatusForbidden, w.Code)
+		s.resetMocks()
+	})
 }
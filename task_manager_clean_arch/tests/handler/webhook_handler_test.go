@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookHandlerSuite defines the test suite for WebhookHandler
+type WebhookHandlerSuite struct {
+	suite.Suite
+	mockWebhookUsecase *mocks_domain.IWebhookUseCase
+	mockUserUsecase    *mocks_domain.IUserUseCase
+	handler            *handler.WebhookHandler
+	webhook            domain.Webhook
+}
+
+// SetupTest initializes the mocks and handler before each test
+func (s *WebhookHandlerSuite) SetupTest() {
+	s.mockWebhookUsecase = mocks_domain.NewIWebhookUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.WebhookHandler{
+		WebhookUsecase: s.mockWebhookUsecase,
+		UserUsecase:    s.mockUserUsecase,
+	}
+	s.webhook = domain.Webhook{
+		ID:        primitive.NewObjectID(),
+		URL:       "https://example.com/hooks",
+		Events:    []string{domain.EventTaskCreated},
+		Secret:    "0123456789abcdef",
+		Active:    true,
+		CreatedBy: "admin",
+		CreatedAt: time.Now(),
+	}
+}
+
+// TestWebhookHandlerSuite runs the test suite
+func TestWebhookHandlerSuite(t *testing.T) {
+	suite.Run(t, new(WebhookHandlerSuite))
+}
+
+func (s *WebhookHandlerSuite) request(method, target, body string, params gin.Params) (*gin.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = params
+	return c, w
+}
+
+// TestCreateWebhook tests the CreateWebhook method
+func (s *WebhookHandlerSuite) TestCreateWebhook() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		s.mockWebhookUsecase.On("Create", mock.MatchedBy(func(w *domain.Webhook) bool {
+			return w.CreatedBy == "admin" && w.Active && w.URL == "https://example.com/hooks"
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.Webhook).Secret = "generated-secret-value"
+		}).Return(nil)
+		c, w := s.request(http.MethodPost, "/webhooks", `{"url":"https://example.com/hooks","events":["task.created","user.registered"]}`, nil)
+
+		serve(c, s.handler.CreateWebhook)
+
+		s.Equal(http.StatusCreated, w.Code)
+		var response dto.WebhookResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("generated-secret-value", response.Secret)
+		s.Equal([]string{"task.created", "user.registered"}, response.Events)
+		s.True(response.Active)
+	})
+
+	s.Run("Inactive", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		s.mockWebhookUsecase.On("Create", mock.MatchedBy(func(w *domain.Webhook) bool { return !w.Active })).Return(nil)
+		c, w := s.request(http.MethodPost, "/webhooks", `{"url":"https://example.com/hooks","events":["task.created"],"active":false}`, nil)
+
+		serve(c, s.handler.CreateWebhook)
+
+		s.Equal(http.StatusCreated, w.Code)
+	})
+
+	cases := []struct {
+		name string
+		body string
+	}{
+		{"MissingURL", `{"events":["task.created"]}`},
+		{"InvalidURL", `{"url":"not a url","events":["task.created"]}`},
+		{"NoEvents", `{"url":"https://example.com/hooks","events":[]}`},
+		{"UnknownEvent", `{"url":"https://example.com/hooks","events":["task.exploded"]}`},
+		{"ShortSecret", `{"url":"https://example.com/hooks","events":["task.created"],"secret":"short"}`},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+			c, w := s.request(http.MethodPost, "/webhooks", tc.body, nil)
+
+			serve(c, s.handler.CreateWebhook)
+
+			s.Equal(http.StatusBadRequest, w.Code)
+			s.mockWebhookUsecase.AssertNotCalled(s.T(), "Create", mock.Anything)
+		})
+	}
+}
+
+// TestGetWebhooks tests that the secret is never listed
+func (s *WebhookHandlerSuite) TestGetWebhooks() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockWebhookUsecase.On("GetAll").Return([]domain.Webhook{s.webhook}, nil)
+		c, w := s.request(http.MethodGet, "/webhooks", "", nil)
+
+		serve(c, s.handler.GetWebhooks)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.NotContains(w.Body.String(), s.webhook.Secret)
+		var response struct {
+			Webhooks []dto.WebhookResponse `json:"webhooks"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Require().Len(response.Webhooks, 1)
+		s.Equal(s.webhook.ID.Hex(), response.Webhooks[0].ID)
+	})
+}
+
+// TestGetWebhook tests the GetWebhook method
+func (s *WebhookHandlerSuite) TestGetWebhook() {
+	s.Run("NotFound", func() {
+		s.SetupTest()
+		s.mockWebhookUsecase.On("GetById", "missing").Return(domain.Webhook{}, domain.NewError(domain.ErrNotFound, "webhook not found"))
+		c, w := s.request(http.MethodGet, "/webhooks/missing", "", gin.Params{{Key: "id", Value: "missing"}})
+
+		serve(c, s.handler.GetWebhook)
+
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+// TestUpdateWebhook tests the UpdateWebhook method
+func (s *WebhookHandlerSuite) TestUpdateWebhook() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		id := s.webhook.ID.Hex()
+		s.mockWebhookUsecase.On("Update", id, mock.MatchedBy(func(w *domain.Webhook) bool {
+			return w.URL == "https://example.com/other" && w.Secret == ""
+		})).Run(func(args mock.Arguments) {
+			updated := args.Get(1).(*domain.Webhook)
+			updated.ID, updated.Secret, updated.CreatedBy = s.webhook.ID, s.webhook.Secret, "admin"
+		}).Return(nil)
+		c, w := s.request(http.MethodPut, "/webhooks/"+id, `{"url":"https://example.com/other","events":["task.deleted"]}`, gin.Params{{Key: "id", Value: id}})
+
+		serve(c, s.handler.UpdateWebhook)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.NotContains(w.Body.String(), s.webhook.Secret)
+		var response dto.WebhookResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(id, response.ID)
+		s.Equal("admin", response.CreatedBy)
+	})
+}
+
+// TestDeleteWebhook tests the DeleteWebhook method
+func (s *WebhookHandlerSuite) TestDeleteWebhook() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockWebhookUsecase.On("Delete", "1").Return(nil)
+		c, w := s.request(http.MethodDelete, "/webhooks/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		serve(c, s.handler.DeleteWebhook)
+
+		s.Equal(http.StatusNoContent, w.Code)
+	})
+}
+
+// TestWebhookDeliveries tests listing and redelivering deliveries
+func (s *WebhookHandlerSuite) TestWebhookDeliveries() {
+	id := primitive.NewObjectID().Hex()
+	delivered := domain.WebhookDelivery{
+		ID:             primitive.NewObjectID(),
+		Event:          domain.Event{ID: primitive.NewObjectID(), Type: domain.EventTaskCreated},
+		Status:         domain.DeliverySucceeded,
+		Attempts:       1,
+		LastStatusCode: 200,
+		NextAttemptAt:  time.Now(),
+		DeliveredAt:    time.Now(),
+	}
+
+	s.Run("GetDeliveries", func() {
+		s.SetupTest()
+		s.mockWebhookUsecase.On("GetDeliveries", id).Return([]domain.WebhookDelivery{delivered}, nil)
+		c, w := s.request(http.MethodGet, "/webhooks/"+id+"/deliveries", "", gin.Params{{Key: "id", Value: id}})
+
+		serve(c, s.handler.GetWebhookDeliveries)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response struct {
+			Deliveries []dto.WebhookDeliveryResponse `json:"deliveries"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Require().Len(response.Deliveries, 1)
+		s.Equal(delivered.Event.ID.Hex(), response.Deliveries[0].EventID)
+		s.Equal(domain.EventTaskCreated, response.Deliveries[0].Event)
+		s.Equal(200, response.Deliveries[0].LastStatusCode)
+		s.Nil(response.Deliveries[0].NextAttemptAt)
+		s.NotNil(response.Deliveries[0].DeliveredAt)
+	})
+
+	s.Run("Redeliver", func() {
+		s.SetupTest()
+		redelivery := domain.WebhookDelivery{ID: primitive.NewObjectID(), Event: delivered.Event, Status: domain.DeliveryPending, NextAttemptAt: time.Now()}
+		s.mockWebhookUsecase.On("Redeliver", id, delivered.ID.Hex()).Return(redelivery, nil)
+		c, w := s.request(http.MethodPost, "/webhooks/"+id+"/deliveries/"+delivered.ID.Hex()+"/redeliver", "", gin.Params{{Key: "id", Value: id}, {Key: "deliveryId", Value: delivered.ID.Hex()}})
+
+		serve(c, s.handler.RedeliverWebhook)
+
+		s.Equal(http.StatusAccepted, w.Code)
+		var response dto.WebhookDeliveryResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(redelivery.ID.Hex(), response.ID)
+		s.Equal(domain.DeliveryPending, response.Status)
+		s.NotNil(response.NextAttemptAt)
+	})
+
+	s.Run("RedeliverNotFound", func() {
+		s.SetupTest()
+		s.mockWebhookUsecase.On("Redeliver", id, "missing").Return(domain.WebhookDelivery{}, domain.NewError(domain.ErrNotFound, "webhook delivery not found"))
+		c, w := s.request(http.MethodPost, "/webhooks/"+id+"/deliveries/missing/redeliver", "", gin.Params{{Key: "id", Value: id}, {Key: "deliveryId", Value: "missing"}})
+
+		serve(c, s.handler.RedeliverWebhook)
+
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+}
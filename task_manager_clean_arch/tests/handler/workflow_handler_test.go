@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/handler"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+)
+
+// WorkflowHandlerSuite defines the test suite for WorkflowHandler
+type WorkflowHandlerSuite struct {
+	suite.Suite
+	mockWorkflowUsecase *mocks_domain.IWorkflowUseCase
+	mockUserUsecase     *mocks_domain.IUserUseCase
+	handler             *handler.WorkflowHandler
+}
+
+// SetupTest initializes the mocks and handler before each test
+func (s *WorkflowHandlerSuite) SetupTest() {
+	s.mockWorkflowUsecase = mocks_domain.NewIWorkflowUseCase(s.T())
+	s.mockUserUsecase = mocks_domain.NewIUserUseCase(s.T())
+	s.handler = &handler.WorkflowHandler{
+		WorkflowUsecase: s.mockWorkflowUsecase,
+		UserUsecase:     s.mockUserUsecase,
+	}
+}
+
+// TestWorkflowHandlerSuite runs the test suite
+func TestWorkflowHandlerSuite(t *testing.T) {
+	suite.Run(t, new(WorkflowHandlerSuite))
+}
+
+// TestGetWorkflow tests the GetWorkflow method
+func (s *WorkflowHandlerSuite) TestGetWorkflow() {
+	s.Run("Default", func() {
+		s.SetupTest()
+		s.mockWorkflowUsecase.On("Get").Return(domain.DefaultWorkflow(), nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/workflow", nil)
+
+		serve(c, s.handler.GetWorkflow)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.WorkflowResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("pending", response.InitialStatus)
+		s.Equal([]string{"completed"}, response.Transitions["pending"])
+		s.Nil(response.UpdatedAt)
+	})
+}
+
+// TestUpdateWorkflow tests the UpdateWorkflow method
+func (s *WorkflowHandlerSuite) TestUpdateWorkflow() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		s.mockWorkflowUsecase.On("Update", mock.MatchedBy(func(w *domain.Workflow) bool {
+			return w.UpdatedBy == "admin" && len(w.Statuses) == 3
+		})).Return(nil)
+		body := `{"statuses":["todo","in_progress","done"],"transitions":{"todo":["in_progress"],"in_progress":["done"]}}`
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/workflow", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		serve(c, s.handler.UpdateWorkflow)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response dto.WorkflowResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal("todo", response.InitialStatus)
+		s.Equal([]string{}, response.Transitions["done"])
+	})
+
+	s.Run("NoStatuses", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/workflow", strings.NewReader(`{"statuses":[]}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		serve(c, s.handler.UpdateWorkflow)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	s.Run("UnknownTransition", func() {
+		s.SetupTest()
+		s.mockUserUsecase.On("GetUserFromContext", mock.Anything).Return(&domain.User{Username: "admin"})
+		s.mockWorkflowUsecase.On("Update", mock.Anything).Return(domain.NewError(domain.ErrValidation, `transition to unknown status "review"`))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/workflow", strings.NewReader(`{"statuses":["todo"],"transitions":{"todo":["review"]}}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		serve(c, s.handler.UpdateWorkflow)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		var response gin.H
+		json.Unmarshal(w.Body.Bytes(), &response)
+		s.Equal(`transition to unknown status "review"`, response["detail"])
+	})
+}
@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/importer"
+)
+
+// ImporterSuite defines the test suite for reading import files
+type ImporterSuite struct {
+	suite.Suite
+}
+
+// TestImporterSuite runs the test suite
+func TestImporterSuite(t *testing.T) {
+	suite.Run(t, new(ImporterSuite))
+}
+
+// TestUnsupportedType tests that only CSV and NDJSON files are read
+func (s *ImporterSuite) TestUnsupportedType() {
+	_, err := importer.ReadTasks("application/json", strings.NewReader("[]"), 10)
+
+	s.True(errors.Is(err, domain.ErrUnsupportedType))
+}
+
+// TestCSV tests reading tasks from CSV files
+func (s *ImporterSuite) TestCSV() {
+	s.Run("Success", func() {
+		file := "\ufeffTitle,Description,due_date,tags,priority,status,rrule,id\n" +
+			"Buy Coffee,\"From Merkato\nAsk for Yirgacheffe\",2030-01-31,home; errands,high,pending,FREQ=WEEKLY,64b7f1c2e1d3a8b9c0d1e2f3\n" +
+			"'=SUM(A1),,2030-01-31T09:00:00+03:00,,,'@review,,\n"
+
+		rows, err := importer.ReadTasks(importer.MediaTypeCSV, strings.NewReader(file), 10)
+
+		s.Require().NoError(err)
+		s.Require().Len(rows, 2)
+		s.Equal(2, rows[0].Line)
+		s.NoError(rows[0].Err)
+		s.Equal("Buy Coffee", rows[0].Request.Title)
+		s.Equal("From Merkato\nAsk for Yirgacheffe", rows[0].Request.Description)
+		s.True(time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC).Equal(rows[0].Request.DueDate))
+		s.Equal([]string{"home", "errands"}, rows[0].Request.Tags)
+		s.Equal("high", rows[0].Request.Priority)
+		s.Equal("FREQ=WEEKLY", rows[0].Request.RRule)
+		s.Equal(4, rows[1].Line)
+		s.Equal("=SUM(A1)", rows[1].Request.Title)
+		s.Equal("@review", rows[1].Request.Status)
+		s.True(time.Date(2030, 1, 31, 6, 0, 0, 0, time.UTC).Equal(rows[1].Request.DueDate))
+	})
+
+	s.Run("RowErrors", func() {
+		file := "title,due_date\n" +
+			"Buy Coffee,31/01/2030\n" +
+			"Sell \"Spices\",2030-01-31\n" +
+			"Roast Coffee,2030-01-31\n"
+
+		rows, err := importer.ReadTasks(importer.MediaTypeCSV, strings.NewReader(file), 10)
+
+		s.Require().NoError(err)
+		s.Require().Len(rows, 3)
+		var domainErr *domain.Error
+		s.Require().True(errors.As(rows[0].Err, &domainErr))
+		s.Equal("due_date", domainErr.Fields[0].Field)
+		s.True(errors.Is(rows[1].Err, domain.ErrValidation))
+		s.Equal(3, rows[1].Line)
+		s.NoError(rows[2].Err)
+		s.Equal("Roast Coffee", rows[2].Request.Title)
+	})
+
+	s.Run("NoTitleColumn", func() {
+		_, err := importer.ReadTasks(importer.MediaTypeCSV, strings.NewReader("name\nBuy Coffee\n"), 10)
+
+		s.True(errors.Is(err, domain.ErrValidation))
+	})
+
+	s.Run("Empty", func() {
+		_, err := importer.ReadTasks(importer.MediaTypeCSV, strings.NewReader(""), 10)
+
+		s.True(errors.Is(err, domain.ErrValidation))
+	})
+
+	s.Run("TooManyRows", func() {
+		_, err := importer.ReadTasks(importer.MediaTypeCSV, strings.NewReader("title\na\nb\nc\n"), 2)
+
+		s.True(errors.Is(err, domain.ErrTooLarge))
+	})
+}
+
+// TestNDJSON tests reading tasks from NDJSON files
+func (s *ImporterSuite) TestNDJSON() {
+	s.Run("Success", func() {
+		file := `{"title":"Buy Coffee","due_date":"2030-01-31T09:00:00Z","tags":["home"],"checklist":[{"text":"Grind beans"}]}` + "\n\n" +
+			`{"title":` + "\n" +
+			`{"title":"Sell Spices","due_date":"2030-01-31T09:00:00Z"}`
+
+		rows, err := importer.ReadTasks("application/ndjson", strings.NewReader(file), 10)
+
+		s.Require().NoError(err)
+		s.Require().Len(rows, 3)
+		s.NoError(rows[0].Err)
+		s.Equal("Buy Coffee", rows[0].Request.Title)
+		s.Equal("Grind beans", rows[0].Request.Checklist[0].Text)
+		s.Equal(3, rows[1].Line)
+		s.True(errors.Is(rows[1].Err, domain.ErrValidation))
+		s.Equal(4, rows[2].Line)
+		s.Equal("Sell Spices", rows[2].Request.Title)
+	})
+
+	s.Run("TooManyRows", func() {
+		_, err := importer.ReadTasks(importer.MediaTypeNDJSON, strings.NewReader("{}\n{}\n{}\n"), 2)
+
+		s.True(errors.Is(err, domain.ErrTooLarge))
+	})
+
+	s.Run("LineTooLong", func() {
+		_, err := importer.ReadTasks(importer.MediaTypeNDJSON, strings.NewReader(`{"title":"`+strings.Repeat("a", 2<<20)+`"}`), 2)
+
+		s.True(errors.Is(err, domain.ErrTooLarge))
+	})
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/patch"
+	"github.com/yiheyistm/task_manager/internal/interfaces/middleware"
+)
+
+// ErrorHandlerSuite defines the test suite for the error middleware
+type ErrorHandlerSuite struct {
+	suite.Suite
+}
+
+// TestErrorHandlerSuite runs the test suite
+func TestErrorHandlerSuite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	suite.Run(t, new(ErrorHandlerSuite))
+}
+
+// serve runs a request through the error middleware and the handler
+func (s *ErrorHandlerSuite) serve(handle gin.HandlerFunc) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.GET("/tasks/:id", handle)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/1", nil))
+	return w
+}
+
+// TestStatusFor tests the mapping of errors to HTTP statuses
+func (s *ErrorHandlerSuite) TestStatusFor() {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{domain.NewError(domain.ErrValidation, "invalid ObjectID"), http.StatusBadRequest},
+		{domain.ErrInvalidRefreshToken, http.StatusUnauthorized},
+		{domain.NewError(domain.ErrForbidden, "not yours"), http.StatusForbidden},
+		{domain.NewError(domain.ErrNotFound, "task not found"), http.StatusNotFound},
+		{domain.NewError(domain.ErrConflict, "already exists"), http.StatusConflict},
+		{domain.ErrVersionConflict, http.StatusPreconditionFailed},
+		{fmt.Errorf("wrapped: %w", domain.ErrInvalidCursor), http.StatusBadRequest},
+		{patch.ErrUnsupportedPatchType, http.StatusUnsupportedMediaType},
+		{domain.NewError(domain.ErrUnsupportedType, "file type is not allowed"), http.StatusUnsupportedMediaType},
+		{domain.NewError(domain.ErrTooLarge, "file is too large"), http.StatusRequestEntityTooLarge},
+		{domain.NewError(domain.ErrTooManyRequests, "slow down"), http.StatusTooManyRequests},
+		{errors.New("connection reset"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		s.Equal(tc.status, middleware.StatusFor(tc.err), tc.err.Error())
+	}
+}
+
+// TestErrorHandler tests the problem details written for recorded errors
+func (s *ErrorHandlerSuite) TestErrorHandler() {
+	s.Run("DomainError", func() {
+		w := s.serve(func(c *gin.Context) {
+			c.Error(domain.NewError(domain.ErrNotFound, "task not found"))
+		})
+
+		s.Equal(http.StatusNotFound, w.Code)
+		s.Equal(dto.ProblemContentType, w.Header().Get("Content-Type"))
+		var problem dto.ProblemResponse
+		json.Unmarshal(w.Body.Bytes(), &problem)
+		s.Equal(dto.ProblemResponse{Type: "about:blank", Title: "Not Found", Status: 404, Detail: "task not found", Instance: "/tasks/1"}, problem)
+	})
+
+	s.Run("FieldErrors", func() {
+		w := s.serve(func(c *gin.Context) {
+			c.Error(&domain.Error{
+				Kind:    domain.ErrValidation,
+				Message: "Request has invalid fields",
+				Fields:  []domain.FieldError{{Field: "title", Message: "is required"}},
+			})
+		})
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		var problem dto.ProblemResponse
+		json.Unmarshal(w.Body.Bytes(), &problem)
+		s.Equal([]dto.FieldProblemResponse{{Field: "title", Message: "is required"}}, problem.Errors)
+	})
+
+	s.Run("UnexpectedErrorIsHidden", func() {
+		w := s.serve(func(c *gin.Context) {
+			c.Error(errors.New("dial tcp: connection refused")).SetMeta("Failed to retrieve task")
+		})
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+		var problem dto.ProblemResponse
+		json.Unmarshal(w.Body.Bytes(), &problem)
+		s.Equal("Failed to retrieve task", problem.Detail)
+	})
+
+	s.Run("ResponseAlreadyWritten", func() {
+		w := s.serve(func(c *gin.Context) {
+			c.Error(errors.New("logged only"))
+			c.JSON(http.StatusOK, gin.H{"message": "ok"})
+		})
+
+		s.Equal(http.StatusOK, w.Code)
+		s.JSONEq(`{"message":"ok"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/dto"
+	"github.com/yiheyistm/task_manager/internal/interfaces/middleware"
+	"github.com/yiheyistm/task_manager/mocks/mocks_domain"
+)
+
+// RateLimitSuite defines the test suite for the rate limit middleware
+type RateLimitSuite struct {
+	suite.Suite
+	store domain.RateLimitStore
+	limit domain.RateLimit
+}
+
+// SetupTest creates an empty store and a bucket of 2 requests for every test
+func (s *RateLimitSuite) SetupTest() {
+	s.store = persistence.NewMemoryRateLimitStore()
+	s.limit = domain.RateLimit{Burst: 2, Every: 30 * time.Second}
+}
+
+// TestRateLimitSuite runs the test suite
+func TestRateLimitSuite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	suite.Run(t, new(RateLimitSuite))
+}
+
+// serve runs a request from the address through the rate limiter, as the
+// user when one is given
+func (s *RateLimitSuite) serve(limit domain.RateLimit, remoteAddr, username string) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.Use(func(c *gin.Context) {
+		if username != "" {
+			c.Set("username", username)
+		}
+	})
+	r.Use(middleware.RateLimitMiddleware(s.store, "test", limit))
+	r.GET("/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestHeaders tests the rate limit headers of allowed requests
+func (s *RateLimitSuite) TestHeaders() {
+	w := s.serve(s.limit, "10.0.0.1:1234", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("2", w.Header().Get("RateLimit-Limit"))
+	s.Equal("1", w.Header().Get("RateLimit-Remaining"))
+	s.Equal("30", w.Header().Get("RateLimit-Reset"))
+	s.Empty(w.Header().Get("Retry-After"))
+}
+
+// TestTooManyRequests tests that a client is refused once its bucket is empty
+func (s *RateLimitSuite) TestTooManyRequests() {
+	s.serve(s.limit, "10.0.0.1:1234", "")
+	s.serve(s.limit, "10.0.0.1:1234", "")
+
+	w := s.serve(s.limit, "10.0.0.1:1234", "")
+
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("0", w.Header().Get("RateLimit-Remaining"))
+	s.Equal("30", w.Header().Get("Retry-After"))
+	s.Equal(dto.ProblemContentType, w.Header().Get("Content-Type"))
+	var problem dto.ProblemResponse
+	json.Unmarshal(w.Body.Bytes(), &problem)
+	s.Equal("Too Many Requests", problem.Title)
+	s.Equal("Too many requests, retry after 30 seconds", problem.Detail)
+}
+
+// TestKeys tests that clients are told apart by user, or by IP when anonymous
+func (s *RateLimitSuite) TestKeys() {
+	s.serve(s.limit, "10.0.0.1:1234", "abebe")
+	s.serve(s.limit, "10.0.0.1:1234", "abebe")
+
+	s.Run("SameUserOtherIP", func() {
+		s.Equal(http.StatusTooManyRequests, s.serve(s.limit, "10.0.0.2:1234", "abebe").Code)
+	})
+
+	s.Run("OtherUserSameIP", func() {
+		s.Equal(http.StatusOK, s.serve(s.limit, "10.0.0.1:1234", "kebede").Code)
+	})
+
+	s.Run("AnonymousSameIP", func() {
+		s.Equal(http.StatusOK, s.serve(s.limit, "10.0.0.1:1234", "").Code)
+	})
+}
+
+// TestDisabled tests that a zero limit lets every request through
+func (s *RateLimitSuite) TestDisabled() {
+	for range 5 {
+		w := s.serve(domain.RateLimit{}, "10.0.0.1:1234", "")
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Empty(w.Header().Get("RateLimit-Limit"))
+	}
+}
+
+// TestStoreError tests that requests are let through when the store fails
+func (s *RateLimitSuite) TestStoreError() {
+	store := mocks_domain.NewRateLimitStore(s.T())
+	store.On("Take", mock.Anything, "test:ip:10.0.0.1", s.limit, mock.AnythingOfType("time.Time")).
+		Return(domain.RateLimitResult{}, errors.New("connection reset"))
+	s.store = store
+
+	w := s.serve(s.limit, "10.0.0.1:1234", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Empty(w.Header().Get("RateLimit-Limit"))
+}
+
+// TestChained tests that the headers of chained limiters show the tighter
+// bucket, whichever runs first
+func (s *RateLimitSuite) TestChained() {
+	loose := domain.RateLimit{Burst: 10, Every: time.Second}
+	serve := func(limits ...domain.RateLimit) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(middleware.ErrorHandler())
+		for i, limit := range limits {
+			r.Use(middleware.RateLimitMiddleware(s.store, string(rune('a'+i)), limit))
+		}
+		r.GET("/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	s.Run("TighterLast", func() {
+		s.SetupTest()
+		w := serve(loose, s.limit)
+
+		s.Equal("2", w.Header().Get("RateLimit-Limit"))
+		s.Equal("1", w.Header().Get("RateLimit-Remaining"))
+		s.Equal("30", w.Header().Get("RateLimit-Reset"))
+	})
+
+	s.Run("TighterFirst", func() {
+		s.SetupTest()
+		w := serve(s.limit, loose)
+
+		s.Equal("2", w.Header().Get("RateLimit-Limit"))
+		s.Equal("1", w.Header().Get("RateLimit-Remaining"))
+		s.Equal("30", w.Header().Get("RateLimit-Reset"))
+	})
+
+	s.Run("RefusedLast", func() {
+		s.SetupTest()
+		first := domain.RateLimit{Burst: 3, Every: time.Second}
+		serve(first, s.limit)
+		serve(first, s.limit)
+
+		w := serve(first, s.limit)
+
+		s.Equal(http.StatusTooManyRequests, w.Code)
+		s.Equal("2", w.Header().Get("RateLimit-Limit"))
+		s.Equal("0", w.Header().Get("RateLimit-Remaining"))
+		s.Equal("30", w.Header().Get("Retry-After"))
+	})
+}
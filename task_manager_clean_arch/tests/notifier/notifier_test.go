@@ -0,0 +1,212 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/notifier"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotifierSuite defines the test suite for the reminder notifiers
+type NotifierSuite struct {
+	suite.Suite
+	reminder domain.Reminder
+}
+
+// SetupTest builds the reminder sent by every test
+func (s *NotifierSuite) SetupTest() {
+	s.reminder = domain.Reminder{
+		Kind: domain.ReminderOverdue,
+		Task: domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", DueDate: time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)},
+		Recipients: []domain.ReminderRecipient{
+			{Username: "abebe", Email: "abebe@example.com"},
+			{Username: "kebede"},
+		},
+	}
+}
+
+// TestNotifierSuite runs the test suite
+func TestNotifierSuite(t *testing.T) {
+	suite.Run(t, new(NotifierSuite))
+}
+
+// smtpMessage is a message received by the test SMTP server.
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// startSMTPServer accepts a single SMTP session on a local port and sends the
+// message it receives on the channel.
+func (s *NotifierSuite) startSMTPServer() (string, string, <-chan smtpMessage) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	messages := make(chan smtpMessage, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		var message smtpMessage
+		reply("220 localhost ready")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.TrimSpace(line)
+			switch verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				message.from = strings.Trim(strings.TrimPrefix(command, "MAIL FROM:"), "<>")
+				reply("250 OK")
+			case "RCPT":
+				message.to = append(message.to, strings.Trim(strings.TrimPrefix(command, "RCPT TO:"), "<>"))
+				reply("250 OK")
+			case "DATA":
+				reply("354 Go ahead")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				message.data = data.String()
+				messages <- message
+				reply("250 OK")
+			case "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	s.Require().NoError(err)
+	return host, port, messages
+}
+
+// TestSMTPNotifier tests that reminders are emailed to the recipients with
+// an email address
+func (s *NotifierSuite) TestSMTPNotifier() {
+	s.Run("Success", func() {
+		host, port, messages := s.startSMTPServer()
+
+		err := notifier.NewSMTPNotifier(host, port, "", "", "tasks@example.com").Notify(context.Background(), s.reminder)
+
+		s.NoError(err)
+		select {
+		case message := <-messages:
+			s.Equal("tasks@example.com", message.from)
+			s.Equal([]string{"abebe@example.com"}, message.to)
+			s.Contains(message.data, "Subject: Task \"Buy Coffee\" is overdue\r\n")
+			s.Contains(message.data, "Task ID: "+s.reminder.Task.ID.Hex())
+		case <-time.After(time.Second):
+			s.Fail("no message was received")
+		}
+	})
+
+	s.Run("NoEmailAddresses", func() {
+		s.reminder.Recipients = []domain.ReminderRecipient{{Username: "kebede"}}
+
+		err := notifier.NewSMTPNotifier("127.0.0.1", "1", "", "", "tasks@example.com").Notify(context.Background(), s.reminder)
+
+		s.NoError(err)
+	})
+
+	s.Run("ServerDown", func() {
+		s.SetupTest()
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		s.Require().NoError(err)
+		host, port, _ := net.SplitHostPort(listener.Addr().String())
+		listener.Close()
+
+		err = notifier.NewSMTPNotifier(host, port, "", "", "tasks@example.com").Notify(context.Background(), s.reminder)
+
+		s.Error(err)
+	})
+}
+
+// TestWebhookNotifier tests that reminders are posted as JSON
+func (s *NotifierSuite) TestWebhookNotifier() {
+	s.Run("Success", func() {
+		var payload notifier.ReminderPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s.Equal(http.MethodPost, r.Method)
+			s.Equal("application/json", r.Header.Get("Content-Type"))
+			s.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err := notifier.NewWebhookNotifier(server.URL).Notify(context.Background(), s.reminder)
+
+		s.NoError(err)
+		s.Equal(notifier.ReminderPayload{
+			Kind:       domain.ReminderOverdue,
+			TaskID:     s.reminder.Task.ID.Hex(),
+			Title:      "Buy Coffee",
+			DueDate:    s.reminder.Task.DueDate,
+			Status:     "pending",
+			Recipients: []string{"abebe", "kebede"},
+		}, payload)
+	})
+
+	s.Run("ErrorStatus", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		err := notifier.NewWebhookNotifier(server.URL).Notify(context.Background(), s.reminder)
+
+		s.EqualError(err, "webhook responded with 500 Internal Server Error")
+	})
+}
+
+// TestNewNotifier tests that the notifier is chosen by NOTIFIER
+func (s *NotifierSuite) TestNewNotifier() {
+	cases := []struct {
+		name     string
+		env      config.Env
+		expected domain.Notifier
+		err      string
+	}{
+		{"Log", config.Env{Notifier: config.NotifierLog}, &notifier.LogNotifier{}, ""},
+		{"SMTP", config.Env{Notifier: config.NotifierSMTP, SMTPHost: "localhost", SMTPPort: "1025"}, &notifier.SMTPNotifier{Addr: "localhost:1025"}, ""},
+		{"WebhookWithoutURL", config.Env{Notifier: config.NotifierWebhook}, nil, "REMINDER_WEBHOOK_URL is required by the webhook notifier"},
+		{"Unknown", config.Env{Notifier: "pigeon"}, nil, `unknown NOTIFIER "pigeon"`},
+	}
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			result, err := notifier.NewNotifier(&c.env)
+
+			if c.err != "" {
+				s.EqualError(err, c.err)
+				return
+			}
+			s.NoError(err)
+			s.Equal(c.expected, result)
+		})
+	}
+}
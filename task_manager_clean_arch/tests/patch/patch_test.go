@@ -0,0 +1,124 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/patch"
+)
+
+// PatchSuite defines the test suite for the patch package
+type PatchSuite struct {
+	suite.Suite
+}
+
+// TestPatchSuite runs the test suite
+func TestPatchSuite(t *testing.T) {
+	suite.Run(t, new(PatchSuite))
+}
+
+const document = `{"title":"Buy Coffee","status":"pending","tags":["errand"],"meta":{"a":1,"b":2}}`
+
+// TestMergePatch tests the MergePatch function against the RFC 7396 rules
+func (s *PatchSuite) TestMergePatch() {
+	cases := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{"ReplaceMember", `{"status":"completed"}`, `{"title":"Buy Coffee","status":"completed","tags":["errand"],"meta":{"a":1,"b":2}}`},
+		{"RemoveMember", `{"title":null}`, `{"status":"pending","tags":["errand"],"meta":{"a":1,"b":2}}`},
+		{"NestedObject", `{"meta":{"a":null,"c":3}}`, `{"title":"Buy Coffee","status":"pending","tags":["errand"],"meta":{"b":2,"c":3}}`},
+		{"ArraysAreReplaced", `{"tags":["home"]}`, `{"title":"Buy Coffee","status":"pending","tags":["home"],"meta":{"a":1,"b":2}}`},
+		{"EmptyPatch", `{}`, document},
+		{"NonObjectPatch", `["x"]`, `["x"]`},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			result, err := patch.MergePatch([]byte(document), []byte(tc.patch))
+
+			s.NoError(err)
+			s.JSONEq(tc.want, string(result))
+		})
+	}
+
+	s.Run("InvalidJSON", func() {
+		_, err := patch.MergePatch([]byte(document), []byte(`{"title":`))
+
+		s.ErrorIs(err, patch.ErrInvalidPatch)
+	})
+}
+
+// TestJSONPatch tests the JSONPatch function against the RFC 6902 operations
+func (s *PatchSuite) TestJSONPatch() {
+	cases := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{"Replace", `[{"op":"replace","path":"/status","value":"completed"}]`, `{"title":"Buy Coffee","status":"completed","tags":["errand"],"meta":{"a":1,"b":2}}`},
+		{"AddMember", `[{"op":"add","path":"/meta/c","value":3}]`, `{"title":"Buy Coffee","status":"pending","tags":["errand"],"meta":{"a":1,"b":2,"c":3}}`},
+		{"AppendToArray", `[{"op":"add","path":"/tags/-","value":"home"}]`, `{"title":"Buy Coffee","status":"pending","tags":["errand","home"],"meta":{"a":1,"b":2}}`},
+		{"InsertIntoArray", `[{"op":"add","path":"/tags/0","value":"home"}]`, `{"title":"Buy Coffee","status":"pending","tags":["home","errand"],"meta":{"a":1,"b":2}}`},
+		{"Remove", `[{"op":"remove","path":"/meta/a"},{"op":"remove","path":"/tags/0"}]`, `{"title":"Buy Coffee","status":"pending","tags":[],"meta":{"b":2}}`},
+		{"Move", `[{"op":"move","from":"/meta/a","path":"/a"}]`, `{"title":"Buy Coffee","status":"pending","tags":["errand"],"meta":{"b":2},"a":1}`},
+		{"Copy", `[{"op":"copy","from":"/meta","path":"/copy"},{"op":"remove","path":"/copy/a"}]`, `{"title":"Buy Coffee","status":"pending","tags":["errand"],"meta":{"a":1,"b":2},"copy":{"b":2}}`},
+		{"TestThenReplace", `[{"op":"test","path":"/status","value":"pending"},{"op":"replace","path":"/status","value":"completed"}]`, `{"title":"Buy Coffee","status":"completed","tags":["errand"],"meta":{"a":1,"b":2}}`},
+		{"EscapedPointer", `[{"op":"add","path":"/meta/a~1b~0c","value":true}]`, `{"title":"Buy Coffee","status":"pending","tags":["errand"],"meta":{"a":1,"b":2,"a/b~c":true}}`},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			result, err := patch.JSONPatch([]byte(document), []byte(tc.patch))
+
+			s.NoError(err)
+			s.JSONEq(tc.want, string(result))
+		})
+	}
+
+	s.Run("TestFails", func() {
+		_, err := patch.JSONPatch([]byte(document), []byte(`[{"op":"test","path":"/status","value":"completed"}]`))
+
+		s.ErrorIs(err, patch.ErrTestFailed)
+	})
+
+	errorCases := map[string]string{
+		"ReplaceMissingMember": `[{"op":"replace","path":"/due_date","value":"x"}]`,
+		"RemoveMissingMember":  `[{"op":"remove","path":"/meta/z"}]`,
+		"IndexOutOfRange":      `[{"op":"add","path":"/tags/5","value":"x"}]`,
+		"UnknownOp":            `[{"op":"merge","path":"/status","value":"x"}]`,
+		"MissingValue":         `[{"op":"add","path":"/status"}]`,
+		"MissingPath":          `[{"op":"remove"}]`,
+		"BadPointer":           `[{"op":"remove","path":"status"}]`,
+		"NotAnArray":           `{"op":"remove","path":"/status"}`,
+	}
+	for name, patchDoc := range errorCases {
+		s.Run(name, func() {
+			_, err := patch.JSONPatch([]byte(document), []byte(patchDoc))
+
+			s.ErrorIs(err, patch.ErrInvalidPatch)
+		})
+	}
+}
+
+// TestApply tests that Apply picks the patch format from the content type
+func (s *PatchSuite) TestApply() {
+	s.Run("MergePatch", func() {
+		result, err := patch.Apply([]byte(document), []byte(`{"status":"completed"}`), patch.MergePatchContentType)
+
+		s.NoError(err)
+		s.Contains(string(result), `"status":"completed"`)
+	})
+
+	s.Run("JSONPatch", func() {
+		result, err := patch.Apply([]byte(document), []byte(`[{"op":"replace","path":"/status","value":"completed"}]`), patch.JSONPatchContentType)
+
+		s.NoError(err)
+		s.Contains(string(result), `"status":"completed"`)
+	})
+
+	s.Run("Unsupported", func() {
+		_, err := patch.Apply([]byte(document), []byte(`status=completed`), "application/x-www-form-urlencoded")
+
+		s.ErrorIs(err, patch.ErrUnsupportedPatchType)
+	})
+}
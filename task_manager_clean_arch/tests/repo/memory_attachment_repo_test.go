@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryAttachmentRepositorySuite defines the test suite for the in-memory
+// attachment repository
+type MemoryAttachmentRepositorySuite struct {
+	suite.Suite
+	repo domain.AttachmentRepository
+	ctx  context.Context
+}
+
+// SetupTest creates an empty repository for every test
+func (s *MemoryAttachmentRepositorySuite) SetupTest() {
+	s.repo = persistence.NewMemoryAttachmentRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryAttachmentRepositorySuite runs the test suite
+func TestMemoryAttachmentRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryAttachmentRepositorySuite))
+}
+
+func (s *MemoryAttachmentRepositorySuite) createAttachment(taskID primitive.ObjectID, filename string, createdAt time.Time) domain.Attachment {
+	attachment := &domain.Attachment{TaskID: taskID, Filename: filename, ContentType: "text/plain; charset=utf-8", Size: 11, UploadedBy: "abebe", CreatedAt: createdAt}
+	s.Require().NoError(s.repo.Create(s.ctx, attachment))
+	return *attachment
+}
+
+// TestAttachments tests creating, listing and deleting attachments
+func (s *MemoryAttachmentRepositorySuite) TestAttachments() {
+	taskID, otherTaskID := primitive.NewObjectID(), primitive.NewObjectID()
+	now := time.Now()
+	later := s.createAttachment(taskID, "later.txt", now.Add(time.Minute))
+	first := s.createAttachment(taskID, "first.txt", now)
+	s.createAttachment(otherTaskID, "other.txt", now)
+
+	s.Run("GetById", func() {
+		result, err := s.repo.GetById(s.ctx, first.ID.Hex())
+
+		s.NoError(err)
+		s.Equal("first.txt", result.Filename)
+		s.Equal(int64(11), result.Size)
+		s.Equal("abebe", result.UploadedBy)
+	})
+
+	s.Run("GetByTask", func() {
+		result, err := s.repo.GetByTask(s.ctx, taskID.Hex())
+
+		s.NoError(err)
+		s.Len(result, 2)
+		s.Equal(first.ID, result[0].ID)
+		s.Equal(later.ID, result[1].ID)
+	})
+
+	s.Run("GetTaskIds", func() {
+		result, err := s.repo.GetTaskIds(s.ctx)
+
+		s.NoError(err)
+		s.ElementsMatch([]primitive.ObjectID{taskID, otherTaskID}, result)
+	})
+
+	s.Run("Delete", func() {
+		s.NoError(s.repo.Delete(s.ctx, later.ID.Hex()))
+
+		_, err := s.repo.GetById(s.ctx, later.ID.Hex())
+		s.ErrorIs(err, domain.ErrNotFound)
+		s.ErrorIs(s.repo.Delete(s.ctx, later.ID.Hex()), domain.ErrNotFound)
+		s.ErrorIs(s.repo.Delete(s.ctx, "invalid"), domain.ErrValidation)
+	})
+}
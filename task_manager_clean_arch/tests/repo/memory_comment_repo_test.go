@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryCommentRepositorySuite defines the test suite for the in-memory
+// comment repository
+type MemoryCommentRepositorySuite struct {
+	suite.Suite
+	repo domain.CommentRepository
+	ctx  context.Context
+}
+
+// SetupTest creates an empty repository for every test
+func (s *MemoryCommentRepositorySuite) SetupTest() {
+	s.repo = persistence.NewMemoryCommentRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryCommentRepositorySuite runs the test suite
+func TestMemoryCommentRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryCommentRepositorySuite))
+}
+
+func (s *MemoryCommentRepositorySuite) createComment(taskID primitive.ObjectID, body string, createdAt time.Time) domain.Comment {
+	comment := &domain.Comment{TaskID: taskID, Author: "abebe", Body: body, Mentions: []string{"kebede"}, CreatedAt: createdAt}
+	s.Require().NoError(s.repo.Create(s.ctx, comment))
+	return *comment
+}
+
+// TestComments tests creating, listing, updating and deleting comments
+func (s *MemoryCommentRepositorySuite) TestComments() {
+	taskID := primitive.NewObjectID()
+	now := time.Now().Truncate(time.Millisecond)
+	later := s.createComment(taskID, "second", now.Add(time.Minute))
+	first := s.createComment(taskID, "first", now)
+	s.createComment(primitive.NewObjectID(), "other task", now)
+
+	s.Run("GetById", func() {
+		result, err := s.repo.GetById(s.ctx, first.ID.Hex())
+
+		s.NoError(err)
+		s.Equal("first", result.Body)
+		s.Equal([]string{"kebede"}, result.Mentions)
+		s.True(result.UpdatedAt.IsZero())
+	})
+
+	s.Run("GetByTask", func() {
+		result, err := s.repo.GetByTask(s.ctx, taskID.Hex())
+
+		s.NoError(err)
+		s.Len(result, 2)
+		s.Equal(first.ID, result[0].ID)
+		s.Equal(later.ID, result[1].ID)
+	})
+
+	s.Run("Update", func() {
+		first.Body = "edited"
+		first.Mentions = nil
+		first.UpdatedAt = now.Add(time.Hour)
+
+		s.NoError(s.repo.Update(s.ctx, &first))
+
+		result, err := s.repo.GetById(s.ctx, first.ID.Hex())
+		s.NoError(err)
+		s.Equal("edited", result.Body)
+		s.Empty(result.Mentions)
+		s.Equal(now.Add(time.Hour), result.UpdatedAt)
+		s.Equal(first.CreatedAt, result.CreatedAt)
+	})
+
+	s.Run("Delete", func() {
+		s.NoError(s.repo.Delete(s.ctx, later.ID.Hex()))
+
+		_, err := s.repo.GetById(s.ctx, later.ID.Hex())
+		s.ErrorIs(err, domain.ErrNotFound)
+		s.ErrorIs(s.repo.Delete(s.ctx, later.ID.Hex()), domain.ErrNotFound)
+	})
+
+	s.Run("NotFound", func() {
+		missing := domain.Comment{ID: primitive.NewObjectID(), Body: "x"}
+
+		s.ErrorIs(s.repo.Update(s.ctx, &missing), domain.ErrNotFound)
+		_, err := s.repo.GetById(s.ctx, "invalid")
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+}
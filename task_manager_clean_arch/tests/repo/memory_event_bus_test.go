@@ -0,0 +1,168 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+)
+
+// MemoryEventBusSuite defines the test suite for the in-memory event bus
+type MemoryEventBusSuite struct {
+	suite.Suite
+	bus domain.EventBus
+	ctx context.Context
+}
+
+// SetupTest creates a bus keeping three events for every test
+func (s *MemoryEventBusSuite) SetupTest() {
+	s.bus = persistence.NewMemoryEventBus(3)
+	s.ctx = context.Background()
+}
+
+// TestMemoryEventBusSuite runs the test suite
+func TestMemoryEventBusSuite(t *testing.T) {
+	suite.Run(t, new(MemoryEventBusSuite))
+}
+
+func (s *MemoryEventBusSuite) publish(types ...string) {
+	for _, eventType := range types {
+		s.bus.Publish(s.ctx, domain.Event{Type: eventType})
+	}
+}
+
+// receive reads the next event or fails after a second.
+func (s *MemoryEventBusSuite) receive(events <-chan domain.StreamEvent) domain.StreamEvent {
+	select {
+	case streamed := <-events:
+		return streamed
+	case <-time.After(time.Second):
+		s.FailNow("no event was received")
+		return domain.StreamEvent{}
+	}
+}
+
+func (s *MemoryEventBusSuite) assertNothingReceived(events <-chan domain.StreamEvent) {
+	select {
+	case streamed, ok := <-events:
+		s.Failf("unexpected event", "%v %v", streamed, ok)
+	default:
+	}
+}
+
+// TestSubscribe tests that subscribers get the events published after they
+// subscribe
+func (s *MemoryEventBusSuite) TestSubscribe() {
+	s.Run("Live", func() {
+		s.SetupTest()
+		s.publish(domain.EventTaskCreated)
+		events, complete := s.bus.Subscribe(s.ctx, 0, nil)
+
+		s.publish(domain.EventTaskUpdated)
+
+		s.True(complete)
+		streamed := s.receive(events)
+		s.Equal(uint64(2), streamed.Seq)
+		s.Equal(domain.EventTaskUpdated, streamed.Event.Type)
+		s.assertNothingReceived(events)
+	})
+
+	s.Run("Filter", func() {
+		s.SetupTest()
+		events, _ := s.bus.Subscribe(s.ctx, 0, func(event domain.Event) bool { return event.Type == domain.EventTaskDeleted })
+
+		s.publish(domain.EventTaskCreated, domain.EventTaskDeleted)
+
+		s.Equal(domain.EventTaskDeleted, s.receive(events).Event.Type)
+		s.assertNothingReceived(events)
+	})
+
+	s.Run("ClosedWhenCancelled", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(s.ctx)
+		events, _ := s.bus.Subscribe(ctx, 0, nil)
+
+		cancel()
+
+		select {
+		case _, ok := <-events:
+			s.False(ok)
+		case <-time.After(time.Second):
+			s.Fail("the channel was not closed")
+		}
+		s.publish(domain.EventTaskCreated)
+	})
+
+	s.Run("SlowSubscriberDropped", func() {
+		s.SetupTest()
+		events, _ := s.bus.Subscribe(s.ctx, 0, nil)
+
+		for range 100 {
+			s.publish(domain.EventTaskUpdated)
+		}
+
+		received := 0
+		for range events {
+			received++
+		}
+		s.Less(received, 100)
+	})
+}
+
+// TestResume tests that subscribers resuming from a position first get the
+// events they missed
+func (s *MemoryEventBusSuite) TestResume() {
+	s.Run("Missed", func() {
+		s.SetupTest()
+		s.publish(domain.EventTaskCreated, domain.EventTaskUpdated, domain.EventTaskDeleted)
+
+		events, complete := s.bus.Subscribe(s.ctx, 1, nil)
+		s.publish(domain.EventTaskCreated)
+
+		s.True(complete)
+		s.Equal(uint64(2), s.receive(events).Seq)
+		s.Equal(uint64(3), s.receive(events).Seq)
+		s.Equal(uint64(4), s.receive(events).Seq)
+	})
+
+	s.Run("UpToDate", func() {
+		s.SetupTest()
+		s.publish(domain.EventTaskCreated, domain.EventTaskUpdated, domain.EventTaskDeleted, domain.EventTaskCreated)
+
+		events, complete := s.bus.Subscribe(s.ctx, 4, nil)
+
+		s.True(complete)
+		s.assertNothingReceived(events)
+	})
+
+	s.Run("NoLongerKept", func() {
+		s.SetupTest()
+		s.publish(domain.EventTaskCreated, domain.EventTaskUpdated, domain.EventTaskDeleted, domain.EventTaskCreated, domain.EventTaskUpdated)
+
+		events, complete := s.bus.Subscribe(s.ctx, 1, nil)
+
+		s.False(complete)
+		s.Equal(uint64(3), s.receive(events).Seq)
+	})
+
+	s.Run("OldestKeptIsNext", func() {
+		s.SetupTest()
+		s.publish(domain.EventTaskCreated, domain.EventTaskUpdated, domain.EventTaskDeleted, domain.EventTaskCreated)
+
+		_, complete := s.bus.Subscribe(s.ctx, 1, nil)
+
+		s.True(complete)
+	})
+
+	s.Run("UnknownPosition", func() {
+		s.SetupTest()
+		s.publish(domain.EventTaskCreated)
+
+		_, complete := s.bus.Subscribe(s.ctx, 42, nil)
+
+		s.False(complete)
+	})
+}
@@ -0,0 +1,140 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryProjectRepositorySuite defines the test suite for the in-memory project repository
+type MemoryProjectRepositorySuite struct {
+	suite.Suite
+	repository domain.ProjectRepository
+	ctx        context.Context
+}
+
+// SetupTest creates an empty repository for every test
+func (s *MemoryProjectRepositorySuite) SetupTest() {
+	s.repository = persistence.NewMemoryProjectRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryProjectRepositorySuite runs the test suite
+func TestMemoryProjectRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryProjectRepositorySuite))
+}
+
+func (s *MemoryProjectRepositorySuite) seed(name string, members ...domain.ProjectMember) domain.Project {
+	project := domain.Project{Name: name, CreatedBy: members[0].Username, Members: members}
+	s.Require().NoError(s.repository.Create(s.ctx, &project))
+	return project
+}
+
+// TestCreateAndGet tests the Create, GetById and GetByMember methods
+func (s *MemoryProjectRepositorySuite) TestCreateAndGet() {
+	piassa := s.seed("Piassa", domain.ProjectMember{Username: "abebe", Role: domain.ProjectOwner})
+	merkato := s.seed("Merkato", domain.ProjectMember{Username: "abebe", Role: domain.ProjectOwner},
+		domain.ProjectMember{Username: "kebede", Role: domain.ProjectViewer})
+
+	s.Run("GetById", func() {
+		project, err := s.repository.GetById(s.ctx, merkato.ID.Hex())
+
+		s.NoError(err)
+		s.Equal("Merkato", project.Name)
+		s.Len(project.Members, 2)
+	})
+
+	s.Run("GetByMemberSortedByName", func() {
+		projects, err := s.repository.GetByMember(s.ctx, "abebe")
+
+		s.NoError(err)
+		s.Len(projects, 2)
+		s.Equal(merkato.ID, projects[0].ID)
+		s.Equal(piassa.ID, projects[1].ID)
+	})
+
+	s.Run("GetByMemberOnlyMemberships", func() {
+		projects, _ := s.repository.GetByMember(s.ctx, "kebede")
+
+		s.Len(projects, 1)
+		s.Equal(merkato.ID, projects[0].ID)
+	})
+
+	s.Run("NotFound", func() {
+		_, err := s.repository.GetById(s.ctx, primitive.NewObjectID().Hex())
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("InvalidID", func() {
+		_, err := s.repository.GetById(s.ctx, "invalid")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+}
+
+// TestUpdateAndDelete tests the Update and Delete methods
+func (s *MemoryProjectRepositorySuite) TestUpdateAndDelete() {
+	project := s.seed("Merkato", domain.ProjectMember{Username: "abebe", Role: domain.ProjectOwner})
+
+	s.Run("Update", func() {
+		s.NoError(s.repository.Update(s.ctx, project.ID.Hex(), &domain.Project{Name: "Piassa", Description: "Shops"}))
+
+		stored, _ := s.repository.GetById(s.ctx, project.ID.Hex())
+		s.Equal("Piassa", stored.Name)
+		s.Equal("Shops", stored.Description)
+		s.Len(stored.Members, 1)
+	})
+
+	s.Run("Delete", func() {
+		s.NoError(s.repository.Delete(s.ctx, project.ID.Hex()))
+
+		err := s.repository.Delete(s.ctx, project.ID.Hex())
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestMembers tests the SetMember and RemoveMember methods
+func (s *MemoryProjectRepositorySuite) TestMembers() {
+	project := s.seed("Merkato", domain.ProjectMember{Username: "abebe", Role: domain.ProjectOwner})
+	id := project.ID.Hex()
+
+	s.Run("AddMember", func() {
+		updated, err := s.repository.SetMember(s.ctx, id, domain.ProjectMember{Username: "kebede", Role: domain.ProjectViewer})
+
+		s.NoError(err)
+		s.Equal(domain.ProjectViewer, updated.RoleOf("kebede"))
+		s.Len(updated.Members, 2)
+	})
+
+	s.Run("ChangeRole", func() {
+		updated, err := s.repository.SetMember(s.ctx, id, domain.ProjectMember{Username: "kebede", Role: domain.ProjectEditor})
+
+		s.NoError(err)
+		s.Equal(domain.ProjectEditor, updated.RoleOf("kebede"))
+		s.Len(updated.Members, 2)
+	})
+
+	s.Run("RemoveMember", func() {
+		updated, err := s.repository.RemoveMember(s.ctx, id, "kebede")
+
+		s.NoError(err)
+		s.Equal("", updated.RoleOf("kebede"))
+		s.Len(updated.Members, 1)
+	})
+
+	s.Run("EarlierReadsUnchanged", func() {
+		s.Len(project.Members, 1)
+		s.Equal(domain.ProjectOwner, project.RoleOf("abebe"))
+	})
+
+	s.Run("ProjectNotFound", func() {
+		_, err := s.repository.SetMember(s.ctx, primitive.NewObjectID().Hex(), domain.ProjectMember{Username: "kebede", Role: domain.ProjectViewer})
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
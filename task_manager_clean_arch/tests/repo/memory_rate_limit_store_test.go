@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+)
+
+// MemoryRateLimitStoreSuite defines the test suite for the in-memory rate limit store
+type MemoryRateLimitStoreSuite struct {
+	suite.Suite
+	store domain.RateLimitStore
+	ctx   context.Context
+	limit domain.RateLimit
+	now   time.Time
+}
+
+// SetupTest creates an empty store for every test
+func (s *MemoryRateLimitStoreSuite) SetupTest() {
+	s.store = persistence.NewMemoryRateLimitStore()
+	s.ctx = context.Background()
+	s.limit = domain.RateLimit{Burst: 2, Every: time.Second}
+	s.now = time.Now()
+}
+
+// TestMemoryRateLimitStoreSuite runs the test suite
+func TestMemoryRateLimitStoreSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRateLimitStoreSuite))
+}
+
+// TestTake tests that the buckets are kept per key
+func (s *MemoryRateLimitStoreSuite) TestTake() {
+	s.Run("SpendsTheBucket", func() {
+		for _, allowed := range []bool{true, true, false} {
+			result, err := s.store.Take(s.ctx, "user:abebe", s.limit, s.now)
+
+			s.NoError(err)
+			s.Equal(allowed, result.Allowed)
+		}
+	})
+
+	s.Run("OtherKey", func() {
+		result, err := s.store.Take(s.ctx, "user:kebede", s.limit, s.now)
+
+		s.NoError(err)
+		s.True(result.Allowed)
+		s.Equal(1, result.Remaining)
+	})
+
+	s.Run("Refills", func() {
+		result, err := s.store.Take(s.ctx, "user:abebe", s.limit, s.now.Add(time.Second))
+
+		s.NoError(err)
+		s.True(result.Allowed)
+		s.Equal(0, result.Remaining)
+	})
+
+	s.Run("ForgetsFullBuckets", func() {
+		result, err := s.store.Take(s.ctx, "user:abebe", s.limit, s.now.Add(time.Hour))
+
+		s.NoError(err)
+		s.Equal(1, result.Remaining)
+	})
+}
+
+// TestConcurrentTake tests that concurrent requests cannot spend the same token
+func (s *MemoryRateLimitStoreSuite) TestConcurrentTake() {
+	limit := domain.RateLimit{Burst: 10, Every: time.Hour}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, _ := s.store.Take(s.ctx, "ip:10.0.0.1", limit, s.now)
+			if result.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(10, allowed)
+}
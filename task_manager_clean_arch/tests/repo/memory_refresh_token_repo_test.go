@@ -0,0 +1,124 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+)
+
+// MemoryRefreshTokenStoreSuite defines the test suite for the in-memory refresh token store
+type MemoryRefreshTokenStoreSuite struct {
+	suite.Suite
+	store domain.RefreshTokenStore
+	ctx   context.Context
+}
+
+// SetupTest creates an empty store for every test
+func (s *MemoryRefreshTokenStoreSuite) SetupTest() {
+	s.store = persistence.NewMemoryRefreshTokenStore()
+	s.ctx = context.Background()
+}
+
+// TestMemoryRefreshTokenStoreSuite runs the test suite
+func TestMemoryRefreshTokenStoreSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRefreshTokenStoreSuite))
+}
+
+// TestSaveAndGet tests the Save and GetByID methods
+func (s *MemoryRefreshTokenStoreSuite) TestSaveAndGet() {
+	session := &domain.RefreshSession{ID: "jti-1", FamilyID: "fam-1", Username: "abebe", ExpiresAt: time.Now().Add(time.Hour)}
+	s.NoError(s.store.Save(s.ctx, session))
+
+	s.Run("Found", func() {
+		result, err := s.store.GetByID(s.ctx, "jti-1")
+
+		s.NoError(err)
+		s.Equal(session, result)
+	})
+
+	s.Run("Duplicate", func() {
+		err := s.store.Save(s.ctx, session)
+
+		s.Error(err)
+		s.Contains(err.Error(), "duplicate key")
+	})
+
+	s.Run("NotFound", func() {
+		result, err := s.store.GetByID(s.ctx, "missing")
+
+		s.Error(err)
+		s.Nil(result)
+	})
+}
+
+// TestMarkUsed tests that only one caller can use a session
+func (s *MemoryRefreshTokenStoreSuite) TestMarkUsed() {
+	s.NoError(s.store.Save(s.ctx, &domain.RefreshSession{ID: "jti-1", FamilyID: "fam-1"}))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	winners := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			marked, err := s.store.MarkUsed(s.ctx, "jti-1")
+			s.NoError(err)
+			if marked {
+				mu.Lock()
+				winners++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(1, winners)
+}
+
+// TestRevokeFamily tests the RevokeFamily method
+func (s *MemoryRefreshTokenStoreSuite) TestRevokeFamily() {
+	s.NoError(s.store.Save(s.ctx, &domain.RefreshSession{ID: "jti-1", FamilyID: "fam-1"}))
+	s.NoError(s.store.Save(s.ctx, &domain.RefreshSession{ID: "jti-2", FamilyID: "fam-1"}))
+	s.NoError(s.store.Save(s.ctx, &domain.RefreshSession{ID: "jti-3", FamilyID: "fam-2"}))
+
+	s.NoError(s.store.RevokeFamily(s.ctx, "fam-1"))
+
+	first, _ := s.store.GetByID(s.ctx, "jti-1")
+	second, _ := s.store.GetByID(s.ctx, "jti-2")
+	other, _ := s.store.GetByID(s.ctx, "jti-3")
+	s.True(first.Revoked)
+	s.True(second.Revoked)
+	s.False(other.Revoked)
+	marked, err := s.store.MarkUsed(s.ctx, "jti-2")
+	s.NoError(err)
+	s.False(marked)
+}
+
+// TestMemoryTokenDenylist tests the in-memory token denylist
+func TestMemoryTokenDenylist(t *testing.T) {
+	ctx := context.Background()
+	denylist := persistence.NewMemoryTokenDenylist()
+
+	if err := denylist.Add(ctx, "active", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := denylist.Add(ctx, "expired", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	for jti, want := range map[string]bool{"active": true, "expired": false, "unknown": false} {
+		got, err := denylist.Contains(ctx, jti)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Contains(%q) = %v, want %v", jti, got, want)
+		}
+	}
+}
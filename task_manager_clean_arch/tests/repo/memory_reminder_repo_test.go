@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryReminderRepositorySuite defines the test suite for the in-memory reminder repository
+type MemoryReminderRepositorySuite struct {
+	suite.Suite
+	repository domain.ReminderRepository
+	ctx        context.Context
+}
+
+// SetupTest creates an empty repository for every test
+func (s *MemoryReminderRepositorySuite) SetupTest() {
+	s.repository = persistence.NewMemoryReminderRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryReminderRepositorySuite runs the test suite
+func TestMemoryReminderRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryReminderRepositorySuite))
+}
+
+// TestClaim tests that a reminder can only be claimed once
+func (s *MemoryReminderRepositorySuite) TestClaim() {
+	taskID := primitive.NewObjectID()
+	dueDate := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	s.Run("First", func() {
+		claimed, err := s.repository.Claim(s.ctx, taskID, domain.ReminderDueSoon, dueDate)
+
+		s.NoError(err)
+		s.True(claimed)
+	})
+
+	s.Run("Again", func() {
+		claimed, err := s.repository.Claim(s.ctx, taskID, domain.ReminderDueSoon, dueDate)
+
+		s.NoError(err)
+		s.False(claimed)
+	})
+
+	s.Run("OtherKind", func() {
+		claimed, err := s.repository.Claim(s.ctx, taskID, domain.ReminderOverdue, dueDate)
+
+		s.NoError(err)
+		s.True(claimed)
+	})
+
+	s.Run("NewDueDate", func() {
+		claimed, err := s.repository.Claim(s.ctx, taskID, domain.ReminderDueSoon, dueDate.Add(24*time.Hour))
+
+		s.NoError(err)
+		s.True(claimed)
+	})
+
+	s.Run("Concurrent", func() {
+		taskID := primitive.NewObjectID()
+		var wg sync.WaitGroup
+		var claims atomic.Int32
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if claimed, _ := s.repository.Claim(s.ctx, taskID, domain.ReminderOverdue, dueDate); claimed {
+					claims.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		s.Equal(int32(1), claims.Load())
+	})
+}
@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryTaskHistoryRepositorySuite defines the test suite for the in-memory task history repository
+type MemoryTaskHistoryRepositorySuite struct {
+	suite.Suite
+	repository domain.TaskHistoryRepository
+	ctx        context.Context
+}
+
+// SetupTest creates an empty repository for every test
+func (s *MemoryTaskHistoryRepositorySuite) SetupTest() {
+	s.repository = persistence.NewMemoryTaskHistoryRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryTaskHistoryRepositorySuite runs the test suite
+func TestMemoryTaskHistoryRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryTaskHistoryRepositorySuite))
+}
+
+// TestAddAndGet tests the Add, GetByTask and GetByTaskAndUser methods
+func (s *MemoryTaskHistoryRepositorySuite) TestAddAndGet() {
+	taskID := primitive.NewObjectID()
+	at := time.Now().Truncate(time.Millisecond)
+	created := &domain.TaskHistory{TaskID: taskID, Owner: "abebe", Action: domain.TaskCreated, Actor: "abebe", At: at, Version: 1,
+		Changes: []domain.TaskChange{{Field: "title", To: "Buy Coffee"}}}
+	updated := &domain.TaskHistory{TaskID: taskID, Owner: "abebe", Action: domain.TaskUpdated, Actor: "admin", At: at.Add(time.Second), Version: 2,
+		Changes: []domain.TaskChange{{Field: "status", From: "pending", To: "completed"}}}
+	other := &domain.TaskHistory{TaskID: primitive.NewObjectID(), Owner: "kebede", Action: domain.TaskCreated, Actor: "kebede", At: at, Version: 1}
+	for _, history := range []*domain.TaskHistory{created, updated, other} {
+		s.Require().NoError(s.repository.Add(s.ctx, history))
+		s.False(history.ID.IsZero())
+	}
+
+	s.Run("GetByTask", func() {
+		result, err := s.repository.GetByTask(s.ctx, taskID.Hex())
+
+		s.NoError(err)
+		s.Len(result, 2)
+		s.Equal(*updated, result[0])
+		s.Equal(*created, result[1])
+	})
+
+	s.Run("GetByTaskAndUser", func() {
+		result, err := s.repository.GetByTaskAndUser(s.ctx, taskID.Hex(), "abebe")
+
+		s.NoError(err)
+		s.Len(result, 2)
+	})
+
+	s.Run("OtherOwner", func() {
+		result, err := s.repository.GetByTaskAndUser(s.ctx, taskID.Hex(), "kebede")
+
+		s.NoError(err)
+		s.Empty(result)
+	})
+
+	s.Run("InvalidID", func() {
+		_, err := s.repository.GetByTask(s.ctx, "invalid")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+}
@@ -0,0 +1,1000 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryTaskRepositorySuite defines the test suite for the in-memory task repository
+type MemoryTaskRepositorySuite struct {
+	suite.Suite
+	repository domain.TaskRepository
+	ctx        context.Context
+}
+
+// SetupTest creates an empty repository for every test
+func (s *MemoryTaskRepositorySuite) SetupTest() {
+	s.repository = persistence.NewMemoryTaskRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryTaskRepositorySuite runs the test suite
+func TestMemoryTaskRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryTaskRepositorySuite))
+}
+
+func (s *MemoryTaskRepositorySuite) seed(tasks ...domain.Task) []domain.Task {
+	for i := range tasks {
+		s.Require().NoError(s.repository.Create(s.ctx, &tasks[i]))
+	}
+	return tasks
+}
+
+// TestCreate tests the Create method
+func (s *MemoryTaskRepositorySuite) TestCreate() {
+	s.Run("Success", func() {
+		dueDate := time.Now().Add(48 * time.Hour).Truncate(time.Millisecond).UTC()
+		task := &domain.Task{Title: "Buy Coffee", Description: "Buy coffee from the store", CreatedBy: "Abebe", Status: "pending", DueDate: dueDate}
+
+		err := s.repository.Create(s.ctx, task)
+
+		s.NoError(err)
+		s.False(task.ID.IsZero())
+		result, err := s.repository.GetById(s.ctx, task.ID.Hex())
+		s.NoError(err)
+		s.Equal(task.Title, result.Title)
+		s.True(dueDate.Equal(result.DueDate))
+	})
+
+	s.Run("DuplicateKey", func() {
+		task := &domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"}
+		s.NoError(s.repository.Create(s.ctx, task))
+
+		err := s.repository.Create(s.ctx, &domain.Task{ID: task.ID, Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending"})
+
+		s.Error(err)
+		s.Contains(err.Error(), "duplicate key")
+	})
+
+	s.Run("NilTask", func() {
+		err := s.repository.Create(s.ctx, nil)
+
+		s.Error(err)
+		s.Contains(err.Error(), "task cannot be nil")
+	})
+}
+
+// TestCreateMany tests that tasks are inserted in order up to the first
+// failure
+func (s *MemoryTaskRepositorySuite) TestCreateMany() {
+	s.Run("Success", func() {
+		tasks := []*domain.Task{
+			{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+			{Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending"},
+		}
+
+		err := s.repository.CreateMany(s.ctx, tasks)
+
+		s.NoError(err)
+		result, err := s.repository.GetByUser(s.ctx, "Abebe")
+		s.NoError(err)
+		s.Require().Len(result, 2)
+		s.Equal(tasks[0].ID, result[0].ID)
+		s.Equal(int64(1), tasks[1].Version)
+	})
+
+	s.Run("StopsAtFailure", func() {
+		s.SetupTest()
+		existing := s.seed(domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"})
+		tasks := []*domain.Task{
+			{Title: "Roast Coffee", CreatedBy: "Abebe", Status: "pending"},
+			{ID: existing[0].ID, Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending"},
+			{Title: "Grind Coffee", CreatedBy: "Abebe", Status: "pending"},
+		}
+
+		err := s.repository.CreateMany(s.ctx, tasks)
+
+		s.True(errors.Is(err, domain.ErrConflict))
+		s.Equal(int64(1), tasks[0].Version)
+		s.Zero(tasks[1].Version)
+		s.Zero(tasks[2].Version)
+		s.True(tasks[2].ID.IsZero())
+		result, err := s.repository.GetByUser(s.ctx, "Abebe")
+		s.NoError(err)
+		s.Len(result, 2)
+	})
+}
+
+// TestBulkWrite tests that each write only applies to the version it
+// replaces, and that an atomic bulk write applies all writes or none
+func (s *MemoryTaskRepositorySuite) TestBulkWrite() {
+	title, completed := "Buy Beans", "completed"
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		tasks := s.seed(
+			domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+			domain.Task{Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending"},
+			domain.Task{Title: "Roast Coffee", CreatedBy: "Abebe", Status: "pending"},
+		)
+
+		errs, err := s.repository.BulkWrite(s.ctx, []domain.TaskWrite{
+			{ID: tasks[0].ID, Update: domain.TaskUpdate{Title: &title, Version: 1}},
+			{ID: tasks[1].ID, Update: domain.TaskUpdate{Version: 1}, Delete: true},
+			{ID: tasks[2].ID, Update: domain.TaskUpdate{Status: &completed, Version: 2}},
+		}, false)
+
+		s.Require().NoError(err)
+		s.NoError(errs[0])
+		s.NoError(errs[1])
+		s.True(errors.Is(errs[2], domain.ErrVersionConflict))
+		updated, _ := s.repository.GetById(s.ctx, tasks[0].ID.Hex())
+		s.Equal(title, updated.Title)
+		s.Equal(int64(2), updated.Version)
+		_, err = s.repository.GetById(s.ctx, tasks[1].ID.Hex())
+		s.True(errors.Is(err, domain.ErrNotFound))
+		unchanged, _ := s.repository.GetById(s.ctx, tasks[2].ID.Hex())
+		s.Equal("pending", unchanged.Status)
+	})
+
+	s.Run("Atomic", func() {
+		s.SetupTest()
+		tasks := s.seed(domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"})
+
+		errs, err := s.repository.BulkWrite(s.ctx, []domain.TaskWrite{
+			{ID: tasks[0].ID, Update: domain.TaskUpdate{Title: &title, Version: 1}},
+			{ID: primitive.NewObjectID(), Update: domain.TaskUpdate{Version: 1}, Delete: true},
+		}, true)
+
+		s.Require().NoError(err)
+		s.True(errors.Is(errs[0], domain.ErrBatchAborted))
+		s.True(errors.Is(errs[1], domain.ErrNotFound))
+		unchanged, _ := s.repository.GetById(s.ctx, tasks[0].ID.Hex())
+		s.Equal("Buy Coffee", unchanged.Title)
+		s.Equal(int64(1), unchanged.Version)
+	})
+}
+
+// TestGetAll tests the GetAll method
+func (s *MemoryTaskRepositorySuite) TestGetAll() {
+	s.Run("EmptyCollection", func() {
+		result, err := s.repository.GetAll(s.ctx)
+
+		s.NoError(err)
+		s.Empty(result)
+	})
+
+	s.Run("Success", func() {
+		tasks := s.seed(
+			domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+			domain.Task{Title: "Sell Spices", CreatedBy: "Kebede", Status: "completed"},
+		)
+
+		result, err := s.repository.GetAll(s.ctx)
+
+		s.NoError(err)
+		s.Equal(tasks[0].ID, result[0].ID)
+		s.Equal(tasks[1].ID, result[1].ID)
+	})
+}
+
+// TestGetAllInBatches tests that the tasks outside the trash come in batches,
+// oldest first
+func (s *MemoryTaskRepositorySuite) TestGetAllInBatches() {
+	s.Run("Success", func() {
+		tasks := s.seed(
+			domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+			domain.Task{Title: "Roast Coffee", CreatedBy: "Abebe", Status: "pending"},
+			domain.Task{Title: "Grind Coffee", CreatedBy: "Kebede", Status: "pending"},
+			domain.Task{Title: "Sell Spices", CreatedBy: "Kebede", Status: "completed"},
+		)
+		s.Require().NoError(s.repository.DeleteByIdAndUser(s.ctx, tasks[1].ID.Hex(), "Abebe", 0))
+
+		var batches [][]primitive.ObjectID
+		err := s.repository.GetAllInBatches(s.ctx, 2, func(batch []domain.Task) error {
+			var ids []primitive.ObjectID
+			for _, task := range batch {
+				ids = append(ids, task.ID)
+			}
+			batches = append(batches, ids)
+			return nil
+		})
+
+		s.NoError(err)
+		s.Equal([][]primitive.ObjectID{{tasks[0].ID}, {tasks[2].ID, tasks[3].ID}}, batches)
+	})
+
+	s.Run("StopsAtError", func() {
+		s.SetupTest()
+		s.seed(
+			domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+			domain.Task{Title: "Sell Spices", CreatedBy: "Kebede", Status: "completed"},
+		)
+		calls := 0
+
+		err := s.repository.GetAllInBatches(s.ctx, 1, func([]domain.Task) error {
+			calls++
+			return errors.New("client went away")
+		})
+
+		s.EqualError(err, "client went away")
+		s.Equal(1, calls)
+	})
+
+	s.Run("CanUseRepository", func() {
+		s.SetupTest()
+		s.seed(domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"})
+
+		err := s.repository.GetAllInBatches(s.ctx, 10, func(batch []domain.Task) error {
+			_, err := s.repository.GetById(s.ctx, batch[0].ID.Hex())
+			return err
+		})
+
+		s.NoError(err)
+	})
+}
+
+// TestGetById tests the GetById method
+func (s *MemoryTaskRepositorySuite) TestGetById() {
+	s.Run("InvalidID", func() {
+		result, err := s.repository.GetById(s.ctx, "invalid_id")
+
+		s.Error(err)
+		s.Contains(err.Error(), "invalid ObjectID")
+		s.Equal(domain.Task{}, result)
+	})
+
+	s.Run("TaskNotFound", func() {
+		result, err := s.repository.GetById(s.ctx, primitive.NewObjectID().Hex())
+
+		s.Error(err)
+		s.Contains(err.Error(), "task not found")
+		s.Equal(domain.Task{}, result)
+	})
+}
+
+// TestUpdate tests the Update method
+func (s *MemoryTaskRepositorySuite) TestUpdate() {
+	s.Run("Success", func() {
+		tasks := s.seed(domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"})
+
+		err := s.repository.Update(s.ctx, tasks[0].ID.Hex(), &domain.Task{Title: "Buy Spices", CreatedBy: "Abebe", Status: "completed"})
+
+		s.NoError(err)
+		result, _ := s.repository.GetById(s.ctx, tasks[0].ID.Hex())
+		s.Equal("Buy Spices", result.Title)
+		s.Equal("completed", result.Status)
+	})
+
+	s.Run("NoUpdate", func() {
+		err := s.repository.Update(s.ctx, primitive.NewObjectID().Hex(), &domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"})
+
+		s.Error(err)
+		s.Contains(err.Error(), "failed to update task or already up to date")
+	})
+
+	s.Run("InvalidID", func() {
+		err := s.repository.Update(s.ctx, "invalid_id", &domain.Task{})
+
+		s.Error(err)
+		s.Contains(err.Error(), "invalid ObjectID")
+	})
+}
+
+// TestVersion tests that writes bump the task version and reject stale ones
+func (s *MemoryTaskRepositorySuite) TestVersion() {
+	tasks := s.seed(domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"})
+	id := tasks[0].ID.Hex()
+
+	s.Run("CreateStartsAtOne", func() {
+		s.Equal(int64(1), tasks[0].Version)
+	})
+
+	s.Run("UpdateBumpsVersion", func() {
+		update := &domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending", Version: 1}
+
+		err := s.repository.Update(s.ctx, id, update)
+
+		s.NoError(err)
+		s.Equal(int64(2), update.Version)
+	})
+
+	s.Run("StaleUpdate", func() {
+		err := s.repository.Update(s.ctx, id, &domain.Task{Title: "Buy Spices", Version: 1})
+
+		s.ErrorIs(err, domain.ErrVersionConflict)
+		result, _ := s.repository.GetById(s.ctx, id)
+		s.Equal("Buy Coffee", result.Title)
+	})
+
+	s.Run("PatchBumpsVersion", func() {
+		status := "completed"
+
+		result, err := s.repository.Patch(s.ctx, id, domain.TaskUpdate{Status: &status, Version: 2})
+
+		s.NoError(err)
+		s.Equal(int64(3), result.Version)
+	})
+
+	s.Run("StalePatch", func() {
+		status := "pending"
+
+		_, err := s.repository.Patch(s.ctx, id, domain.TaskUpdate{Status: &status, Version: 2})
+
+		s.ErrorIs(err, domain.ErrVersionConflict)
+	})
+
+	s.Run("StaleDelete", func() {
+		err := s.repository.Delete(s.ctx, id, 2)
+
+		s.ErrorIs(err, domain.ErrVersionConflict)
+		_, err = s.repository.GetById(s.ctx, id)
+		s.NoError(err)
+	})
+
+	s.Run("Delete", func() {
+		err := s.repository.Delete(s.ctx, id, 3)
+
+		s.NoError(err)
+	})
+}
+
+// TestPatch tests the Patch and PatchByIdAndUser methods
+func (s *MemoryTaskRepositorySuite) TestPatch() {
+	tasks := s.seed(domain.Task{Title: "Buy Coffee", Description: "Get buna from Merkato", CreatedBy: "Abebe", Status: "pending"})
+	id := tasks[0].ID.Hex()
+
+	s.Run("OnlySuppliedFields", func() {
+		status := "completed"
+
+		result, err := s.repository.Patch(s.ctx, id, domain.TaskUpdate{Status: &status})
+
+		s.NoError(err)
+		s.Equal("completed", result.Status)
+		s.Equal("Get buna from Merkato", result.Description)
+		stored, _ := s.repository.GetById(s.ctx, id)
+		s.Equal(result, stored)
+	})
+
+	s.Run("ByIdAndUserWrongOwner", func() {
+		title := "Stolen"
+
+		_, err := s.repository.PatchByIdAndUser(s.ctx, id, domain.TaskUpdate{Title: &title}, "Kebede")
+
+		s.Error(err)
+		s.Contains(err.Error(), "task not found")
+	})
+
+	s.Run("InvalidID", func() {
+		_, err := s.repository.Patch(s.ctx, "invalid_id", domain.TaskUpdate{})
+
+		s.Error(err)
+		s.Contains(err.Error(), "invalid ObjectID")
+	})
+}
+
+// TestDelete tests the Delete method
+func (s *MemoryTaskRepositorySuite) TestDelete() {
+	s.Run("Success", func() {
+		tasks := s.seed(domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"})
+
+		err := s.repository.Delete(s.ctx, tasks[0].ID.Hex(), 0)
+
+		s.NoError(err)
+		_, err = s.repository.GetById(s.ctx, tasks[0].ID.Hex())
+		s.Error(err)
+	})
+
+	s.Run("NoDelete", func() {
+		err := s.repository.Delete(s.ctx, primitive.NewObjectID().Hex(), 0)
+
+		s.Error(err)
+		s.Contains(err.Error(), "task not found or already deleted")
+	})
+}
+
+// TestTrash tests that deleted tasks leave every read until restored or purged
+func (s *MemoryTaskRepositorySuite) TestTrash() {
+	tasks := s.seed(
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+		domain.Task{Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending"},
+	)
+	id := tasks[0].ID.Hex()
+	s.NoError(s.repository.DeleteByIdAndUser(s.ctx, id, "Abebe", 0))
+
+	s.Run("HiddenFromReads", func() {
+		_, err := s.repository.GetByIdAndUser(s.ctx, id, "Abebe")
+		s.ErrorIs(err, domain.ErrNotFound)
+		page, _ := s.repository.Find(s.ctx, domain.TaskQuery{CreatedBy: "Abebe"})
+		s.Len(page.Tasks, 1)
+		matches, _ := s.repository.Search(s.ctx, domain.TaskSearch{Text: "coffee"})
+		s.Empty(matches)
+		stats, _ := s.repository.GetTaskStatsByUser(s.ctx, "Abebe")
+		s.Equal([]domain.StatusCount{{Status: "pending", Count: 1}}, stats.ByStatus)
+	})
+
+	s.Run("DeleteAgain", func() {
+		err := s.repository.Delete(s.ctx, id, 0)
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("GetTrashByUser", func() {
+		trash, err := s.repository.GetTrashByUser(s.ctx, "Abebe")
+
+		s.NoError(err)
+		s.Len(trash, 1)
+		s.Equal(tasks[0].ID, trash[0].ID)
+		s.False(trash[0].DeletedAt.IsZero())
+	})
+
+	s.Run("RestoreWrongOwner", func() {
+		_, err := s.repository.RestoreByIdAndUser(s.ctx, id, "Kebede")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("RestoreByIdAndUser", func() {
+		restored, err := s.repository.RestoreByIdAndUser(s.ctx, id, "Abebe")
+
+		s.NoError(err)
+		s.True(restored.DeletedAt.IsZero())
+		s.Equal(int64(3), restored.Version)
+		_, err = s.repository.GetById(s.ctx, id)
+		s.NoError(err)
+	})
+
+	s.Run("PurgeDeletedBefore", func() {
+		s.NoError(s.repository.Delete(s.ctx, tasks[1].ID.Hex(), 0))
+
+		purged, err := s.repository.PurgeDeletedBefore(s.ctx, time.Now().Add(-time.Hour))
+		s.NoError(err)
+		s.Zero(purged)
+
+		purged, err = s.repository.PurgeDeletedBefore(s.ctx, time.Now().Add(time.Minute))
+		s.NoError(err)
+		s.Equal(int64(1), purged)
+		trash, _ := s.repository.GetTrashByUser(s.ctx, "Abebe")
+		s.Empty(trash)
+	})
+}
+
+// TestAssignees tests AddAssignee, RemoveAssignee and the assignee filter
+func (s *MemoryTaskRepositorySuite) TestAssignees() {
+	tasks := s.seed(
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+		domain.Task{Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending"},
+	)
+	id := tasks[0].ID.Hex()
+
+	s.Run("AddAssignee", func() {
+		task, err := s.repository.AddAssignee(s.ctx, id, "Kebede")
+
+		s.NoError(err)
+		s.Equal([]string{"Kebede"}, task.Assignees)
+		s.Equal(int64(2), task.Version)
+	})
+
+	s.Run("AddAssigneeTwice", func() {
+		task, err := s.repository.AddAssignee(s.ctx, id, "Kebede")
+
+		s.NoError(err)
+		s.Equal([]string{"Kebede"}, task.Assignees)
+	})
+
+	s.Run("FindByAssignee", func() {
+		page, err := s.repository.Find(s.ctx, domain.TaskQuery{Assignee: "Kebede"})
+
+		s.NoError(err)
+		s.Len(page.Tasks, 1)
+		s.Equal(tasks[0].ID, page.Tasks[0].ID)
+	})
+
+	s.Run("UpdateKeepsAssignees", func() {
+		s.NoError(s.repository.Update(s.ctx, id, &domain.Task{Title: "Buy Tea", CreatedBy: "Abebe"}))
+
+		task, _ := s.repository.GetById(s.ctx, id)
+		s.Equal([]string{"Kebede"}, task.Assignees)
+	})
+
+	s.Run("RemoveAssignee", func() {
+		task, err := s.repository.RemoveAssignee(s.ctx, id, "Kebede")
+
+		s.NoError(err)
+		s.Empty(task.Assignees)
+		page, _ := s.repository.Find(s.ctx, domain.TaskQuery{Assignee: "Kebede"})
+		s.Empty(page.Tasks)
+	})
+
+	s.Run("TrashedTask", func() {
+		s.NoError(s.repository.Delete(s.ctx, tasks[1].ID.Hex(), 0))
+
+		_, err := s.repository.AddAssignee(s.ctx, tasks[1].ID.Hex(), "Kebede")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestProjectTasks tests that tasks keep their project and can be listed by it
+func (s *MemoryTaskRepositorySuite) TestProjectTasks() {
+	projectID := primitive.NewObjectID()
+	tasks := s.seed(
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending", ProjectID: projectID},
+		domain.Task{Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending"},
+	)
+
+	s.Run("FindByProject", func() {
+		page, err := s.repository.Find(s.ctx, domain.TaskQuery{ProjectID: projectID})
+
+		s.NoError(err)
+		s.Len(page.Tasks, 1)
+		s.Equal(tasks[0].ID, page.Tasks[0].ID)
+	})
+
+	s.Run("UpdateKeepsProject", func() {
+		update := &domain.Task{Title: "Buy Tea", CreatedBy: "Abebe"}
+		s.NoError(s.repository.Update(s.ctx, tasks[0].ID.Hex(), update))
+
+		s.Equal(projectID, update.ProjectID)
+		task, _ := s.repository.GetById(s.ctx, tasks[0].ID.Hex())
+		s.Equal(projectID, task.ProjectID)
+	})
+
+	s.Run("CountByProjectIncludesTrash", func() {
+		s.NoError(s.repository.DeleteByIdAndUser(s.ctx, tasks[0].ID.Hex(), "Abebe", 0))
+
+		count, err := s.repository.CountByProject(s.ctx, projectID)
+
+		s.NoError(err)
+		s.Equal(int64(1), count)
+	})
+}
+
+// TestUserScopedMethods tests the *ByIdAndUser and GetByUser methods
+func (s *MemoryTaskRepositorySuite) TestUserScopedMethods() {
+	tasks := s.seed(
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+		domain.Task{Title: "Sell Spices", CreatedBy: "Abebe", Status: "completed"},
+		domain.Task{Title: "Brew Coffee", CreatedBy: "Kebede", Status: "pending"},
+	)
+
+	s.Run("GetByUser", func() {
+		result, err := s.repository.GetByUser(s.ctx, "Abebe")
+
+		s.NoError(err)
+		s.Len(result, 2)
+	})
+
+	s.Run("GetByIdAndUserWrongOwner", func() {
+		_, err := s.repository.GetByIdAndUser(s.ctx, tasks[2].ID.Hex(), "Abebe")
+
+		s.Error(err)
+		s.Contains(err.Error(), "task not found")
+	})
+
+	s.Run("UpdateByIdAndUser", func() {
+		update := &domain.Task{Title: "Buy Spices", CreatedBy: "Abebe", Status: "completed"}
+
+		err := s.repository.UpdateByIdAndUser(s.ctx, tasks[0].ID.Hex(), update, "Abebe")
+
+		s.NoError(err)
+		s.Equal(tasks[0].ID, update.ID)
+	})
+
+	s.Run("UpdateByIdAndUserWrongOwner", func() {
+		err := s.repository.UpdateByIdAndUser(s.ctx, tasks[2].ID.Hex(), &domain.Task{Title: "Stolen"}, "Abebe")
+
+		s.Error(err)
+		s.Contains(err.Error(), "failed to update task or task not found for user")
+	})
+
+	s.Run("DeleteByIdAndUserWrongOwner", func() {
+		err := s.repository.DeleteByIdAndUser(s.ctx, tasks[2].ID.Hex(), "Abebe", 0)
+
+		s.Error(err)
+		s.Contains(err.Error(), "task not found or not owned by user")
+	})
+
+	s.Run("DeleteByIdAndUser", func() {
+		err := s.repository.DeleteByIdAndUser(s.ctx, tasks[1].ID.Hex(), "Abebe", 0)
+
+		s.NoError(err)
+	})
+}
+
+// TestAggregations tests GetTaskCountByStatus and GetTaskStatsByUser
+func (s *MemoryTaskRepositorySuite) TestAggregations() {
+	s.seed(
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending", Priority: "high", Tags: []string{"market", "home"}},
+		domain.Task{Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending", Tags: []string{"market"}},
+		domain.Task{Title: "Brew Coffee", CreatedBy: "Abebe", Status: "completed", Priority: "high"},
+		domain.Task{Title: "Deliver Goods", CreatedBy: "Kebede", Status: "pending", Priority: "low"},
+	)
+
+	s.Run("GetTaskCountByStatus", func() {
+		result, err := s.repository.GetTaskCountByStatus(s.ctx)
+
+		s.NoError(err)
+		s.Equal([]domain.StatusCount{{Status: "pending", Count: 3}, {Status: "completed", Count: 1}}, result.ByStatus)
+		s.Equal([]domain.PriorityCount{{Priority: "high", Count: 2}, {Priority: "low", Count: 1}, {Priority: "medium", Count: 1}}, result.ByPriority)
+		s.Equal([]domain.TagCount{{Tag: "market", Count: 2}, {Tag: "home", Count: 1}}, result.ByTag)
+	})
+
+	s.Run("GetTaskStatsByUser", func() {
+		result, err := s.repository.GetTaskStatsByUser(s.ctx, "Abebe")
+
+		s.NoError(err)
+		s.Equal([]domain.StatusCount{{Status: "pending", Count: 2}, {Status: "completed", Count: 1}}, result.ByStatus)
+		s.Equal([]domain.PriorityCount{{Priority: "high", Count: 2}, {Priority: "medium", Count: 1}}, result.ByPriority)
+	})
+
+	s.Run("GetTaskStatsByUserNoTasks", func() {
+		result, err := s.repository.GetTaskStatsByUser(s.ctx, "samson")
+
+		s.NoError(err)
+		s.Empty(result.ByStatus)
+		s.Empty(result.ByPriority)
+		s.Empty(result.ByTag)
+	})
+}
+
+// TestFind tests filtering, sorting and both pagination styles of Find
+func (s *MemoryTaskRepositorySuite) TestFind() {
+	day := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	s.seed(
+		domain.Task{Title: "Deliver Goods", CreatedBy: "Abebe", Status: "pending", DueDate: day.Add(72 * time.Hour)},
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending", DueDate: day},
+		domain.Task{Title: "Sell Spices", CreatedBy: "Abebe", Status: "completed", DueDate: day.Add(24 * time.Hour)},
+		domain.Task{Title: "Clean Office", CreatedBy: "Abebe", Status: "pending", DueDate: day.Add(48 * time.Hour)},
+		domain.Task{Title: "Brew Coffee", CreatedBy: "Kebede", Status: "pending", DueDate: day},
+	)
+	titles := func(page domain.TaskPage) []string {
+		var result []string
+		for _, task := range page.Tasks {
+			result = append(result, task.Title)
+		}
+		return result
+	}
+
+	s.Run("Filters", func() {
+		page, err := s.repository.Find(s.ctx, domain.TaskQuery{CreatedBy: "Abebe", Status: "pending", DueAfter: day, DueBefore: day.Add(72 * time.Hour)})
+
+		s.NoError(err)
+		s.Equal([]string{"Clean Office"}, titles(page))
+		s.Equal(int64(1), page.Total)
+	})
+
+	s.Run("SortAndPage", func() {
+		page, err := s.repository.Find(s.ctx, domain.TaskQuery{CreatedBy: "Abebe", Sort: domain.SortDueDateDesc, Page: 2, Limit: 2})
+
+		s.NoError(err)
+		s.Equal([]string{"Sell Spices", "Buy Coffee"}, titles(page))
+		s.Equal(int64(4), page.Total)
+		s.Equal(2, page.Page)
+		s.Empty(page.NextCursor)
+	})
+
+	s.Run("PageOutOfRange", func() {
+		page, err := s.repository.Find(s.ctx, domain.TaskQuery{Page: 10, Limit: 2})
+
+		s.NoError(err)
+		s.Empty(page.Tasks)
+		s.Equal(int64(5), page.Total)
+	})
+
+	s.Run("Cursor", func() {
+		query := domain.TaskQuery{Sort: domain.SortTitleAsc, Limit: 2}
+		var seen []string
+		for {
+			page, err := s.repository.Find(s.ctx, query)
+			s.Require().NoError(err)
+			seen = append(seen, titles(page)...)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+
+		s.Equal([]string{"Brew Coffee", "Buy Coffee", "Clean Office", "Deliver Goods", "Sell Spices"}, seen)
+	})
+
+	s.Run("CursorWithTies", func() {
+		first, err := s.repository.Find(s.ctx, domain.TaskQuery{Sort: domain.SortDueDateAsc, Limit: 1})
+		s.Require().NoError(err)
+		second, err := s.repository.Find(s.ctx, domain.TaskQuery{Sort: domain.SortDueDateAsc, Limit: 1, Cursor: first.NextCursor})
+		s.Require().NoError(err)
+
+		s.ElementsMatch([]string{"Buy Coffee", "Brew Coffee"}, append(titles(first), titles(second)...))
+	})
+
+	s.Run("InvalidCursor", func() {
+		_, err := s.repository.Find(s.ctx, domain.TaskQuery{Cursor: "not-a-cursor"})
+
+		s.ErrorIs(err, domain.ErrInvalidCursor)
+	})
+
+	s.Run("CursorUnderOtherSort", func() {
+		first, err := s.repository.Find(s.ctx, domain.TaskQuery{Sort: domain.SortDueDateAsc, Limit: 2})
+		s.Require().NoError(err)
+
+		_, err = s.repository.Find(s.ctx, domain.TaskQuery{Sort: domain.SortTitleDesc, Limit: 2, Cursor: first.NextCursor})
+
+		s.ErrorIs(err, domain.ErrInvalidCursor)
+	})
+
+	s.Run("CursorWithOtherFilters", func() {
+		first, err := s.repository.Find(s.ctx, domain.TaskQuery{CreatedBy: "Abebe", Sort: domain.SortTitleAsc, Limit: 2})
+		s.Require().NoError(err)
+
+		_, err = s.repository.Find(s.ctx, domain.TaskQuery{CreatedBy: "Abebe", Status: "pending", Sort: domain.SortTitleAsc, Limit: 2, Cursor: first.NextCursor})
+
+		s.ErrorIs(err, domain.ErrInvalidCursor)
+	})
+
+	s.Run("CursorWithOtherLimit", func() {
+		first, err := s.repository.Find(s.ctx, domain.TaskQuery{Sort: domain.SortTitleAsc, Limit: 2})
+		s.Require().NoError(err)
+
+		second, err := s.repository.Find(s.ctx, domain.TaskQuery{Sort: domain.SortTitleAsc, Limit: 3, Cursor: first.NextCursor})
+
+		s.NoError(err)
+		s.Equal([]string{"Clean Office", "Deliver Goods", "Sell Spices"}, titles(second))
+	})
+}
+
+// TestSearch tests the Search method
+func (s *MemoryTaskRepositorySuite) TestSearch() {
+	s.seed(
+		domain.Task{Title: "Sell Spices", Description: "Bring coffee for the buyers", CreatedBy: "Abebe", Status: "pending"},
+		domain.Task{Title: "Buy Coffee", Description: "Get buna from Merkato", CreatedBy: "Abebe", Status: "pending"},
+		domain.Task{Title: "Brew Coffee", Description: "Coffee ceremony", CreatedBy: "Kebede", Status: "pending"},
+		domain.Task{Title: "Clean Office", CreatedBy: "Abebe", Status: "pending"},
+	)
+
+	s.Run("RankedByRelevance", func() {
+		result, err := s.repository.Search(s.ctx, domain.TaskSearch{Text: "coffee"})
+
+		s.NoError(err)
+		s.Len(result, 3)
+		s.Equal("Brew Coffee", result[0].Task.Title)
+		s.Equal("Buy Coffee", result[1].Task.Title)
+		s.Equal("Sell Spices", result[2].Task.Title)
+		s.Greater(result[1].Score, result[2].Score)
+	})
+
+	s.Run("ScopedToUser", func() {
+		result, err := s.repository.Search(s.ctx, domain.TaskSearch{Text: "COFFEE", CreatedBy: "Abebe", Limit: 1})
+
+		s.NoError(err)
+		s.Len(result, 1)
+		s.Equal("Buy Coffee", result[0].Task.Title)
+	})
+
+	s.Run("NoMatch", func() {
+		result, err := s.repository.Search(s.ctx, domain.TaskSearch{Text: "tea"})
+
+		s.NoError(err)
+		s.Empty(result)
+	})
+}
+
+// TestConcurrentAccess exercises the repository from several goroutines
+func (s *MemoryTaskRepositorySuite) TestConcurrentAccess() {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task := &domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"}
+			s.NoError(s.repository.Create(s.ctx, task))
+			_, _ = s.repository.GetByUser(s.ctx, "Abebe")
+			_, _ = s.repository.GetTaskStatsByUser(s.ctx, "Abebe")
+		}()
+	}
+	wg.Wait()
+
+	result, err := s.repository.GetAll(s.ctx)
+	s.NoError(err)
+	s.Len(result, 50)
+}
+
+// TestPriorityAndTags tests storing, patching and filtering by priority and tag
+func (s *MemoryTaskRepositorySuite) TestPriorityAndTags() {
+	tasks := s.seed(
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending", Priority: "urgent", Tags: []string{"market"}},
+		domain.Task{Title: "Sell Spices", CreatedBy: "Abebe", Status: "pending"},
+	)
+
+	s.Run("MissingPriorityIsMedium", func() {
+		task, err := s.repository.GetById(s.ctx, tasks[1].ID.Hex())
+
+		s.NoError(err)
+		s.Equal(domain.PriorityMedium, task.Priority)
+
+		page, _ := s.repository.Find(s.ctx, domain.TaskQuery{Priority: domain.PriorityMedium})
+		s.Len(page.Tasks, 1)
+		s.Equal(tasks[1].ID, page.Tasks[0].ID)
+	})
+
+	s.Run("FindByTag", func() {
+		page, err := s.repository.Find(s.ctx, domain.TaskQuery{Tag: "market"})
+
+		s.NoError(err)
+		s.Len(page.Tasks, 1)
+		s.Equal(tasks[0].ID, page.Tasks[0].ID)
+	})
+
+	s.Run("Patch", func() {
+		priority := "low"
+		tags := []string{"home"}
+
+		task, err := s.repository.Patch(s.ctx, tasks[0].ID.Hex(), domain.TaskUpdate{Priority: &priority, Tags: &tags})
+
+		s.NoError(err)
+		s.Equal("low", task.Priority)
+		s.Equal([]string{"home"}, task.Tags)
+	})
+}
+
+// TestSubtasks tests the GetSubtasks and CountSubtasksByStatus methods and the checklist
+func (s *MemoryTaskRepositorySuite) TestSubtasks() {
+	parents := s.seed(
+		domain.Task{Title: "Plan trip", CreatedBy: "Abebe", Status: "pending", Checklist: []domain.ChecklistItem{{Text: "Pack"}}},
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"},
+	)
+	subtasks := s.seed(
+		domain.Task{Title: "Book hotel", CreatedBy: "Abebe", Status: "completed", ParentID: parents[0].ID},
+		domain.Task{Title: "Book bus", CreatedBy: "Abebe", Status: "pending", ParentID: parents[0].ID},
+		domain.Task{Title: "Rent car", CreatedBy: "Abebe", Status: "pending", ParentID: parents[0].ID},
+	)
+	s.Require().NoError(s.repository.Delete(s.ctx, subtasks[2].ID.Hex(), 0))
+
+	s.Run("GetSubtasks", func() {
+		tasks, err := s.repository.GetSubtasks(s.ctx, parents[0].ID.Hex())
+
+		s.NoError(err)
+		s.Len(tasks, 2)
+		s.Equal(subtasks[0].ID, tasks[0].ID)
+		s.Equal(parents[0].ID, tasks[0].ParentID)
+	})
+
+	s.Run("CountSubtasksByStatus", func() {
+		counts, err := s.repository.CountSubtasksByStatus(s.ctx, []primitive.ObjectID{parents[0].ID, parents[1].ID})
+
+		s.NoError(err)
+		s.ElementsMatch([]domain.StatusCount{{Status: "completed", Count: 1}, {Status: "pending", Count: 1}}, counts[parents[0].ID])
+		s.NotContains(counts, parents[1].ID)
+	})
+
+	s.Run("UpdateKeepsParent", func() {
+		update := &domain.Task{Title: "Book a hotel", CreatedBy: "Abebe", Status: "completed"}
+
+		s.NoError(s.repository.Update(s.ctx, subtasks[0].ID.Hex(), update))
+
+		s.Equal(parents[0].ID, update.ParentID)
+		task, _ := s.repository.GetById(s.ctx, subtasks[0].ID.Hex())
+		s.Equal(parents[0].ID, task.ParentID)
+	})
+
+	s.Run("PatchChecklist", func() {
+		checklist := []domain.ChecklistItem{{Text: "Pack", Done: true}, {Text: "Lock the door"}}
+
+		task, err := s.repository.Patch(s.ctx, parents[0].ID.Hex(), domain.TaskUpdate{Checklist: &checklist})
+
+		s.NoError(err)
+		s.Equal(checklist, task.Checklist)
+	})
+}
+
+// TestBlockers tests the AddBlocker, RemoveBlocker, GetStatuses and
+// GetExistingIds methods
+func (s *MemoryTaskRepositorySuite) TestBlockers() {
+	tasks := s.seed(
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "completed"},
+		domain.Task{Title: "Roast Coffee", CreatedBy: "Abebe", Status: "pending"},
+		domain.Task{Title: "Sell Coffee", CreatedBy: "Abebe", Status: "pending"},
+	)
+	s.Require().NoError(s.repository.Delete(s.ctx, tasks[2].ID.Hex(), 0))
+
+	s.Run("AddBlocker", func() {
+		task, err := s.repository.AddBlocker(s.ctx, tasks[1].ID.Hex(), tasks[0].ID)
+		s.NoError(err)
+		task, err = s.repository.AddBlocker(s.ctx, tasks[1].ID.Hex(), tasks[0].ID)
+
+		s.NoError(err)
+		s.Equal([]primitive.ObjectID{tasks[0].ID}, task.BlockedBy)
+		s.Equal(tasks[1].Version+2, task.Version)
+	})
+
+	s.Run("UpdateKeepsBlockers", func() {
+		update := &domain.Task{Title: "Roast the Coffee", CreatedBy: "Abebe", Status: "pending"}
+
+		s.NoError(s.repository.Update(s.ctx, tasks[1].ID.Hex(), update))
+
+		task, _ := s.repository.GetById(s.ctx, tasks[1].ID.Hex())
+		s.Equal([]primitive.ObjectID{tasks[0].ID}, task.BlockedBy)
+	})
+
+	s.Run("RemoveBlocker", func() {
+		task, err := s.repository.RemoveBlocker(s.ctx, tasks[1].ID.Hex(), tasks[0].ID)
+
+		s.NoError(err)
+		s.Empty(task.BlockedBy)
+	})
+
+	s.Run("GetStatuses", func() {
+		statuses, err := s.repository.GetStatuses(s.ctx, []primitive.ObjectID{tasks[0].ID, tasks[2].ID, primitive.NewObjectID()})
+
+		s.NoError(err)
+		s.Equal(map[primitive.ObjectID]string{tasks[0].ID: "completed"}, statuses)
+	})
+
+	s.Run("GetExistingIds", func() {
+		existing, err := s.repository.GetExistingIds(s.ctx, []primitive.ObjectID{tasks[0].ID, tasks[2].ID, primitive.NewObjectID()})
+
+		s.NoError(err)
+		s.Equal([]primitive.ObjectID{tasks[0].ID, tasks[2].ID}, existing)
+	})
+}
+
+// TestRecurrence tests that the recurrence is stored, patched and removed
+func (s *MemoryTaskRepositorySuite) TestRecurrence() {
+	// Stored times are read back in local time.
+	template := domain.OccurrenceTemplate{Title: "Water plants", DueDate: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC).Local(), Priority: "medium"}
+	recurrence := &domain.Recurrence{Rule: "FREQ=WEEKLY", SeriesID: primitive.NewObjectID(), Occurrence: 2, Template: &template}
+	tasks := s.seed(domain.Task{Title: "Water plants", CreatedBy: "Abebe", Status: "pending", Recurrence: recurrence})
+
+	s.Run("Create", func() {
+		task, err := s.repository.GetById(s.ctx, tasks[0].ID.Hex())
+
+		s.NoError(err)
+		s.Equal(recurrence, task.Recurrence)
+	})
+
+	s.Run("Patch", func() {
+		patched := *recurrence
+		patched.NextID = primitive.NewObjectID()
+
+		task, err := s.repository.Patch(s.ctx, tasks[0].ID.Hex(), domain.TaskUpdate{Recurrence: &patched})
+
+		s.NoError(err)
+		s.Equal(&patched, task.Recurrence)
+	})
+
+	s.Run("PatchRemoves", func() {
+		task, err := s.repository.Patch(s.ctx, tasks[0].ID.Hex(), domain.TaskUpdate{Recurrence: &domain.Recurrence{}})
+
+		s.NoError(err)
+		s.Nil(task.Recurrence)
+	})
+}
+
+// TestGetDueBetween tests that only unfinished tasks outside the trash and
+// due in the range are listed, due first
+func (s *MemoryTaskRepositorySuite) TestGetDueBetween() {
+	now := time.Now()
+	tasks := s.seed(
+		domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending", DueDate: now.Add(30 * time.Minute)},
+		domain.Task{Title: "Roast Coffee", CreatedBy: "Abebe", Status: "pending", DueDate: now.Add(-time.Hour)},
+		domain.Task{Title: "Grind Coffee", CreatedBy: "Abebe", Status: "completed", DueDate: now.Add(-time.Hour)},
+		domain.Task{Title: "Sell Coffee", CreatedBy: "Abebe", Status: "pending", DueDate: now.Add(-time.Hour)},
+		domain.Task{Title: "Drink Coffee", CreatedBy: "Abebe", Status: "pending", DueDate: now.Add(2 * time.Hour)},
+		domain.Task{Title: "Plant Coffee", CreatedBy: "Abebe", Status: "pending", DueDate: now.Add(-48 * time.Hour)},
+	)
+	s.Require().NoError(s.repository.Delete(s.ctx, tasks[3].ID.Hex(), 0))
+
+	result, err := s.repository.GetDueBetween(s.ctx, now.Add(-24*time.Hour), now.Add(time.Hour), "completed")
+
+	s.NoError(err)
+	s.Len(result, 2)
+	s.Equal(tasks[1].ID, result[0].ID)
+	s.Equal(tasks[0].ID, result[1].ID)
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+)
+
+// MemoryUserRepositorySuite defines the test suite for the in-memory user repository
+type MemoryUserRepositorySuite struct {
+	suite.Suite
+	repository domain.UserRepository
+	ctx        context.Context
+}
+
+// SetupTest creates an empty repository for every test
+func (s *MemoryUserRepositorySuite) SetupTest() {
+	s.repository = persistence.NewMemoryUserRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryUserRepositorySuite runs the test suite
+func TestMemoryUserRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryUserRepositorySuite))
+}
+
+// TestInsert tests the Insert method
+func (s *MemoryUserRepositorySuite) TestInsert() {
+	s.Run("Success", func() {
+		user := &domain.User{Username: "abebe", Email: "abebe@example.com", Password: "hashed_password", Role: "user"}
+
+		err := s.repository.Insert(s.ctx, user)
+
+		s.NoError(err)
+		result, err := s.repository.GetByUsername(s.ctx, "abebe")
+		s.NoError(err)
+		s.NotEmpty(result.ID)
+		s.Equal(user.Email, result.Email)
+	})
+
+	s.Run("NilUser", func() {
+		err := s.repository.Insert(s.ctx, nil)
+
+		s.Error(err)
+		s.Contains(err.Error(), "user cannot be nil")
+	})
+
+	s.Run("DuplicateKey", func() {
+		err := s.repository.Insert(s.ctx, &domain.User{Username: "abebe", Email: "other@example.com", Role: "user"})
+
+		s.Error(err)
+		s.Contains(err.Error(), "duplicate key")
+	})
+}
+
+// TestLookups tests GetAll, GetUser, GetByUsername and GetByEmail
+func (s *MemoryUserRepositorySuite) TestLookups() {
+	s.NoError(s.repository.Insert(s.ctx, &domain.User{Username: "abebe", Email: "abebe@example.com", Role: "user"}))
+	s.NoError(s.repository.Insert(s.ctx, &domain.User{Username: "kebede", Email: "kebede@example.com", Role: "admin"}))
+
+	s.Run("GetAll", func() {
+		result, err := s.repository.GetAll(s.ctx)
+
+		s.NoError(err)
+		s.Len(result, 2)
+	})
+
+	s.Run("GetUserByRole", func() {
+		result, err := s.repository.GetUser(s.ctx, "role", "admin")
+
+		s.NoError(err)
+		s.Equal("kebede", result.Username)
+	})
+
+	s.Run("GetByEmail", func() {
+		result, err := s.repository.GetByEmail(s.ctx, "abebe@example.com")
+
+		s.NoError(err)
+		s.Equal("abebe", result.Username)
+	})
+
+	s.Run("UserNotFound", func() {
+		result, err := s.repository.GetByUsername(s.ctx, "samson")
+
+		s.Error(err)
+		s.Contains(err.Error(), "user not found")
+		s.Nil(result)
+	})
+
+	s.Run("UnknownKey", func() {
+		result, err := s.repository.GetUser(s.ctx, "nickname", "abebe")
+
+		s.Error(err)
+		s.Nil(result)
+	})
+}
+
+// TestGetUserFromContext tests the GetUserFromContext method
+func (s *MemoryUserRepositorySuite) TestGetUserFromContext() {
+	s.NoError(s.repository.Insert(s.ctx, &domain.User{Username: "abebe", Email: "abebe@example.com", Role: "user"}))
+
+	s.Run("Success", func() {
+		c, _ := gin.CreateTestContext(nil)
+		c.Set("username", "abebe")
+
+		result := s.repository.GetUserFromContext(c)
+
+		s.Equal("abebe", result.Username)
+	})
+
+	s.Run("EmptyUsername", func() {
+		c, _ := gin.CreateTestContext(nil)
+
+		result := s.repository.GetUserFromContext(c)
+
+		s.Nil(result)
+	})
+}
+
+// TestIncrementTokenVersion tests the IncrementTokenVersion method
+func (s *MemoryUserRepositorySuite) TestIncrementTokenVersion() {
+	s.NoError(s.repository.Insert(s.ctx, &domain.User{Username: "abebe", Email: "abebe@example.com", Role: "user"}))
+
+	s.Run("Success", func() {
+		s.NoError(s.repository.IncrementTokenVersion(s.ctx, "abebe"))
+		s.NoError(s.repository.IncrementTokenVersion(s.ctx, "abebe"))
+
+		result, err := s.repository.GetByUsername(s.ctx, "abebe")
+		s.NoError(err)
+		s.Equal(2, result.TokenVersion)
+	})
+
+	s.Run("UserNotFound", func() {
+		err := s.repository.IncrementTokenVersion(s.ctx, "samson")
+
+		s.Error(err)
+		s.Contains(err.Error(), "user not found")
+	})
+}
@@ -0,0 +1,216 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryWebhookRepositorySuite defines the test suite for the in-memory webhook
+// and webhook delivery repositories
+type MemoryWebhookRepositorySuite struct {
+	suite.Suite
+	webhooks   domain.WebhookRepository
+	deliveries domain.WebhookDeliveryRepository
+	ctx        context.Context
+}
+
+// SetupTest creates empty repositories for every test
+func (s *MemoryWebhookRepositorySuite) SetupTest() {
+	s.webhooks = persistence.NewMemoryWebhookRepository()
+	s.deliveries = persistence.NewMemoryWebhookDeliveryRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryWebhookRepositorySuite runs the test suite
+func TestMemoryWebhookRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryWebhookRepositorySuite))
+}
+
+func (s *MemoryWebhookRepositorySuite) createWebhook(active bool, events ...string) domain.Webhook {
+	webhook := &domain.Webhook{URL: "https://example.com/hooks", Events: events, Secret: "0123456789abcdef", Active: active, CreatedBy: "admin", CreatedAt: time.Now()}
+	s.Require().NoError(s.webhooks.Create(s.ctx, webhook))
+	return *webhook
+}
+
+func (s *MemoryWebhookRepositorySuite) createDelivery(webhookID primitive.ObjectID, nextAttemptAt time.Time) domain.WebhookDelivery {
+	delivery := &domain.WebhookDelivery{
+		WebhookID:     webhookID,
+		Event:         domain.Event{ID: primitive.NewObjectID(), Type: domain.EventTaskCreated, At: time.Now(), Task: &domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee"}},
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     nextAttemptAt,
+	}
+	s.Require().NoError(s.deliveries.Create(s.ctx, delivery))
+	return *delivery
+}
+
+// TestWebhooks tests creating, finding, updating and deleting webhooks
+func (s *MemoryWebhookRepositorySuite) TestWebhooks() {
+	created := s.createWebhook(true, domain.EventTaskCreated, domain.EventTaskDeleted)
+	inactive := s.createWebhook(false, domain.EventTaskCreated)
+	s.createWebhook(true, domain.EventUserRegistered)
+
+	s.Run("GetById", func() {
+		result, err := s.webhooks.GetById(s.ctx, created.ID.Hex())
+
+		s.NoError(err)
+		s.Equal(created.URL, result.URL)
+		s.Equal(created.Events, result.Events)
+		s.Equal(created.Secret, result.Secret)
+	})
+
+	s.Run("GetByEvent", func() {
+		result, err := s.webhooks.GetByEvent(s.ctx, domain.EventTaskCreated)
+
+		s.NoError(err)
+		s.Require().Len(result, 1)
+		s.Equal(created.ID, result[0].ID)
+	})
+
+	s.Run("Update", func() {
+		update := &domain.Webhook{URL: "https://example.com/other", Events: []string{domain.EventTaskCreated}, Secret: "fedcba9876543210", Active: true}
+
+		s.NoError(s.webhooks.Update(s.ctx, inactive.ID.Hex(), update))
+
+		result, err := s.webhooks.GetByEvent(s.ctx, domain.EventTaskCreated)
+		s.NoError(err)
+		s.Len(result, 2)
+		updated, err := s.webhooks.GetById(s.ctx, inactive.ID.Hex())
+		s.NoError(err)
+		s.Equal("https://example.com/other", updated.URL)
+		s.Equal("admin", updated.CreatedBy)
+	})
+
+	s.Run("Delete", func() {
+		s.NoError(s.webhooks.Delete(s.ctx, created.ID.Hex()))
+
+		_, err := s.webhooks.GetById(s.ctx, created.ID.Hex())
+		s.ErrorIs(err, domain.ErrNotFound)
+		s.ErrorIs(s.webhooks.Delete(s.ctx, created.ID.Hex()), domain.ErrNotFound)
+	})
+
+	s.Run("GetAll", func() {
+		result, err := s.webhooks.GetAll(s.ctx)
+
+		s.NoError(err)
+		s.Len(result, 2)
+	})
+
+	s.Run("InvalidID", func() {
+		_, err := s.webhooks.GetById(s.ctx, "invalid")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+}
+
+// TestClaimDue tests that due deliveries are claimed earliest first and only
+// once within the lease
+func (s *MemoryWebhookRepositorySuite) TestClaimDue() {
+	webhookID := primitive.NewObjectID()
+	now := time.Now()
+	later := s.createDelivery(webhookID, now.Add(-time.Minute))
+	earlier := s.createDelivery(webhookID, now.Add(-time.Hour))
+	s.createDelivery(webhookID, now.Add(time.Hour))
+
+	s.Run("EarliestFirst", func() {
+		result, err := s.deliveries.ClaimDue(s.ctx, now, time.Minute)
+
+		s.NoError(err)
+		s.Equal(earlier.ID, result.ID)
+		s.Equal(earlier.Event.ID, result.Event.ID)
+		s.Equal("Buy Coffee", result.Event.Task.Title)
+	})
+
+	s.Run("Next", func() {
+		result, err := s.deliveries.ClaimDue(s.ctx, now, time.Minute)
+
+		s.NoError(err)
+		s.Equal(later.ID, result.ID)
+	})
+
+	s.Run("NoneDue", func() {
+		_, err := s.deliveries.ClaimDue(s.ctx, now, time.Minute)
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("LeaseExpired", func() {
+		result, err := s.deliveries.ClaimDue(s.ctx, now.Add(2*time.Minute), time.Minute)
+
+		s.NoError(err)
+		s.Contains([]primitive.ObjectID{earlier.ID, later.ID}, result.ID)
+	})
+
+	s.Run("SettledNotClaimed", func() {
+		s.SetupTest()
+		delivery := s.createDelivery(webhookID, now.Add(-time.Minute))
+		delivery.Status = domain.DeliverySucceeded
+		s.Require().NoError(s.deliveries.Update(s.ctx, &delivery))
+
+		_, err := s.deliveries.ClaimDue(s.ctx, now, time.Minute)
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("Concurrent", func() {
+		s.SetupTest()
+		s.createDelivery(webhookID, now.Add(-time.Minute))
+		var wg sync.WaitGroup
+		var claims atomic.Int32
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := s.deliveries.ClaimDue(s.ctx, now, time.Minute); err == nil {
+					claims.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		s.Equal(int32(1), claims.Load())
+	})
+}
+
+// TestGetByWebhook tests listing and deleting the deliveries of a webhook
+func (s *MemoryWebhookRepositorySuite) TestGetByWebhook() {
+	webhookID := primitive.NewObjectID()
+	now := time.Now()
+	first := s.createDelivery(webhookID, now.Add(-time.Hour))
+	second := s.createDelivery(webhookID, now)
+	s.createDelivery(primitive.NewObjectID(), now)
+
+	s.Run("MostRecentFirst", func() {
+		result, err := s.deliveries.GetByWebhook(s.ctx, webhookID.Hex(), 50)
+
+		s.NoError(err)
+		s.Require().Len(result, 2)
+		s.Equal(second.ID, result[0].ID)
+		s.Equal(first.ID, result[1].ID)
+	})
+
+	s.Run("Limit", func() {
+		result, err := s.deliveries.GetByWebhook(s.ctx, webhookID.Hex(), 1)
+
+		s.NoError(err)
+		s.Len(result, 1)
+	})
+
+	s.Run("DeleteByWebhook", func() {
+		s.NoError(s.deliveries.DeleteByWebhook(s.ctx, webhookID.Hex()))
+
+		result, err := s.deliveries.GetByWebhook(s.ctx, webhookID.Hex(), 50)
+		s.NoError(err)
+		s.Empty(result)
+		_, err = s.deliveries.GetById(s.ctx, first.ID.Hex())
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+)
+
+// MemoryWorkflowRepositorySuite defines the test suite for the in-memory workflow repository
+type MemoryWorkflowRepositorySuite struct {
+	suite.Suite
+	repository domain.WorkflowRepository
+	ctx        context.Context
+}
+
+// SetupTest creates an empty repository for every test
+func (s *MemoryWorkflowRepositorySuite) SetupTest() {
+	s.repository = persistence.NewMemoryWorkflowRepository()
+	s.ctx = context.Background()
+}
+
+// TestMemoryWorkflowRepositorySuite runs the test suite
+func TestMemoryWorkflowRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MemoryWorkflowRepositorySuite))
+}
+
+// TestGetAndSave tests the Get and Save methods
+func (s *MemoryWorkflowRepositorySuite) TestGetAndSave() {
+	s.Run("NotConfigured", func() {
+		_, err := s.repository.Get(s.ctx)
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	workflow := domain.Workflow{
+		Statuses:    []string{"todo", "done"},
+		Transitions: map[string][]string{"todo": {"done"}},
+		UpdatedBy:   "admin",
+		UpdatedAt:   time.Now().Truncate(time.Millisecond),
+	}
+	s.Require().NoError(s.repository.Save(s.ctx, &workflow))
+
+	s.Run("Saved", func() {
+		stored, err := s.repository.Get(s.ctx)
+
+		s.NoError(err)
+		s.Equal(workflow.Statuses, stored.Statuses)
+		s.Equal(workflow.Transitions, stored.Transitions)
+		s.Equal("admin", stored.UpdatedBy)
+		s.True(workflow.UpdatedAt.Equal(stored.UpdatedAt))
+	})
+
+	s.Run("NotShared", func() {
+		workflow.Transitions["todo"][0] = "todo"
+
+		stored, _ := s.repository.Get(s.ctx)
+		s.Equal([]string{"done"}, stored.Transitions["todo"])
+	})
+
+	s.Run("Replaced", func() {
+		s.NoError(s.repository.Save(s.ctx, &domain.Workflow{Statuses: []string{"open"}}))
+
+		stored, _ := s.repository.Get(s.ctx)
+		s.Equal([]string{"open"}, stored.Statuses)
+	})
+}
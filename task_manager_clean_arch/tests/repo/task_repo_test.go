@@ -31,6 +31,9 @@ type TaskRepositorySuite struct {
 func (s *TaskRepositorySuite) SetupSuite() {
 	_ = godotenv.Load("../../../env") // for Testing purpose
 	env := config.Load()
+	if env.DBDriver == config.DBDriverMemory {
+		s.T().Skip("DB_DRIVER=memory, skipping MongoDB repository tests")
+	}
 	DBHostURI := fmt.Sprintf("mongodb+srv://%s:%s@%s.r31b5bc.mongodb.net/?retryWrites=true&w=majority", env.DBUser, env.DBPass, env.DBHost)
 	var err error
 	s.ctx = context.Background()
@@ -228,6 +231,54 @@ func (s *TaskRepositorySuite) TestUpdate() {
 	})
 }
 
+// TestVersion tests that writes bump the task version and reject stale ones
+func (s *TaskRepositorySuite) TestVersion() {
+	task := &domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"}
+	err := s.repository.Create(s.ctx, task)
+	s.NoError(err)
+	id := task.ID.Hex()
+
+	s.Run("CreateStartsAtOne", func() {
+		s.Equal(int64(1), task.Version)
+	})
+
+	s.Run("UpdateBumpsVersion", func() {
+		update := &domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending", Version: 1}
+
+		err := s.repository.Update(s.ctx, id, update)
+
+		s.NoError(err)
+		s.Equal(int64(2), update.Version)
+	})
+
+	s.Run("StaleUpdate", func() {
+		err := s.repository.Update(s.ctx, id, &domain.Task{Title: "Buy Spices", Version: 1})
+
+		s.ErrorIs(err, domain.ErrVersionConflict)
+	})
+
+	s.Run("PatchBumpsVersion", func() {
+		status := "completed"
+
+		result, err := s.repository.Patch(s.ctx, id, domain.TaskUpdate{Status: &status, Version: 2})
+
+		s.NoError(err)
+		s.Equal(int64(3), result.Version)
+	})
+
+	s.Run("StaleDelete", func() {
+		err := s.repository.Delete(s.ctx, id, 2)
+
+		s.ErrorIs(err, domain.ErrVersionConflict)
+	})
+
+	s.Run("Delete", func() {
+		err := s.repository.Delete(s.ctx, id, 3)
+
+		s.NoError(err)
+	})
+}
+
 // TestDelete tests the Delete method
 func (s *TaskRepositorySuite) TestDelete() {
 	s.Run("Success", func() {
@@ -236,18 +287,21 @@ func (s *TaskRepositorySuite) TestDelete() {
 		_, err := s.database.Collection("tasks").InsertOne(s.ctx, task)
 		s.NoError(err)
 
-		err = s.repository.Delete(s.ctx, taskID.Hex())
+		err = s.repository.Delete(s.ctx, taskID.Hex(), 0)
 
 		s.NoError(err)
 
-		// Verify the task was deleted
-		err = s.database.Collection("tasks").FindOne(s.ctx, bson.M{"_id": taskID}).Err()
-		s.Error(err)
-		s.Equal(mongo.ErrNoDocuments, err)
+		// Verify the task was moved to the trash
+		var result database.TaskEntity
+		err = s.database.Collection("tasks").FindOne(s.ctx, bson.M{"_id": taskID}).Decode(&result)
+		s.NoError(err)
+		s.NotZero(result.DeletedAt)
+		_, err = s.repository.GetById(s.ctx, taskID.Hex())
+		s.ErrorIs(err, domain.ErrNotFound)
 	})
 
 	s.Run("InvalidID", func() {
-		err := s.repository.Delete(s.ctx, "invalid_id")
+		err := s.repository.Delete(s.ctx, "invalid_id", 0)
 
 		s.Error(err)
 		s.Contains(err.Error(), "invalid ObjectID")
@@ -255,13 +309,110 @@ func (s *TaskRepositorySuite) TestDelete() {
 
 	s.Run("NoDelete", func() {
 		taskID := primitive.NewObjectID()
-		err := s.repository.Delete(s.ctx, taskID.Hex())
+		err := s.repository.Delete(s.ctx, taskID.Hex(), 0)
 
 		s.Error(err)
 		s.Contains(err.Error(), "task not found or already deleted")
 	})
 }
 
+// TestTrash tests listing, restoring and purging deleted tasks
+func (s *TaskRepositorySuite) TestTrash() {
+	task := &domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"}
+	s.NoError(s.repository.Create(s.ctx, task))
+	id := task.ID.Hex()
+	s.NoError(s.repository.DeleteByIdAndUser(s.ctx, id, "Abebe", 0))
+
+	s.Run("HiddenFromReads", func() {
+		page, err := s.repository.Find(s.ctx, domain.TaskQuery{CreatedBy: "Abebe"})
+
+		s.NoError(err)
+		s.Empty(page.Tasks)
+		err = s.repository.Update(s.ctx, id, &domain.Task{Title: "Buy Spices"})
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("GetTrashByUser", func() {
+		trash, err := s.repository.GetTrashByUser(s.ctx, "Abebe")
+
+		s.NoError(err)
+		s.Len(trash, 1)
+		s.False(trash[0].DeletedAt.IsZero())
+	})
+
+	s.Run("PurgeKeepsRecentlyDeleted", func() {
+		purged, err := s.repository.PurgeDeletedBefore(s.ctx, time.Now().Add(-time.Hour))
+
+		s.NoError(err)
+		s.Zero(purged)
+	})
+
+	s.Run("RestoreByIdAndUser", func() {
+		restored, err := s.repository.RestoreByIdAndUser(s.ctx, id, "Abebe")
+
+		s.NoError(err)
+		s.True(restored.DeletedAt.IsZero())
+		s.Equal(int64(3), restored.Version)
+		_, err = s.repository.GetById(s.ctx, id)
+		s.NoError(err)
+	})
+
+	s.Run("RestoreNotInTrash", func() {
+		_, err := s.repository.RestoreByIdAndUser(s.ctx, id, "Abebe")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("PurgeDeletedBefore", func() {
+		s.NoError(s.repository.Delete(s.ctx, id, 0))
+
+		purged, err := s.repository.PurgeDeletedBefore(s.ctx, time.Now().Add(time.Minute))
+
+		s.NoError(err)
+		s.Equal(int64(1), purged)
+		count, _ := s.database.Collection("tasks").CountDocuments(s.ctx, bson.M{})
+		s.Zero(count)
+	})
+}
+
+// TestAssignees tests AddAssignee, RemoveAssignee and the assignee filter
+func (s *TaskRepositorySuite) TestAssignees() {
+	task := &domain.Task{Title: "Buy Coffee", CreatedBy: "Abebe", Status: "pending"}
+	s.NoError(s.repository.Create(s.ctx, task))
+	id := task.ID.Hex()
+
+	s.Run("AddAssignee", func() {
+		_, err := s.repository.AddAssignee(s.ctx, id, "Kebede")
+		s.NoError(err)
+		assigned, err := s.repository.AddAssignee(s.ctx, id, "Kebede")
+
+		s.NoError(err)
+		s.Equal([]string{"Kebede"}, assigned.Assignees)
+		page, _ := s.repository.Find(s.ctx, domain.TaskQuery{Assignee: "Kebede"})
+		s.Len(page.Tasks, 1)
+	})
+
+	s.Run("UpdateKeepsAssignees", func() {
+		s.NoError(s.repository.Update(s.ctx, id, &domain.Task{Title: "Buy Tea", CreatedBy: "Abebe"}))
+
+		updated, _ := s.repository.GetById(s.ctx, id)
+		s.Equal([]string{"Kebede"}, updated.Assignees)
+	})
+
+	s.Run("RemoveAssignee", func() {
+		unassigned, err := s.repository.RemoveAssignee(s.ctx, id, "Kebede")
+
+		s.NoError(err)
+		s.Empty(unassigned.Assignees)
+	})
+
+	s.Run("TaskNotFound", func() {
+		_, err := s.repository.AddAssignee(s.ctx, primitive.NewObjectID().Hex(), "Kebede")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
 // TestGetTaskCountByStatus tests the GetTaskCountByStatus method
 func (s *TaskRepositorySuite) TestGetTaskCountByStatus() {
 	s.Run("Success", func() {
@@ -282,7 +433,8 @@ func (s *TaskRepositorySuite) TestGetTaskCountByStatus() {
 			{Status: "pending", Count: 2},
 			{Status: "completed", Count: 1},
 		}
-		s.ElementsMatch(expected, result)
+		s.ElementsMatch(expected, result.ByStatus)
+		s.Equal([]domain.PriorityCount{{Priority: "medium", Count: 3}}, result.ByPriority)
 	})
 
 	s.Run("EmptyCollection", func() {
@@ -291,7 +443,7 @@ func (s *TaskRepositorySuite) TestGetTaskCountByStatus() {
 		result, err := s.repository.GetTaskCountByStatus(s.ctx)
 
 		s.NoError(err)
-		s.Empty(result)
+		s.Empty(result.ByStatus)
 	})
 }
 
@@ -410,18 +562,21 @@ func (s *TaskRepositorySuite) TestDeleteByIdAndUser() {
 		_, err := s.database.Collection("tasks").InsertOne(s.ctx, task)
 		s.NoError(err)
 
-		err = s.repository.DeleteByIdAndUser(s.ctx, taskID.Hex(), "Abebe")
+		err = s.repository.DeleteByIdAndUser(s.ctx, taskID.Hex(), "Abebe", 0)
 
 		s.NoError(err)
 
-		// Verify the task was deleted
-		err = s.database.Collection("tasks").FindOne(s.ctx, bson.M{"_id": taskID}).Err()
-		s.Error(err)
-		s.Equal(mongo.ErrNoDocuments, err)
+		// Verify the task was moved to the trash
+		var result database.TaskEntity
+		err = s.database.Collection("tasks").FindOne(s.ctx, bson.M{"_id": taskID}).Decode(&result)
+		s.NoError(err)
+		s.NotZero(result.DeletedAt)
+		_, err = s.repository.GetById(s.ctx, taskID.Hex())
+		s.ErrorIs(err, domain.ErrNotFound)
 	})
 
 	s.Run("InvalidID", func() {
-		err := s.repository.DeleteByIdAndUser(s.ctx, "invalid_id", "Abebe")
+		err := s.repository.DeleteByIdAndUser(s.ctx, "invalid_id", "Abebe", 0)
 
 		s.Error(err)
 		s.Contains(err.Error(), "invalid ObjectID")
@@ -429,7 +584,7 @@ func (s *TaskRepositorySuite) TestDeleteByIdAndUser() {
 
 	s.Run("NoDelete", func() {
 		taskID := primitive.NewObjectID()
-		err := s.repository.DeleteByIdAndUser(s.ctx, taskID.Hex(), "Abebe")
+		err := s.repository.DeleteByIdAndUser(s.ctx, taskID.Hex(), "Abebe", 0)
 
 		s.Error(err)
 		s.Contains(err.Error(), "task not found or not owned by user")
@@ -467,3 +622,94 @@ func (s *TaskRepositorySuite) TestGetTaskStatsByUser() {
 		s.Empty(result)
 	})
 }
+
+// TestFind tests the Find method
+func (s *TaskRepositorySuite) TestFind() {
+	day := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []database.TaskEntity{
+		{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "Abebe", DueDate: primitive.NewDateTimeFromTime(day), Status: "pending"},
+		{ID: primitive.NewObjectID(), Title: "Sell Spices", CreatedBy: "Abebe", DueDate: primitive.NewDateTimeFromTime(day.Add(24 * time.Hour)), Status: "completed"},
+		{ID: primitive.NewObjectID(), Title: "Clean Office", CreatedBy: "Abebe", DueDate: primitive.NewDateTimeFromTime(day.Add(48 * time.Hour)), Status: "pending"},
+		{ID: primitive.NewObjectID(), Title: "Brew Coffee", CreatedBy: "Kebede", DueDate: primitive.NewDateTimeFromTime(day), Status: "pending"},
+	}
+	for _, task := range tasks {
+		_, err := s.database.Collection("tasks").InsertOne(s.ctx, task)
+		s.NoError(err)
+	}
+
+	s.Run("FilterAndSort", func() {
+		page, err := s.repository.Find(s.ctx, domain.TaskQuery{CreatedBy: "Abebe", Status: "pending", Sort: domain.SortDueDateDesc, Limit: 10})
+
+		s.NoError(err)
+		s.Equal(int64(2), page.Total)
+		s.Len(page.Tasks, 2)
+		s.Equal("Clean Office", page.Tasks[0].Title)
+		s.Equal("Buy Coffee", page.Tasks[1].Title)
+	})
+
+	s.Run("Cursor", func() {
+		first, err := s.repository.Find(s.ctx, domain.TaskQuery{Sort: domain.SortTitleAsc, Limit: 3})
+		s.NoError(err)
+		s.NotEmpty(first.NextCursor)
+
+		second, err := s.repository.Find(s.ctx, domain.TaskQuery{Sort: domain.SortTitleAsc, Limit: 3, Cursor: first.NextCursor})
+
+		s.NoError(err)
+		s.Len(second.Tasks, 1)
+		s.Equal("Sell Spices", second.Tasks[0].Title)
+		s.Empty(second.NextCursor)
+	})
+}
+
+// TestSearch tests the Search method
+func (s *TaskRepositorySuite) TestSearch() {
+	_, err := s.database.Collection("tasks").Indexes().CreateOne(s.ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().SetWeights(bson.D{{Key: "title", Value: database.TaskTitleWeight}, {Key: "description", Value: 1}}),
+	})
+	s.NoError(err)
+	tasks := []database.TaskEntity{
+		{ID: primitive.NewObjectID(), Title: "Sell Spices", Description: "Bring coffee for the buyers", CreatedBy: "Abebe", Status: "pending"},
+		{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", CreatedBy: "Abebe", Status: "pending"},
+		{ID: primitive.NewObjectID(), Title: "Clean Office", CreatedBy: "Kebede", Status: "pending"},
+	}
+	for _, task := range tasks {
+		_, err := s.database.Collection("tasks").InsertOne(s.ctx, task)
+		s.NoError(err)
+	}
+
+	s.Run("RankedByRelevance", func() {
+		result, err := s.repository.Search(s.ctx, domain.TaskSearch{Text: "coffee", CreatedBy: "Abebe", Limit: 10})
+
+		s.NoError(err)
+		s.Len(result, 2)
+		s.Equal("Buy Coffee", result[0].Task.Title)
+		s.Greater(result[0].Score, result[1].Score)
+	})
+}
+
+// TestPatch tests the Patch method
+func (s *TaskRepositorySuite) TestPatch() {
+	task := database.TaskEntity{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", CreatedBy: "Abebe", Status: "pending"}
+	_, err := s.database.Collection("tasks").InsertOne(s.ctx, task)
+	s.NoError(err)
+
+	s.Run("OnlySuppliedFields", func() {
+		status := "completed"
+
+		result, err := s.repository.Patch(s.ctx, task.ID.Hex(), domain.TaskUpdate{Status: &status})
+
+		s.NoError(err)
+		s.Equal("completed", result.Status)
+		s.Equal(task.Description, result.Description)
+	})
+
+	s.Run("ByIdAndUserWrongOwner", func() {
+		title := "Stolen"
+
+		_, err := s.repository.PatchByIdAndUser(s.ctx, task.ID.Hex(), domain.TaskUpdate{Title: &title}, "Kebede")
+
+		s.Error(err)
+		s.Contains(err.Error(), "task not found")
+	})
+}
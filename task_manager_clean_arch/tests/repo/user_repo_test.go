@@ -31,6 +31,9 @@ type UserRepositorySuite struct {
 func (s *UserRepositorySuite) SetupSuite() {
 	_ = godotenv.Load("../../../env") // for Testing purpose
 	env := config.Load()
+	if env.DBDriver == config.DBDriverMemory {
+		s.T().Skip("DB_DRIVER=memory, skipping MongoDB repository tests")
+	}
 	DBHostURI := fmt.Sprintf("mongodb+srv://%s:%s@%s.r31b5bc.mongodb.net/?retryWrites=true&w=majority", env.DBUser, env.DBPass, env.DBHost)
 	var err error
 	fmt.Println("Connecting to MongoDB at:", DBHostURI)
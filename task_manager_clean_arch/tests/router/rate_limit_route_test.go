@@ -0,0 +1,85 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/config"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/persistence"
+	"github.com/yiheyistm/task_manager/internal/interfaces/http/router"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RateLimitRouteSuite defines the test suite for the rate limits installed by the router
+type RateLimitRouteSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+// SetupTest builds a router on the memory driver with small limits
+func (s *RateLimitRouteSuite) SetupTest() {
+	env := &config.Env{
+		DBDriver:                 config.DBDriverMemory,
+		BlobDir:                  s.T().TempDir(),
+		AccessTokenSecret:        "secret",
+		RefreshTokenSecret:       "secret",
+		AccessTokenExpiryHour:    1,
+		RefreshTokenExpiryHour:   1,
+		EventStreamBufferSize:    10,
+		RateLimitPublicPerMinute: 1,
+		RateLimitPublicBurst:     2,
+		RateLimitIPPerMinute:     1,
+		RateLimitIPBurst:         3,
+		RateLimitUserPerMinute:   1,
+		RateLimitUserBurst:       5,
+	}
+	s.router = router.SetupRouter(env, persistence.NewRepositories(env, mongo.Database{}))
+}
+
+// TestRateLimitRouteSuite runs the test suite
+func TestRateLimitRouteSuite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	suite.Run(t, new(RateLimitRouteSuite))
+}
+
+func (s *RateLimitRouteSuite) serve(method, path, body, token, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestInvalidTokens tests that requests with invalid tokens are throttled by IP
+func (s *RateLimitRouteSuite) TestInvalidTokens() {
+	var codes []int
+	for range 5 {
+		codes = append(codes, s.serve(http.MethodGet, "/api/v1/users/abebe/tasks", "", "forged", "10.0.0.1:1234").Code)
+	}
+
+	s.Equal([]int{401, 401, 401, 429, 429}, codes)
+
+	s.Run("OtherIP", func() {
+		s.Equal(http.StatusUnauthorized, s.serve(http.MethodGet, "/api/v1/users/abebe/tasks", "", "forged", "10.0.0.2:1234").Code)
+	})
+}
+
+// TestLogin tests that login attempts are throttled by IP
+func (s *RateLimitRouteSuite) TestLogin() {
+	var codes []int
+	for range 3 {
+		w := s.serve(http.MethodPost, "/api/v1/users/login", `{"identifier":"abebe","password":"wrong"}`, "", "10.0.0.1:1234")
+		codes = append(codes, w.Code)
+	}
+
+	s.Equal(http.StatusTooManyRequests, codes[2])
+	s.NotEqual(http.StatusTooManyRequests, codes[0])
+}
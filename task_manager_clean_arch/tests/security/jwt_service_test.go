@@ -56,9 +56,10 @@ func (s *JwtServiceSuite) TestNewJWTService() {
 func (s *JwtServiceSuite) TestGenerateTokens() {
 	s.Run("Success", func() {
 		user := domain.User{
-			ID:       "1",
-			Username: "Abebe",
-			Role:     "user",
+			ID:           "1",
+			Username:     "Abebe",
+			Role:         "user",
+			TokenVersion: 3,
 		}
 
 		tokens, err := s.jwtService.GenerateTokens(user)
@@ -75,6 +76,8 @@ func (s *JwtServiceSuite) TestGenerateTokens() {
 		s.Equal(user.Role, accessClaims["role"])
 		s.NotEmpty(accessClaims["iat"])
 		s.NotEmpty(accessClaims["exp"])
+		s.NotEmpty(accessClaims["jti"])
+		s.Equal(float64(user.TokenVersion), accessClaims["ver"])
 
 		// Validate refresh token
 		refreshClaims, err := s.jwtService.ValidateRefreshToken(tokens.RefreshToken)
@@ -83,6 +86,11 @@ func (s *JwtServiceSuite) TestGenerateTokens() {
 		s.Equal(user.Username, refreshClaims["username"])
 		s.NotEmpty(refreshClaims["iat"])
 		s.NotEmpty(refreshClaims["exp"])
+		s.NotEmpty(refreshClaims["jti"])
+		s.Equal(refreshClaims["jti"], refreshClaims["fam"]) // a login starts a new family
+		s.Equal(refreshClaims["fam"], accessClaims["fam"])
+		s.NotEqual(refreshClaims["jti"], accessClaims["jti"])
+		s.Equal(float64(user.TokenVersion), refreshClaims["ver"])
 	})
 
 	s.Run("EmptyUserID", func() {
@@ -100,6 +108,33 @@ func (s *JwtServiceSuite) TestGenerateTokens() {
 	})
 }
 
+// TestRotateTokens tests the RotateTokens function
+func (s *JwtServiceSuite) TestRotateTokens() {
+	s.Run("KeepsFamily", func() {
+		user := domain.User{ID: "1", Username: "Abebe", Role: "user"}
+
+		tokens, err := s.jwtService.RotateTokens(user, "family-1")
+
+		s.NoError(err)
+		claims, err := s.jwtService.ValidateRefreshToken(tokens.RefreshToken)
+		s.NoError(err)
+		s.Equal("family-1", claims["fam"])
+		s.NotEqual("family-1", claims["jti"])
+	})
+
+	s.Run("UniqueTokenIDs", func() {
+		user := domain.User{ID: "1", Username: "Abebe", Role: "user"}
+		first, err := s.jwtService.RotateTokens(user, "family-1")
+		s.NoError(err)
+		second, err := s.jwtService.RotateTokens(user, "family-1")
+		s.NoError(err)
+
+		firstClaims, _ := s.jwtService.ValidateRefreshToken(first.RefreshToken)
+		secondClaims, _ := s.jwtService.ValidateRefreshToken(second.RefreshToken)
+		s.NotEqual(firstClaims["jti"], secondClaims["jti"])
+	})
+}
+
 // TestValidateToken tests the ValidateToken function
 func (s *JwtServiceSuite) TestValidateToken() {
 	s.Run("Success", func() {
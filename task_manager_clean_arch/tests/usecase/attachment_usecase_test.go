@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AttachmentUseCaseSuite defines the test suite for AttachmentUseCase
+type AttachmentUseCaseSuite struct {
+	suite.Suite
+	mockAttachments *mocks_domain.AttachmentRepository
+	mockTasks       *mocks_domain.TaskRepository
+	mockBlobs       *mocks_domain.BlobStore
+	useCase         domain.IAttachmentUseCase
+	task            domain.Task
+	attachment      domain.Attachment
+}
+
+// SetupTest initializes the mocks and use case before each test. Files of up
+// to 16 bytes are accepted. The task is created by abebe and assigned to
+// kebede, who uploaded the attachment.
+func (s *AttachmentUseCaseSuite) SetupTest() {
+	s.mockAttachments = mocks_domain.NewAttachmentRepository(s.T())
+	s.mockTasks = mocks_domain.NewTaskRepository(s.T())
+	s.mockBlobs = mocks_domain.NewBlobStore(s.T())
+	s.useCase = usecase.NewAttachmentUseCase(s.mockAttachments, s.mockTasks, s.mockBlobs, 16, []string{"text/plain", "image/png"})
+	s.task = domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe", Assignees: []string{"kebede"}}
+	s.attachment = domain.Attachment{ID: primitive.NewObjectID(), TaskID: s.task.ID, Filename: "notes.txt", Size: 5, UploadedBy: "kebede"}
+	s.mockTasks.On("GetById", mock.Anything, s.task.ID.Hex()).Return(s.task, nil).Maybe()
+}
+
+// TestAttachmentUseCaseSuite runs the test suite
+func TestAttachmentUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentUseCaseSuite))
+}
+
+// storeBlob makes the blob store read all of the content it is given.
+func (s *AttachmentUseCaseSuite) storeBlob() *string {
+	stored := new(string)
+	s.mockBlobs.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ string, content io.Reader) (int64, error) {
+		data, err := io.ReadAll(content)
+		*stored = string(data)
+		return int64(len(data)), err
+	}).Once()
+	return stored
+}
+
+// TestCreateByTaskAndUser tests the CreateByTaskAndUser method
+func (s *AttachmentUseCaseSuite) TestCreateByTaskAndUser() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		stored := s.storeBlob()
+		attachment := &domain.Attachment{Filename: `C:\Users\kebede\..\notes.txt`}
+		s.mockAttachments.On("Create", mock.Anything, attachment).Return(nil)
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), attachment, strings.NewReader("hello world"), "kebede")
+
+		s.NoError(err)
+		s.Equal("hello world", *stored)
+		s.Equal("notes.txt", attachment.Filename)
+		s.Equal("text/plain; charset=utf-8", attachment.ContentType)
+		s.Equal(int64(11), attachment.Size)
+		s.Equal("kebede", attachment.UploadedBy)
+		s.Equal(s.task.ID, attachment.TaskID)
+		s.False(attachment.ID.IsZero())
+		s.mockBlobs.AssertCalled(s.T(), "Put", mock.Anything, attachment.ID.Hex(), mock.Anything)
+	})
+
+	s.Run("NotVisible", func() {
+		s.SetupTest()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Attachment{Filename: "a.txt"}, strings.NewReader("hi"), "almaz")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("DisallowedType", func() {
+		s.SetupTest()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Attachment{Filename: "a.txt"}, strings.NewReader("<html>hi</html>"), "kebede")
+
+		s.ErrorIs(err, domain.ErrUnsupportedType)
+	})
+
+	s.Run("DeclaredTooLarge", func() {
+		s.SetupTest()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Attachment{Filename: "a.txt", Size: 17}, strings.NewReader("hi"), "kebede")
+
+		s.ErrorIs(err, domain.ErrTooLarge)
+	})
+
+	s.Run("TooLarge", func() {
+		s.SetupTest()
+		stored := s.storeBlob()
+		s.mockBlobs.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Attachment{Filename: "a.txt"}, strings.NewReader(strings.Repeat("a", 100)), "kebede")
+
+		s.ErrorIs(err, domain.ErrTooLarge)
+		s.Len(*stored, 17, "reading stops after one byte more than allowed")
+	})
+
+	s.Run("Empty", func() {
+		s.SetupTest()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Attachment{Filename: "a.txt"}, strings.NewReader(""), "kebede")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("NoFilename", func() {
+		s.SetupTest()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Attachment{Filename: "../"}, strings.NewReader("hi"), "kebede")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("RepositoryError", func() {
+		s.SetupTest()
+		s.storeBlob()
+		s.mockAttachments.On("Create", mock.Anything, mock.Anything).Return(errors.New("database error"))
+		s.mockBlobs.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Attachment{Filename: "a.txt"}, strings.NewReader("hi"), "kebede")
+
+		s.EqualError(err, "database error")
+	})
+}
+
+// TestOpenByIdAndUser tests the OpenByIdAndUser method
+func (s *AttachmentUseCaseSuite) TestOpenByIdAndUser() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockAttachments.On("GetById", mock.Anything, s.attachment.ID.Hex()).Return(s.attachment, nil)
+		s.mockBlobs.On("Open", mock.Anything, s.attachment.ID.Hex()).Return(io.NopCloser(strings.NewReader("hello")), nil)
+
+		attachment, content, err := s.useCase.OpenByIdAndUser(s.task.ID.Hex(), s.attachment.ID.Hex(), "abebe")
+
+		s.NoError(err)
+		s.Equal(s.attachment.ID, attachment.ID)
+		data, _ := io.ReadAll(content)
+		s.Equal("hello", string(data))
+	})
+
+	s.Run("OtherTask", func() {
+		s.SetupTest()
+		other := s.attachment
+		other.TaskID = primitive.NewObjectID()
+		s.mockAttachments.On("GetById", mock.Anything, s.attachment.ID.Hex()).Return(other, nil)
+
+		_, _, err := s.useCase.OpenByIdAndUser(s.task.ID.Hex(), s.attachment.ID.Hex(), "abebe")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestDeleteByIdAndUser tests the DeleteByIdAndUser method
+func (s *AttachmentUseCaseSuite) TestDeleteByIdAndUser() {
+	for _, username := range []string{"kebede", "abebe"} {
+		s.Run(username, func() {
+			s.SetupTest()
+			s.mockAttachments.On("GetById", mock.Anything, s.attachment.ID.Hex()).Return(s.attachment, nil)
+			s.mockAttachments.On("Delete", mock.Anything, s.attachment.ID.Hex()).Return(nil)
+			s.mockBlobs.On("Delete", mock.Anything, s.attachment.ID.Hex()).Return(nil)
+
+			s.NoError(s.useCase.DeleteByIdAndUser(s.task.ID.Hex(), s.attachment.ID.Hex(), username))
+		})
+	}
+
+	s.Run("OtherAssignee", func() {
+		s.SetupTest()
+		task := domain.Task{ID: primitive.NewObjectID(), CreatedBy: "almaz", Assignees: []string{"abebe", "kebede"}}
+		attachment := domain.Attachment{ID: primitive.NewObjectID(), TaskID: task.ID, UploadedBy: "abebe"}
+		s.mockTasks.On("GetById", mock.Anything, task.ID.Hex()).Return(task, nil)
+		s.mockAttachments.On("GetById", mock.Anything, attachment.ID.Hex()).Return(attachment, nil)
+
+		err := s.useCase.DeleteByIdAndUser(task.ID.Hex(), attachment.ID.Hex(), "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("BlobError", func() {
+		s.SetupTest()
+		s.mockAttachments.On("GetById", mock.Anything, s.attachment.ID.Hex()).Return(s.attachment, nil)
+		s.mockAttachments.On("Delete", mock.Anything, s.attachment.ID.Hex()).Return(nil)
+		s.mockBlobs.On("Delete", mock.Anything, s.attachment.ID.Hex()).Return(errors.New("disk error"))
+
+		s.NoError(s.useCase.DeleteByIdAndUser(s.task.ID.Hex(), s.attachment.ID.Hex(), "kebede"))
+	})
+}
+
+// TestPurgeOrphans tests that only the attachments of purged tasks are
+// deleted
+func (s *AttachmentUseCaseSuite) TestPurgeOrphans() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		purgedTask := primitive.NewObjectID()
+		orphans := []domain.Attachment{{ID: primitive.NewObjectID(), TaskID: purgedTask}, {ID: primitive.NewObjectID(), TaskID: purgedTask}}
+		s.mockAttachments.On("GetTaskIds", mock.Anything).Return([]primitive.ObjectID{s.task.ID, purgedTask}, nil)
+		s.mockTasks.On("GetExistingIds", mock.Anything, []primitive.ObjectID{s.task.ID, purgedTask}).Return([]primitive.ObjectID{s.task.ID}, nil)
+		s.mockAttachments.On("GetByTask", mock.Anything, purgedTask.Hex()).Return(orphans, nil)
+		for _, orphan := range orphans {
+			s.mockAttachments.On("Delete", mock.Anything, orphan.ID.Hex()).Return(nil)
+			s.mockBlobs.On("Delete", mock.Anything, orphan.ID.Hex()).Return(nil)
+		}
+
+		purged, err := s.useCase.PurgeOrphans()
+
+		s.NoError(err)
+		s.Equal(int64(2), purged)
+	})
+
+	s.Run("NoAttachments", func() {
+		s.SetupTest()
+		s.mockAttachments.On("GetTaskIds", mock.Anything).Return([]primitive.ObjectID{}, nil)
+
+		purged, err := s.useCase.PurgeOrphans()
+
+		s.NoError(err)
+		s.Zero(purged)
+		s.mockTasks.AssertNotCalled(s.T(), "GetExistingIds", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CommentUseCaseSuite defines the test suite for CommentUseCase
+type CommentUseCaseSuite struct {
+	suite.Suite
+	mockComments *mocks_domain.CommentRepository
+	mockTasks    *mocks_domain.TaskRepository
+	mockHistory  *mocks_domain.TaskHistoryRepository
+	mockUsers    *mocks_domain.UserRepository
+	useCase      domain.ICommentUseCase
+	task         domain.Task
+	comment      domain.Comment
+}
+
+// SetupTest initializes the mocks and use case before each test. The task is
+// created by abebe and assigned to kebede, who wrote the comment.
+func (s *CommentUseCaseSuite) SetupTest() {
+	s.mockComments = mocks_domain.NewCommentRepository(s.T())
+	s.mockTasks = mocks_domain.NewTaskRepository(s.T())
+	s.mockHistory = mocks_domain.NewTaskHistoryRepository(s.T())
+	s.mockUsers = mocks_domain.NewUserRepository(s.T())
+	s.useCase = usecase.NewCommentUseCase(s.mockComments, s.mockTasks, s.mockHistory, s.mockUsers)
+	s.task = domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe", Assignees: []string{"kebede"}}
+	s.comment = domain.Comment{ID: primitive.NewObjectID(), TaskID: s.task.ID, Author: "kebede", Body: "On it", CreatedAt: time.Now()}
+	s.mockTasks.On("GetById", mock.Anything, s.task.ID.Hex()).Return(s.task, nil).Maybe()
+}
+
+// TestCommentUseCaseSuite runs the test suite
+func TestCommentUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(CommentUseCaseSuite))
+}
+
+// TestCreateByTaskAndUser tests the CreateByTaskAndUser method
+func (s *CommentUseCaseSuite) TestCreateByTaskAndUser() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		comment := &domain.Comment{Body: "  <b>@kebede</b> please check @ghost and @abebe.  ", Author: "someone-else"}
+		s.mockUsers.On("GetByUsername", mock.Anything, "kebede").Return(&domain.User{Username: "kebede"}, nil)
+		s.mockUsers.On("GetByUsername", mock.Anything, "ghost").Return(nil, domain.NewError(domain.ErrNotFound, "user not found"))
+		s.mockUsers.On("GetByUsername", mock.Anything, "abebe").Return(&domain.User{Username: "abebe"}, nil)
+		s.mockComments.On("Create", mock.Anything, comment).Return(nil)
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), comment, "kebede")
+
+		s.NoError(err)
+		s.Equal("@kebede please check @ghost and @abebe.", comment.Body)
+		s.Equal([]string{"kebede", "abebe"}, comment.Mentions)
+		s.Equal("kebede", comment.Author)
+		s.Equal(s.task.ID, comment.TaskID)
+		s.False(comment.CreatedAt.IsZero())
+	})
+
+	s.Run("NotVisible", func() {
+		s.SetupTest()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Comment{Body: "hi"}, "almaz")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("OnlyMarkup", func() {
+		s.SetupTest()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Comment{Body: " <script></script> "}, "abebe")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("TooLong", func() {
+		s.SetupTest()
+
+		err := s.useCase.CreateByTaskAndUser(s.task.ID.Hex(), &domain.Comment{Body: strings.Repeat("é", domain.MaxCommentLength+1)}, "abebe")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+}
+
+// TestUpdateByIdAndUser tests the UpdateByIdAndUser method
+func (s *CommentUseCaseSuite) TestUpdateByIdAndUser() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockComments.On("GetById", mock.Anything, s.comment.ID.Hex()).Return(s.comment, nil)
+		s.mockComments.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Comment) bool {
+			return c.ID == s.comment.ID && c.Body == "Done, [see](#)" && len(c.Mentions) == 0 && !c.UpdatedAt.IsZero()
+		})).Return(nil)
+
+		result, err := s.useCase.UpdateByIdAndUser(s.task.ID.Hex(), s.comment.ID.Hex(), "Done, [see](javascript:void(0))", "kebede")
+
+		s.NoError(err)
+		s.Equal("Done, [see](#)", result.Body)
+		s.Equal(s.comment.CreatedAt, result.CreatedAt)
+	})
+
+	s.Run("NotAuthor", func() {
+		s.SetupTest()
+		s.mockComments.On("GetById", mock.Anything, s.comment.ID.Hex()).Return(s.comment, nil)
+
+		_, err := s.useCase.UpdateByIdAndUser(s.task.ID.Hex(), s.comment.ID.Hex(), "edited", "abebe")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("OtherTask", func() {
+		s.SetupTest()
+		other := s.comment
+		other.TaskID = primitive.NewObjectID()
+		s.mockComments.On("GetById", mock.Anything, s.comment.ID.Hex()).Return(other, nil)
+
+		_, err := s.useCase.UpdateByIdAndUser(s.task.ID.Hex(), s.comment.ID.Hex(), "edited", "kebede")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestDeleteByIdAndUser tests the DeleteByIdAndUser method
+func (s *CommentUseCaseSuite) TestDeleteByIdAndUser() {
+	s.Run("Author", func() {
+		s.SetupTest()
+		s.mockComments.On("GetById", mock.Anything, s.comment.ID.Hex()).Return(s.comment, nil)
+		s.mockComments.On("Delete", mock.Anything, s.comment.ID.Hex()).Return(nil)
+
+		s.NoError(s.useCase.DeleteByIdAndUser(s.task.ID.Hex(), s.comment.ID.Hex(), "kebede"))
+	})
+
+	s.Run("TaskCreator", func() {
+		s.SetupTest()
+		s.mockComments.On("GetById", mock.Anything, s.comment.ID.Hex()).Return(s.comment, nil)
+		s.mockComments.On("Delete", mock.Anything, s.comment.ID.Hex()).Return(nil)
+
+		s.NoError(s.useCase.DeleteByIdAndUser(s.task.ID.Hex(), s.comment.ID.Hex(), "abebe"))
+	})
+
+	s.Run("OtherAssignee", func() {
+		s.SetupTest()
+		task := domain.Task{ID: primitive.NewObjectID(), CreatedBy: "almaz", Assignees: []string{"abebe", "kebede"}}
+		comment := domain.Comment{ID: primitive.NewObjectID(), TaskID: task.ID, Author: "abebe"}
+		s.mockTasks.On("GetById", mock.Anything, task.ID.Hex()).Return(task, nil)
+		s.mockComments.On("GetById", mock.Anything, comment.ID.Hex()).Return(comment, nil)
+
+		err := s.useCase.DeleteByIdAndUser(task.ID.Hex(), comment.ID.Hex(), "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+}
+
+// TestGetActivityByTaskAndUser tests that comments and status changes are
+// merged, most recent first
+func (s *CommentUseCaseSuite) TestGetActivityByTaskAndUser() {
+	s.SetupTest()
+	start := time.Now().Add(-time.Hour)
+	s.comment.CreatedAt = start.Add(20 * time.Minute)
+	s.mockComments.On("GetByTask", mock.Anything, s.task.ID.Hex()).Return([]domain.Comment{s.comment}, nil)
+	s.mockHistory.On("GetByTask", mock.Anything, s.task.ID.Hex()).Return([]domain.TaskHistory{
+		{Action: domain.TaskUpdated, Actor: "kebede", At: start.Add(30 * time.Minute), Changes: []domain.TaskChange{{Field: "status", From: "pending", To: "in_progress"}}},
+		{Action: domain.TaskUpdated, Actor: "abebe", At: start.Add(10 * time.Minute), Changes: []domain.TaskChange{{Field: "title", From: "Coffee", To: "Buy Coffee"}}},
+		{Action: domain.TaskCreated, Actor: "abebe", At: start, Changes: []domain.TaskChange{{Field: "title", To: "Coffee"}, {Field: "status", To: "pending"}}},
+	}, nil)
+
+	activity, err := s.useCase.GetActivityByTaskAndUser(s.task.ID.Hex(), "kebede")
+
+	s.NoError(err)
+	s.Len(activity, 3)
+	s.Equal(domain.ActivityStatusChange, activity[0].Kind)
+	s.Equal("in_progress", activity[0].To)
+	s.Equal(domain.ActivityComment, activity[1].Kind)
+	s.Equal(s.comment.ID, activity[1].Comment.ID)
+	s.Equal(domain.ActivityStatusChange, activity[2].Kind)
+	s.Equal("", activity[2].From)
+	s.Equal("abebe", activity[2].Actor)
+}
@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProjectUseCaseSuite defines the test suite for ProjectUseCase
+type ProjectUseCaseSuite struct {
+	suite.Suite
+	mockRepo  *mocks_domain.ProjectRepository
+	mockTasks *mocks_domain.ITaskUseCase
+	taskRepo  *mocks_domain.TaskRepository
+	userRepo  *mocks_domain.UserRepository
+	useCase   domain.IProjectUseCase
+	project   domain.Project
+}
+
+// SetupTest initializes the mocks and use case before each test
+func (s *ProjectUseCaseSuite) SetupTest() {
+	s.mockRepo = mocks_domain.NewProjectRepository(s.T())
+	s.mockTasks = mocks_domain.NewITaskUseCase(s.T())
+	s.taskRepo = mocks_domain.NewTaskRepository(s.T())
+	s.userRepo = mocks_domain.NewUserRepository(s.T())
+	s.useCase = usecase.NewProjectUseCase(s.mockRepo, s.taskRepo, s.userRepo, s.mockTasks)
+	s.project = domain.Project{
+		ID:        primitive.NewObjectID(),
+		Name:      "Merkato",
+		CreatedBy: "abebe",
+		Members: []domain.ProjectMember{
+			{Username: "abebe", Role: domain.ProjectOwner},
+			{Username: "kebede", Role: domain.ProjectEditor},
+			{Username: "almaz", Role: domain.ProjectViewer},
+		},
+	}
+}
+
+// TestProjectUseCaseSuite runs the test suite
+func TestProjectUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ProjectUseCaseSuite))
+}
+
+func (s *ProjectUseCaseSuite) expectProject() {
+	s.mockRepo.On("GetById", mock.Anything, s.project.ID.Hex()).Return(s.project, nil)
+}
+
+// TestCreate tests the Create method
+func (s *ProjectUseCaseSuite) TestCreate() {
+	s.Run("CreatorBecomesOwner", func() {
+		project := &domain.Project{Name: "Merkato", CreatedBy: "abebe"}
+		s.mockRepo.On("Create", mock.Anything, project).Return(nil)
+
+		err := s.useCase.Create(project)
+
+		s.NoError(err)
+		s.Equal([]domain.ProjectMember{{Username: "abebe", Role: domain.ProjectOwner}}, project.Members)
+		s.False(project.CreatedAt.IsZero())
+	})
+
+	s.Run("EmptyName", func() {
+		err := s.useCase.Create(&domain.Project{Name: " ", CreatedBy: "abebe"})
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+}
+
+// TestGetByIdAndUser tests the GetByIdAndUser method
+func (s *ProjectUseCaseSuite) TestGetByIdAndUser() {
+	s.Run("Member", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		project, err := s.useCase.GetByIdAndUser(s.project.ID.Hex(), "almaz")
+
+		s.NoError(err)
+		s.Equal(s.project, project)
+	})
+
+	s.Run("NotMember", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		_, err := s.useCase.GetByIdAndUser(s.project.ID.Hex(), "chala")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("EmptyIDs", func() {
+		_, err := s.useCase.GetByIdAndUser("", "")
+
+		s.EqualError(err, "project ID and username cannot be empty")
+	})
+}
+
+// TestUpdateAndDelete tests the UpdateByIdAndUser and DeleteByIdAndUser methods
+func (s *ProjectUseCaseSuite) TestUpdateAndDelete() {
+	s.Run("UpdateByOwner", func() {
+		s.SetupTest()
+		s.expectProject()
+		update := &domain.Project{Name: "Piassa"}
+		s.mockRepo.On("Update", mock.Anything, s.project.ID.Hex(), update).Return(nil)
+
+		err := s.useCase.UpdateByIdAndUser(s.project.ID.Hex(), update, "abebe")
+
+		s.NoError(err)
+		s.Equal(s.project.ID, update.ID)
+		s.Equal(s.project.Members, update.Members)
+	})
+
+	s.Run("UpdateByEditor", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		err := s.useCase.UpdateByIdAndUser(s.project.ID.Hex(), &domain.Project{Name: "Piassa"}, "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("DeleteWithTasks", func() {
+		s.SetupTest()
+		s.expectProject()
+		s.taskRepo.On("CountByProject", mock.Anything, s.project.ID).Return(int64(2), nil)
+
+		err := s.useCase.DeleteByIdAndUser(s.project.ID.Hex(), "abebe")
+
+		s.ErrorIs(err, domain.ErrConflict)
+	})
+
+	s.Run("DeleteEmpty", func() {
+		s.SetupTest()
+		s.expectProject()
+		s.taskRepo.On("CountByProject", mock.Anything, s.project.ID).Return(int64(0), nil)
+		s.mockRepo.On("Delete", mock.Anything, s.project.ID.Hex()).Return(nil)
+
+		s.NoError(s.useCase.DeleteByIdAndUser(s.project.ID.Hex(), "abebe"))
+	})
+}
+
+// TestMembers tests the SetMember and RemoveMember methods
+func (s *ProjectUseCaseSuite) TestMembers() {
+	s.Run("AddMember", func() {
+		s.SetupTest()
+		s.expectProject()
+		member := domain.ProjectMember{Username: "chala", Role: domain.ProjectViewer}
+		s.userRepo.On("GetByUsername", mock.Anything, "chala").Return(&domain.User{Username: "chala"}, nil)
+		s.mockRepo.On("SetMember", mock.Anything, s.project.ID.Hex(), member).Return(s.project, nil)
+
+		_, err := s.useCase.SetMember(s.project.ID.Hex(), member, "abebe")
+
+		s.NoError(err)
+	})
+
+	s.Run("UnknownUser", func() {
+		s.SetupTest()
+		s.expectProject()
+		s.userRepo.On("GetByUsername", mock.Anything, "chalaa").Return(nil, domain.NewError(domain.ErrNotFound, "user not found"))
+
+		_, err := s.useCase.SetMember(s.project.ID.Hex(), domain.ProjectMember{Username: "chalaa", Role: domain.ProjectOwner}, "abebe")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("InvalidRole", func() {
+		_, err := s.useCase.SetMember(s.project.ID.Hex(), domain.ProjectMember{Username: "chala", Role: "admin"}, "abebe")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("SetByEditor", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		_, err := s.useCase.SetMember(s.project.ID.Hex(), domain.ProjectMember{Username: "chala", Role: domain.ProjectViewer}, "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("DemoteLastOwner", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		_, err := s.useCase.SetMember(s.project.ID.Hex(), domain.ProjectMember{Username: "abebe", Role: domain.ProjectEditor}, "abebe")
+
+		s.ErrorIs(err, domain.ErrConflict)
+	})
+
+	s.Run("LeaveProject", func() {
+		s.SetupTest()
+		s.expectProject()
+		s.mockRepo.On("RemoveMember", mock.Anything, s.project.ID.Hex(), "almaz").Return(s.project, nil)
+
+		_, err := s.useCase.RemoveMember(s.project.ID.Hex(), "almaz", "almaz")
+
+		s.NoError(err)
+	})
+
+	s.Run("RemoveOtherByEditor", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		_, err := s.useCase.RemoveMember(s.project.ID.Hex(), "almaz", "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("RemoveLastOwner", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		_, err := s.useCase.RemoveMember(s.project.ID.Hex(), "abebe", "abebe")
+
+		s.ErrorIs(err, domain.ErrConflict)
+	})
+
+	s.Run("RemoveNonMember", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		_, err := s.useCase.RemoveMember(s.project.ID.Hex(), "chala", "abebe")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestTasks tests the project task methods
+func (s *ProjectUseCaseSuite) TestTasks() {
+	status := "completed"
+	taskID := primitive.NewObjectID()
+
+	s.Run("ListTasks", func() {
+		s.SetupTest()
+		s.expectProject()
+		s.mockTasks.On("ListTasks", domain.TaskQuery{ProjectID: s.project.ID, Status: "pending"}).Return(domain.TaskPage{Total: 1}, nil)
+
+		page, err := s.useCase.ListTasks(s.project.ID.Hex(), domain.TaskQuery{Status: "pending"}, "almaz")
+
+		s.NoError(err)
+		s.Equal(int64(1), page.Total)
+	})
+
+	s.Run("CreateTaskByEditor", func() {
+		s.SetupTest()
+		s.expectProject()
+		task := &domain.Task{Title: "Buy Coffee"}
+		s.mockTasks.On("Create", task).Return(nil)
+
+		err := s.useCase.CreateTask(s.project.ID.Hex(), task, "kebede")
+
+		s.NoError(err)
+		s.Equal(s.project.ID, task.ProjectID)
+		s.Equal("kebede", task.CreatedBy)
+	})
+
+	s.Run("CreateTaskByViewer", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		err := s.useCase.CreateTask(s.project.ID.Hex(), &domain.Task{Title: "Buy Coffee"}, "almaz")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("PatchTask", func() {
+		s.SetupTest()
+		s.expectProject()
+		update := domain.TaskUpdate{Status: &status}
+		s.mockTasks.On("GetById", taskID.Hex()).Return(domain.Task{ID: taskID, ProjectID: s.project.ID}, nil)
+		s.mockTasks.On("Patch", taskID.Hex(), update, "kebede").Return(domain.Task{ID: taskID, Status: status}, nil)
+
+		task, err := s.useCase.PatchTask(s.project.ID.Hex(), taskID.Hex(), update, "kebede")
+
+		s.NoError(err)
+		s.Equal(status, task.Status)
+	})
+
+	s.Run("TaskOfAnotherProject", func() {
+		s.SetupTest()
+		s.expectProject()
+		s.mockTasks.On("GetById", taskID.Hex()).Return(domain.Task{ID: taskID}, nil)
+
+		_, err := s.useCase.GetTask(s.project.ID.Hex(), taskID.Hex(), "almaz")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("DeleteTaskByViewer", func() {
+		s.SetupTest()
+		s.expectProject()
+
+		err := s.useCase.DeleteTask(s.project.ID.Hex(), taskID.Hex(), 0, "almaz")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("DeleteTask", func() {
+		s.SetupTest()
+		s.expectProject()
+		s.mockTasks.On("GetById", taskID.Hex()).Return(domain.Task{ID: taskID, ProjectID: s.project.ID}, nil)
+		s.mockTasks.On("Delete", taskID.Hex(), int64(3), "abebe").Return(nil)
+
+		s.NoError(s.useCase.DeleteTask(s.project.ID.Hex(), taskID.Hex(), 3, "abebe"))
+	})
+}
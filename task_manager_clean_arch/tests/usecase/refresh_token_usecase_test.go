@@ -3,6 +3,7 @@ package usecase
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/mock"
@@ -17,15 +18,19 @@ import (
 type RefreshTokenUsecaseSuite struct {
 	suite.Suite
 	mockUserRepo *mocks_domain.UserRepository
-	mockJwt      *mocks_security.IRefreshTokenUsecase
+	mockJwt      *mocks_security.RefreshTokenRepository
+	mockStore    *mocks_security.RefreshTokenStore
+	mockDenylist *mocks_security.TokenDenylist
 	useCase      domain.IRefreshTokenUsecase
 }
 
 // SetupTest initializes the mocks and use case before each test
 func (s *RefreshTokenUsecaseSuite) SetupTest() {
 	s.mockUserRepo = mocks_domain.NewUserRepository(s.T())
-	s.mockJwt = mocks_security.NewIRefreshTokenUsecase(s.T())
-	s.useCase = usecase.NewRefreshTokenUsecase(s.mockUserRepo, s.mockJwt)
+	s.mockJwt = mocks_security.NewRefreshTokenRepository(s.T())
+	s.mockStore = mocks_security.NewRefreshTokenStore(s.T())
+	s.mockDenylist = mocks_security.NewTokenDenylist(s.T())
+	s.useCase = usecase.NewRefreshTokenUsecase(s.mockUserRepo, s.mockJwt, s.mockStore, s.mockDenylist)
 }
 
 // TestRefreshTokenUsecaseSuite runs the test suite
@@ -62,6 +67,10 @@ func (s *RefreshTokenUsecaseSuite) TestGenerateTokens() {
 		user := domain.User{ID: "1", Username: "abebe", Email: "abebe@example.com", Role: "user"}
 		expectedTokens := domain.RefreshToken{AccessToken: "access_token", RefreshToken: "refresh_token"}
 		s.mockJwt.On("GenerateTokens", user).Return(expectedTokens, nil)
+		s.mockJwt.On("ValidateRefreshToken", "refresh_token").Return(jwt.MapClaims{"jti": "jti-1", "fam": "jti-1", "username": "abebe", "exp": float64(1893456000)}, nil)
+		s.mockStore.On("Save", mock.Anything, mock.MatchedBy(func(session *domain.RefreshSession) bool {
+			return session.ID == "jti-1" && session.FamilyID == "jti-1" && session.Username == "abebe" && !session.Used
+		})).Return(nil)
 
 		result, err := s.useCase.GenerateTokens(user)
 		s.NoError(err)
@@ -82,6 +91,183 @@ func (s *RefreshTokenUsecaseSuite) TestGenerateTokens() {
 	})
 }
 
+// TestRefresh tests the Refresh method
+func (s *RefreshTokenUsecaseSuite) TestRefresh() {
+	s.Run("Success", func() {
+		s.resetMocks()
+		user := &domain.User{ID: "1", Username: "abebe", Role: "user"}
+		session := &domain.RefreshSession{ID: "jti-1", FamilyID: "fam-1", Username: "abebe"}
+		rotated := domain.RefreshToken{AccessToken: "new_access_token", RefreshToken: "new_refresh_token"}
+		s.mockJwt.On("ValidateRefreshToken", "refresh_token").Return(jwt.MapClaims{"jti": "jti-1", "fam": "fam-1", "username": "abebe"}, nil)
+		s.mockStore.On("GetByID", mock.Anything, "jti-1").Return(session, nil)
+		s.mockStore.On("MarkUsed", mock.Anything, "jti-1").Return(true, nil)
+		s.mockUserRepo.On("GetByUsername", mock.Anything, "abebe").Return(user, nil)
+		s.mockJwt.On("RotateTokens", *user, "fam-1").Return(rotated, nil)
+		s.mockJwt.On("ValidateRefreshToken", "new_refresh_token").Return(jwt.MapClaims{"jti": "jti-2", "fam": "fam-1", "username": "abebe"}, nil)
+		s.mockStore.On("Save", mock.Anything, mock.MatchedBy(func(session *domain.RefreshSession) bool {
+			return session.ID == "jti-2" && session.FamilyID == "fam-1"
+		})).Return(nil)
+
+		result, err := s.useCase.Refresh("refresh_token")
+
+		s.NoError(err)
+		s.Equal(rotated, result)
+	})
+
+	s.Run("InvalidSignature", func() {
+		s.resetMocks()
+		s.mockJwt.On("ValidateRefreshToken", "bad_token").Return(nil, errors.New("invalid token"))
+
+		_, err := s.useCase.Refresh("bad_token")
+
+		s.ErrorIs(err, domain.ErrInvalidRefreshToken)
+	})
+
+	s.Run("MissingJti", func() {
+		s.resetMocks()
+		s.mockJwt.On("ValidateRefreshToken", "legacy_token").Return(jwt.MapClaims{"username": "abebe"}, nil)
+
+		_, err := s.useCase.Refresh("legacy_token")
+
+		s.ErrorIs(err, domain.ErrInvalidRefreshToken)
+	})
+
+	s.Run("UnknownSession", func() {
+		s.resetMocks()
+		s.mockJwt.On("ValidateRefreshToken", "refresh_token").Return(jwt.MapClaims{"jti": "jti-1"}, nil)
+		s.mockStore.On("GetByID", mock.Anything, "jti-1").Return(nil, errors.New("refresh session not found"))
+
+		_, err := s.useCase.Refresh("refresh_token")
+
+		s.ErrorIs(err, domain.ErrInvalidRefreshToken)
+	})
+
+	s.Run("RevokedFamily", func() {
+		s.resetMocks()
+		s.mockJwt.On("ValidateRefreshToken", "refresh_token").Return(jwt.MapClaims{"jti": "jti-1"}, nil)
+		s.mockStore.On("GetByID", mock.Anything, "jti-1").Return(&domain.RefreshSession{ID: "jti-1", FamilyID: "fam-1", Revoked: true}, nil)
+
+		_, err := s.useCase.Refresh("refresh_token")
+
+		s.ErrorIs(err, domain.ErrInvalidRefreshToken)
+	})
+
+	s.Run("LoggedOutEverywhere", func() {
+		s.resetMocks()
+		user := &domain.User{ID: "1", Username: "abebe", Role: "user", TokenVersion: 1}
+		s.mockJwt.On("ValidateRefreshToken", "refresh_token").Return(jwt.MapClaims{"jti": "jti-1", "ver": float64(0)}, nil)
+		s.mockStore.On("GetByID", mock.Anything, "jti-1").Return(&domain.RefreshSession{ID: "jti-1", FamilyID: "fam-1", Username: "abebe"}, nil)
+		s.mockStore.On("MarkUsed", mock.Anything, "jti-1").Return(true, nil)
+		s.mockUserRepo.On("GetByUsername", mock.Anything, "abebe").Return(user, nil)
+
+		_, err := s.useCase.Refresh("refresh_token")
+
+		s.ErrorIs(err, domain.ErrInvalidRefreshToken)
+	})
+
+	s.Run("ReuseRevokesFamily", func() {
+		s.resetMocks()
+		s.mockJwt.On("ValidateRefreshToken", "refresh_token").Return(jwt.MapClaims{"jti": "jti-1"}, nil)
+		s.mockStore.On("GetByID", mock.Anything, "jti-1").Return(&domain.RefreshSession{ID: "jti-1", FamilyID: "fam-1", Used: true}, nil)
+		s.mockStore.On("MarkUsed", mock.Anything, "jti-1").Return(false, nil)
+		s.mockStore.On("RevokeFamily", mock.Anything, "fam-1").Return(nil)
+
+		_, err := s.useCase.Refresh("refresh_token")
+
+		s.ErrorIs(err, domain.ErrRefreshTokenReused)
+	})
+}
+
+// TestLogout tests the Logout method
+func (s *RefreshTokenUsecaseSuite) TestLogout() {
+	expiresAt := time.Now().Add(time.Hour)
+
+	s.Run("Success", func() {
+		s.resetMocks()
+		s.mockDenylist.On("Add", mock.Anything, "access-jti", expiresAt).Return(nil)
+		s.mockStore.On("RevokeFamily", mock.Anything, "fam-1").Return(nil)
+
+		err := s.useCase.Logout("access-jti", "fam-1", expiresAt)
+
+		s.NoError(err)
+	})
+
+	s.Run("EmptyTokenID", func() {
+		s.resetMocks()
+
+		err := s.useCase.Logout("", "fam-1", expiresAt)
+
+		s.EqualError(err, "token ID cannot be empty")
+	})
+
+	s.Run("DenylistError", func() {
+		s.resetMocks()
+		s.mockDenylist.On("Add", mock.Anything, "access-jti", expiresAt).Return(errors.New("database error"))
+
+		err := s.useCase.Logout("access-jti", "fam-1", expiresAt)
+
+		s.EqualError(err, "database error")
+	})
+}
+
+// TestLogoutAll tests the LogoutAll method
+func (s *RefreshTokenUsecaseSuite) TestLogoutAll() {
+	s.Run("Success", func() {
+		s.resetMocks()
+		s.mockUserRepo.On("IncrementTokenVersion", mock.Anything, "abebe").Return(nil)
+
+		s.NoError(s.useCase.LogoutAll("abebe"))
+	})
+
+	s.Run("EmptyUsername", func() {
+		s.resetMocks()
+
+		s.EqualError(s.useCase.LogoutAll(""), "username cannot be empty")
+	})
+}
+
+// TestIsAccessTokenRevoked tests the IsAccessTokenRevoked method
+func (s *RefreshTokenUsecaseSuite) TestIsAccessTokenRevoked() {
+	s.Run("Denylisted", func() {
+		s.resetMocks()
+		s.mockDenylist.On("Contains", mock.Anything, "access-jti").Return(true, nil)
+
+		revoked, err := s.useCase.IsAccessTokenRevoked("access-jti", "abebe", 0)
+
+		s.NoError(err)
+		s.True(revoked)
+	})
+
+	s.Run("StaleVersion", func() {
+		s.resetMocks()
+		s.mockDenylist.On("Contains", mock.Anything, "access-jti").Return(false, nil)
+		s.mockUserRepo.On("GetByUsername", mock.Anything, "abebe").Return(&domain.User{Username: "abebe", TokenVersion: 2}, nil)
+
+		revoked, err := s.useCase.IsAccessTokenRevoked("access-jti", "abebe", 1)
+
+		s.NoError(err)
+		s.True(revoked)
+	})
+
+	s.Run("Valid", func() {
+		s.resetMocks()
+		s.mockDenylist.On("Contains", mock.Anything, "access-jti").Return(false, nil)
+		s.mockUserRepo.On("GetByUsername", mock.Anything, "abebe").Return(&domain.User{Username: "abebe", TokenVersion: 2}, nil)
+
+		revoked, err := s.useCase.IsAccessTokenRevoked("access-jti", "abebe", 2)
+
+		s.NoError(err)
+		s.False(revoked)
+	})
+}
+
+func (s *RefreshTokenUsecaseSuite) resetMocks() {
+	s.mockJwt.ExpectedCalls = nil
+	s.mockStore.ExpectedCalls = nil
+	s.mockDenylist.ExpectedCalls = nil
+	s.mockUserRepo.ExpectedCalls = nil
+}
+
 // TestValidateRefreshToken tests the ValidateRefreshToken method
 func (s *RefreshTokenUsecaseSuite) TestValidateRefreshToken() {
 	s.Run("Success", func() {
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReminderUseCaseSuite defines the test suite for ReminderUseCase
+type ReminderUseCaseSuite struct {
+	suite.Suite
+	mockTasks     *mocks_domain.TaskRepository
+	mockReminders *mocks_domain.ReminderRepository
+	mockWorkflow  *mocks_domain.WorkflowRepository
+	mockUsers     *mocks_domain.UserRepository
+	mockNotifier  *mocks_domain.Notifier
+	useCase       domain.IReminderUseCase
+}
+
+// SetupTest initializes the mocks and use case before each test
+func (s *ReminderUseCaseSuite) SetupTest() {
+	s.mockTasks = mocks_domain.NewTaskRepository(s.T())
+	s.mockReminders = mocks_domain.NewReminderRepository(s.T())
+	s.mockWorkflow = mocks_domain.NewWorkflowRepository(s.T())
+	s.mockWorkflow.On("Get", mock.Anything).Return(domain.Workflow{}, domain.NewError(domain.ErrNotFound, "workflow not found")).Maybe()
+	s.mockUsers = mocks_domain.NewUserRepository(s.T())
+	s.mockUsers.On("GetByUsername", mock.Anything, "abebe").Return(&domain.User{Username: "abebe", Email: "abebe@example.com"}, nil).Maybe()
+	s.mockUsers.On("GetByUsername", mock.Anything, mock.Anything).Return(nil, domain.NewError(domain.ErrNotFound, "user not found")).Maybe()
+	s.mockNotifier = mocks_domain.NewNotifier(s.T())
+	s.useCase = usecase.NewReminderUseCase(s.mockTasks, s.mockReminders, s.mockWorkflow, s.mockUsers, s.mockNotifier, time.Hour)
+}
+
+// TestReminderUseCaseSuite runs the test suite
+func TestReminderUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ReminderUseCaseSuite))
+}
+
+// TestSendReminders tests the SendReminders method
+func (s *ReminderUseCaseSuite) TestSendReminders() {
+	dueSoon := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe", Assignees: []string{"kebede"}, Status: "pending", DueDate: time.Now().Add(30 * time.Minute)}
+	overdue := domain.Task{ID: primitive.NewObjectID(), Title: "Roast Coffee", CreatedBy: "abebe", Status: "pending", DueDate: time.Now().Add(-time.Hour)}
+	windowEnd := mock.MatchedBy(func(before time.Time) bool {
+		return before.After(time.Now().Add(59*time.Minute)) && before.Before(time.Now().Add(61*time.Minute))
+	})
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockTasks.On("GetDueBetween", mock.Anything, mock.Anything, windowEnd, "completed").Return([]domain.Task{overdue, dueSoon}, nil)
+		s.mockReminders.On("Claim", mock.Anything, overdue.ID, domain.ReminderOverdue, overdue.DueDate).Return(true, nil)
+		s.mockReminders.On("Claim", mock.Anything, dueSoon.ID, domain.ReminderDueSoon, dueSoon.DueDate).Return(true, nil)
+		var reminders []domain.Reminder
+		s.mockNotifier.On("Notify", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			reminders = append(reminders, args.Get(1).(domain.Reminder))
+		})
+
+		sent, err := s.useCase.SendReminders()
+
+		s.NoError(err)
+		s.Equal(2, sent)
+		s.Require().Len(reminders, 2)
+		s.Equal(domain.ReminderOverdue, reminders[0].Kind)
+		s.True(reminders[0].Task.Overdue)
+		s.Equal([]domain.ReminderRecipient{{Username: "abebe", Email: "abebe@example.com"}}, reminders[0].Recipients)
+		s.Equal(domain.ReminderDueSoon, reminders[1].Kind)
+		s.False(reminders[1].Task.Overdue)
+		s.Equal([]domain.ReminderRecipient{{Username: "abebe", Email: "abebe@example.com"}, {Username: "kebede"}}, reminders[1].Recipients)
+	})
+
+	s.Run("AlreadySent", func() {
+		s.SetupTest()
+		s.mockTasks.On("GetDueBetween", mock.Anything, mock.Anything, windowEnd, "completed").Return([]domain.Task{overdue}, nil)
+		s.mockReminders.On("Claim", mock.Anything, overdue.ID, domain.ReminderOverdue, overdue.DueDate).Return(false, nil)
+
+		sent, err := s.useCase.SendReminders()
+
+		s.NoError(err)
+		s.Zero(sent)
+		s.mockNotifier.AssertNotCalled(s.T(), "Notify", mock.Anything, mock.Anything)
+	})
+
+	s.Run("NotifierError", func() {
+		s.SetupTest()
+		s.mockTasks.On("GetDueBetween", mock.Anything, mock.Anything, windowEnd, "completed").Return([]domain.Task{overdue, dueSoon}, nil)
+		s.mockReminders.On("Claim", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+		s.mockNotifier.On("Notify", mock.Anything, mock.MatchedBy(func(r domain.Reminder) bool { return r.Task.ID == overdue.ID })).Return(errors.New("connection refused"))
+		s.mockNotifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+
+		sent, err := s.useCase.SendReminders()
+
+		s.NoError(err)
+		s.Equal(1, sent)
+		s.mockReminders.AssertNumberOfCalls(s.T(), "Claim", 2)
+	})
+
+	s.Run("ClaimError", func() {
+		s.SetupTest()
+		s.mockTasks.On("GetDueBetween", mock.Anything, mock.Anything, windowEnd, "completed").Return([]domain.Task{overdue, dueSoon}, nil)
+		s.mockReminders.On("Claim", mock.Anything, overdue.ID, domain.ReminderOverdue, overdue.DueDate).Return(false, errors.New("database error"))
+		s.mockReminders.On("Claim", mock.Anything, dueSoon.ID, domain.ReminderDueSoon, dueSoon.DueDate).Return(true, nil)
+		s.mockNotifier.On("Notify", mock.Anything, mock.MatchedBy(func(r domain.Reminder) bool { return r.Task.ID == dueSoon.ID })).Return(nil)
+
+		sent, err := s.useCase.SendReminders()
+
+		s.NoError(err)
+		s.Equal(1, sent)
+	})
+
+	s.Run("SinceLastRun", func() {
+		s.SetupTest()
+		var since []time.Time
+		s.mockTasks.On("GetDueBetween", mock.Anything, mock.Anything, windowEnd, "completed").Return([]domain.Task{}, nil).Run(func(args mock.Arguments) {
+			since = append(since, args.Get(1).(time.Time))
+		})
+
+		start := time.Now()
+		_, err := s.useCase.SendReminders()
+		s.Require().NoError(err)
+		_, err = s.useCase.SendReminders()
+		s.Require().NoError(err)
+
+		s.Require().Len(since, 2)
+		s.WithinDuration(start.Add(-24*time.Hour), since[0], time.Second)
+		s.WithinDuration(start, since[1], time.Second)
+		s.False(since[1].Before(start))
+	})
+
+	s.Run("CustomWorkflow", func() {
+		s.SetupTest()
+		s.mockWorkflow.ExpectedCalls = nil
+		s.mockWorkflow.On("Get", mock.Anything).Return(domain.Workflow{Statuses: []string{"todo", "done"}}, nil)
+		s.mockTasks.On("GetDueBetween", mock.Anything, mock.Anything, windowEnd, "done").Return([]domain.Task{}, nil)
+
+		sent, err := s.useCase.SendReminders()
+
+		s.NoError(err)
+		s.Zero(sent)
+	})
+
+	s.Run("RepositoryError", func() {
+		s.SetupTest()
+		s.mockTasks.On("GetDueBetween", mock.Anything, mock.Anything, windowEnd, "completed").Return(nil, errors.New("database error"))
+
+		sent, err := s.useCase.SendReminders()
+
+		s.EqualError(err, "database error")
+		s.Zero(sent)
+	})
+}
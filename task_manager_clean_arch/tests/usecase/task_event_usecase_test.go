@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+)
+
+// TaskEventUseCaseSuite defines the test suite for TaskEventUseCase
+type TaskEventUseCaseSuite struct {
+	suite.Suite
+	mockBus *mocks_domain.EventBus
+	useCase domain.ITaskEventUseCase
+	filter  func(domain.Event) bool
+}
+
+// SetupTest initializes the mocks and use case before each test, keeping the
+// filter the use case subscribes with
+func (s *TaskEventUseCaseSuite) SetupTest() {
+	s.mockBus = mocks_domain.NewEventBus(s.T())
+	s.mockBus.On("Subscribe", mock.Anything, uint64(7), mock.Anything).Return((<-chan domain.StreamEvent)(make(chan domain.StreamEvent)), true).Run(func(args mock.Arguments) {
+		s.filter = args.Get(2).(func(domain.Event) bool)
+	}).Maybe()
+	s.useCase = usecase.NewTaskEventUseCase(s.mockBus)
+}
+
+// TestTaskEventUseCaseSuite runs the test suite
+func TestTaskEventUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(TaskEventUseCaseSuite))
+}
+
+// TestWatch tests which events a user is sent
+func (s *TaskEventUseCaseSuite) TestWatch() {
+	own := domain.Event{Type: domain.EventTaskCreated, Task: &domain.Task{CreatedBy: "abebe"}}
+	assigned := domain.Event{Type: domain.EventTaskUpdated, Task: &domain.Task{CreatedBy: "kebede", Assignees: []string{"abebe"}}}
+	other := domain.Event{Type: domain.EventTaskDeleted, Task: &domain.Task{CreatedBy: "kebede"}}
+	registered := domain.Event{Type: domain.EventUserRegistered, User: &domain.User{Username: "abebe"}}
+
+	s.Run("User", func() {
+		s.SetupTest()
+
+		_, complete := s.useCase.Watch(context.Background(), "abebe", 7)
+
+		s.True(complete)
+		s.True(s.filter(own))
+		s.True(s.filter(assigned))
+		s.False(s.filter(other))
+		s.False(s.filter(registered))
+	})
+
+	s.Run("AllTasks", func() {
+		s.SetupTest()
+
+		s.useCase.Watch(context.Background(), "", 7)
+
+		s.True(s.filter(own))
+		s.True(s.filter(other))
+		s.False(s.filter(registered))
+	})
+}
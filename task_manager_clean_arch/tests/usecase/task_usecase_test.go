@@ -1,7 +1,9 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -16,14 +18,36 @@ import (
 // TaskUseCaseSuite defines the test suite for TaskUseCase
 type TaskUseCaseSuite struct {
 	suite.Suite
-	mockRepo *mocks_domain.TaskRepository
-	useCase  domain.ITaskUseCase
+	mockRepo     *mocks_domain.TaskRepository
+	mockHistory  *mocks_domain.TaskHistoryRepository
+	mockWorkflow *mocks_domain.WorkflowRepository
+	mockEvents   *mocks_domain.EventPublisher
+	useCase      domain.ITaskUseCase
 }
 
 // SetupTest initializes the mocks and use case before each test
 func (s *TaskUseCaseSuite) SetupTest() {
 	s.mockRepo = mocks_domain.NewTaskRepository(s.T())
-	s.useCase = usecase.NewTaskUseCase(s.mockRepo)
+	s.expectNoSubtasks()
+	s.mockHistory = mocks_domain.NewTaskHistoryRepository(s.T())
+	s.mockHistory.On("Add", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockWorkflow = mocks_domain.NewWorkflowRepository(s.T())
+	s.mockWorkflow.On("Get", mock.Anything).Return(domain.Workflow{}, domain.NewError(domain.ErrNotFound, "workflow not found")).Maybe()
+	s.mockEvents = mocks_domain.NewEventPublisher(s.T())
+	s.mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	s.useCase = usecase.NewTaskUseCase(s.mockRepo, s.mockHistory, s.mockWorkflow, s.mockEvents)
+}
+
+// expectNoSubtasks lets the use case count subtasks, which it does on most
+// reads, and find none.
+func (s *TaskUseCaseSuite) expectNoSubtasks() {
+	s.mockRepo.On("CountSubtasksByStatus", mock.Anything, mock.Anything).Return(map[primitive.ObjectID][]domain.StatusCount{}, nil).Maybe()
+}
+
+// resetRepo drops the task repository expectations set so far.
+func (s *TaskUseCaseSuite) resetRepo() {
+	s.mockRepo.ExpectedCalls = nil
+	s.expectNoSubtasks()
 }
 
 // TestTaskUseCaseSuite runs the test suite
@@ -48,7 +72,7 @@ func (s *TaskUseCaseSuite) TestGetAll() {
 	})
 
 	s.Run("RepositoryError", func() {
-		s.mockRepo.ExpectedCalls = nil
+		s.resetRepo()
 		s.mockRepo.On("GetAll", mock.Anything).Return(nil, errors.New("database error"))
 
 		result, err := s.useCase.GetAll()
@@ -59,11 +83,51 @@ func (s *TaskUseCaseSuite) TestGetAll() {
 	})
 }
 
+// TestExportAll tests that ExportAll passes on every task of every batch
+func (s *TaskUseCaseSuite) TestExportAll() {
+	first := []domain.Task{{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe"}}
+	second := []domain.Task{{ID: primitive.NewObjectID(), Title: "Sell Spices", Status: "completed", CreatedBy: "kebede"}}
+	batches := func(ctx context.Context, size int, fn func([]domain.Task) error) error {
+		if err := fn(first); err != nil {
+			return err
+		}
+		return fn(second)
+	}
+
+	s.Run("Success", func() {
+		s.resetRepo()
+		s.mockRepo.On("GetAllInBatches", mock.Anything, mock.Anything, mock.Anything).Return(batches)
+		var titles []string
+
+		err := s.useCase.ExportAll(func(task domain.Task) error {
+			titles = append(titles, task.Title)
+			return nil
+		})
+
+		s.NoError(err)
+		s.Equal([]string{"Buy Coffee", "Sell Spices"}, titles)
+	})
+
+	s.Run("StopsAtError", func() {
+		s.resetRepo()
+		s.mockRepo.On("GetAllInBatches", mock.Anything, mock.Anything, mock.Anything).Return(batches)
+		calls := 0
+
+		err := s.useCase.ExportAll(func(domain.Task) error {
+			calls++
+			return errors.New("client went away")
+		})
+
+		s.EqualError(err, "client went away")
+		s.Equal(1, calls)
+	})
+}
+
 // TestGetById tests the GetById method
 func (s *TaskUseCaseSuite) TestGetById() {
 	s.Run("Success", func() {
 		id := primitive.NewObjectID()
-		task := domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: time.Now()}
+		task := domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: time.Now().Add(24 * time.Hour)}
 		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(task, nil)
 
 		result, err := s.useCase.GetById(id.Hex())
@@ -106,19 +170,212 @@ func (s *TaskUseCaseSuite) TestCreate() {
 	})
 }
 
+// TestImportByUser tests that imported tasks are checked like new tasks and
+// inserted together
+func (s *TaskUseCaseSuite) TestImportByUser() {
+	newTasks := func() []*domain.Task {
+		return []*domain.Task{
+			{Title: "Buy Coffee", CreatedBy: "kebede", DueDate: time.Now().Add(time.Hour)},
+			{Title: "Sell Spices", Status: "archived", DueDate: time.Now().Add(time.Hour)},
+			{Title: "Roast Coffee", Priority: domain.PriorityHigh, Tags: []string{"Home"}, DueDate: time.Now().Add(time.Hour)},
+		}
+	}
+	insert := func(ctx context.Context, tasks []*domain.Task) error {
+		for _, task := range tasks {
+			task.ID = primitive.NewObjectID()
+			task.Version = 1
+		}
+		return nil
+	}
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		tasks := newTasks()
+		s.mockRepo.On("CreateMany", mock.Anything, []*domain.Task{tasks[0], tasks[2]}).Return(insert).Once()
+
+		errs, err := s.useCase.ImportByUser("abebe", tasks, false)
+
+		s.Require().NoError(err)
+		s.Require().Len(errs, 3)
+		s.NoError(errs[0])
+		s.True(errors.Is(errs[1], domain.ErrValidation))
+		s.NoError(errs[2])
+		s.Equal("abebe", tasks[0].CreatedBy)
+		s.Equal("pending", tasks[0].Status)
+		s.Equal(domain.PriorityMedium, tasks[0].Priority)
+		s.Equal([]string{"home"}, tasks[2].Tags)
+		s.False(tasks[2].ID.IsZero())
+		s.mockHistory.AssertNumberOfCalls(s.T(), "Add", 2)
+	})
+
+	s.Run("DryRun", func() {
+		s.SetupTest()
+
+		errs, err := s.useCase.ImportByUser("abebe", newTasks(), true)
+
+		s.Require().NoError(err)
+		s.NoError(errs[0])
+		s.Error(errs[1])
+		s.NoError(errs[2])
+		s.mockRepo.AssertNotCalled(s.T(), "CreateMany", mock.Anything, mock.Anything)
+	})
+
+	s.Run("RepositoryError", func() {
+		s.SetupTest()
+		tasks := newTasks()
+		s.mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(func(ctx context.Context, batch []*domain.Task) error {
+			batch[0].ID = primitive.NewObjectID()
+			batch[0].Version = 1
+			return errors.New("database error")
+		}).Once()
+
+		errs, err := s.useCase.ImportByUser("abebe", tasks, false)
+
+		s.Require().NoError(err)
+		s.NoError(errs[0])
+		s.EqualError(errs[2], "database error")
+	})
+}
+
+// TestBatch tests that the operations of a batch are checked one by one and
+// written together
+func (s *TaskUseCaseSuite) TestBatch() {
+	completed, title := "completed", "Buy Beans"
+	owned := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe", Version: 2}
+	shared := domain.Task{ID: primitive.NewObjectID(), Title: "Roast Coffee", Status: "pending", CreatedBy: "kebede", Assignees: []string{"abebe"}, Version: 1}
+	expectTasks := func() {
+		s.mockRepo.On("GetById", mock.Anything, owned.ID.Hex()).Return(owned, nil).Maybe()
+		s.mockRepo.On("GetById", mock.Anything, shared.ID.Hex()).Return(shared, nil).Maybe()
+	}
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		expectTasks()
+		s.mockRepo.On("BulkWrite", mock.Anything, mock.Anything, false).Return(func(ctx context.Context, writes []domain.TaskWrite, atomic bool) ([]error, error) {
+			s.Require().Len(writes, 2)
+			s.Equal(owned.ID, writes[0].ID)
+			s.Equal(int64(2), writes[0].Update.Version)
+			s.Equal(&title, writes[0].Update.Title)
+			s.Equal(int64(1), writes[1].Update.Version)
+			return make([]error, len(writes)), nil
+		}).Once()
+
+		results, err := s.useCase.BatchByUser([]domain.TaskOperation{
+			{Kind: domain.TaskOpUpdate, ID: owned.ID.Hex(), Update: domain.TaskUpdate{Title: &title}},
+			{Kind: domain.TaskOpStatus, ID: shared.ID.Hex(), Update: domain.TaskUpdate{Status: &completed}},
+		}, false, "abebe")
+
+		s.Require().NoError(err)
+		s.Require().Len(results, 2)
+		s.NoError(results[0].Err)
+		s.Equal(title, results[0].Task.Title)
+		s.Equal(int64(3), results[0].Task.Version)
+		s.NoError(results[1].Err)
+		s.Equal(completed, results[1].Task.Status)
+		s.mockHistory.AssertNumberOfCalls(s.T(), "Add", 2)
+	})
+
+	s.Run("FailedOperations", func() {
+		s.SetupTest()
+		expectTasks()
+		s.mockRepo.On("BulkWrite", mock.Anything, mock.Anything, false).Return([]error{domain.ErrVersionConflict}, nil).Once()
+
+		results, err := s.useCase.BatchByUser([]domain.TaskOperation{
+			{Kind: domain.TaskOpDelete, ID: owned.ID.Hex()},
+			{Kind: domain.TaskOpDelete, ID: shared.ID.Hex()},
+			{Kind: domain.TaskOpUpdate, ID: shared.ID.Hex(), Update: domain.TaskUpdate{Title: &title}},
+			{Kind: domain.TaskOpStatus, ID: primitive.NewObjectID().Hex(), Update: domain.TaskUpdate{Title: &title}},
+		}, false, "abebe")
+
+		s.Require().NoError(err)
+		s.True(errors.Is(results[0].Err, domain.ErrConflict))
+		s.True(errors.Is(results[1].Err, domain.ErrForbidden))
+		s.True(errors.Is(results[2].Err, domain.ErrValidation))
+		s.True(errors.Is(results[3].Err, domain.ErrValidation))
+		s.mockHistory.AssertNotCalled(s.T(), "Add", mock.Anything, mock.Anything)
+	})
+
+	s.Run("StaleVersion", func() {
+		s.SetupTest()
+		expectTasks()
+
+		results, err := s.useCase.Batch([]domain.TaskOperation{
+			{Kind: domain.TaskOpStatus, ID: owned.ID.Hex(), Update: domain.TaskUpdate{Status: &completed, Version: 1}},
+		}, false, "admin")
+
+		s.Require().NoError(err)
+		s.True(errors.Is(results[0].Err, domain.ErrVersionConflict))
+		s.mockRepo.AssertNotCalled(s.T(), "BulkWrite", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("AtomicStopsAtFirstFailure", func() {
+		s.SetupTest()
+		expectTasks()
+
+		results, err := s.useCase.BatchByUser([]domain.TaskOperation{
+			{Kind: domain.TaskOpStatus, ID: owned.ID.Hex(), Update: domain.TaskUpdate{Status: &completed}},
+			{Kind: domain.TaskOpDelete, ID: shared.ID.Hex()},
+		}, true, "abebe")
+
+		s.Require().NoError(err)
+		s.True(errors.Is(results[0].Err, domain.ErrBatchAborted))
+		s.True(errors.Is(results[1].Err, domain.ErrForbidden))
+		s.mockRepo.AssertNotCalled(s.T(), "BulkWrite", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("DuplicateTask", func() {
+		s.SetupTest()
+		expectTasks()
+		s.mockRepo.On("BulkWrite", mock.Anything, mock.Anything, false).Return([]error{nil}, nil).Once()
+
+		results, err := s.useCase.Batch([]domain.TaskOperation{
+			{Kind: domain.TaskOpDelete, ID: owned.ID.Hex()},
+			{Kind: domain.TaskOpDelete, ID: owned.ID.Hex()},
+		}, false, "admin")
+
+		s.Require().NoError(err)
+		s.NoError(results[0].Err)
+		s.False(results[0].Task.DeletedAt.IsZero())
+		s.True(errors.Is(results[1].Err, domain.ErrValidation))
+	})
+
+	s.Run("Limits", func() {
+		s.SetupTest()
+
+		_, err := s.useCase.Batch(nil, false, "admin")
+		s.True(errors.Is(err, domain.ErrValidation))
+
+		_, err = s.useCase.Batch(make([]domain.TaskOperation, 101), false, "admin")
+		s.True(errors.Is(err, domain.ErrValidation))
+	})
+
+	s.Run("RepositoryError", func() {
+		s.SetupTest()
+		expectTasks()
+		s.mockRepo.On("BulkWrite", mock.Anything, mock.Anything, true).Return(nil, errors.New("database error")).Once()
+
+		_, err := s.useCase.Batch([]domain.TaskOperation{
+			{Kind: domain.TaskOpDelete, ID: owned.ID.Hex()},
+		}, true, "admin")
+
+		s.EqualError(err, "database error")
+	})
+}
+
 // TestUpdate tests the Update method
 func (s *TaskUseCaseSuite) TestUpdate() {
 	s.Run("Success", func() {
 		id := primitive.NewObjectID()
 		task := &domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "completed", CreatedBy: "abebe", DueDate: time.Now()}
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(*task, nil)
 		s.mockRepo.On("Update", mock.Anything, id.Hex(), task).Return(nil)
-		err := s.useCase.Update(id.Hex(), task)
+		err := s.useCase.Update(id.Hex(), task, "admin")
 		s.NoError(err)
 	})
 
 	s.Run("NilTask", func() {
 		id := primitive.NewObjectID()
-		err := s.useCase.Update(id.Hex(), nil)
+		err := s.useCase.Update(id.Hex(), nil, "admin")
 
 		s.Error(err)
 		s.EqualError(err, "task cannot be nil")
@@ -126,9 +383,10 @@ func (s *TaskUseCaseSuite) TestUpdate() {
 
 	s.Run("RepositoryError", func() {
 		task := &domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "completed", CreatedBy: "abebe", DueDate: time.Now()}
+		s.mockRepo.On("GetById", mock.Anything, task.ID.Hex()).Return(*task, nil)
 		s.mockRepo.On("Update", mock.Anything, task.ID.Hex(), task).Return(errors.New("update failed"))
 
-		err := s.useCase.Update(task.ID.Hex(), task)
+		err := s.useCase.Update(task.ID.Hex(), task, "admin")
 
 		s.Error(err)
 		s.EqualError(err, "update failed")
@@ -139,25 +397,87 @@ func (s *TaskUseCaseSuite) TestUpdate() {
 func (s *TaskUseCaseSuite) TestDelete() {
 	s.Run("Success", func() {
 		id := primitive.NewObjectID()
-		s.mockRepo.On("Delete", mock.Anything, id.Hex()).Return(nil)
-		err := s.useCase.Delete(id.Hex())
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id}, nil)
+		s.mockRepo.On("Delete", mock.Anything, id.Hex(), int64(0)).Return(nil)
+		err := s.useCase.Delete(id.Hex(), 0, "admin")
 		s.NoError(err)
 	})
 
 	s.Run("EmptyID", func() {
-		err := s.useCase.Delete("")
+		err := s.useCase.Delete("", 0, "admin")
 		s.Error(err)
 		s.EqualError(err, "task ID cannot be empty")
 	})
 	s.Run("RepositoryError", func() {
 		id := primitive.NewObjectID()
-		s.mockRepo.On("Delete", mock.Anything, id.Hex()).Return(errors.New("delete failed"))
-		err := s.useCase.Delete(id.Hex())
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id}, nil)
+		s.mockRepo.On("Delete", mock.Anything, id.Hex(), int64(0)).Return(errors.New("delete failed"))
+		err := s.useCase.Delete(id.Hex(), 0, "admin")
 		s.Error(err)
 		s.EqualError(err, "delete failed")
 	})
 }
 
+// TestPatch tests the Patch and PatchByIdAndUser methods
+func (s *TaskUseCaseSuite) TestPatch() {
+	id := primitive.NewObjectID()
+	status := "completed"
+	task := domain.Task{ID: id, Title: "Buy Coffee", Status: status, CreatedBy: "abebe"}
+
+	s.Run("Success", func() {
+		s.resetRepo()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(task, nil)
+		s.mockRepo.On("Patch", mock.Anything, id.Hex(), domain.TaskUpdate{Status: &status}).Return(task, nil)
+
+		result, err := s.useCase.Patch(id.Hex(), domain.TaskUpdate{Status: &status}, "admin")
+
+		s.NoError(err)
+		s.Equal(task, result)
+	})
+
+	s.Run("EmptyUpdate", func() {
+		s.resetRepo()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(task, nil)
+
+		result, err := s.useCase.Patch(id.Hex(), domain.TaskUpdate{}, "admin")
+
+		s.NoError(err)
+		s.Equal(task, result)
+	})
+
+	s.Run("EmptyUpdateStaleVersion", func() {
+		s.resetRepo()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id, Version: 3}, nil)
+
+		_, err := s.useCase.Patch(id.Hex(), domain.TaskUpdate{Version: 2}, "admin")
+
+		s.ErrorIs(err, domain.ErrVersionConflict)
+	})
+
+	s.Run("EmptyID", func() {
+		_, err := s.useCase.Patch("", domain.TaskUpdate{Status: &status}, "admin")
+
+		s.EqualError(err, "task ID cannot be empty")
+	})
+
+	s.Run("ByIdAndUser", func() {
+		s.resetRepo()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(task, nil)
+		s.mockRepo.On("PatchByIdAndUser", mock.Anything, id.Hex(), domain.TaskUpdate{Status: &status}, "abebe").Return(task, nil)
+
+		result, err := s.useCase.PatchByIdAndUser(id.Hex(), domain.TaskUpdate{Status: &status}, "abebe")
+
+		s.NoError(err)
+		s.Equal(task, result)
+	})
+
+	s.Run("ByIdAndUserEmptyUsername", func() {
+		_, err := s.useCase.PatchByIdAndUser(id.Hex(), domain.TaskUpdate{Status: &status}, "")
+
+		s.EqualError(err, "task ID and username cannot be empty")
+	})
+}
+
 // TestGetTasksByUser tests the GetTasksByUser method
 func (s *TaskUseCaseSuite) TestGetTasksByUser() {
 	s.Run("Success", func() {
@@ -181,7 +501,7 @@ func (s *TaskUseCaseSuite) TestGetTasksByUser() {
 	})
 
 	s.Run("RepositoryError", func() {
-		s.mockRepo.ExpectedCalls = nil
+		s.resetRepo()
 		s.mockRepo.On("GetByUser", mock.Anything, "abebe").Return(nil, errors.New("database error"))
 
 		result, err := s.useCase.GetTasksByUser("abebe")
@@ -191,12 +511,118 @@ func (s *TaskUseCaseSuite) TestGetTasksByUser() {
 	})
 }
 
+// TestListTasks tests the ListTasks method
+func (s *TaskUseCaseSuite) TestListTasks() {
+	s.Run("Defaults", func() {
+		s.resetRepo()
+		page := domain.TaskPage{Tasks: []domain.Task{{ID: primitive.NewObjectID(), Title: "Buy Coffee"}}, Total: 1, Page: 1, Limit: domain.DefaultTaskPageLimit}
+		s.mockRepo.On("Find", mock.Anything, domain.TaskQuery{CreatedBy: "abebe", Page: 1, Limit: domain.DefaultTaskPageLimit}).Return(page, nil)
+
+		result, err := s.useCase.ListTasks(domain.TaskQuery{CreatedBy: "abebe"})
+
+		s.NoError(err)
+		s.Equal(page, result)
+	})
+
+	s.Run("LimitCapped", func() {
+		s.resetRepo()
+		s.mockRepo.On("Find", mock.Anything, domain.TaskQuery{Page: 3, Limit: domain.MaxTaskPageLimit, Sort: domain.SortTitleDesc}).Return(domain.TaskPage{}, nil)
+
+		_, err := s.useCase.ListTasks(domain.TaskQuery{Page: 3, Limit: 1000, Sort: domain.SortTitleDesc})
+
+		s.NoError(err)
+	})
+
+	s.Run("InvalidSort", func() {
+		_, err := s.useCase.ListTasks(domain.TaskQuery{Sort: "created_by"})
+
+		s.EqualError(err, "invalid sort order")
+	})
+
+	s.Run("RepositoryError", func() {
+		s.resetRepo()
+		s.mockRepo.On("Find", mock.Anything, mock.Anything).Return(domain.TaskPage{}, domain.ErrInvalidCursor)
+
+		_, err := s.useCase.ListTasks(domain.TaskQuery{Cursor: "bogus"})
+
+		s.ErrorIs(err, domain.ErrInvalidCursor)
+	})
+}
+
+// TestSearchTasks tests the SearchTasks method
+func (s *TaskUseCaseSuite) TestSearchTasks() {
+	s.Run("HighlightsMatches", func() {
+		s.resetRepo()
+		matches := []domain.TaskMatch{
+			{Task: domain.Task{Title: "Buy Coffee", Description: "Get buna and coffee beans from Merkato"}, Score: 1.5},
+			{Task: domain.Task{Title: "Plan meetings", Description: "Book the hall"}, Score: 0.75},
+		}
+		s.mockRepo.On("Search", mock.Anything, domain.TaskSearch{Text: "coffee Meeting", CreatedBy: "abebe", Limit: domain.DefaultTaskPageLimit}).Return(matches, nil)
+
+		result, err := s.useCase.SearchTasks(domain.TaskSearch{Text: "  coffee Meeting ", CreatedBy: "abebe"})
+
+		s.NoError(err)
+		s.Len(result, 2)
+		s.Equal(map[string]string{
+			"title":       "Buy <em>Coffee</em>",
+			"description": "Get buna and <em>coffee</em> beans from Merkato",
+		}, result[0].Highlights)
+		s.Equal(map[string]string{"title": "Plan <em>meetings</em>"}, result[1].Highlights)
+	})
+
+	s.Run("EmptyQuery", func() {
+		result, err := s.useCase.SearchTasks(domain.TaskSearch{Text: " ?! "})
+
+		s.EqualError(err, "search query cannot be empty")
+		s.Nil(result)
+	})
+
+	s.Run("SpecialCharacters", func() {
+		s.resetRepo()
+		matches := []domain.TaskMatch{{Task: domain.Task{Title: "Fix c++ build"}}}
+		s.mockRepo.On("Search", mock.Anything, mock.Anything).Return(matches, nil)
+
+		result, err := s.useCase.SearchTasks(domain.TaskSearch{Text: "c++ (build"})
+
+		s.NoError(err)
+		s.Equal("Fix <em>c</em>++ <em>build</em>", result[0].Highlights["title"])
+	})
+
+	s.Run("EscapesHTML", func() {
+		s.resetRepo()
+		matches := []domain.TaskMatch{{Task: domain.Task{
+			Title:       `<script>alert("meeting")</script> meeting`,
+			Description: "<img src=x onerror=alert(1)> lt & gt",
+		}}}
+		s.mockRepo.On("Search", mock.Anything, mock.Anything).Return(matches, nil)
+
+		result, err := s.useCase.SearchTasks(domain.TaskSearch{Text: "meeting lt"})
+
+		s.NoError(err)
+		s.Equal(map[string]string{
+			"title":       "&lt;script&gt;alert(&#34;<em>meeting</em>&#34;)&lt;/script&gt; <em>meeting</em>",
+			"description": "&lt;img src=x onerror=alert(1)&gt; <em>lt</em> &amp; gt",
+		}, result[0].Highlights)
+	})
+
+	s.Run("RepositoryError", func() {
+		s.resetRepo()
+		s.mockRepo.On("Search", mock.Anything, mock.Anything).Return(nil, errors.New("text index required"))
+
+		result, err := s.useCase.SearchTasks(domain.TaskSearch{Text: "coffee"})
+
+		s.EqualError(err, "text index required")
+		s.Nil(result)
+	})
+}
+
 // TestGetTaskStatsByUser tests the GetTaskStatsByUser method
 func (s *TaskUseCaseSuite) TestGetTaskStatsByUser() {
 	s.Run("Success", func() {
-		stats := []domain.StatusCount{
-			{Status: "pending", Count: 5},
-			{Status: "completed", Count: 3},
+		stats := domain.TaskStats{
+			ByStatus:   []domain.StatusCount{{Status: "pending", Count: 5}, {Status: "completed", Count: 3}},
+			ByPriority: []domain.PriorityCount{{Priority: "medium", Count: 8}},
+			ByTag:      []domain.TagCount{{Tag: "market", Count: 2}},
 		}
 		s.mockRepo.On("GetTaskStatsByUser", mock.Anything, "abebe").Return(stats, nil)
 		result, err := s.useCase.GetTaskStatsByUser("abebe")
@@ -208,25 +634,25 @@ func (s *TaskUseCaseSuite) TestGetTaskStatsByUser() {
 		result, err := s.useCase.GetTaskStatsByUser("")
 		s.Error(err)
 		s.EqualError(err, "username cannot be empty")
-		s.Nil(result)
+		s.Empty(result.ByStatus)
 	})
 
 	s.Run("RepositoryError", func() {
-		s.mockRepo.ExpectedCalls = nil
-		s.mockRepo.On("GetTaskStatsByUser", mock.Anything, "abebe").Return(nil, errors.New("stats error"))
+		s.resetRepo()
+		s.mockRepo.On("GetTaskStatsByUser", mock.Anything, "abebe").Return(domain.TaskStats{}, errors.New("stats error"))
 		result, err := s.useCase.GetTaskStatsByUser("abebe")
 		s.Error(err)
 		s.EqualError(err, "stats error")
-		s.Nil(result)
+		s.Empty(result.ByStatus)
 	})
 }
 
 // TestGetTaskCountByStatus tests the GetTaskCountByStatus method
 func (s *TaskUseCaseSuite) TestGetTaskCountByStatus() {
 	s.Run("Success", func() {
-		stats := []domain.StatusCount{
-			{Status: "pending", Count: 10},
-			{Status: "completed", Count: 8},
+		stats := domain.TaskStats{
+			ByStatus:   []domain.StatusCount{{Status: "pending", Count: 10}, {Status: "completed", Count: 8}},
+			ByPriority: []domain.PriorityCount{{Priority: "high", Count: 18}},
 		}
 		s.mockRepo.On("GetTaskCountByStatus", mock.Anything).Return(stats, nil)
 		result, err := s.useCase.GetTaskCountByStatus()
@@ -235,12 +661,12 @@ func (s *TaskUseCaseSuite) TestGetTaskCountByStatus() {
 	})
 
 	s.Run("RepositoryError", func() {
-		s.mockRepo.ExpectedCalls = nil
-		s.mockRepo.On("GetTaskCountByStatus", mock.Anything).Return(nil, errors.New("stats error"))
+		s.resetRepo()
+		s.mockRepo.On("GetTaskCountByStatus", mock.Anything).Return(domain.TaskStats{}, errors.New("stats error"))
 		result, err := s.useCase.GetTaskCountByStatus()
 		s.Error(err)
 		s.EqualError(err, "stats error")
-		s.Nil(result)
+		s.Empty(result.ByStatus)
 	})
 }
 
@@ -248,8 +674,8 @@ func (s *TaskUseCaseSuite) TestGetTaskCountByStatus() {
 func (s *TaskUseCaseSuite) TestGetByIdAndUser() {
 	s.Run("Success", func() {
 		id := primitive.NewObjectID()
-		task := domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: time.Now()}
-		s.mockRepo.On("GetByIdAndUser", mock.Anything, task.ID.Hex(), "abebe").Return(task, nil)
+		task := domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "pending", CreatedBy: "abebe", DueDate: time.Now().Add(24 * time.Hour)}
+		s.mockRepo.On("GetById", mock.Anything, task.ID.Hex()).Return(task, nil)
 		result, err := s.useCase.GetByIdAndUser(id.Hex(), "abebe")
 		s.NoError(err)
 		s.Equal(task, result)
@@ -264,7 +690,7 @@ func (s *TaskUseCaseSuite) TestGetByIdAndUser() {
 
 	s.Run("RepositoryError", func() {
 		id := primitive.NewObjectID()
-		s.mockRepo.On("GetByIdAndUser", mock.Anything, id.Hex(), "abebe").Return(domain.Task{}, errors.New("task not found"))
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{}, errors.New("task not found"))
 		result, err := s.useCase.GetByIdAndUser(id.Hex(), "abebe")
 		s.Error(err)
 		s.EqualError(err, "task not found")
@@ -277,6 +703,7 @@ func (s *TaskUseCaseSuite) TestUpdateByIdAndUser() {
 	s.Run("Success", func() {
 		id := primitive.NewObjectID()
 		task := &domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "completed", CreatedBy: "abebe", DueDate: time.Now()}
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(*task, nil)
 		s.mockRepo.On("UpdateByIdAndUser", mock.Anything, task.ID.Hex(), task, "abebe").Return(nil)
 		err := s.useCase.UpdateByIdAndUser(id.Hex(), task, "abebe")
 		s.NoError(err)
@@ -298,9 +725,10 @@ func (s *TaskUseCaseSuite) TestUpdateByIdAndUser() {
 	})
 
 	s.Run("RepositoryError", func() {
-		s.mockRepo.ExpectedCalls = nil
+		s.resetRepo()
 		id := primitive.NewObjectID()
 		task := &domain.Task{ID: id, Title: "Buy Coffee", Description: "Get buna from Merkato", Status: "completed", CreatedBy: "abebe", DueDate: time.Now()}
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(*task, nil)
 		s.mockRepo.On("UpdateByIdAndUser", mock.Anything, id.Hex(), task, "abebe").Return(errors.New("update failed"))
 		err := s.useCase.UpdateByIdAndUser(id.Hex(), task, "abebe")
 		s.Error(err)
@@ -312,22 +740,961 @@ func (s *TaskUseCaseSuite) TestUpdateByIdAndUser() {
 func (s *TaskUseCaseSuite) TestDeleteByIdAndUser() {
 	s.Run("Success", func() {
 		id := primitive.NewObjectID()
-		s.mockRepo.On("DeleteByIdAndUser", mock.Anything, id.Hex(), "abebe").Return(nil)
-		err := s.useCase.DeleteByIdAndUser(id.Hex(), "abebe")
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id, CreatedBy: "abebe"}, nil)
+		s.mockRepo.On("DeleteByIdAndUser", mock.Anything, id.Hex(), "abebe", int64(0)).Return(nil)
+		err := s.useCase.DeleteByIdAndUser(id.Hex(), "abebe", 0)
 
 		s.NoError(err)
 	})
 
 	s.Run("EmptyIDs", func() {
-		err := s.useCase.DeleteByIdAndUser("", "")
+		err := s.useCase.DeleteByIdAndUser("", "", 0)
 		s.EqualError(err, "task ID and username cannot be empty")
 	})
 
 	s.Run("RepositoryError", func() {
 		id := primitive.NewObjectID()
-		s.mockRepo.On("DeleteByIdAndUser", mock.Anything, id.Hex(), "abebe").Return(errors.New("delete failed"))
-		err := s.useCase.DeleteByIdAndUser(id.Hex(), "abebe")
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id, CreatedBy: "abebe"}, nil)
+		s.mockRepo.On("DeleteByIdAndUser", mock.Anything, id.Hex(), "abebe", int64(0)).Return(errors.New("delete failed"))
+		err := s.useCase.DeleteByIdAndUser(id.Hex(), "abebe", 0)
 		s.Error(err)
 		s.EqualError(err, "delete failed")
 	})
 }
+
+// TestGetTrashByUser tests the GetTrashByUser method
+func (s *TaskUseCaseSuite) TestGetTrashByUser() {
+	s.Run("Success", func() {
+		trash := []domain.Task{{ID: primitive.NewObjectID(), Title: "Buy Coffee", DeletedAt: time.Now()}}
+		s.mockRepo.On("GetTrashByUser", mock.Anything, "abebe").Return(trash, nil)
+		result, err := s.useCase.GetTrashByUser("abebe")
+
+		s.NoError(err)
+		s.Equal(trash, result)
+	})
+
+	s.Run("EmptyUsername", func() {
+		_, err := s.useCase.GetTrashByUser("")
+		s.EqualError(err, "username cannot be empty")
+	})
+}
+
+// TestRestoreByIdAndUser tests the RestoreByIdAndUser method
+func (s *TaskUseCaseSuite) TestRestoreByIdAndUser() {
+	s.Run("Success", func() {
+		task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Version: 3}
+		s.mockRepo.On("RestoreByIdAndUser", mock.Anything, task.ID.Hex(), "abebe").Return(task, nil)
+		result, err := s.useCase.RestoreByIdAndUser(task.ID.Hex(), "abebe")
+
+		s.NoError(err)
+		s.Equal(task, result)
+	})
+
+	s.Run("EmptyIDs", func() {
+		_, err := s.useCase.RestoreByIdAndUser("", "")
+		s.EqualError(err, "task ID and username cannot be empty")
+	})
+
+	s.Run("NotInTrash", func() {
+		id := primitive.NewObjectID()
+		s.mockRepo.On("RestoreByIdAndUser", mock.Anything, id.Hex(), "abebe").
+			Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found in trash"))
+		_, err := s.useCase.RestoreByIdAndUser(id.Hex(), "abebe")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestPurgeTrash tests the PurgeTrash method
+func (s *TaskUseCaseSuite) TestPurgeTrash() {
+	s.Run("Success", func() {
+		before := time.Now().Add(-time.Hour)
+		s.mockRepo.On("PurgeDeletedBefore", mock.Anything, mock.MatchedBy(func(cutoff time.Time) bool {
+			return !cutoff.Before(before) && cutoff.Before(time.Now().Add(-time.Hour+time.Minute))
+		})).Return(int64(2), nil).Once()
+		purged, err := s.useCase.PurgeTrash(time.Hour)
+
+		s.NoError(err)
+		s.Equal(int64(2), purged)
+	})
+
+	s.Run("NegativeRetention", func() {
+		_, err := s.useCase.PurgeTrash(-time.Hour)
+		s.EqualError(err, "trash retention cannot be negative")
+	})
+}
+
+// TestHistoryRecording tests that writes are recorded in the task history
+func (s *TaskUseCaseSuite) TestHistoryRecording() {
+	s.Run("Create", func() {
+		s.SetupTest()
+		task := &domain.Task{Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe"}
+		s.mockRepo.On("Create", mock.Anything, task).Return(nil)
+		s.mockHistory.ExpectedCalls = nil
+		s.mockHistory.On("Add", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+			return h.Action == domain.TaskCreated && h.Actor == "abebe" && h.Owner == "abebe" && len(h.Changes) == 4
+		})).Return(nil).Once()
+
+		s.NoError(s.useCase.Create(task))
+	})
+
+	s.Run("Update", func() {
+		s.SetupTest()
+		id := primitive.NewObjectID()
+		before := domain.Task{ID: id, Title: "Buy Coffee", Status: "pending", Priority: "medium", CreatedBy: "abebe", Version: 1}
+		task := &domain.Task{Title: "Buy Coffee", Status: "completed", CreatedBy: "abebe"}
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(before, nil)
+		s.mockRepo.On("Update", mock.Anything, id.Hex(), task).Run(func(args mock.Arguments) {
+			updated := args.Get(2).(*domain.Task)
+			updated.ID = id
+			updated.Version = 2
+		}).Return(nil)
+		s.mockHistory.ExpectedCalls = nil
+		s.mockHistory.On("Add", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+			return h.TaskID == id && h.Owner == "abebe" && h.Actor == "admin" && h.Version == 2 &&
+				!h.At.IsZero() && reflect.DeepEqual([]domain.TaskChange{{Field: "status", From: "pending", To: "completed"}}, h.Changes)
+		})).Return(nil).Once()
+
+		s.NoError(s.useCase.Update(id.Hex(), task, "admin"))
+	})
+
+	s.Run("Delete", func() {
+		s.SetupTest()
+		id := primitive.NewObjectID()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id, CreatedBy: "abebe", Version: 4}, nil)
+		s.mockRepo.On("DeleteByIdAndUser", mock.Anything, id.Hex(), "abebe", int64(0)).Return(nil)
+		s.mockHistory.ExpectedCalls = nil
+		s.mockHistory.On("Add", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+			return h.Action == domain.TaskDeleted && h.Version == 5 &&
+				len(h.Changes) == 1 && h.Changes[0].Field == "deleted_at" && h.Changes[0].From == ""
+		})).Return(nil).Once()
+
+		s.NoError(s.useCase.DeleteByIdAndUser(id.Hex(), "abebe", 0))
+	})
+
+	s.Run("NotRecordedOnFailure", func() {
+		s.SetupTest()
+		id := primitive.NewObjectID()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id}, nil)
+		s.mockRepo.On("Delete", mock.Anything, id.Hex(), int64(2)).Return(domain.ErrVersionConflict)
+
+		err := s.useCase.Delete(id.Hex(), 2, "admin")
+
+		s.ErrorIs(err, domain.ErrVersionConflict)
+		s.mockHistory.AssertNotCalled(s.T(), "Add", mock.Anything, mock.Anything)
+	})
+
+	s.Run("HistoryErrorIgnored", func() {
+		s.SetupTest()
+		task := &domain.Task{Title: "Buy Coffee", CreatedBy: "abebe"}
+		s.mockRepo.On("Create", mock.Anything, task).Return(nil)
+		s.mockHistory.ExpectedCalls = nil
+		s.mockHistory.On("Add", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+		s.NoError(s.useCase.Create(task))
+	})
+}
+
+// TestEventPublishing tests that task changes are published as webhook events
+func (s *TaskUseCaseSuite) TestEventPublishing() {
+	expectEvent := func(eventType, actor string, check func(*domain.Task) bool) {
+		s.mockEvents.ExpectedCalls = nil
+		s.mockEvents.On("Publish", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+			return event.Type == eventType && event.Actor == actor && !event.At.IsZero() && event.Task != nil && check(event.Task)
+		})).Once()
+	}
+
+	s.Run("Create", func() {
+		s.SetupTest()
+		task := &domain.Task{Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe"}
+		s.mockRepo.On("Create", mock.Anything, task).Return(nil)
+		expectEvent(domain.EventTaskCreated, "abebe", func(t *domain.Task) bool { return t.Title == "Buy Coffee" })
+
+		s.NoError(s.useCase.Create(task))
+	})
+
+	s.Run("Update", func() {
+		s.SetupTest()
+		id := primitive.NewObjectID()
+		task := &domain.Task{Title: "Buy Coffee", Status: "completed", CreatedBy: "abebe"}
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id, Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe"}, nil)
+		s.mockRepo.On("Update", mock.Anything, id.Hex(), task).Run(func(args mock.Arguments) {
+			args.Get(2).(*domain.Task).ID = id
+		}).Return(nil)
+		expectEvent(domain.EventTaskUpdated, "admin", func(t *domain.Task) bool { return t.ID == id && t.Status == "completed" })
+
+		s.NoError(s.useCase.Update(id.Hex(), task, "admin"))
+	})
+
+	s.Run("Delete", func() {
+		s.SetupTest()
+		id := primitive.NewObjectID()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id, CreatedBy: "abebe"}, nil)
+		s.mockRepo.On("DeleteByIdAndUser", mock.Anything, id.Hex(), "abebe", int64(0)).Return(nil)
+		expectEvent(domain.EventTaskDeleted, "abebe", func(t *domain.Task) bool { return t.ID == id && !t.DeletedAt.IsZero() })
+
+		s.NoError(s.useCase.DeleteByIdAndUser(id.Hex(), "abebe", 0))
+	})
+
+	s.Run("NotPublishedOnFailure", func() {
+		s.SetupTest()
+		task := &domain.Task{Title: "Buy Coffee", CreatedBy: "abebe"}
+		s.mockRepo.On("Create", mock.Anything, task).Return(errors.New("database error"))
+
+		s.Error(s.useCase.Create(task))
+		s.mockEvents.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+}
+
+// TestGetHistory tests the GetHistory and GetHistoryByIdAndUser methods
+func (s *TaskUseCaseSuite) TestGetHistory() {
+	id := primitive.NewObjectID()
+	history := []domain.TaskHistory{{TaskID: id, Action: domain.TaskCreated, Actor: "abebe", Owner: "abebe"}}
+
+	s.Run("Success", func() {
+		s.mockHistory.On("GetByTask", mock.Anything, id.Hex()).Return(history, nil).Once()
+
+		result, err := s.useCase.GetHistory(id.Hex())
+
+		s.NoError(err)
+		s.Equal(history, result)
+	})
+
+	s.Run("NoChangesRecorded", func() {
+		s.mockHistory.On("GetByTask", mock.Anything, id.Hex()).Return(nil, nil).Once()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id}, nil).Once()
+
+		result, err := s.useCase.GetHistory(id.Hex())
+
+		s.NoError(err)
+		s.Empty(result)
+	})
+
+	s.Run("TaskNotFound", func() {
+		s.mockHistory.On("GetByTask", mock.Anything, id.Hex()).Return(nil, nil).Once()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).
+			Return(domain.Task{}, domain.NewError(domain.ErrNotFound, "task not found")).Once()
+
+		_, err := s.useCase.GetHistory(id.Hex())
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("ByIdAndUser", func() {
+		s.mockHistory.On("GetByTaskAndUser", mock.Anything, id.Hex(), "abebe").Return(history, nil).Once()
+
+		result, err := s.useCase.GetHistoryByIdAndUser(id.Hex(), "abebe")
+
+		s.NoError(err)
+		s.Equal(history, result)
+	})
+
+	s.Run("ByIdAndUserOtherOwner", func() {
+		s.mockHistory.On("GetByTaskAndUser", mock.Anything, id.Hex(), "kebede").Return(nil, nil).Once()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(domain.Task{ID: id, CreatedBy: "abebe"}, nil).Once()
+
+		_, err := s.useCase.GetHistoryByIdAndUser(id.Hex(), "kebede")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("EmptyIDs", func() {
+		_, err := s.useCase.GetHistoryByIdAndUser("", "")
+
+		s.EqualError(err, "task ID and username cannot be empty")
+	})
+}
+
+// TestAssignees tests assigning users and what assignees are allowed to do
+func (s *TaskUseCaseSuite) TestAssignees() {
+	id := primitive.NewObjectID()
+	status := "completed"
+	title := "Buy Tea"
+	shared := domain.Task{ID: id, Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe", Assignees: []string{"kebede"}, Version: 1}
+
+	s.Run("Assign", func() {
+		s.SetupTest()
+		task := shared
+		task.Assignees = nil
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(task, nil)
+		s.mockRepo.On("AddAssignee", mock.Anything, id.Hex(), "kebede").Return(shared, nil)
+		s.mockHistory.ExpectedCalls = nil
+		s.mockHistory.On("Add", mock.Anything, mock.MatchedBy(func(h *domain.TaskHistory) bool {
+			return h.Action == domain.TaskUpdated && h.Actor == "abebe" &&
+				reflect.DeepEqual([]domain.TaskChange{{Field: "assignees", From: "", To: "kebede"}}, h.Changes)
+		})).Return(nil).Once()
+
+		result, err := s.useCase.AssignByIdAndUser(id.Hex(), "kebede", "abebe")
+
+		s.NoError(err)
+		s.Equal(shared, result)
+	})
+
+	s.Run("AssignAlreadyAssigned", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		result, err := s.useCase.AssignByIdAndUser(id.Hex(), "kebede", "abebe")
+
+		s.NoError(err)
+		s.Equal(shared, result)
+		s.mockRepo.AssertNotCalled(s.T(), "AddAssignee", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("AssignCreator", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		_, err := s.useCase.AssignByIdAndUser(id.Hex(), "abebe", "abebe")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("AssignByAssignee", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		_, err := s.useCase.AssignByIdAndUser(id.Hex(), "almaz", "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("AssigneeCanRead", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		result, err := s.useCase.GetByIdAndUser(id.Hex(), "kebede")
+
+		s.NoError(err)
+		s.Equal(shared, result)
+	})
+
+	s.Run("OtherUserCannotRead", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		_, err := s.useCase.GetByIdAndUser(id.Hex(), "almaz")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("AssigneeCanChangeStatus", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+		s.mockRepo.On("Patch", mock.Anything, id.Hex(), domain.TaskUpdate{Status: &status}).Return(shared, nil)
+
+		_, err := s.useCase.PatchByIdAndUser(id.Hex(), domain.TaskUpdate{Status: &status}, "kebede")
+
+		s.NoError(err)
+		s.mockRepo.AssertNotCalled(s.T(), "PatchByIdAndUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("AssigneeCannotChangeTitle", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		_, err := s.useCase.PatchByIdAndUser(id.Hex(), domain.TaskUpdate{Title: &title, Status: &status}, "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("AssigneeCannotReplace", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		err := s.useCase.UpdateByIdAndUser(id.Hex(), &domain.Task{Title: title, CreatedBy: "kebede"}, "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("AssigneeCannotDelete", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		err := s.useCase.DeleteByIdAndUser(id.Hex(), "kebede", 0)
+
+		s.ErrorIs(err, domain.ErrForbidden)
+		s.mockRepo.AssertNotCalled(s.T(), "DeleteByIdAndUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("AssigneeUnassignsSelf", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+		s.mockRepo.On("RemoveAssignee", mock.Anything, id.Hex(), "kebede").Return(domain.Task{ID: id, CreatedBy: "abebe", Version: 2}, nil)
+
+		result, err := s.useCase.UnassignByIdAndUser(id.Hex(), "kebede", "kebede")
+
+		s.NoError(err)
+		s.Empty(result.Assignees)
+	})
+
+	s.Run("UnassignNotAssigned", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(shared, nil)
+
+		_, err := s.useCase.UnassignByIdAndUser(id.Hex(), "almaz", "abebe")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("EmptyAssignee", func() {
+		_, err := s.useCase.AssignByIdAndUser(id.Hex(), "", "abebe")
+
+		s.EqualError(err, "task ID, assignee and username cannot be empty")
+	})
+}
+
+// TestPriorityAndTags tests the defaults and checks applied to priorities and
+// tags
+func (s *TaskUseCaseSuite) TestPriorityAndTags() {
+	s.Run("CreateDefaults", func() {
+		s.SetupTest()
+		task := &domain.Task{Title: "Buy Coffee", CreatedBy: "abebe", Tags: []string{" Market", "market", "", "Home"}}
+		s.mockRepo.On("Create", mock.Anything, task).Return(nil)
+
+		s.NoError(s.useCase.Create(task))
+		s.Equal("pending", task.Status)
+		s.Equal(domain.PriorityMedium, task.Priority)
+		s.Equal([]string{"market", "home"}, task.Tags)
+	})
+
+	s.Run("CreateInvalidPriority", func() {
+		s.SetupTest()
+
+		err := s.useCase.Create(&domain.Task{Title: "Buy Coffee", CreatedBy: "abebe", Priority: "critical"})
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("PatchNormalizesTags", func() {
+		s.SetupTest()
+		id := primitive.NewObjectID()
+		tags := []string{"Market", "MARKET"}
+		before := domain.Task{ID: id, Status: "pending", CreatedBy: "abebe"}
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(before, nil)
+		s.mockRepo.On("PatchByIdAndUser", mock.Anything, id.Hex(), mock.MatchedBy(func(u domain.TaskUpdate) bool {
+			return u.Tags != nil && reflect.DeepEqual([]string{"market"}, *u.Tags)
+		}), "abebe").Return(before, nil)
+
+		_, err := s.useCase.PatchByIdAndUser(id.Hex(), domain.TaskUpdate{Tags: &tags}, "abebe")
+
+		s.NoError(err)
+	})
+}
+
+// TestWorkflow tests that status changes follow the configured workflow
+func (s *TaskUseCaseSuite) TestWorkflow() {
+	id := primitive.NewObjectID()
+	workflow := domain.Workflow{
+		Statuses:    []string{"todo", "in_progress", "done"},
+		Transitions: map[string][]string{"todo": {"in_progress"}, "in_progress": {"todo", "done"}},
+	}
+	setup := func(before domain.Task) {
+		s.SetupTest()
+		s.mockWorkflow.ExpectedCalls = nil
+		s.mockWorkflow.On("Get", mock.Anything).Return(workflow, nil).Maybe()
+		s.mockRepo.On("GetById", mock.Anything, id.Hex()).Return(before, nil).Maybe()
+	}
+
+	s.Run("CreateStartsInFirstStatus", func() {
+		setup(domain.Task{})
+		task := &domain.Task{Title: "Buy Coffee", CreatedBy: "abebe"}
+		s.mockRepo.On("Create", mock.Anything, task).Return(nil)
+
+		s.NoError(s.useCase.Create(task))
+		s.Equal("todo", task.Status)
+	})
+
+	s.Run("CreateUnknownStatus", func() {
+		setup(domain.Task{})
+
+		err := s.useCase.Create(&domain.Task{Title: "Buy Coffee", CreatedBy: "abebe", Status: "pending"})
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.EqualError(err, "status must be one of todo, in_progress, done")
+	})
+
+	s.Run("AllowedMove", func() {
+		setup(domain.Task{ID: id, Status: "todo", CreatedBy: "abebe"})
+		status := "in_progress"
+		update := domain.TaskUpdate{Status: &status}
+		s.mockRepo.On("PatchByIdAndUser", mock.Anything, id.Hex(), update, "abebe").Return(domain.Task{ID: id, Status: status}, nil)
+
+		_, err := s.useCase.PatchByIdAndUser(id.Hex(), update, "abebe")
+
+		s.NoError(err)
+	})
+
+	s.Run("SkippedStatus", func() {
+		setup(domain.Task{ID: id, Status: "todo", CreatedBy: "abebe", Assignees: []string{"kebede"}})
+		status := "done"
+
+		_, err := s.useCase.PatchByIdAndUser(id.Hex(), domain.TaskUpdate{Status: &status}, "kebede")
+
+		s.ErrorIs(err, domain.ErrConflict)
+		s.EqualError(err, `a task cannot move from "todo" to "done"`)
+	})
+
+	s.Run("FinalStatus", func() {
+		setup(domain.Task{ID: id, Status: "done", CreatedBy: "abebe"})
+
+		err := s.useCase.Update(id.Hex(), &domain.Task{Title: "Buy Coffee", Status: "todo", CreatedBy: "abebe"}, "admin")
+
+		s.ErrorIs(err, domain.ErrConflict)
+	})
+
+	s.Run("UpdateKeepsStatus", func() {
+		setup(domain.Task{ID: id, Status: "done", CreatedBy: "abebe"})
+		task := &domain.Task{Title: "Buy Tea", CreatedBy: "abebe"}
+		s.mockRepo.On("UpdateByIdAndUser", mock.Anything, id.Hex(), task, "abebe").Return(nil)
+
+		s.NoError(s.useCase.UpdateByIdAndUser(id.Hex(), task, "abebe"))
+		s.Equal("done", task.Status)
+	})
+
+	s.Run("StatusNoLongerInWorkflow", func() {
+		setup(domain.Task{ID: id, Status: "completed", CreatedBy: "abebe"})
+		status := "done"
+		update := domain.TaskUpdate{Status: &status}
+		s.mockRepo.On("Patch", mock.Anything, id.Hex(), update).Return(domain.Task{ID: id, Status: status}, nil)
+
+		_, err := s.useCase.Patch(id.Hex(), update, "admin")
+
+		s.NoError(err)
+	})
+}
+
+// TestSubtasks tests subtasks and the completion rule of their parents
+func (s *TaskUseCaseSuite) TestSubtasks() {
+	parentID := primitive.NewObjectID()
+	projectID := primitive.NewObjectID()
+	parent := domain.Task{ID: parentID, Title: "Plan trip", Status: "pending", CreatedBy: "abebe", ProjectID: projectID, Assignees: []string{"kebede"}}
+	setup := func(parent domain.Task, counts map[primitive.ObjectID][]domain.StatusCount) {
+		s.SetupTest()
+		s.mockRepo.ExpectedCalls = nil
+		s.mockRepo.On("GetById", mock.Anything, parentID.Hex()).Return(parent, nil).Maybe()
+		s.mockRepo.On("CountSubtasksByStatus", mock.Anything, []primitive.ObjectID{parentID}).Return(counts, nil).Maybe()
+	}
+	pending := map[primitive.ObjectID][]domain.StatusCount{parentID: {{Status: "completed", Count: 2}, {Status: "pending", Count: 1}}}
+	completed := map[primitive.ObjectID][]domain.StatusCount{parentID: {{Status: "completed", Count: 3}}}
+
+	s.Run("CreateSubtask", func() {
+		setup(parent, nil)
+		task := &domain.Task{Title: "Book hotel"}
+		s.mockRepo.On("Create", mock.Anything, task).Return(nil)
+
+		err := s.useCase.CreateSubtask(parentID.Hex(), task, "abebe")
+
+		s.NoError(err)
+		s.Equal(parentID, task.ParentID)
+		s.Equal(projectID, task.ProjectID)
+		s.Equal("abebe", task.CreatedBy)
+		s.Equal("pending", task.Status)
+	})
+
+	s.Run("CreateSubtaskByAssignee", func() {
+		setup(parent, nil)
+
+		err := s.useCase.CreateSubtask(parentID.Hex(), &domain.Task{Title: "Book hotel"}, "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("CreateSubtaskOfSubtask", func() {
+		subtask := parent
+		subtask.ParentID = primitive.NewObjectID()
+		setup(subtask, nil)
+
+		err := s.useCase.CreateSubtask(parentID.Hex(), &domain.Task{Title: "Book hotel"}, "abebe")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("CountsOnRead", func() {
+		setup(parent, pending)
+
+		task, err := s.useCase.GetByIdAndUser(parentID.Hex(), "kebede")
+
+		s.NoError(err)
+		s.Equal(domain.SubtaskCount{Total: 3, Done: 2}, task.Subtasks)
+	})
+
+	s.Run("CompleteWithPendingSubtasks", func() {
+		setup(parent, pending)
+		status := "completed"
+
+		_, err := s.useCase.PatchByIdAndUser(parentID.Hex(), domain.TaskUpdate{Status: &status}, "abebe")
+
+		s.ErrorIs(err, domain.ErrConflict)
+		s.EqualError(err, "a task cannot be completed while it has pending subtasks")
+	})
+
+	s.Run("CompleteWithCompletedSubtasks", func() {
+		setup(parent, completed)
+		status := "completed"
+		update := domain.TaskUpdate{Status: &status}
+		after := parent
+		after.Status = status
+		s.mockRepo.On("PatchByIdAndUser", mock.Anything, parentID.Hex(), update, "abebe").Return(after, nil)
+
+		task, err := s.useCase.PatchByIdAndUser(parentID.Hex(), update, "abebe")
+
+		s.NoError(err)
+		s.Equal(domain.SubtaskCount{Total: 3, Done: 3}, task.Subtasks)
+	})
+
+	s.Run("DeleteWithSubtasks", func() {
+		setup(parent, completed)
+
+		err := s.useCase.DeleteByIdAndUser(parentID.Hex(), "abebe", 0)
+
+		s.ErrorIs(err, domain.ErrConflict)
+	})
+
+	s.Run("GetSubtasks", func() {
+		setup(parent, nil)
+		subtasks := []domain.Task{{ID: primitive.NewObjectID(), Title: "Book hotel", ParentID: parentID}}
+		s.mockRepo.On("GetSubtasks", mock.Anything, parentID.Hex()).Return(subtasks, nil)
+
+		result, err := s.useCase.GetSubtasksByIdAndUser(parentID.Hex(), "kebede")
+
+		s.NoError(err)
+		s.Equal(subtasks, result)
+	})
+
+	s.Run("EmptyChecklistItem", func() {
+		err := s.useCase.Create(&domain.Task{Title: "Buy Coffee", CreatedBy: "abebe", Checklist: []domain.ChecklistItem{{Text: " "}}})
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+}
+
+// TestDependencies tests blockers, their cycle check and the next tasks listing
+func (s *TaskUseCaseSuite) TestDependencies() {
+	taskA := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe", DueDate: time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC)}
+	taskB := domain.Task{ID: primitive.NewObjectID(), Title: "Roast Coffee", Status: "pending", CreatedBy: "abebe", DueDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+	taskC := domain.Task{ID: primitive.NewObjectID(), Title: "Serve Coffee", Status: "pending", CreatedBy: "abebe", DueDate: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)}
+	setup := func(tasks ...domain.Task) {
+		s.SetupTest()
+		s.resetRepo()
+		for _, task := range tasks {
+			s.mockRepo.On("GetById", mock.Anything, task.ID.Hex()).Return(task, nil).Maybe()
+		}
+	}
+
+	s.Run("AddBlocker", func() {
+		setup(taskA, taskB)
+		after := taskB
+		after.BlockedBy = []primitive.ObjectID{taskA.ID}
+		s.mockRepo.On("AddBlocker", mock.Anything, taskB.ID.Hex(), taskA.ID).Return(after, nil)
+		s.mockRepo.On("GetStatuses", mock.Anything, []primitive.ObjectID{taskA.ID}).Return(map[primitive.ObjectID]string{taskA.ID: "pending"}, nil)
+
+		task, err := s.useCase.AddBlockerByIdAndUser(taskB.ID.Hex(), taskA.ID.Hex(), "abebe")
+
+		s.NoError(err)
+		s.Equal([]primitive.ObjectID{taskA.ID}, task.BlockedBy)
+		s.True(task.Blocked)
+	})
+
+	s.Run("FinishedBlocker", func() {
+		blocked := taskB
+		blocked.BlockedBy = []primitive.ObjectID{taskA.ID}
+		setup(blocked)
+		s.mockRepo.On("GetStatuses", mock.Anything, []primitive.ObjectID{taskA.ID}).Return(map[primitive.ObjectID]string{taskA.ID: "completed"}, nil)
+
+		task, err := s.useCase.GetByIdAndUser(taskB.ID.Hex(), "abebe")
+
+		s.NoError(err)
+		s.False(task.Blocked)
+	})
+
+	s.Run("SelfBlocker", func() {
+		setup(taskA)
+
+		_, err := s.useCase.AddBlockerByIdAndUser(taskA.ID.Hex(), taskA.ID.Hex(), "abebe")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("Cycle", func() {
+		// A waits for C, which waits for B, so B cannot wait for A.
+		a, c := taskA, taskC
+		a.BlockedBy = []primitive.ObjectID{taskC.ID}
+		c.BlockedBy = []primitive.ObjectID{taskB.ID}
+		setup(a, taskB, c)
+
+		_, err := s.useCase.AddBlockerByIdAndUser(taskB.ID.Hex(), taskA.ID.Hex(), "abebe")
+
+		s.ErrorIs(err, domain.ErrConflict)
+		s.EqualError(err, "the blocker depends on the task, adding it would create a cycle")
+	})
+
+	s.Run("NotCreator", func() {
+		assigned := taskB
+		assigned.Assignees = []string{"kebede"}
+		setup(taskA, assigned)
+
+		_, err := s.useCase.AddBlockerByIdAndUser(taskB.ID.Hex(), taskA.ID.Hex(), "kebede")
+
+		s.ErrorIs(err, domain.ErrForbidden)
+	})
+
+	s.Run("BlockerNotVisible", func() {
+		other := taskA
+		other.CreatedBy = "kebede"
+		setup(other, taskB)
+
+		_, err := s.useCase.AddBlockerByIdAndUser(taskB.ID.Hex(), taskA.ID.Hex(), "abebe")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("RemoveMissingBlocker", func() {
+		setup(taskB)
+
+		_, err := s.useCase.RemoveBlockerByIdAndUser(taskB.ID.Hex(), taskA.ID.Hex(), "abebe")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	s.Run("RemoveBlocker", func() {
+		blocked := taskB
+		blocked.BlockedBy = []primitive.ObjectID{taskA.ID}
+		setup(blocked)
+		s.mockRepo.On("RemoveBlocker", mock.Anything, taskB.ID.Hex(), taskA.ID).Return(taskB, nil)
+
+		task, err := s.useCase.RemoveBlockerByIdAndUser(taskB.ID.Hex(), taskA.ID.Hex(), "abebe")
+
+		s.NoError(err)
+		s.Empty(task.BlockedBy)
+		s.False(task.Blocked)
+	})
+
+	s.Run("NextTasks", func() {
+		// B is due first but waits for A, which waits for nothing.
+		b := taskB
+		b.BlockedBy = []primitive.ObjectID{taskA.ID}
+		done := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Cups", Status: "completed", CreatedBy: "abebe"}
+		setup()
+		s.mockRepo.On("GetByUser", mock.Anything, "abebe").Return([]domain.Task{taskA, b, taskC, done}, nil)
+		s.mockRepo.On("GetStatuses", mock.Anything, []primitive.ObjectID{taskA.ID}).Return(map[primitive.ObjectID]string{taskA.ID: "pending"}, nil)
+
+		tasks, err := s.useCase.GetNextTasksByUser("abebe")
+
+		s.NoError(err)
+		s.Require().Len(tasks, 3)
+		s.Equal([]string{"Serve Coffee", "Buy Coffee", "Roast Coffee"}, []string{tasks[0].Title, tasks[1].Title, tasks[2].Title})
+		s.True(tasks[2].Blocked)
+	})
+}
+
+// TestRecurrence tests recurring tasks and the creation of their next occurrences
+func (s *TaskUseCaseSuite) TestRecurrence() {
+	taskID := primitive.NewObjectID()
+	dueDate := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+	recurring := domain.Task{
+		ID: taskID, Title: "Water plants", Status: "pending", CreatedBy: "abebe", DueDate: dueDate, Priority: "medium",
+		Assignees: []string{"kebede"}, Checklist: []domain.ChecklistItem{{Text: "Balcony", Done: true}},
+		Recurrence: &domain.Recurrence{Rule: "FREQ=WEEKLY", Occurrence: 1},
+	}
+	setup := func(before domain.Task) {
+		s.SetupTest()
+		s.resetRepo()
+		s.mockRepo.On("GetById", mock.Anything, taskID.Hex()).Return(before, nil).Maybe()
+	}
+	// completed is the task as sent to be completed, without its ID and
+	// series.
+	completed := func(task domain.Task) *domain.Task {
+		task.ID = primitive.NilObjectID
+		task.Status = "completed"
+		task.Recurrence = &domain.Recurrence{Rule: task.Recurrence.Rule}
+		return &task
+	}
+	expectNext := func(title string, dueDate time.Time) {
+		s.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+			return task.Title == title && task.DueDate.Equal(dueDate)
+		})).Return(nil)
+	}
+
+	s.Run("CreateNormalizesRule", func() {
+		setup(recurring)
+		task := &domain.Task{Title: "Water plants", CreatedBy: "abebe", Recurrence: &domain.Recurrence{Rule: "rrule:freq=weekly;byday=we", Occurrence: 7}}
+		s.mockRepo.On("Create", mock.Anything, task).Return(nil)
+
+		err := s.useCase.Create(task)
+
+		s.NoError(err)
+		s.Equal(&domain.Recurrence{Rule: "FREQ=WEEKLY;BYDAY=WE", Occurrence: 1}, task.Recurrence)
+	})
+
+	s.Run("InvalidRule", func() {
+		err := s.useCase.Create(&domain.Task{Title: "Water plants", CreatedBy: "abebe", Recurrence: &domain.Recurrence{Rule: "FREQ=HOURLY"}})
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("RecurringSubtask", func() {
+		err := s.useCase.Create(&domain.Task{Title: "Water plants", ParentID: taskID, Recurrence: &domain.Recurrence{Rule: "FREQ=DAILY"}})
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("CompleteCreatesNextOccurrence", func() {
+		setup(recurring)
+		var next *domain.Task
+		s.mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			next = args.Get(1).(*domain.Task)
+		}).Return(nil)
+		s.mockRepo.On("UpdateByIdAndUser", mock.Anything, taskID.Hex(), mock.Anything, "abebe").Return(nil)
+		task := completed(recurring)
+
+		err := s.useCase.UpdateByIdAndUser(taskID.Hex(), task, "abebe")
+
+		s.NoError(err)
+		s.Require().NotNil(next)
+		s.Equal(next.ID, task.Recurrence.NextID)
+		s.Equal("Water plants", next.Title)
+		s.Equal(time.Date(2030, 1, 9, 9, 0, 0, 0, time.UTC), next.DueDate)
+		s.Equal("pending", next.Status)
+		s.Equal([]string{"kebede"}, next.Assignees)
+		s.Equal([]domain.ChecklistItem{{Text: "Balcony"}}, next.Checklist)
+		s.Equal(&domain.Recurrence{Rule: "FREQ=WEEKLY", SeriesID: taskID, Occurrence: 2}, next.Recurrence)
+	})
+
+	s.Run("EditThisOccurrence", func() {
+		setup(recurring)
+		expectNext("Water plants", time.Date(2030, 1, 9, 9, 0, 0, 0, time.UTC))
+		s.mockRepo.On("UpdateByIdAndUser", mock.Anything, taskID.Hex(), mock.Anything, "abebe").Return(nil)
+		task := completed(recurring)
+		task.Title = "Water plants and herbs"
+		task.DueDate = dueDate.AddDate(0, 0, 1)
+
+		err := s.useCase.UpdateOccurrenceByIdAndUser(taskID.Hex(), task, domain.ScopeThisOccurrence, "abebe")
+
+		s.NoError(err)
+		s.Require().NotNil(task.Recurrence.Template)
+		s.Equal("Water plants", task.Recurrence.Template.Title)
+	})
+
+	s.Run("EditFutureOccurrences", func() {
+		edited := recurring
+		edited.Title = "Water plants and herbs"
+		template := domain.TemplateOf(recurring)
+		edited.Recurrence = &domain.Recurrence{Rule: "FREQ=WEEKLY", Occurrence: 1, Template: &template}
+		setup(edited)
+		expectNext("Water plants", time.Date(2030, 1, 16, 9, 0, 0, 0, time.UTC))
+		s.mockRepo.On("UpdateByIdAndUser", mock.Anything, taskID.Hex(), mock.Anything, "abebe").Return(nil)
+		task := completed(edited)
+		task.DueDate = dueDate.AddDate(0, 0, 7)
+
+		err := s.useCase.UpdateOccurrenceByIdAndUser(taskID.Hex(), task, domain.ScopeFutureOccurrences, "abebe")
+
+		s.NoError(err)
+		s.Equal(task.DueDate, task.Recurrence.Template.DueDate)
+	})
+
+	s.Run("ThisOccurrenceCannotChangeRule", func() {
+		setup(recurring)
+		task := completed(recurring)
+		task.Recurrence.Rule = "FREQ=DAILY"
+
+		err := s.useCase.UpdateOccurrenceByIdAndUser(taskID.Hex(), task, domain.ScopeThisOccurrence, "abebe")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("InvalidScope", func() {
+		err := s.useCase.UpdateOccurrenceByIdAndUser(taskID.Hex(), completed(recurring), "all", "abebe")
+
+		s.ErrorIs(err, domain.ErrValidation)
+	})
+
+	s.Run("LastOccurrence", func() {
+		last := recurring
+		last.Recurrence = &domain.Recurrence{Rule: "FREQ=WEEKLY;COUNT=2", SeriesID: primitive.NewObjectID(), Occurrence: 2}
+		setup(last)
+		s.mockRepo.On("UpdateByIdAndUser", mock.Anything, taskID.Hex(), mock.Anything, "abebe").Return(nil)
+		task := completed(last)
+
+		err := s.useCase.UpdateByIdAndUser(taskID.Hex(), task, "abebe")
+
+		s.NoError(err)
+		s.True(task.Recurrence.NextID.IsZero())
+		s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("CompletedAgain", func() {
+		reopened := recurring
+		reopened.Recurrence = &domain.Recurrence{Rule: "FREQ=WEEKLY", Occurrence: 1, NextID: primitive.NewObjectID()}
+		setup(reopened)
+		s.mockRepo.On("UpdateByIdAndUser", mock.Anything, taskID.Hex(), mock.Anything, "abebe").Return(nil)
+
+		err := s.useCase.UpdateByIdAndUser(taskID.Hex(), completed(reopened), "abebe")
+
+		s.NoError(err)
+		s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("PatchByAssignee", func() {
+		setup(recurring)
+		expectNext("Water plants", time.Date(2030, 1, 9, 9, 0, 0, 0, time.UTC))
+		status := "completed"
+		s.mockRepo.On("Patch", mock.Anything, taskID.Hex(), mock.MatchedBy(func(update domain.TaskUpdate) bool {
+			return *update.Status == status && update.Recurrence != nil && !update.Recurrence.NextID.IsZero()
+		})).Return(*completed(recurring), nil)
+
+		_, err := s.useCase.PatchByIdAndUser(taskID.Hex(), domain.TaskUpdate{Status: &status}, "kebede")
+
+		s.NoError(err)
+	})
+
+	s.Run("PatchStopsRecurring", func() {
+		setup(recurring)
+		stopped := recurring
+		stopped.Recurrence = nil
+		s.mockRepo.On("PatchByIdAndUser", mock.Anything, taskID.Hex(), domain.TaskUpdate{Recurrence: &domain.Recurrence{}}, "abebe").Return(stopped, nil)
+
+		task, err := s.useCase.PatchByIdAndUser(taskID.Hex(), domain.TaskUpdate{Recurrence: &domain.Recurrence{}}, "abebe")
+
+		s.NoError(err)
+		s.Nil(task.Recurrence)
+	})
+}
+
+// TestOverdue tests that tasks past their due date are marked overdue until
+// they are finished
+func (s *TaskUseCaseSuite) TestOverdue() {
+	cases := []struct {
+		name    string
+		status  string
+		dueDate time.Time
+		overdue bool
+	}{
+		{"PastDue", "pending", time.Now().Add(-time.Hour), true},
+		{"Finished", "completed", time.Now().Add(-time.Hour), false},
+		{"NotDueYet", "pending", time.Now().Add(time.Hour), false},
+	}
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			s.resetRepo()
+			task := domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", CreatedBy: "abebe", Status: c.status, DueDate: c.dueDate}
+			s.mockRepo.On("GetById", mock.Anything, task.ID.Hex()).Return(task, nil)
+
+			result, err := s.useCase.GetByIdAndUser(task.ID.Hex(), "abebe")
+
+			s.NoError(err)
+			s.Equal(c.overdue, result.Overdue)
+		})
+	}
+
+	s.Run("CreatedPastDue", func() {
+		s.resetRepo()
+		task := &domain.Task{Title: "Buy Coffee", CreatedBy: "abebe", DueDate: time.Now().Add(-time.Hour)}
+		s.mockRepo.On("Create", mock.Anything, task).Return(nil)
+
+		s.NoError(s.useCase.Create(task))
+
+		s.True(task.Overdue)
+	})
+}
@@ -15,14 +15,17 @@ import (
 // UserUseCaseSuite defines the test suite for UserUseCase
 type UserUseCaseSuite struct {
 	suite.Suite
-	mockRepo *mocks_domain.UserRepository
-	useCase  domain.IUserUseCase
+	mockRepo   *mocks_domain.UserRepository
+	mockEvents *mocks_domain.EventPublisher
+	useCase    domain.IUserUseCase
 }
 
 // SetupTest initializes the mocks and use case before each test
 func (s *UserUseCaseSuite) SetupTest() {
 	s.mockRepo = mocks_domain.NewUserRepository(s.T())
-	s.useCase = usecase.NewUserUseCase(s.mockRepo)
+	s.mockEvents = mocks_domain.NewEventPublisher(s.T())
+	s.mockEvents.On("Publish", mock.Anything, mock.Anything).Maybe()
+	s.useCase = usecase.NewUserUseCase(s.mockRepo, s.mockEvents)
 }
 
 // TestUserUseCaseSuite runs the test suite
@@ -114,6 +117,31 @@ func (s *UserUseCaseSuite) TestInsert() {
 		s.NoError(err)
 	})
 
+	s.Run("PublishesUserRegistered", func() {
+		s.SetupTest()
+		user := &domain.User{ID: "1", Username: "abebe", Email: "abebe@example.com", Password: "hashed", Role: "user"}
+		s.mockRepo.On("Insert", mock.Anything, user).Return(nil)
+		s.mockEvents.ExpectedCalls = nil
+		s.mockEvents.On("Publish", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+			return event.Type == domain.EventUserRegistered && event.Actor == "abebe" && event.User != nil && event.User.Username == "abebe"
+		})).Once()
+
+		err := s.useCase.Insert(user)
+
+		s.NoError(err)
+	})
+
+	s.Run("RepositoryErrorPublishesNothing", func() {
+		s.SetupTest()
+		user := &domain.User{ID: "1", Username: "abebe", Email: "abebe@example.com", Role: "user"}
+		s.mockRepo.On("Insert", mock.Anything, user).Return(errors.New("insert failed"))
+
+		err := s.useCase.Insert(user)
+
+		s.Error(err)
+		s.mockEvents.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
 	s.Run("NilUser", func() {
 		err := s.useCase.Insert(nil)
 
@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookUseCaseSuite defines the test suite for WebhookUseCase and
+// EventPublisher
+type WebhookUseCaseSuite struct {
+	suite.Suite
+	mockWebhooks   *mocks_domain.WebhookRepository
+	mockDeliveries *mocks_domain.WebhookDeliveryRepository
+	mockSender     *mocks_domain.WebhookSender
+	mockBus        *mocks_domain.EventBus
+	useCase        domain.IWebhookUseCase
+	publisher      domain.EventPublisher
+	webhook        domain.Webhook
+}
+
+// SetupTest initializes the mocks and use case before each test
+func (s *WebhookUseCaseSuite) SetupTest() {
+	s.mockWebhooks = mocks_domain.NewWebhookRepository(s.T())
+	s.mockDeliveries = mocks_domain.NewWebhookDeliveryRepository(s.T())
+	s.mockSender = mocks_domain.NewWebhookSender(s.T())
+	s.useCase = usecase.NewWebhookUseCase(s.mockWebhooks, s.mockDeliveries, s.mockSender)
+	s.mockBus = mocks_domain.NewEventBus(s.T())
+	s.publisher = usecase.NewEventPublisher(s.mockWebhooks, s.mockDeliveries, s.mockBus)
+	s.webhook = domain.Webhook{
+		ID:        primitive.NewObjectID(),
+		URL:       "https://example.com/hooks",
+		Events:    []string{domain.EventTaskCreated},
+		Secret:    "0123456789abcdef",
+		Active:    true,
+		CreatedBy: "admin",
+	}
+}
+
+// TestWebhookUseCaseSuite runs the test suite
+func TestWebhookUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(WebhookUseCaseSuite))
+}
+
+// TestCreate tests the Create method
+func (s *WebhookUseCaseSuite) TestCreate() {
+	s.Run("GeneratesSecret", func() {
+		s.SetupTest()
+		webhook := &domain.Webhook{URL: "https://example.com/hooks", Events: []string{domain.EventTaskCreated, domain.EventTaskCreated, domain.EventUserRegistered}, Active: true}
+		s.mockWebhooks.On("Create", mock.Anything, webhook).Return(nil)
+
+		err := s.useCase.Create(webhook)
+
+		s.NoError(err)
+		s.Len(webhook.Secret, 64)
+		s.False(webhook.CreatedAt.IsZero())
+		s.Equal([]string{domain.EventTaskCreated, domain.EventUserRegistered}, webhook.Events)
+	})
+
+	s.Run("KeepsGivenSecret", func() {
+		s.SetupTest()
+		webhook := &domain.Webhook{URL: "http://localhost:9000", Events: []string{domain.EventTaskDeleted}, Secret: "my-own-secret-value"}
+		s.mockWebhooks.On("Create", mock.Anything, webhook).Return(nil)
+
+		s.NoError(s.useCase.Create(webhook))
+		s.Equal("my-own-secret-value", webhook.Secret)
+	})
+
+	cases := []struct {
+		name    string
+		webhook *domain.Webhook
+		err     string
+	}{
+		{"NilWebhook", nil, "webhook cannot be nil"},
+		{"RelativeURL", &domain.Webhook{URL: "/hooks", Events: []string{domain.EventTaskCreated}}, "webhook url must be an absolute http or https URL"},
+		{"UnsupportedScheme", &domain.Webhook{URL: "ftp://example.com", Events: []string{domain.EventTaskCreated}}, "webhook url must be an absolute http or https URL"},
+		{"NoEvents", &domain.Webhook{URL: "https://example.com"}, "webhook must subscribe to at least one event"},
+		{"UnknownEvent", &domain.Webhook{URL: "https://example.com", Events: []string{"task.exploded"}}, "unknown webhook event"},
+	}
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			s.SetupTest()
+
+			err := s.useCase.Create(c.webhook)
+
+			s.EqualError(err, c.err)
+			s.ErrorIs(err, domain.ErrValidation)
+		})
+	}
+}
+
+// TestUpdate tests the Update method
+func (s *WebhookUseCaseSuite) TestUpdate() {
+	s.Run("KeepsSecret", func() {
+		s.SetupTest()
+		webhook := &domain.Webhook{URL: "https://example.com/other", Events: []string{domain.EventTaskUpdated}}
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(s.webhook, nil)
+		s.mockWebhooks.On("Update", mock.Anything, s.webhook.ID.Hex(), webhook).Return(nil)
+
+		err := s.useCase.Update(s.webhook.ID.Hex(), webhook)
+
+		s.NoError(err)
+		s.Equal(s.webhook.Secret, webhook.Secret)
+		s.Equal(s.webhook.ID, webhook.ID)
+		s.Equal("admin", webhook.CreatedBy)
+	})
+
+	s.Run("NotFound", func() {
+		s.SetupTest()
+		s.mockWebhooks.On("GetById", mock.Anything, "missing").Return(domain.Webhook{}, domain.NewError(domain.ErrNotFound, "webhook not found"))
+
+		err := s.useCase.Update("missing", &domain.Webhook{URL: "https://example.com", Events: []string{domain.EventTaskCreated}})
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestDelete tests that deleting a webhook drops its deliveries
+func (s *WebhookUseCaseSuite) TestDelete() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockWebhooks.On("Delete", mock.Anything, s.webhook.ID.Hex()).Return(nil)
+		s.mockDeliveries.On("DeleteByWebhook", mock.Anything, s.webhook.ID.Hex()).Return(nil)
+
+		s.NoError(s.useCase.Delete(s.webhook.ID.Hex()))
+	})
+
+	s.Run("NotFound", func() {
+		s.SetupTest()
+		s.mockWebhooks.On("Delete", mock.Anything, "missing").Return(domain.NewError(domain.ErrNotFound, "webhook not found"))
+
+		s.ErrorIs(s.useCase.Delete("missing"), domain.ErrNotFound)
+		s.mockDeliveries.AssertNotCalled(s.T(), "DeleteByWebhook", mock.Anything, mock.Anything)
+	})
+}
+
+// TestGetDeliveries tests the GetDeliveries method
+func (s *WebhookUseCaseSuite) TestGetDeliveries() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		deliveries := []domain.WebhookDelivery{{ID: primitive.NewObjectID(), WebhookID: s.webhook.ID}}
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(s.webhook, nil)
+		s.mockDeliveries.On("GetByWebhook", mock.Anything, s.webhook.ID.Hex(), 50).Return(deliveries, nil)
+
+		result, err := s.useCase.GetDeliveries(s.webhook.ID.Hex())
+
+		s.NoError(err)
+		s.Equal(deliveries, result)
+	})
+
+	s.Run("WebhookNotFound", func() {
+		s.SetupTest()
+		s.mockWebhooks.On("GetById", mock.Anything, "missing").Return(domain.Webhook{}, domain.NewError(domain.ErrNotFound, "webhook not found"))
+
+		_, err := s.useCase.GetDeliveries("missing")
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestRedeliver tests the Redeliver method
+func (s *WebhookUseCaseSuite) TestRedeliver() {
+	event := domain.Event{ID: primitive.NewObjectID(), Type: domain.EventTaskCreated, At: time.Now()}
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		delivery := domain.WebhookDelivery{ID: primitive.NewObjectID(), WebhookID: s.webhook.ID, Event: event, Status: domain.DeliveryFailed, Attempts: 8}
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(s.webhook, nil)
+		s.mockDeliveries.On("GetById", mock.Anything, delivery.ID.Hex()).Return(delivery, nil)
+		s.mockDeliveries.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := s.useCase.Redeliver(s.webhook.ID.Hex(), delivery.ID.Hex())
+
+		s.NoError(err)
+		s.NotEqual(delivery.ID, result.ID)
+		s.Equal(event.ID, result.Event.ID)
+		s.Equal(domain.DeliveryPending, result.Status)
+		s.Zero(result.Attempts)
+	})
+
+	s.Run("OtherWebhook", func() {
+		s.SetupTest()
+		delivery := domain.WebhookDelivery{ID: primitive.NewObjectID(), WebhookID: primitive.NewObjectID(), Event: event}
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(s.webhook, nil)
+		s.mockDeliveries.On("GetById", mock.Anything, delivery.ID.Hex()).Return(delivery, nil)
+
+		_, err := s.useCase.Redeliver(s.webhook.ID.Hex(), delivery.ID.Hex())
+
+		s.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+// TestDeliverDue tests the DeliverDue method
+func (s *WebhookUseCaseSuite) TestDeliverDue() {
+	noneDue := domain.NewError(domain.ErrNotFound, "no webhook delivery is due")
+	claim := func(delivery domain.WebhookDelivery) {
+		s.mockDeliveries.On("ClaimDue", mock.Anything, mock.Anything, time.Minute).Return(delivery, nil).Once()
+		s.mockDeliveries.On("ClaimDue", mock.Anything, mock.Anything, time.Minute).Return(domain.WebhookDelivery{}, noneDue)
+	}
+	var updated domain.WebhookDelivery
+	expectUpdate := func() {
+		s.mockDeliveries.On("Update", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			updated = *args.Get(1).(*domain.WebhookDelivery)
+		})
+	}
+	pending := func(attempts int) domain.WebhookDelivery {
+		return domain.WebhookDelivery{ID: primitive.NewObjectID(), WebhookID: s.webhook.ID, Status: domain.DeliveryPending, Attempts: attempts}
+	}
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		claim(pending(0))
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(s.webhook, nil)
+		s.mockSender.On("Send", mock.Anything, s.webhook, mock.Anything).Return(200, nil)
+		expectUpdate()
+
+		delivered, err := s.useCase.DeliverDue()
+
+		s.NoError(err)
+		s.Equal(1, delivered)
+		s.Equal(domain.DeliverySucceeded, updated.Status)
+		s.Equal(1, updated.Attempts)
+		s.Equal(200, updated.LastStatusCode)
+		s.False(updated.DeliveredAt.IsZero())
+	})
+
+	s.Run("RetriedWithBackoff", func() {
+		s.SetupTest()
+		claim(pending(2))
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(s.webhook, nil)
+		s.mockSender.On("Send", mock.Anything, s.webhook, mock.Anything).Return(503, errors.New("webhook responded with 503 Service Unavailable"))
+		expectUpdate()
+
+		delivered, err := s.useCase.DeliverDue()
+
+		s.NoError(err)
+		s.Zero(delivered)
+		s.Equal(domain.DeliveryPending, updated.Status)
+		s.Equal(3, updated.Attempts)
+		s.Equal(503, updated.LastStatusCode)
+		s.Equal("webhook responded with 503 Service Unavailable", updated.LastError)
+		s.WithinDuration(time.Now().Add(2*time.Minute), updated.NextAttemptAt, 5*time.Second)
+	})
+
+	s.Run("FailsAfterMaxAttempts", func() {
+		s.SetupTest()
+		claim(pending(domain.MaxWebhookAttempts - 1))
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(s.webhook, nil)
+		s.mockSender.On("Send", mock.Anything, s.webhook, mock.Anything).Return(0, errors.New("connection refused"))
+		expectUpdate()
+
+		_, err := s.useCase.DeliverDue()
+
+		s.NoError(err)
+		s.Equal(domain.DeliveryFailed, updated.Status)
+		s.Equal(domain.MaxWebhookAttempts, updated.Attempts)
+	})
+
+	s.Run("InactiveWebhook", func() {
+		s.SetupTest()
+		claim(pending(0))
+		s.webhook.Active = false
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(s.webhook, nil)
+		expectUpdate()
+
+		_, err := s.useCase.DeliverDue()
+
+		s.NoError(err)
+		s.Equal(domain.DeliveryFailed, updated.Status)
+		s.Equal("webhook is inactive", updated.LastError)
+		s.mockSender.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("DeletedWebhook", func() {
+		s.SetupTest()
+		claim(pending(0))
+		s.mockWebhooks.On("GetById", mock.Anything, s.webhook.ID.Hex()).Return(domain.Webhook{}, domain.NewError(domain.ErrNotFound, "webhook not found"))
+		expectUpdate()
+
+		_, err := s.useCase.DeliverDue()
+
+		s.NoError(err)
+		s.Equal(domain.DeliveryFailed, updated.Status)
+	})
+
+	s.Run("ClaimError", func() {
+		s.SetupTest()
+		s.mockDeliveries.On("ClaimDue", mock.Anything, mock.Anything, time.Minute).Return(domain.WebhookDelivery{}, errors.New("database error"))
+
+		_, err := s.useCase.DeliverDue()
+
+		s.EqualError(err, "database error")
+	})
+}
+
+// TestPublish tests that events are passed to the bus and queued for the
+// subscribed webhooks
+func (s *WebhookUseCaseSuite) TestPublish() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		other := domain.Webhook{ID: primitive.NewObjectID(), Active: true, Events: []string{domain.EventTaskCreated}}
+		s.mockBus.On("Publish", mock.Anything, mock.MatchedBy(func(event domain.Event) bool {
+			return event.Type == domain.EventTaskCreated && !event.ID.IsZero()
+		})).Once()
+		s.mockWebhooks.On("GetByEvent", mock.Anything, domain.EventTaskCreated).Return([]domain.Webhook{s.webhook, other}, nil)
+		var deliveries []domain.WebhookDelivery
+		s.mockDeliveries.On("Create", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			deliveries = append(deliveries, *args.Get(1).(*domain.WebhookDelivery))
+		})
+
+		s.publisher.Publish(s.T().Context(), domain.Event{Type: domain.EventTaskCreated, Actor: "abebe", Task: &domain.Task{Title: "Buy Coffee"}})
+
+		s.Require().Len(deliveries, 2)
+		s.Equal(s.webhook.ID, deliveries[0].WebhookID)
+		s.Equal(other.ID, deliveries[1].WebhookID)
+		s.Equal(deliveries[0].Event.ID, deliveries[1].Event.ID)
+		s.False(deliveries[0].Event.ID.IsZero())
+		s.False(deliveries[0].Event.At.IsZero())
+		s.Equal(domain.DeliveryPending, deliveries[0].Status)
+	})
+
+	s.Run("RepositoryErrorIgnored", func() {
+		s.SetupTest()
+		s.mockBus.On("Publish", mock.Anything, mock.Anything).Once()
+		s.mockWebhooks.On("GetByEvent", mock.Anything, domain.EventTaskDeleted).Return(nil, errors.New("database error"))
+
+		s.publisher.Publish(s.T().Context(), domain.Event{Type: domain.EventTaskDeleted})
+
+		s.mockDeliveries.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+}
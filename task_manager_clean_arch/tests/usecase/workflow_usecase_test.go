@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/usecase"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+)
+
+// WorkflowUseCaseSuite defines the test suite for WorkflowUseCase
+type WorkflowUseCaseSuite struct {
+	suite.Suite
+	mockRepo *mocks_domain.WorkflowRepository
+	useCase  domain.IWorkflowUseCase
+}
+
+// SetupTest initializes the mock and use case before each test
+func (s *WorkflowUseCaseSuite) SetupTest() {
+	s.mockRepo = mocks_domain.NewWorkflowRepository(s.T())
+	s.useCase = usecase.NewWorkflowUseCase(s.mockRepo)
+}
+
+// TestWorkflowUseCaseSuite runs the test suite
+func TestWorkflowUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(WorkflowUseCaseSuite))
+}
+
+// TestGet tests the Get method
+func (s *WorkflowUseCaseSuite) TestGet() {
+	s.Run("NotConfigured", func() {
+		s.SetupTest()
+		s.mockRepo.On("Get", mock.Anything).Return(domain.Workflow{}, domain.NewError(domain.ErrNotFound, "workflow not found"))
+
+		workflow, err := s.useCase.Get()
+
+		s.NoError(err)
+		s.Equal(domain.DefaultWorkflow(), workflow)
+	})
+
+	s.Run("RepositoryError", func() {
+		s.SetupTest()
+		s.mockRepo.On("Get", mock.Anything).Return(domain.Workflow{}, errors.New("database error"))
+
+		_, err := s.useCase.Get()
+
+		s.EqualError(err, "database error")
+	})
+}
+
+// TestUpdate tests the Update method
+func (s *WorkflowUseCaseSuite) TestUpdate() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		workflow := &domain.Workflow{Statuses: []string{"todo", "done"}, UpdatedBy: "admin"}
+		s.mockRepo.On("Save", mock.Anything, workflow).Return(nil)
+
+		s.NoError(s.useCase.Update(workflow))
+		s.NotNil(workflow.Transitions)
+		s.False(workflow.UpdatedAt.IsZero())
+	})
+
+	for name, workflow := range map[string]domain.Workflow{
+		"NoStatuses":        {},
+		"InvalidStatus":     {Statuses: []string{"In Progress"}},
+		"DuplicateStatus":   {Statuses: []string{"todo", "todo"}},
+		"UnknownTransition": {Statuses: []string{"todo", "done"}, Transitions: map[string][]string{"todo": {"review"}}},
+	} {
+		s.Run(name, func() {
+			s.SetupTest()
+
+			err := s.useCase.Update(&workflow)
+
+			s.ErrorIs(err, domain.ErrValidation)
+			s.mockRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
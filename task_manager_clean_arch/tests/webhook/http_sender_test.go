@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/domain"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/webhook"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HTTPSenderSuite defines the test suite for HTTPSender
+type HTTPSenderSuite struct {
+	suite.Suite
+	hook     domain.Webhook
+	delivery domain.WebhookDelivery
+}
+
+// SetupTest builds the delivery sent by every test
+func (s *HTTPSenderSuite) SetupTest() {
+	s.hook = domain.Webhook{ID: primitive.NewObjectID(), Secret: "0123456789abcdef", Active: true}
+	s.delivery = domain.WebhookDelivery{
+		ID:        primitive.NewObjectID(),
+		WebhookID: s.hook.ID,
+		Event: domain.Event{
+			ID:    primitive.NewObjectID(),
+			Type:  domain.EventTaskCreated,
+			At:    time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC),
+			Actor: "abebe",
+			Task:  &domain.Task{ID: primitive.NewObjectID(), Title: "Buy Coffee", Status: "pending", CreatedBy: "abebe", Version: 1},
+		},
+	}
+}
+
+// TestHTTPSenderSuite runs the test suite
+func TestHTTPSenderSuite(t *testing.T) {
+	suite.Run(t, new(HTTPSenderSuite))
+}
+
+// TestSend tests that deliveries are posted as signed JSON
+func (s *HTTPSenderSuite) TestSend() {
+	s.Run("Success", func() {
+		var body []byte
+		var header http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s.Equal(http.MethodPost, r.Method)
+			header = r.Header
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		s.hook.URL = server.URL
+
+		status, err := webhook.NewHTTPSender(time.Second).Send(context.Background(), s.hook, s.delivery)
+
+		s.NoError(err)
+		s.Equal(http.StatusNoContent, status)
+		s.Equal("application/json", header.Get("Content-Type"))
+		s.Equal(domain.EventTaskCreated, header.Get(webhook.HeaderEvent))
+		s.Equal(s.delivery.ID.Hex(), header.Get(webhook.HeaderDelivery))
+		timestamp := header.Get(webhook.HeaderTimestamp)
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		s.Require().NoError(err)
+		s.WithinDuration(time.Now(), time.Unix(seconds, 0), 5*time.Second)
+		mac := hmac.New(sha256.New, []byte(s.hook.Secret))
+		mac.Write([]byte(timestamp + "."))
+		mac.Write(body)
+		s.Equal("sha256="+hex.EncodeToString(mac.Sum(nil)), header.Get(webhook.HeaderSignature))
+		s.True(webhook.Verify(s.hook.Secret, header.Get(webhook.HeaderSignature), timestamp, body, time.Now()))
+		var payload webhook.EventPayload
+		s.Require().NoError(json.Unmarshal(body, &payload))
+		s.Equal(s.delivery.Event.ID.Hex(), payload.ID)
+		s.Equal(domain.EventTaskCreated, payload.Type)
+		s.Equal("abebe", payload.Actor)
+		s.Require().NotNil(payload.Data.Task)
+		s.Equal("Buy Coffee", payload.Data.Task.Title)
+		s.Nil(payload.Data.User)
+	})
+
+	s.Run("ErrorStatus", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		s.hook.URL = server.URL
+
+		status, err := webhook.NewHTTPSender(time.Second).Send(context.Background(), s.hook, s.delivery)
+
+		s.EqualError(err, "webhook responded with 503 Service Unavailable")
+		s.Equal(http.StatusServiceUnavailable, status)
+	})
+
+	s.Run("Unreachable", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		s.Require().NoError(err)
+		s.hook.URL = "http://" + listener.Addr().String()
+		listener.Close()
+
+		status, err := webhook.NewHTTPSender(time.Second).Send(context.Background(), s.hook, s.delivery)
+
+		s.Error(err)
+		s.Zero(status)
+	})
+}
+
+// TestVerify tests the checks a receiver makes on a delivery
+func (s *HTTPSenderSuite) TestVerify() {
+	body := []byte(`{"type":"task.created"}`)
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := webhook.Sign(s.hook.Secret, timestamp, body)
+
+	s.Run("Valid", func() {
+		s.True(webhook.Verify(s.hook.Secret, signature, timestamp, body, now.Add(time.Minute)))
+	})
+
+	s.Run("Replayed", func() {
+		s.False(webhook.Verify(s.hook.Secret, signature, timestamp, body, now.Add(webhook.SignatureTolerance+time.Second)))
+	})
+
+	s.Run("TimestampChanged", func() {
+		later := strconv.FormatInt(now.Add(time.Hour).Unix(), 10)
+
+		s.False(webhook.Verify(s.hook.Secret, signature, later, body, now.Add(time.Hour)))
+	})
+
+	s.Run("BodyChanged", func() {
+		s.False(webhook.Verify(s.hook.Secret, signature, timestamp, []byte(`{"type":"task.deleted"}`), now))
+	})
+
+	s.Run("WrongSecret", func() {
+		s.False(webhook.Verify("another secret", signature, timestamp, body, now))
+	})
+
+	s.Run("InvalidTimestamp", func() {
+		s.False(webhook.Verify(s.hook.Secret, signature, "yesterday", body, now))
+	})
+}
+
+// TestNewEventPayload tests that user events carry the user without its
+// password
+func (s *HTTPSenderSuite) TestNewEventPayload() {
+	event := domain.Event{ID: primitive.NewObjectID(), Type: domain.EventUserRegistered, Actor: "kebede", User: &domain.User{Username: "kebede", Email: "kebede@example.com", Password: "hashed", Role: "user"}}
+
+	payload := webhook.NewEventPayload(event)
+
+	s.Nil(payload.Data.Task)
+	s.Equal(&webhook.UserPayload{Username: "kebede", Email: "kebede@example.com", Role: "user"}, payload.Data.User)
+	body, err := json.Marshal(payload)
+	s.NoError(err)
+	s.NotContains(string(body), "hashed")
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/worker"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+)
+
+// ReminderSchedulerSuite defines the test suite for ReminderScheduler
+type ReminderSchedulerSuite struct {
+	suite.Suite
+	mockUsecase *mocks_domain.IReminderUseCase
+}
+
+// SetupTest initializes the mock usecase before each test
+func (s *ReminderSchedulerSuite) SetupTest() {
+	s.mockUsecase = new(mocks_domain.IReminderUseCase)
+}
+
+// TestReminderSchedulerSuite runs the test suite
+func TestReminderSchedulerSuite(t *testing.T) {
+	suite.Run(t, new(ReminderSchedulerSuite))
+}
+
+// TestRun tests the Run method
+func (s *ReminderSchedulerSuite) TestRun() {
+	s.Run("SendsUntilCancelled", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		s.mockUsecase.On("SendReminders").Return(2, nil).Once()
+		s.mockUsecase.On("SendReminders").Return(0, nil).Run(func(_ mock.Arguments) {
+			cancel()
+		})
+
+		done := make(chan struct{})
+		go func() {
+			worker.NewReminderScheduler(s.mockUsecase, time.Millisecond).Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			s.Fail("scheduler did not stop after the context was cancelled")
+		}
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("KeepsRunningAfterError", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		s.mockUsecase.On("SendReminders").Return(0, errors.New("database error")).Once()
+		s.mockUsecase.On("SendReminders").Return(0, nil).Run(func(_ mock.Arguments) {
+			cancel()
+		})
+
+		worker.NewReminderScheduler(s.mockUsecase, time.Millisecond).Run(ctx)
+
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Disabled", func() {
+		s.SetupTest()
+
+		worker.NewReminderScheduler(s.mockUsecase, 0).Run(context.Background())
+
+		s.mockUsecase.AssertNotCalled(s.T(), "SendReminders")
+	})
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/worker"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+)
+
+// TrashPurgerSuite defines the test suite for TrashPurger
+type TrashPurgerSuite struct {
+	suite.Suite
+	mockUsecase     *mocks_domain.ITaskUseCase
+	mockAttachments *mocks_domain.IAttachmentUseCase
+}
+
+// SetupTest initializes the mock usecases before each test
+func (s *TrashPurgerSuite) SetupTest() {
+	s.mockUsecase = new(mocks_domain.ITaskUseCase)
+	s.mockAttachments = new(mocks_domain.IAttachmentUseCase)
+	s.mockAttachments.On("PurgeOrphans").Return(int64(0), nil)
+}
+
+// TestTrashPurgerSuite runs the test suite
+func TestTrashPurgerSuite(t *testing.T) {
+	suite.Run(t, new(TrashPurgerSuite))
+}
+
+// TestRun tests the Run method
+func (s *TrashPurgerSuite) TestRun() {
+	s.Run("PurgesUntilCancelled", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		s.mockUsecase.On("PurgeTrash", 24*time.Hour).Return(int64(1), nil).Once()
+		s.mockUsecase.On("PurgeTrash", 24*time.Hour).Return(int64(0), nil).Run(func(_ mock.Arguments) {
+			cancel()
+		})
+
+		done := make(chan struct{})
+		go func() {
+			worker.NewTrashPurger(s.mockUsecase, s.mockAttachments, 24*time.Hour, time.Millisecond).Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			s.Fail("purger did not stop after the context was cancelled")
+		}
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("KeepsRunningAfterError", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		s.mockUsecase.On("PurgeTrash", time.Hour).Return(int64(0), errors.New("database error")).Once()
+		s.mockUsecase.On("PurgeTrash", time.Hour).Return(int64(0), nil).Run(func(_ mock.Arguments) {
+			cancel()
+		})
+
+		worker.NewTrashPurger(s.mockUsecase, s.mockAttachments, time.Hour, time.Millisecond).Run(ctx)
+
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("PurgesAttachments", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		s.mockUsecase.On("PurgeTrash", time.Hour).Return(int64(0), errors.New("database error"))
+		s.mockAttachments.ExpectedCalls = nil
+		s.mockAttachments.On("PurgeOrphans").Return(int64(2), nil).Run(func(_ mock.Arguments) {
+			cancel()
+		})
+
+		worker.NewTrashPurger(s.mockUsecase, s.mockAttachments, time.Hour, time.Millisecond).Run(ctx)
+
+		s.mockAttachments.AssertCalled(s.T(), "PurgeOrphans")
+	})
+
+	s.Run("Disabled", func() {
+		s.SetupTest()
+
+		worker.NewTrashPurger(s.mockUsecase, s.mockAttachments, time.Hour, 0).Run(context.Background())
+
+		s.mockUsecase.AssertNotCalled(s.T(), "PurgeTrash", time.Hour)
+		s.mockAttachments.AssertNotCalled(s.T(), "PurgeOrphans")
+	})
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/yiheyistm/task_manager/internal/infrastructure/worker"
+	mocks_domain "github.com/yiheyistm/task_manager/mocks/mocks_domain"
+)
+
+// WebhookDispatcherSuite defines the test suite for WebhookDispatcher
+type WebhookDispatcherSuite struct {
+	suite.Suite
+	mockUsecase *mocks_domain.IWebhookUseCase
+}
+
+// SetupTest initializes the mock usecase before each test
+func (s *WebhookDispatcherSuite) SetupTest() {
+	s.mockUsecase = new(mocks_domain.IWebhookUseCase)
+}
+
+// TestWebhookDispatcherSuite runs the test suite
+func TestWebhookDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(WebhookDispatcherSuite))
+}
+
+// TestRun tests the Run method
+func (s *WebhookDispatcherSuite) TestRun() {
+	s.Run("DeliversUntilCancelled", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		s.mockUsecase.On("DeliverDue").Return(3, nil).Once()
+		s.mockUsecase.On("DeliverDue").Return(0, nil).Run(func(_ mock.Arguments) {
+			cancel()
+		})
+
+		done := make(chan struct{})
+		go func() {
+			worker.NewWebhookDispatcher(s.mockUsecase, time.Millisecond).Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			s.Fail("dispatcher did not stop after the context was cancelled")
+		}
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("KeepsRunningAfterError", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		s.mockUsecase.On("DeliverDue").Return(0, errors.New("database error")).Once()
+		s.mockUsecase.On("DeliverDue").Return(0, nil).Run(func(_ mock.Arguments) {
+			cancel()
+		})
+
+		worker.NewWebhookDispatcher(s.mockUsecase, time.Millisecond).Run(ctx)
+
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Disabled", func() {
+		s.SetupTest()
+
+		worker.NewWebhookDispatcher(s.mockUsecase, 0).Run(context.Background())
+
+		s.mockUsecase.AssertNotCalled(s.T(), "DeliverDue")
+	})
+}